@@ -8,6 +8,8 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/urfave/cli"
 	"golang.org/x/net/context"
+	"io"
+	"os"
 	"strings"
 )
 
@@ -43,6 +45,12 @@ var createInvoiceCommand = cli.Command{
 				"itself, which would allow user to see what he paid for later " +
 				"in the wallet.",
 		},
+		cli.StringFlag{
+			Name: "idempotency_key",
+			Usage: "(optional) Unique key of the request, retry with the " +
+				"same key returns the result of the original request " +
+				"instead of creation of the new invoice.",
+		},
 	},
 	Action: createInvoice,
 }
@@ -66,8 +74,9 @@ func createInvoice(ctx *cli.Context) error {
 
 	ctxb := context.Background()
 	resp, err := client.CreateInvoice(ctxb, &hubrpc.CreateInvoiceRequest{
-		Amount:      amount,
-		Description: description,
+		Amount:         amount,
+		Description:    description,
+		IdempotencyKey: ctx.String("idempotency_key"),
 	})
 	if err != nil {
 		return err
@@ -216,6 +225,12 @@ var sendPaymentCommand = cli.Command{
 				"destination, and other info which is needed for sender " +
 				"to successfully send payment.",
 		},
+		cli.StringFlag{
+			Name: "idempotency_key",
+			Usage: "(optional) Unique key of the request, retry with the " +
+				"same key returns the result of the original request " +
+				"instead of sending of the payment again.",
+		},
 	},
 	Action: sendPayment,
 }
@@ -241,8 +256,9 @@ func sendPayment(ctx *cli.Context) error {
 
 	ctxb := context.Background()
 	resp, err := client.SendPayment(ctxb, &hubrpc.SendPaymentRequest{
-		Amount:  amount,
-		Invoice: invoice,
+		Amount:         amount,
+		Invoice:        invoice,
+		IdempotencyKey: ctx.String("idempotency_key"),
 	})
 	if err != nil {
 		return err
@@ -447,15 +463,47 @@ var checkNodeStatsCommand = cli.Command{
 			Usage: "(optional) Node is public key or node name of about which" +
 				" we should show statistic, by default it will show all",
 		},
-		cli.StringFlag{
+		cli.Int64Flag{
 			Name:  "limit",
 			Usage: "(optional) Limit output to the given number",
 		},
+		cli.Int64Flag{
+			Name:  "offset",
+			Usage: "(optional) Skip the given number of nodes",
+		},
 		cli.StringFlag{
 			Name: "sort_type",
 			Usage: "(optional) Sorts nodes by the given sorting algo, " +
 				"by default by volume, (by_sent_num, " +
-				"by_idleness, by_volume)",
+				"by_idleness, by_volume, by_received)",
+		},
+		cli.BoolFlag{
+			Name:  "important_only",
+			Usage: "(optional) Show only nodes which are important for us",
+		},
+		cli.BoolFlag{
+			Name: "available_only",
+			Usage: "(optional) Show only nodes to which we could send " +
+				"payment directly",
+		},
+		cli.Float64Flag{
+			Name: "min_volume",
+			Usage: "(optional) Show only nodes average daily payment " +
+				"volume of which is greater than the given one, in USD",
+		},
+		cli.Int64Flag{
+			Name: "start",
+			Usage: "(optional) Unix time of the custom period beginning, " +
+				"if specified period is ignored",
+		},
+		cli.Int64Flag{
+			Name:  "end",
+			Usage: "(optional) Unix time of the custom period end, current time is used by default",
+		},
+		cli.StringFlag{
+			Name: "bucket",
+			Usage: "(optional) Size of the payment series bucket, if " +
+				"specified series is shown for every node, (hour, day, week)",
 		},
 	},
 	Action: checkNodeStats,
@@ -466,10 +514,14 @@ func checkNodeStats(ctx *cli.Context) error {
 	defer cleanUp()
 
 	var (
-		period   hubrpc.Period
-		node     string
-		limit    int64
-		sortType hubrpc.SortType
+		period     hubrpc.Period
+		node       string
+		limit      int64
+		offset     int64
+		sortType   hubrpc.SortType
+		minVolume  float64
+		bucketSize hubrpc.BucketSize
+		err        error
 	)
 
 	if ctx.IsSet("period") {
@@ -496,6 +548,14 @@ func checkNodeStats(ctx *cli.Context) error {
 		limit = ctx.Int64("limit")
 	}
 
+	if ctx.IsSet("offset") {
+		offset = ctx.Int64("offset")
+	}
+
+	if ctx.IsSet("min_volume") {
+		minVolume = ctx.Float64("min_volume")
+	}
+
 	if ctx.IsSet("sort_type") {
 		st := ctx.String("sort_type")
 		switch st {
@@ -505,17 +565,157 @@ func checkNodeStats(ctx *cli.Context) error {
 			sortType = hubrpc.SortType_BY_IDLENESS
 		case "by_volume":
 			sortType = hubrpc.SortType_BY_VOLUME
+		case "by_received":
+			sortType = hubrpc.SortType_BY_RECEIVED
 		default:
 			return errors.Errorf("unknown sort type(%v)", st)
 		}
 	}
 
+	if ctx.IsSet("bucket") {
+		bucketSize, err = parseBucketSize(ctx.String("bucket"))
+		if err != nil {
+			return err
+		}
+	}
+
 	ctxb := context.Background()
 	resp, err := client.CheckNodeStats(ctxb, &hubrpc.CheckNodeStatsRequest{
-		Period:   period,
-		Node:     node,
-		Limit:    int32(limit),
-		SortType: sortType,
+		Period:        period,
+		Node:          node,
+		Limit:         int32(limit),
+		SortType:      sortType,
+		Offset:        int32(offset),
+		ImportantOnly: ctx.Bool("important_only"),
+		AvailableOnly: ctx.Bool("available_only"),
+		MinVolume:     minVolume,
+		Start:         ctx.Int64("start"),
+		End:           ctx.Int64("end"),
+		BucketSize:    bucketSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var budgetCommand = cli.Command{
+	Name:     "budget",
+	Category: "Nodes",
+	Usage: "Return the state of the daily budget of channel open and" +
+		" close operations.",
+	Action: budget,
+}
+
+func budget(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	ctxb := context.Background()
+	resp, err := client.Budget(ctxb, &hubrpc.BudgetRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var feePolicyUpdatesCommand = cli.Command{
+	Name:     "feeupdates",
+	Category: "Nodes",
+	Usage:    "Return the history of channel routing fee policy updates.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "limit",
+			Usage: "(optional) Limit output to the given number of latest updates",
+		},
+	},
+	Action: feePolicyUpdates,
+}
+
+func feePolicyUpdates(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var limit int64
+	if ctx.IsSet("limit") {
+		limit = ctx.Int64("limit")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.FeePolicyUpdates(ctxb, &hubrpc.FeePolicyUpdatesRequest{
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var channelsChangeCommand = cli.Command{
+	Name:     "channelschange",
+	Category: "Nodes",
+	Usage:    "Return the history of channels count and capacity changes.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "limit",
+			Usage: "(optional) Limit output to the given number of latest reports",
+		},
+	},
+	Action: channelsChange,
+}
+
+func channelsChange(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var limit int64
+	if ctx.IsSet("limit") {
+		limit = ctx.Int64("limit")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.ChannelsChange(ctxb, &hubrpc.ChannelsChangeRequest{
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var networkGraphCommand = cli.Command{
+	Name:     "networkgraph",
+	Category: "Network",
+	Usage:    "Return the state of the lightning network graph at the given time.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "time",
+			Usage: "(optional) Unix time of the graph state, current time is used by default",
+		},
+		cli.Int64Flag{
+			Name:  "limit",
+			Usage: "(optional) Limit output to the given number of nodes with the biggest capacity",
+		},
+	},
+	Action: networkGraph,
+}
+
+func networkGraph(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	ctxb := context.Background()
+	resp, err := client.NetworkGraph(ctxb, &hubrpc.NetworkGraphRequest{
+		Time:  ctx.Int64("time"),
+		Limit: int32(ctx.Int64("limit")),
 	})
 	if err != nil {
 		return err
@@ -524,3 +724,295 @@ func checkNodeStats(ctx *cli.Context) error {
 	printRespJSON(resp)
 	return nil
 }
+
+var nodeCapacityHistoryCommand = cli.Command{
+	Name:     "nodecapacity",
+	Category: "Network",
+	Usage:    "Return the history of node channels capacity changes.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "node_id",
+			Usage: "Public key of the node",
+		},
+		cli.Int64Flag{
+			Name:  "start",
+			Usage: "(optional) Unix time of the period beginning",
+		},
+		cli.Int64Flag{
+			Name:  "end",
+			Usage: "(optional) Unix time of the period end, current time is used by default",
+		},
+	},
+	Action: nodeCapacityHistory,
+}
+
+func nodeCapacityHistory(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var nodeID string
+	if ctx.IsSet("node_id") {
+		nodeID = ctx.String("node_id")
+	} else {
+		return errors.Errorf("node_id argument is missing")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.NodeCapacityHistory(ctxb,
+		&hubrpc.NodeCapacityHistoryRequest{
+			NodeId: nodeID,
+			Start:  ctx.Int64("start"),
+			End:    ctx.Int64("end"),
+		})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var networkAnomaliesCommand = cli.Command{
+	Name:     "anomalies",
+	Category: "Network",
+	Usage:    "Return the history of detected lightning network anomalies.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "limit",
+			Usage: "(optional) Limit output to the given number of latest anomalies",
+		},
+	},
+	Action: networkAnomalies,
+}
+
+func networkAnomalies(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var limit int64
+	if ctx.IsSet("limit") {
+		limit = ctx.Int64("limit")
+	}
+
+	ctxb := context.Background()
+	resp, err := client.NetworkAnomalies(ctxb,
+		&hubrpc.NetworkAnomaliesRequest{
+			Limit: int32(limit),
+		})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var networkStatsCommand = cli.Command{
+	Name:     "networkstats",
+	Category: "Network",
+	Usage: "Return the economics statistics of the lightning network " +
+		"and our channels.",
+	Action: networkStats,
+}
+
+func networkStats(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	ctxb := context.Background()
+	resp, err := client.NetworkStats(ctxb, &hubrpc.NetworkStatsRequest{})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var paymentTimeSeriesCommand = cli.Command{
+	Name:     "paymentseries",
+	Category: "Nodes",
+	Usage:    "Return the time series of payments activity with nodes.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name: "node",
+			Usage: "(optional) Node is public key or node name of which" +
+				" series should be shown, by default it will show all",
+		},
+		cli.Int64Flag{
+			Name: "start",
+			Usage: "(optional) Unix time of the period beginning, by " +
+				"default thirty buckets before the end",
+		},
+		cli.Int64Flag{
+			Name:  "end",
+			Usage: "(optional) Unix time of the period end, current time is used by default",
+		},
+		cli.StringFlag{
+			Name:  "bucket",
+			Usage: "(optional) Size of the series bucket, by default day, (hour, day, week)",
+		},
+	},
+	Action: paymentTimeSeries,
+}
+
+func paymentTimeSeries(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	var (
+		bucketSize hubrpc.BucketSize
+		err        error
+	)
+
+	if ctx.IsSet("bucket") {
+		bucketSize, err = parseBucketSize(ctx.String("bucket"))
+		if err != nil {
+			return err
+		}
+	}
+
+	ctxb := context.Background()
+	resp, err := client.PaymentTimeSeries(ctxb,
+		&hubrpc.PaymentTimeSeriesRequest{
+			Node:       ctx.String("node"),
+			Start:      ctx.Int64("start"),
+			End:        ctx.Int64("end"),
+			BucketSize: bucketSize,
+		})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+// parseBucketSize converts the name of the bucket size in the proto bucket
+// size.
+func parseBucketSize(b string) (hubrpc.BucketSize, error) {
+	switch b {
+	case "hour":
+		return hubrpc.BucketSize_BUCKET_HOUR, nil
+	case "day":
+		return hubrpc.BucketSize_BUCKET_DAY, nil
+	case "week":
+		return hubrpc.BucketSize_BUCKET_WEEK, nil
+	default:
+		return hubrpc.BucketSize_BUCKET_NONE, errors.Errorf("unknown "+
+			"bucket option(%v)", b)
+	}
+}
+
+var profitabilityReportCommand = cli.Command{
+	Name:     "profitability",
+	Category: "Nodes",
+	Usage: "Return the profitability of our channels and peers, " +
+		"the least profitable first.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "start",
+			Usage: "(optional) Unix time of the period beginning, whole history is used by default",
+		},
+		cli.Int64Flag{
+			Name:  "end",
+			Usage: "(optional) Unix time of the period end, current time is used by default",
+		},
+	},
+	Action: profitabilityReport,
+}
+
+func profitabilityReport(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	ctxb := context.Background()
+	resp, err := client.ProfitabilityReport(ctxb,
+		&hubrpc.ProfitabilityReportRequest{
+			Start: ctx.Int64("start"),
+			End:   ctx.Int64("end"),
+		})
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+	return nil
+}
+
+var exportLedgerCommand = cli.Command{
+	Name:     "exportledger",
+	Category: "Payment",
+	Usage: "Export chronological ledger of payments and channel " +
+		"management fees in csv format.",
+	Flags: []cli.Flag{
+		cli.Int64Flag{
+			Name:  "start",
+			Usage: "(optional) Unix time of the period beginning, whole history is used by default",
+		},
+		cli.Int64Flag{
+			Name:  "end",
+			Usage: "(optional) Unix time of the period end, current time is used by default",
+		},
+		cli.BoolFlag{
+			Name:  "fiat",
+			Usage: "(optional) Calculate USD value of entries with the bitcoin price of the event day",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "(optional) Path of the csv file, by default ledger is printed",
+		},
+	},
+	Action: exportLedger,
+}
+
+func exportLedger(ctx *cli.Context) error {
+	client, cleanUp := getClient(ctx)
+	defer cleanUp()
+
+	output := io.Writer(os.Stdout)
+	if ctx.IsSet("output") {
+		file, err := os.Create(ctx.String("output"))
+		if err != nil {
+			return errors.Errorf("unable to create ledger file: %v",
+				err)
+		}
+		defer file.Close()
+
+		output = file
+	}
+
+	// Ledger is returned by pages, which are written one after another,
+	// header is returned only in the first page.
+	ctxb := context.Background()
+	var offset int32
+	for {
+		req := &hubrpc.ExportLedgerRequest{
+			Start:  ctx.Int64("start"),
+			End:    ctx.Int64("end"),
+			Fiat:   ctx.Bool("fiat"),
+			Offset: offset,
+		}
+
+		resp, err := client.ExportLedger(ctxb, req)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.WriteString(output, resp.Csv); err != nil {
+			return errors.Errorf("unable to write ledger: %v", err)
+		}
+
+		offset += resp.NumEntries
+		if resp.NumEntries == 0 || offset >= resp.Total {
+			break
+		}
+	}
+
+	if ctx.IsSet("output") {
+		fmt.Printf("%v entries were written in %v\n", offset,
+			ctx.String("output"))
+	}
+
+	return nil
+}
@@ -3,9 +3,15 @@ package main
 import (
 	"fmt"
 	"github.com/bitlum/hub/hubrpc"
+	"github.com/btcsuite/btcutil"
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon.v2"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 )
 
 const (
@@ -13,11 +19,41 @@ const (
 	defaultRPCHostPort = "localhost:" + defaultRPCPort
 )
 
+var (
+	defaultHubDir       = btcutil.AppDataDir("hubmanager", false)
+	defaultTLSCertPath  = filepath.Join(defaultHubDir, "tls.cert")
+	defaultMacaroonPath = filepath.Join(defaultHubDir,
+		hubrpc.MacaroonFilename(hubrpc.PermissionAdmin))
+)
+
 func fatal(err error) {
-	fmt.Fprintf(os.Stderr, "[pscli] %v\n", err)
+	fmt.Fprintf(os.Stderr, "[pscli] %v\n", formatError(err))
 	os.Exit(1)
 }
 
+// formatError renders error returned by hub with the gRPC status code, and
+// the reason, field and retryable flag of the error info if hub attached it.
+func formatError(err error) string {
+	s, ok := status.FromError(err)
+	if !ok {
+		return err.Error()
+	}
+
+	msg := fmt.Sprintf("%v (code: %v", s.Message(), s.Code())
+
+	if info, ok := hubrpc.ErrorInfoFromError(err); ok {
+		msg += fmt.Sprintf(", reason: %v", info.Reason)
+		if field := info.Metadata[hubrpc.ErrorFieldKey]; field != "" {
+			msg += fmt.Sprintf(", field: %v", field)
+		}
+		if info.Metadata[hubrpc.ErrorRetryableKey] == "true" {
+			msg += ", retryable"
+		}
+	}
+
+	return msg + ")"
+}
+
 func getClient(ctx *cli.Context) (hubrpc.HubClient, func()) {
 	conn := getClientConn(ctx, false)
 
@@ -30,8 +66,36 @@ func getClient(ctx *cli.Context) (hubrpc.HubClient, func()) {
 
 func getClientConn(ctx *cli.Context, skipMacaroons bool) *grpc.ClientConn {
 	// Create a dial options array.
-	opts := []grpc.DialOption{
-		grpc.WithInsecure(),
+	var opts []grpc.DialOption
+
+	noTLS := ctx.GlobalBool("notls")
+	if noTLS {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		creds, err := credentials.NewClientTLSFromFile(
+			ctx.GlobalString("tlscertpath"), "")
+		if err != nil {
+			fatal(err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	}
+
+	if !skipMacaroons && !ctx.GlobalBool("no-macaroons") {
+		data, err := ioutil.ReadFile(ctx.GlobalString("macaroonpath"))
+		if err != nil {
+			fatal(fmt.Errorf("unable to read macaroon: %v", err))
+		}
+
+		mac := &macaroon.Macaroon{}
+		if err := mac.UnmarshalBinary(data); err != nil {
+			fatal(fmt.Errorf("unable to decode macaroon: %v", err))
+		}
+
+		opts = append(opts, grpc.WithPerRPCCredentials(
+			hubrpc.MacaroonCredential{
+				Macaroon: mac,
+				Insecure: noTLS,
+			}))
 	}
 
 	conn, err := grpc.Dial(ctx.GlobalString("rpcserver"), opts...)
@@ -53,6 +117,26 @@ func main() {
 			Value: defaultRPCHostPort,
 			Usage: "host:port of hub rpc",
 		},
+		cli.StringFlag{
+			Name:  "tlscertpath",
+			Value: defaultTLSCertPath,
+			Usage: "path to hub's TLS certificate",
+		},
+		cli.BoolFlag{
+			Name:  "notls",
+			Usage: "disable TLS, needed if hub is started with --hub.notls",
+		},
+		cli.StringFlag{
+			Name:  "macaroonpath",
+			Value: defaultMacaroonPath,
+			Usage: "path to macaroon file, readonly.macaroon, " +
+				"invoice.macaroon or admin.macaroon",
+		},
+		cli.BoolFlag{
+			Name: "no-macaroons",
+			Usage: "disable macaroon authentication, needed if hub is " +
+				"started with --hub.nomacaroons",
+		},
 	}
 	app.Commands = []cli.Command{
 		createInvoiceCommand,
@@ -64,6 +148,16 @@ func main() {
 		paymentByInvoiceCommand,
 		listPaymentsCommand,
 		checkNodeStatsCommand,
+		budgetCommand,
+		feePolicyUpdatesCommand,
+		channelsChangeCommand,
+		networkGraphCommand,
+		nodeCapacityHistoryCommand,
+		networkAnomaliesCommand,
+		networkStatsCommand,
+		paymentTimeSeriesCommand,
+		profitabilityReportCommand,
+		exportLedgerCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -0,0 +1,208 @@
+package common
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/go-errors/errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// certOrganization is the organization of the self-signed
+	// certificates.
+	certOrganization = "hub autogenerated cert"
+
+	// certValidity is the period of time during which self-signed
+	// certificate is valid.
+	certValidity = 14 * 30 * 24 * time.Hour
+)
+
+// FileExists reports whether the named file or directory exists.
+func FileExists(name string) bool {
+	if _, err := os.Stat(name); err != nil {
+		if os.IsNotExist(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// GenCertPair generates self-signed certificate and key pair and writes
+// them to the given files, the way lnd does it. Certificate is valid for
+// the localhost, host name of the machine, all interface addresses, and the
+// given extra ips and domains.
+func GenCertPair(certPath, keyPath string, extraIPs,
+	extraDomains []string) error {
+
+	now := time.Now()
+	validUntil := now.Add(certValidity)
+
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return errors.Errorf("unable to generate serial number: %v", err)
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		host = "localhost"
+	}
+
+	dnsNames := []string{host}
+	if host != "localhost" {
+		dnsNames = append(dnsNames, "localhost")
+	}
+	dnsNames = append(dnsNames, extraDomains...)
+
+	ipAddresses := []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("::1")}
+	addIP := func(ip net.IP) {
+		for _, known := range ipAddresses {
+			if known.Equal(ip) {
+				return
+			}
+		}
+		ipAddresses = append(ipAddresses, ip)
+	}
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return errors.Errorf("unable to get interface addresses: %v", err)
+	}
+
+	for _, a := range addrs {
+		ip, _, err := net.ParseCIDR(a.String())
+		if err == nil {
+			addIP(ip)
+		}
+	}
+
+	for _, ipStr := range extraIPs {
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return errors.Errorf("invalid extra ip(%v)", ipStr)
+		}
+		addIP(ip)
+	}
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Errorf("unable to generate key: %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{certOrganization},
+			CommonName:   host,
+		},
+		NotBefore: now.Add(-time.Hour * 24),
+		NotAfter:  validUntil,
+
+		KeyUsage: x509.KeyUsageKeyEncipherment |
+			x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+
+		DNSNames:    dnsNames,
+		IPAddresses: ipAddresses,
+	}
+
+	derBytes, err := x509.CreateCertificate(rand.Reader, &template,
+		&template, &priv.PublicKey, priv)
+	if err != nil {
+		return errors.Errorf("unable to create certificate: %v", err)
+	}
+
+	certBuf := &bytes.Buffer{}
+	err = pem.Encode(certBuf, &pem.Block{Type: "CERTIFICATE",
+		Bytes: derBytes})
+	if err != nil {
+		return errors.Errorf("unable to encode certificate: %v", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return errors.Errorf("unable to encode private key: %v", err)
+	}
+
+	keyBuf := &bytes.Buffer{}
+	err = pem.Encode(keyBuf, &pem.Block{Type: "EC PRIVATE KEY",
+		Bytes: keyBytes})
+	if err != nil {
+		return errors.Errorf("unable to encode private key: %v", err)
+	}
+
+	if err = ioutil.WriteFile(certPath, certBuf.Bytes(), 0644); err != nil {
+		return errors.Errorf("unable to write certificate: %v", err)
+	}
+
+	if err = ioutil.WriteFile(keyPath, keyBuf.Bytes(), 0600); err != nil {
+		os.Remove(certPath)
+		return errors.Errorf("unable to write private key: %v", err)
+	}
+
+	return nil
+}
+
+// CertLoader keeps the certificate loaded from the files, and allows to
+// reload it without restart of the server which uses it.
+type CertLoader struct {
+	certPath string
+	keyPath  string
+
+	mutex sync.RWMutex
+	cert  *tls.Certificate
+}
+
+// NewCertLoader creates certificate loader and loads the certificate from
+// the given files.
+func NewCertLoader(certPath, keyPath string) (*CertLoader, error) {
+	l := &CertLoader{
+		certPath: certPath,
+		keyPath:  keyPath,
+	}
+
+	if err := l.Reload(); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Reload loads certificate from the files, in case of error previously
+// loaded certificate is kept.
+func (l *CertLoader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(l.certPath, l.keyPath)
+	if err != nil {
+		return errors.Errorf("unable to load certificate(%v) and "+
+			"key(%v): %v", l.certPath, l.keyPath, err)
+	}
+
+	l.mutex.Lock()
+	l.cert = &cert
+	l.mutex.Unlock()
+
+	return nil
+}
+
+// GetCertificate returns the last loaded certificate, it is intended to be
+// used as tls.Config GetCertificate callback.
+func (l *CertLoader) GetCertificate(*tls.ClientHelloInfo) (
+	*tls.Certificate, error) {
+
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.cert, nil
+}
@@ -0,0 +1,113 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimiterCleanupInterval is the interval after which buckets of the
+// idle keys are removed, in order to not keep them forever.
+const rateLimiterCleanupInterval = time.Minute
+
+// TokenBucket is the token bucket rate limiter, tokens are refilled with
+// constant rate up to the burst, every event takes one token.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates token bucket which allows the given number of
+// events per second, with the given burst.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// refill adds tokens which have been accumulated since the last refill.
+func (b *TokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens += elapsed * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+// Allow takes the token if it is available, and returns false otherwise.
+//
+// NOTE: Not safe for concurrent use.
+func (b *TokenBucket) Allow(now time.Time) bool {
+	b.refill(now)
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// isFull returns true if bucket has been refilled completely, and
+// therefore is the same as the new one.
+func (b *TokenBucket) isFull(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.burst
+}
+
+// RateLimiter limits the rate of events separately for every key, e.g.
+// client ip address.
+type RateLimiter struct {
+	rate  float64
+	burst int
+
+	mutex       sync.Mutex
+	buckets     map[string]*TokenBucket
+	lastCleanup time.Time
+}
+
+// NewRateLimiter creates limiter which allows the given number of events per
+// second for every key, with the given burst.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:        rate,
+		burst:       burst,
+		buckets:     make(map[string]*TokenBucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+// Allow returns true if event of the given key is within the limit.
+func (l *RateLimiter) Allow(key string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+
+	if now.Sub(l.lastCleanup) > rateLimiterCleanupInterval {
+		for k, bucket := range l.buckets {
+			if bucket.isFull(now) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastCleanup = now
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = NewTokenBucket(l.rate, l.burst)
+		l.buckets[key] = bucket
+	}
+
+	return bucket.Allow(now)
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/go-errors/errors"
 	"net/http"
 	"runtime"
@@ -9,6 +10,16 @@ import (
 	"time"
 )
 
+// DateLayout is the layout of the date, which is used to index daily
+// prices.
+const DateLayout = "2006-01-02"
+
+// priceClient is the http client which is used to fetch bitcoin prices, its
+// timeout prevents requests from hanging on the unresponsive price source.
+var priceClient = &http.Client{
+	Timeout: time.Second * 30,
+}
+
 // GetFunctionName() returns name of the function within which it executed.
 func GetFunctionName() string {
 	pc, _, _, _ := runtime.Caller(1)
@@ -29,7 +40,7 @@ func ConvertDurationToMilliSeconds(t time.Duration) int64 {
 }
 
 func GetBitcoinUSDPRice() (float64, error) {
-	resp, err := http.Get("https://blockchain.info/ticker")
+	resp, err := priceClient.Get("https://blockchain.info/ticker")
 	if err != nil {
 		return 0, errors.Errorf("unable to fetch bitcoin price: %v",
 			err)
@@ -51,3 +62,39 @@ func GetBitcoinUSDPRice() (float64, error) {
 
 	return data["USD"].Last, nil
 }
+
+// GetBitcoinUSDPriceHistory returns daily close prices of bitcoin in USD
+// within the given range of time, prices are indexed by the date in
+// "2006-01-02" format.
+func GetBitcoinUSDPriceHistory(start, end time.Time) (map[string]float64,
+	error) {
+
+	url := fmt.Sprintf("https://api.coindesk.com/v1/bpi/historical/close."+
+		"json?start=%v&end=%v", start.UTC().Format(DateLayout),
+		end.UTC().Format(DateLayout))
+
+	resp, err := priceClient.Get(url)
+	if err != nil {
+		return nil, errors.Errorf("unable to fetch bitcoin price history: %v",
+			err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unable to fetch bitcoin price "+
+			"history: status(%v)", resp.Status)
+	}
+
+	type RespData struct {
+		BPI map[string]float64 `json:"bpi"`
+	}
+	d := json.NewDecoder(resp.Body)
+
+	var data RespData
+	if err := d.Decode(&data); err != nil {
+		return nil, errors.Errorf("unable to decode bitcoin price "+
+			"history: %v", err)
+	}
+
+	return data.BPI, nil
+}
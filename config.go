@@ -5,10 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"log"
 
+	"github.com/bitlum/hub/fees"
+	"github.com/bitlum/hub/lightning"
 	"github.com/btcsuite/btcutil"
 	"github.com/jessevdk/go-flags"
 )
@@ -17,10 +21,24 @@ const (
 	defaultHubPort = "8686"
 	defaultHubHost = "localhost"
 
+	defaultHubRESTPort = "8687"
+	defaultHubRESTHost = "localhost"
+
+	defaultHubIdempotencyTTL = time.Hour * 24
+
+	defaultHubRateLimit              = 10
+	defaultHubRateBurst              = 20
+	defaultHubExpensiveRateLimit     = 0.1
+	defaultHubExpensiveRateBurst     = 3
+	defaultHubMaxConcurrentExpensive = 2
+
 	defaultGraphQLHost       = "0.0.0.0"
 	defaultGraphQLPort       = "3000"
 	defaultGraphQLSecurePort = "3443"
 
+	defaultGraphQLMutationsPerMinute = 20
+	defaultGraphQLQueriesPerMinute   = 60
+
 	defaultLogDirname     = "logs"
 	defaultLogLevel       = "info"
 	defaultConfigFilename = "hub.conf"
@@ -32,18 +50,46 @@ const (
 
 	defaultDbPath = "/tmp"
 	defaultNet    = "simnet"
+
+	defaultFeesBaseFeeMsat   = 1000
+	defaultFeesMinFeeRatePPM = 1
+	defaultFeesMaxFeeRatePPM = 1000
+
+	defaultFeesUpdatePeriod      = time.Minute * 10
+	defaultFeesMinUpdateInterval = time.Hour * 6
+
+	defaultTopologyNetworkCapacityThreshold = 0.1
+	defaultTopologyNodeCapacityThreshold    = 0.3
+	defaultTopologyNodeMinCapacity          = 10000000
+	defaultTopologyCapacityWindow           = time.Hour * 24
+	defaultTopologyUptimeDropThreshold      = 0.3
+	defaultTopologyUptimeWindow             = time.Hour * 3
 )
 
 type graphqlConfig struct {
 	ListenHost       string `long:"listenhost" description:"The host on which GraphQL will listen for incoming requests"`
 	ListenPort       string `long:"listenport" description:"The port on which GraphQL will listen for incoming requests"`
 	SecureListenPort string `long:"securelistenport" description:"The secure port on which GraphQL will listen for incoming requests"`
+
+	TLSCertPath     string   `long:"tlscertpath" description:"Path to the TLS certificate, if specified GraphQL is served over HTTPS on the secure port"`
+	TLSKeyPath      string   `long:"tlskeypath" description:"Path to the TLS key"`
+	TLSSelfSigned   bool     `long:"tlsselfsigned" description:"Generate self-signed TLS certificate and key if they don't exist"`
+	TLSExtraIPs     []string `long:"tlsextraip" description:"Adds an extra ip to the generated certificate"`
+	TLSExtraDomains []string `long:"tlsextradomain" description:"Adds an extra domain to the generated certificate"`
+	RedirectHTTP    bool     `long:"redirecthttp" description:"Redirect HTTP requests to HTTPS instead of serving them"`
+
+	APIToken           string `long:"apitoken" description:"The token which is required to execute mutations, private queries and subscriptions, if not specified mutations and private queries are disabled and subscriptions are public"`
+	MutationsPerMinute int    `long:"mutationsperminute" description:"The number of mutations which client ip is allowed to execute per minute"`
+	QueriesPerMinute   int    `long:"queriesperminute" description:"The number of requests with private queries which client ip is allowed to execute per minute"`
 }
 
 var (
 	homeDir           = btcutil.AppDataDir("hubmanager", false)
 	defaultConfigFile = filepath.Join(homeDir, defaultConfigFilename)
 	defaultLogDir     = filepath.Join(homeDir, defaultLogDirname)
+
+	defaultHubTLSCertPath = filepath.Join(homeDir, "tls.cert")
+	defaultHubTLSKeyPath  = filepath.Join(homeDir, "tls.key")
 )
 
 // config defines the configuration options for lnd.
@@ -59,7 +105,10 @@ type config struct {
 
 	Prometheus *prometheusConfig `group:"Prometheus" namespace:"prometheus"`
 	Hub        *hubConfig        `group:"Hub" namespace:"hub"`
+	Manager    *managerConfig    `group:"Manager" namespace:"manager"`
 	GraphQL    *graphqlConfig    `group:"GraphQL" namespace:"graphql"`
+	Fees       *feesConfig       `group:"Fees" namespace:"fees"`
+	Topology   *topologyConfig   `group:"Topology" namespace:"topology"`
 
 	ConfigFile string `long:"config" description:"Path to configuration file"`
 	LogDir     string `long:"logdir" description:"Directory to log output."`
@@ -72,6 +121,27 @@ type config struct {
 type hubConfig struct {
 	Port string `long:"port" description:"Port on which GRPC hub manager is working"`
 	Host string `long:"host" description:"Host on which GRPC hub manager is working"`
+
+	TLSCertPath     string   `long:"tlscertpath" description:"Path to the TLS certificate of GRPC endpoint, self-signed one is generated if it doesn't exist"`
+	TLSKeyPath      string   `long:"tlskeypath" description:"Path to the TLS key of GRPC endpoint"`
+	TLSExtraIPs     []string `long:"tlsextraip" description:"Adds an extra ip to the generated certificate"`
+	TLSExtraDomains []string `long:"tlsextradomain" description:"Adds an extra domain to the generated certificate"`
+	NoTLS           bool     `long:"notls" description:"Disable TLS of GRPC endpoint, should be used only if endpoint is listening on localhost"`
+
+	MacaroonDir string `long:"macaroondir" description:"Directory in which macaroons of readonly, invoice and admin permission levels are generated, remove macaroons.key to revoke all of them"`
+	NoMacaroons bool   `long:"nomacaroons" description:"Disable macaroon authentication of GRPC endpoint"`
+
+	RESTPort string `long:"restport" description:"Port on which REST gateway of GRPC hub manager is working"`
+	RESTHost string `long:"resthost" description:"Host on which REST gateway of GRPC hub manager is working"`
+	NoREST   bool   `long:"norest" description:"Disable REST gateway of GRPC endpoint"`
+
+	IdempotencyTTL time.Duration `long:"idempotencyttl" description:"Period of time during which result of the request with idempotency key is returned on retries"`
+
+	RateLimit              float64 `long:"ratelimit" description:"Number of requests per second allowed for every client credential"`
+	RateBurst              int     `long:"rateburst" description:"Number of requests which client credential could make at once before rate limit is applied"`
+	ExpensiveRateLimit     float64 `long:"expensiveratelimit" description:"Number of requests per second of every expensive method, such as checknodestats, allowed for every client credential"`
+	ExpensiveRateBurst     int     `long:"expensiverateburst" description:"Number of requests of every expensive method which client credential could make at once before rate limit is applied"`
+	MaxConcurrentExpensive int     `long:"maxconcurrentexpensive" description:"Maximum number of expensive requests of all clients which are processed concurrently"`
 }
 
 type lndClientConfig struct {
@@ -95,6 +165,35 @@ type bitcoindConfig struct {
 	Port string `long:"port" description:"Port on which we expect to bitcoind"`
 }
 
+// managerConfig defines the parameters of node manager, which maintains
+// channels with important nodes.
+type managerConfig struct {
+	CloseIdleChannels bool `long:"closeidlechannels" description:"Close channels with nodes which have the most idle funds, if there is not enough funds to open channel with important node, closes are restricted by the daily close budget"`
+}
+
+// feesConfig defines the parameters of routing fee policy manager.
+type feesConfig struct {
+	DryRun        bool              `long:"dryrun" description:"Only log and save in history calculated channel fee policies, without applying them"`
+	BaseFeeMsat   int64             `long:"basefeemsat" description:"Base fee in millisatoshis of the default channel fee policy"`
+	MinFeeRatePPM int64             `long:"minfeerate" description:"Fee rate in parts per million of channels with the biggest local balance"`
+	MaxFeeRatePPM int64             `long:"maxfeerate" description:"Fee rate in parts per million of drained channels with high forwarding activity"`
+	NodePolicies  map[string]string `long:"nodepolicy" description:"A map from node public key to its fee policy in format '<base_fee_msat>:<min_fee_rate>:<max_fee_rate>'"`
+
+	UpdatePeriod      time.Duration `long:"updateperiod" description:"Period of recalculation of channel fee policies"`
+	MinUpdateInterval time.Duration `long:"minupdateinterval" description:"Minimal interval of time between two policy updates of the same channel"`
+}
+
+// topologyConfig defines the thresholds and windows of network anomaly
+// detectors.
+type topologyConfig struct {
+	NetworkCapacityThreshold float64       `long:"networkcapacitythreshold" description:"Relative change of overall network capacity within capacity window, which is considered as anomaly"`
+	NodeCapacityThreshold    float64       `long:"nodecapacitythreshold" description:"Relative change of node capacity within capacity window, which is considered as anomaly"`
+	NodeMinCapacity          int64         `long:"nodemincapacity" description:"Capacity of the node in satoshis, below which its capacity changes are ignored"`
+	CapacityWindow           time.Duration `long:"capacitywindow" description:"Period of time over which capacity change is calculated"`
+	UptimeDropThreshold      float64       `long:"uptimedropthreshold" description:"Drop of known node online percentage within uptime window in comparison with weekly one, which is considered as anomaly"`
+	UptimeWindow             time.Duration `long:"uptimewindow" description:"Period of time over which recent online percentage of known node is calculated"`
+}
+
 type prometheusConfig struct {
 	ListenHost string `long:"listenhost" description:"The host of the prometheus metrics endpoint, from which metric server is trying to fetch metrics"`
 	ListenPort string `long:"listenport" description:"The port of the prometheus metrics endpoint, from which metric server is trying to fetch metrics"`
@@ -109,9 +208,24 @@ func getDefaultConfig() config {
 		UpdateLogFile: defaultUpdatesLogFileName,
 
 		Hub: &hubConfig{
-			Port: defaultHubPort,
-			Host: defaultHubHost,
+			Port:        defaultHubPort,
+			Host:        defaultHubHost,
+			TLSCertPath: defaultHubTLSCertPath,
+			TLSKeyPath:  defaultHubTLSKeyPath,
+			MacaroonDir: homeDir,
+
+			RESTPort: defaultHubRESTPort,
+			RESTHost: defaultHubRESTHost,
+
+			IdempotencyTTL: defaultHubIdempotencyTTL,
+
+			RateLimit:              defaultHubRateLimit,
+			RateBurst:              defaultHubRateBurst,
+			ExpensiveRateLimit:     defaultHubExpensiveRateLimit,
+			ExpensiveRateBurst:     defaultHubExpensiveRateBurst,
+			MaxConcurrentExpensive: defaultHubMaxConcurrentExpensive,
 		},
+		Manager: &managerConfig{},
 		Prometheus: &prometheusConfig{
 			ListenHost: defaultPrometheusHost,
 			ListenPort: defaultPrometheusPort,
@@ -126,6 +240,26 @@ func getDefaultConfig() config {
 			ListenHost:       defaultGraphQLHost,
 			ListenPort:       defaultGraphQLPort,
 			SecureListenPort: defaultGraphQLSecurePort,
+
+			MutationsPerMinute: defaultGraphQLMutationsPerMinute,
+			QueriesPerMinute:   defaultGraphQLQueriesPerMinute,
+		},
+
+		Fees: &feesConfig{
+			BaseFeeMsat:   defaultFeesBaseFeeMsat,
+			MinFeeRatePPM: defaultFeesMinFeeRatePPM,
+			MaxFeeRatePPM: defaultFeesMaxFeeRatePPM,
+
+			UpdatePeriod:      defaultFeesUpdatePeriod,
+			MinUpdateInterval: defaultFeesMinUpdateInterval,
+		},
+		Topology: &topologyConfig{
+			NetworkCapacityThreshold: defaultTopologyNetworkCapacityThreshold,
+			NodeCapacityThreshold:    defaultTopologyNodeCapacityThreshold,
+			NodeMinCapacity:          defaultTopologyNodeMinCapacity,
+			CapacityWindow:           defaultTopologyCapacityWindow,
+			UptimeDropThreshold:      defaultTopologyUptimeDropThreshold,
+			UptimeWindow:             defaultTopologyUptimeWindow,
 		},
 	}
 }
@@ -188,6 +322,15 @@ func (c *config) loadConfig() error {
 
 	// Ensure that the paths are expanded and cleaned.
 	c.LogDir = cleanAndExpandPath(c.LogDir)
+	c.Hub.TLSCertPath = cleanAndExpandPath(c.Hub.TLSCertPath)
+	c.Hub.TLSKeyPath = cleanAndExpandPath(c.Hub.TLSKeyPath)
+	c.Hub.MacaroonDir = cleanAndExpandPath(c.Hub.MacaroonDir)
+	if c.GraphQL.TLSCertPath != "" {
+		c.GraphQL.TLSCertPath = cleanAndExpandPath(c.GraphQL.TLSCertPath)
+	}
+	if c.GraphQL.TLSKeyPath != "" {
+		c.GraphQL.TLSKeyPath = cleanAndExpandPath(c.GraphQL.TLSKeyPath)
+	}
 
 	// Parse, validate, and set debug log level(s).
 	if err := parseAndSetDebugLevels(c.DebugLevel); err != nil {
@@ -305,3 +448,36 @@ func supportedSubsystems() []string {
 	sort.Strings(subsystems)
 	return subsystems
 }
+
+// parseNodePolicies parses node fee policies given in the format
+// '<base_fee_msat>:<min_fee_rate>:<max_fee_rate>'.
+func parseNodePolicies(policies map[string]string) (
+	map[lightning.NodeID]*fees.NodePolicy, error) {
+
+	nodePolicies := make(map[lightning.NodeID]*fees.NodePolicy)
+	for nodePubKey, policyStr := range policies {
+		parts := strings.Split(policyStr, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("unable to split policy(%v) of node(%v)"+
+				" on base fee, min and max fee rates", policyStr, nodePubKey)
+		}
+
+		var values [3]int64
+		for i, part := range parts {
+			value, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse policy(%v) of "+
+					"node(%v): %v", policyStr, nodePubKey, err)
+			}
+			values[i] = value
+		}
+
+		nodePolicies[lightning.NodeID(nodePubKey)] = &fees.NodePolicy{
+			BaseFeeMsat:   values[0],
+			MinFeeRatePPM: values[1],
+			MaxFeeRatePPM: values[2],
+		}
+	}
+
+	return nodePolicies, nil
+}
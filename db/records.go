@@ -0,0 +1,168 @@
+// Package db contains the records which are persisted by the hub storage
+// implementations. Subsystems define storage interfaces in terms of this
+// records, so that storage implementations don't depend on the subsystems.
+package db
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+)
+
+// ChannelsSnapshot is the state of our opened channels at the particular
+// moment of time.
+type ChannelsSnapshot struct {
+	// Time is the time when snapshot has been taken.
+	Time int64
+
+	// NumChannels is number of opened channels.
+	NumChannels int
+
+	// Capacity is the overall balance locked in opened channels.
+	Capacity btcutil.Amount
+
+	// ChannelIDs is the ids of opened channels, is used to find out which
+	// channels were closed since the snapshot.
+	ChannelIDs []lightning.ChannelID
+}
+
+// NodeState is the state of the node announced in the lightning network,
+// starting from the particular moment of time.
+type NodeState struct {
+	NodeID    lightning.NodeID
+	Alias     string
+	Addresses []string
+
+	// Time is the time since which node is in this state.
+	Time int64
+}
+
+// ChannelState is the state of the channel announced in the lightning
+// network, starting from the particular moment of time.
+type ChannelState struct {
+	ShortChannelID uint64
+
+	// ChannelID is the channel funding output, empty if it is not known
+	// yet.
+	ChannelID lightning.ChannelID
+
+	Node1 lightning.NodeID
+	Node2 lightning.NodeID
+
+	Capacity btcutil.Amount
+
+	// Node1Policy is the routing fee policy of the first node, nil if node
+	// hasn't announced it.
+	Node1Policy *lightning.FeePolicy
+
+	// Node2Policy is the routing fee policy of the second node, nil if
+	// node hasn't announced it.
+	Node2Policy *lightning.FeePolicy
+
+	// Closed is true if channel has been removed from the graph.
+	Closed bool
+
+	// ClosedHeight is the height of the block in which channel has been
+	// closed, zero if it is unknown.
+	ClosedHeight uint32
+
+	// Time is the time since which channel is in this state.
+	Time int64
+}
+
+// IdempotentResult is the result of the request with idempotency key, which
+// is returned on the repeated request instead of its execution.
+type IdempotentResult struct {
+	// Method is the name of the rpc method, keys of different methods
+	// don't clash.
+	Method string
+
+	// Key is the idempotency key generated by the client.
+	Key string
+
+	// RequestHash is the hash of the request parameters, is used to reject
+	// requests with the same key but different parameters.
+	RequestHash string
+
+	// Response is the serialised response of the request.
+	Response []byte
+
+	// Time is the time when request has been executed.
+	Time int64
+}
+
+// FeePolicyUpdate is the record about change of channel fee policy.
+type FeePolicyUpdate struct {
+	ChannelID lightning.ChannelID
+	NodeID    lightning.NodeID
+
+	// Old is the policy before the update, might be nil if it was unknown.
+	Old *lightning.FeePolicy
+
+	// New is the policy after the update.
+	New *lightning.FeePolicy
+
+	// LocalRatio is the ratio of local balance to the channel balance
+	// at the moment of update.
+	LocalRatio float64
+
+	// VolumeRatio is the ratio of forwarded through the channel volume to
+	// the channel balance at the moment of update.
+	VolumeRatio float64
+
+	// Time is the time of the update.
+	Time int64
+
+	// DryRun is true if update was not applied.
+	DryRun bool
+}
+
+// PeerEventType is the type of event which affects healthiness of the node.
+type PeerEventType string
+
+const (
+	// PeerConnected is the event of node connection to us.
+	PeerConnected PeerEventType = "connected"
+
+	// PeerDisconnected is the event of node disconnection from us.
+	PeerDisconnected PeerEventType = "disconnected"
+)
+
+// PeerEvent is the record about node connection or disconnection, which is
+// used to restore node health metrics.
+type PeerEvent struct {
+	NodeID lightning.NodeID
+	Type   PeerEventType
+	Time   int64
+}
+
+// NodeAnnouncements is the number of gossip announcements received from the
+// node during the day, which is used to restore gossip activity of the node.
+type NodeAnnouncements struct {
+	NodeID lightning.NodeID
+
+	// Day is the beginning of the day.
+	Day int64
+
+	// Count is the number of announcements received during the day.
+	Count int
+
+	// LastAnnouncement is the time of the last announcement received
+	// during the day.
+	LastAnnouncement int64
+}
+
+// AnomalyEvent is the record about detected anomaly of the lightning
+// network, which is used to restore the history of anomalies.
+type AnomalyEvent struct {
+	Type string
+
+	// NodeID is the node to which anomaly is related, empty for network
+	// wide anomalies.
+	NodeID lightning.NodeID
+
+	Time        int64
+	Previous    float64
+	Current     float64
+	Change      float64
+	Description string
+}
@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+)
+
+// AddAnomalyEvent saves the detected anomaly of the lightning network.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) AddAnomalyEvent(event *db.AnomalyEvent) error {
+	return d.Create(&AnomalyEvent{
+		Type:        event.Type,
+		NodeID:      string(event.NodeID),
+		Time:        event.Time,
+		Previous:    event.Previous,
+		Current:     event.Current,
+		Change:      event.Change,
+		Description: event.Description,
+	}).Error
+}
+
+// LastAnomalyEvents returns the given number of the latest saved anomaly
+// events, sorted from the oldest to the newest one.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) LastAnomalyEvents(limit int) ([]*db.AnomalyEvent, error) {
+	var records []AnomalyEvent
+	err := d.Order("id desc").Limit(limit).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*db.AnomalyEvent, len(records))
+	for i, record := range records {
+		events[len(records)-1-i] = &db.AnomalyEvent{
+			Type:        record.Type,
+			NodeID:      lightning.NodeID(record.NodeID),
+			Time:        record.Time,
+			Previous:    record.Previous,
+			Current:     record.Current,
+			Change:      record.Change,
+			Description: record.Description,
+		}
+	}
+
+	return events, nil
+}
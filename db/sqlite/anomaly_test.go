@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"reflect"
+	"testing"
+)
+
+func TestAnomalyEventsStorage(t *testing.T) {
+	storage, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	events := []*db.AnomalyEvent{
+		{Type: "network_capacity", Time: 1, Previous: 1000, Current: 500,
+			Change: -0.5, Description: "network capacity dropped"},
+		{Type: "node_capacity", NodeID: "a", Time: 2, Current: 1000,
+			Change: 1, Description: "node appeared"},
+		{Type: "node_uptime", NodeID: "b", Time: 3, Previous: 0.9,
+			Current: 0.1, Change: -0.8, Description: "node went offline"},
+	}
+
+	for _, event := range events {
+		if err := storage.AddAnomalyEvent(event); err != nil {
+			t.Fatalf("unable to add anomaly event: %v", err)
+		}
+	}
+
+	stored, err := storage.LastAnomalyEvents(10)
+	if err != nil {
+		t.Fatalf("unable to get anomaly events: %v", err)
+	}
+
+	if !reflect.DeepEqual(stored, events) {
+		t.Fatalf("wrong anomaly events")
+	}
+
+	stored, err = storage.LastAnomalyEvents(2)
+	if err != nil {
+		t.Fatalf("unable to get anomaly events: %v", err)
+	}
+
+	if !reflect.DeepEqual(stored, events[1:]) {
+		t.Fatalf("wrong last anomaly events")
+	}
+}
@@ -40,7 +40,15 @@ func Open(dbPath string, dbName string) (*DB, error) {
 		&User{},
 		&State{},
 		&ChannelIDShortChanIDIndex{},
-		&UserIDShortChanIDIndex{}).Error
+		&UserIDShortChanIDIndex{},
+		&ChannelsSnapshot{},
+		&GraphNodeState{},
+		&GraphChannelState{},
+		&IdempotentResult{},
+		&FeePolicyUpdate{},
+		&PeerEvent{},
+		&NodeAnnouncements{},
+		&AnomalyEvent{}).Error
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+)
+
+// AddFeePolicyUpdate saves the channel fee policy update.
+//
+// NOTE: Part of the fees.Storage interface.
+func (d *DB) AddFeePolicyUpdate(update *db.FeePolicyUpdate) error {
+	dbUpdate := &FeePolicyUpdate{
+		ChannelID:      string(update.ChannelID),
+		NodeID:         string(update.NodeID),
+		NewBaseFeeMsat: update.New.BaseFeeMsat,
+		NewFeeRatePPM:  update.New.FeeRatePPM,
+		LocalRatio:     update.LocalRatio,
+		VolumeRatio:    update.VolumeRatio,
+		Time:           update.Time,
+		DryRun:         update.DryRun,
+	}
+
+	if update.Old != nil {
+		dbUpdate.OldBaseFeeMsat = &update.Old.BaseFeeMsat
+		dbUpdate.OldFeeRatePPM = &update.Old.FeeRatePPM
+	}
+
+	return d.Create(dbUpdate).Error
+}
+
+// FeePolicyUpdates returns policy updates which were made at or after the
+// given time, sorted from the oldest to the newest one. If limit isn't
+// zero, only the last limit updates are returned.
+//
+// NOTE: Part of the fees.Storage interface.
+func (d *DB) FeePolicyUpdates(start int64,
+	limit int) ([]*db.FeePolicyUpdate, error) {
+
+	query := d.Where("time >= ?", start).Order("time desc, id desc")
+	if limit != 0 {
+		query = query.Limit(limit)
+	}
+
+	var dbUpdates []FeePolicyUpdate
+	if err := query.Find(&dbUpdates).Error; err != nil {
+		return nil, err
+	}
+
+	updates := make([]*db.FeePolicyUpdate, len(dbUpdates))
+	for i, dbUpdate := range dbUpdates {
+		update := &db.FeePolicyUpdate{
+			ChannelID: lightning.ChannelID(dbUpdate.ChannelID),
+			NodeID:    lightning.NodeID(dbUpdate.NodeID),
+			New: &lightning.FeePolicy{
+				BaseFeeMsat: dbUpdate.NewBaseFeeMsat,
+				FeeRatePPM:  dbUpdate.NewFeeRatePPM,
+			},
+			LocalRatio:  dbUpdate.LocalRatio,
+			VolumeRatio: dbUpdate.VolumeRatio,
+			Time:        dbUpdate.Time,
+			DryRun:      dbUpdate.DryRun,
+		}
+
+		if dbUpdate.OldBaseFeeMsat != nil && dbUpdate.OldFeeRatePPM != nil {
+			update.Old = &lightning.FeePolicy{
+				BaseFeeMsat: *dbUpdate.OldBaseFeeMsat,
+				FeeRatePPM:  *dbUpdate.OldFeeRatePPM,
+			}
+		}
+
+		// Updates are fetched from the newest one, so that limit is
+		// applied to them, but are returned from the oldest one.
+		updates[len(dbUpdates)-1-i] = update
+	}
+
+	return updates, nil
+}
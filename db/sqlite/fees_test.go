@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"reflect"
+	"testing"
+)
+
+func TestFeePolicyStorage(t *testing.T) {
+	storage, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	updates := []*db.FeePolicyUpdate{
+		{
+			ChannelID: "1:0",
+			NodeID:    "a",
+			New: &lightning.FeePolicy{
+				BaseFeeMsat: 1000,
+				FeeRatePPM:  100,
+			},
+			LocalRatio:  0.5,
+			VolumeRatio: 0.1,
+			Time:        1,
+		},
+		{
+			ChannelID: "1:0",
+			NodeID:    "a",
+			Old: &lightning.FeePolicy{
+				BaseFeeMsat: 1000,
+				FeeRatePPM:  100,
+			},
+			New: &lightning.FeePolicy{
+				BaseFeeMsat: 1000,
+				FeeRatePPM:  200,
+			},
+			LocalRatio:  0.2,
+			VolumeRatio: 0.3,
+			Time:        2,
+			DryRun:      true,
+		},
+		{
+			ChannelID: "2:0",
+			NodeID:    "b",
+			New: &lightning.FeePolicy{
+				BaseFeeMsat: 0,
+				FeeRatePPM:  1,
+			},
+			Time: 3,
+		},
+	}
+
+	for _, update := range updates {
+		if err := storage.AddFeePolicyUpdate(update); err != nil {
+			t.Fatalf("unable to add update: %v", err)
+		}
+	}
+
+	savedUpdates, err := storage.FeePolicyUpdates(0, 0)
+	if err != nil {
+		t.Fatalf("unable to get updates: %v", err)
+	}
+
+	if !reflect.DeepEqual(savedUpdates, updates) {
+		t.Fatalf("wrong updates")
+	}
+
+	savedUpdates, err = storage.FeePolicyUpdates(0, 2)
+	if err != nil {
+		t.Fatalf("unable to get updates: %v", err)
+	}
+
+	if !reflect.DeepEqual(savedUpdates, updates[1:]) {
+		t.Fatalf("last updates should be returned")
+	}
+
+	savedUpdates, err = storage.FeePolicyUpdates(3, 0)
+	if err != nil {
+		t.Fatalf("unable to get updates: %v", err)
+	}
+
+	if !reflect.DeepEqual(savedUpdates, updates[2:]) {
+		t.Fatalf("updates before start should be skipped")
+	}
+}
@@ -0,0 +1,165 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"strings"
+)
+
+// AddNodeState saves the new state of the node.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) AddNodeState(state *db.NodeState) error {
+	return d.Create(&GraphNodeState{
+		NodeID:    string(state.NodeID),
+		Alias:     state.Alias,
+		Addresses: strings.Join(state.Addresses, ","),
+		Time:      state.Time,
+	}).Error
+}
+
+// AddChannelState saves the new state of the channel.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) AddChannelState(state *db.ChannelState) error {
+	dbState := &GraphChannelState{
+		ShortChannelID: state.ShortChannelID,
+		ChannelID:      string(state.ChannelID),
+		Node1:          string(state.Node1),
+		Node2:          string(state.Node2),
+		Capacity:       int64(state.Capacity),
+		Closed:         state.Closed,
+		ClosedHeight:   state.ClosedHeight,
+		Time:           state.Time,
+	}
+
+	if state.Node1Policy != nil {
+		dbState.Node1BaseFeeMsat = &state.Node1Policy.BaseFeeMsat
+		dbState.Node1FeeRatePPM = &state.Node1Policy.FeeRatePPM
+	}
+
+	if state.Node2Policy != nil {
+		dbState.Node2BaseFeeMsat = &state.Node2Policy.BaseFeeMsat
+		dbState.Node2FeeRatePPM = &state.Node2Policy.FeeRatePPM
+	}
+
+	return d.Create(dbState).Error
+}
+
+// LatestNodeStates returns the last state of every node, which was saved
+// before or at the given time.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) LatestNodeStates(end int64) ([]*db.NodeState, error) {
+	// States are saved in the order of their time, so the state with the
+	// greatest id is the latest one.
+	var dbStates []GraphNodeState
+	err := d.Where("id IN (SELECT MAX(id) FROM graph_node_states "+
+		"WHERE time <= ? GROUP BY node_id)", end).
+		Order("time, id").
+		Find(&dbStates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]*db.NodeState, len(dbStates))
+	for i, dbState := range dbStates {
+		states[i] = convertNodeState(dbState)
+	}
+
+	return states, nil
+}
+
+// LatestChannelStates returns the last state of every channel, which was
+// saved before or at the given time, including the closed channels.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) LatestChannelStates(end int64) ([]*db.ChannelState, error) {
+	var dbStates []GraphChannelState
+	err := d.Where("id IN (SELECT MAX(id) FROM graph_channel_states "+
+		"WHERE time <= ? GROUP BY short_channel_id)", end).
+		Order("time, id").
+		Find(&dbStates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return convertChannelStates(dbStates), nil
+}
+
+// NodeChannelStates returns states of the given node channels, which were
+// saved within the given period, preceded by the last states of the node
+// channels saved before the period, sorted by time.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) NodeChannelStates(nodeID lightning.NodeID, start,
+	end int64) ([]*db.ChannelState, error) {
+
+	var dbStates []GraphChannelState
+	err := d.Where("(node1 = ? OR node2 = ?) AND time <= ?", nodeID,
+		nodeID, end).
+		Where("time >= ? OR id IN (SELECT MAX(id) FROM "+
+			"graph_channel_states WHERE (node1 = ? OR node2 = ?) AND "+
+			"time < ? GROUP BY short_channel_id)", start, nodeID, nodeID,
+			start).
+		Order("time, id").
+		Find(&dbStates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return convertChannelStates(dbStates), nil
+}
+
+// convertNodeState converts stored node state to the node state.
+func convertNodeState(dbState GraphNodeState) *db.NodeState {
+	state := &db.NodeState{
+		NodeID: lightning.NodeID(dbState.NodeID),
+		Alias:  dbState.Alias,
+		Time:   dbState.Time,
+	}
+
+	if dbState.Addresses != "" {
+		state.Addresses = strings.Split(dbState.Addresses, ",")
+	}
+
+	return state
+}
+
+// convertChannelStates converts stored channel states to the channel
+// states.
+func convertChannelStates(dbStates []GraphChannelState) []*db.ChannelState {
+	states := make([]*db.ChannelState, len(dbStates))
+	for i, dbState := range dbStates {
+		states[i] = &db.ChannelState{
+			ShortChannelID: dbState.ShortChannelID,
+			ChannelID:      lightning.ChannelID(dbState.ChannelID),
+			Node1:          lightning.NodeID(dbState.Node1),
+			Node2:          lightning.NodeID(dbState.Node2),
+			Capacity:       btcutil.Amount(dbState.Capacity),
+			Node1Policy: convertFeePolicy(dbState.Node1BaseFeeMsat,
+				dbState.Node1FeeRatePPM),
+			Node2Policy: convertFeePolicy(dbState.Node2BaseFeeMsat,
+				dbState.Node2FeeRatePPM),
+			Closed:       dbState.Closed,
+			ClosedHeight: dbState.ClosedHeight,
+			Time:         dbState.Time,
+		}
+	}
+
+	return states
+}
+
+// convertFeePolicy converts stored policy fields to the fee policy, nil is
+// returned if policy wasn't stored.
+func convertFeePolicy(baseFeeMsat, feeRatePPM *int64) *lightning.FeePolicy {
+	if baseFeeMsat == nil || feeRatePPM == nil {
+		return nil
+	}
+
+	return &lightning.FeePolicy{
+		BaseFeeMsat: *baseFeeMsat,
+		FeeRatePPM:  *feeRatePPM,
+	}
+}
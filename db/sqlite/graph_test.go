@@ -0,0 +1,166 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"reflect"
+	"testing"
+)
+
+func TestGraphStorage(t *testing.T) {
+	storage, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	nodeStates := []*db.NodeState{
+		{
+			NodeID:    "1",
+			Alias:     "a",
+			Addresses: []string{"h1:p1", "h2:p2"},
+			Time:      1,
+		},
+		{
+			NodeID: "2",
+			Alias:  "c",
+			Time:   2,
+		},
+		{
+			NodeID: "1",
+			Alias:  "b",
+			Time:   3,
+		},
+	}
+
+	for _, state := range nodeStates {
+		if err := storage.AddNodeState(state); err != nil {
+			t.Fatalf("unable to add node state: %v", err)
+		}
+	}
+
+	channelStates := []*db.ChannelState{
+		{
+			ShortChannelID: 1,
+			ChannelID:      "txid:0",
+			Node1:          "1",
+			Node2:          "2",
+			Capacity:       100,
+			Node1Policy: &lightning.FeePolicy{
+				BaseFeeMsat: 1000,
+				FeeRatePPM:  1,
+			},
+			Time: 1,
+		},
+		{
+			ShortChannelID: 2,
+			ChannelID:      "txid:1",
+			Node1:          "2",
+			Node2:          "3",
+			Capacity:       200,
+			Time:           2,
+		},
+		{
+			ShortChannelID: 1,
+			ChannelID:      "txid:0",
+			Node1:          "1",
+			Node2:          "2",
+			Capacity:       100,
+			Closed:         true,
+			ClosedHeight:   10,
+			Time:           3,
+		},
+		{
+			ShortChannelID: 3,
+			ChannelID:      "txid:2",
+			Node1:          "3",
+			Node2:          "1",
+			Capacity:       300,
+			Time:           4,
+		},
+	}
+
+	for _, state := range channelStates {
+		if err := storage.AddChannelState(state); err != nil {
+			t.Fatalf("unable to add channel state: %v", err)
+		}
+	}
+
+	nodes, err := storage.LatestNodeStates(2)
+	if err != nil {
+		t.Fatalf("unable to get node states: %v", err)
+	}
+
+	if !reflect.DeepEqual(nodes, nodeStates[:2]) {
+		t.Fatalf("wrong node states at 2")
+	}
+
+	nodes, err = storage.LatestNodeStates(3)
+	if err != nil {
+		t.Fatalf("unable to get node states: %v", err)
+	}
+
+	if !reflect.DeepEqual(nodes, nodeStates[1:]) {
+		t.Fatalf("wrong node states at 3")
+	}
+
+	channels, err := storage.LatestChannelStates(3)
+	if err != nil {
+		t.Fatalf("unable to get channel states: %v", err)
+	}
+
+	if !reflect.DeepEqual(channels, channelStates[1:3]) {
+		t.Fatalf("wrong channel states")
+	}
+
+	tests := []struct {
+		name     string
+		nodeID   lightning.NodeID
+		start    int64
+		end      int64
+		expected []*db.ChannelState
+	}{
+		{
+			name:     "whole history",
+			nodeID:   "1",
+			start:    0,
+			end:      4,
+			expected: []*db.ChannelState{channelStates[0], channelStates[2], channelStates[3]},
+		},
+		{
+			name:     "last state before period",
+			nodeID:   "2",
+			start:    3,
+			end:      4,
+			expected: channelStates[:3],
+		},
+		{
+			name:     "period end",
+			nodeID:   "1",
+			start:    2,
+			end:      3,
+			expected: []*db.ChannelState{channelStates[0], channelStates[2]},
+		},
+		{
+			name:   "unknown node",
+			nodeID: "4",
+			start:  0,
+			end:    4,
+		},
+	}
+
+	for _, test := range tests {
+		channels, err := storage.NodeChannelStates(test.nodeID, test.start,
+			test.end)
+		if err != nil {
+			t.Fatalf("(%v) unable to get node channel states: %v",
+				test.name, err)
+		}
+
+		if len(channels) != len(test.expected) ||
+			(len(channels) != 0 && !reflect.DeepEqual(channels,
+				test.expected)) {
+			t.Fatalf("(%v) wrong node channel states", test.name)
+		}
+	}
+}
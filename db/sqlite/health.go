@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+)
+
+// AddPeerEvent saves the node connection or disconnection event.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) AddPeerEvent(event *db.PeerEvent) error {
+	return d.Create(&PeerEvent{
+		NodeID: string(event.NodeID),
+		Type:   string(event.Type),
+		Time:   event.Time,
+	}).Error
+}
+
+// PeerEvents returns all saved peer events, sorted by time.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) PeerEvents() ([]*db.PeerEvent, error) {
+	var dbEvents []PeerEvent
+	if err := d.Order("time, id").Find(&dbEvents).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]*db.PeerEvent, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		events[i] = &db.PeerEvent{
+			NodeID: lightning.NodeID(dbEvent.NodeID),
+			Type:   db.PeerEventType(dbEvent.Type),
+			Time:   dbEvent.Time,
+		}
+	}
+
+	return events, nil
+}
+
+// RemovePeerEvents removes peer events which were saved before the given
+// time, except the last connection or disconnection event of every node,
+// which is needed to restore the current connection state of the node.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) RemovePeerEvents(end int64) error {
+	return d.Where("time < ? AND id NOT IN (SELECT MAX(id) FROM "+
+		"peer_events WHERE type IN (?, ?) GROUP BY node_id)", end,
+		string(db.PeerConnected), string(db.PeerDisconnected)).
+		Delete(&PeerEvent{}).Error
+}
+
+// SaveNodeAnnouncements saves the given numbers of node announcements,
+// replacing the previously saved numbers of the same node and day.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) SaveNodeAnnouncements(announcements []*db.NodeAnnouncements) error {
+	tx := d.Begin()
+	for _, a := range announcements {
+		err := tx.Where("node_id = ? AND day = ?", string(a.NodeID), a.Day).
+			Delete(&NodeAnnouncements{}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = tx.Create(&NodeAnnouncements{
+			NodeID:           string(a.NodeID),
+			Day:              a.Day,
+			Count:            a.Count,
+			LastAnnouncement: a.LastAnnouncement,
+		}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// NodeAnnouncements returns all saved numbers of node announcements, sorted
+// by day.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) NodeAnnouncements() ([]*db.NodeAnnouncements, error) {
+	var dbAnnouncements []NodeAnnouncements
+	err := d.Order("day, node_id").Find(&dbAnnouncements).Error
+	if err != nil {
+		return nil, err
+	}
+
+	announcements := make([]*db.NodeAnnouncements, len(dbAnnouncements))
+	for i, a := range dbAnnouncements {
+		announcements[i] = &db.NodeAnnouncements{
+			NodeID:           lightning.NodeID(a.NodeID),
+			Day:              a.Day,
+			Count:            a.Count,
+			LastAnnouncement: a.LastAnnouncement,
+		}
+	}
+
+	return announcements, nil
+}
+
+// RemoveNodeAnnouncements removes numbers of node announcements of the days
+// which started before the given time.
+//
+// NOTE: Part of the topology.Storage interface.
+func (d *DB) RemoveNodeAnnouncements(end int64) error {
+	return d.Where("day < ?", end).Delete(&NodeAnnouncements{}).Error
+}
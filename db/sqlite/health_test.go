@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"reflect"
+	"testing"
+)
+
+func TestPeerEventsStorage(t *testing.T) {
+	storage, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	events := []*db.PeerEvent{
+		{NodeID: "a", Type: db.PeerConnected, Time: 1},
+		{NodeID: "b", Type: db.PeerConnected, Time: 2},
+		{NodeID: "b", Type: db.PeerDisconnected, Time: 4},
+		{NodeID: "b", Type: db.PeerConnected, Time: 5},
+		{NodeID: "a", Type: db.PeerDisconnected, Time: 6},
+	}
+
+	for _, event := range events {
+		if err := storage.AddPeerEvent(event); err != nil {
+			t.Fatalf("unable to add peer event: %v", err)
+		}
+	}
+
+	stored, err := storage.PeerEvents()
+	if err != nil {
+		t.Fatalf("unable to get peer events: %v", err)
+	}
+
+	if !reflect.DeepEqual(stored, events) {
+		t.Fatalf("wrong peer events")
+	}
+
+	// Last connection events of the nodes should be kept, even if they
+	// are out of the window.
+	if err := storage.RemovePeerEvents(6); err != nil {
+		t.Fatalf("unable to remove peer events: %v", err)
+	}
+
+	stored, err = storage.PeerEvents()
+	if err != nil {
+		t.Fatalf("unable to get peer events: %v", err)
+	}
+
+	expected := []*db.PeerEvent{events[3], events[4]}
+	if !reflect.DeepEqual(stored, expected) {
+		t.Fatalf("wrong peer events after removal")
+	}
+}
+
+func TestNodeAnnouncementsStorage(t *testing.T) {
+	storage, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	announcements := []*db.NodeAnnouncements{
+		{NodeID: "a", Day: 0, Count: 1, LastAnnouncement: 10},
+		{NodeID: "b", Day: 0, Count: 2, LastAnnouncement: 20},
+		{NodeID: "a", Day: 100, Count: 3, LastAnnouncement: 110},
+	}
+
+	if err := storage.SaveNodeAnnouncements(announcements); err != nil {
+		t.Fatalf("unable to save announcements: %v", err)
+	}
+
+	// Saving of the same node and day should replace the number.
+	updated := &db.NodeAnnouncements{
+		NodeID:           "a",
+		Day:              100,
+		Count:            5,
+		LastAnnouncement: 150,
+	}
+	err = storage.SaveNodeAnnouncements([]*db.NodeAnnouncements{updated})
+	if err != nil {
+		t.Fatalf("unable to update announcements: %v", err)
+	}
+
+	stored, err := storage.NodeAnnouncements()
+	if err != nil {
+		t.Fatalf("unable to get announcements: %v", err)
+	}
+
+	expected := []*db.NodeAnnouncements{
+		announcements[0], announcements[1], updated,
+	}
+	if !reflect.DeepEqual(stored, expected) {
+		t.Fatalf("wrong announcements: %v", stored)
+	}
+
+	if err := storage.RemoveNodeAnnouncements(100); err != nil {
+		t.Fatalf("unable to remove announcements: %v", err)
+	}
+
+	stored, err = storage.NodeAnnouncements()
+	if err != nil {
+		t.Fatalf("unable to get announcements: %v", err)
+	}
+
+	if !reflect.DeepEqual(stored, []*db.NodeAnnouncements{updated}) {
+		t.Fatalf("wrong announcements after removal: %v", stored)
+	}
+}
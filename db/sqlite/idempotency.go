@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+)
+
+// IdempotentResult returns the result of the method request with the given
+// idempotency key, nil is returned if there is no such result.
+//
+// NOTE: Part of the hubrpc.IdempotencyStorage interface.
+func (d *DB) IdempotentResult(method, key string) (*db.IdempotentResult,
+	error) {
+
+	var results []IdempotentResult
+	err := d.Where("method = ? AND key = ?", method, key).Limit(1).
+		Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return &db.IdempotentResult{
+		Method:      results[0].Method,
+		Key:         results[0].Key,
+		RequestHash: results[0].RequestHash,
+		Response:    results[0].Response,
+		Time:        results[0].Time,
+	}, nil
+}
+
+// AddIdempotentResult saves the result of the request, previous result with
+// the same method and key is replaced.
+//
+// NOTE: Part of the hubrpc.IdempotencyStorage interface.
+func (d *DB) AddIdempotentResult(result *db.IdempotentResult) error {
+	tx := d.Begin()
+
+	err := tx.Where("method = ? AND key = ?", result.Method, result.Key).
+		Delete(&IdempotentResult{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = tx.Create(&IdempotentResult{
+		Method:      result.Method,
+		Key:         result.Key,
+		RequestHash: result.RequestHash,
+		Response:    result.Response,
+		Time:        result.Time,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// RemoveIdempotentResults removes results of requests which were executed
+// before the given time.
+//
+// NOTE: Part of the hubrpc.IdempotencyStorage interface.
+func (d *DB) RemoveIdempotentResults(before int64) error {
+	return d.Where("time < ?", before).Delete(&IdempotentResult{}).Error
+}
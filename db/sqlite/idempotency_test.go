@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"reflect"
+	"testing"
+)
+
+func TestIdempotencyStorage(t *testing.T) {
+	storage, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	result, err := storage.IdempotentResult("CreateInvoice", "1")
+	if err != nil {
+		t.Fatalf("unable to get result: %v", err)
+	}
+
+	if result != nil {
+		t.Fatalf("result should be nil")
+	}
+
+	results := []*db.IdempotentResult{
+		{
+			Method:      "CreateInvoice",
+			Key:         "1",
+			RequestHash: "hash1",
+			Response:    []byte{1, 2},
+			Time:        1,
+		},
+		{
+			Method:      "SendPayment",
+			Key:         "1",
+			RequestHash: "hash2",
+			Response:    []byte{3},
+			Time:        2,
+		},
+		{
+			Method:      "CreateInvoice",
+			Key:         "2",
+			RequestHash: "hash3",
+			Response:    []byte{4},
+			Time:        3,
+		},
+	}
+
+	for _, result := range results {
+		if err := storage.AddIdempotentResult(result); err != nil {
+			t.Fatalf("unable to add result: %v", err)
+		}
+	}
+
+	for _, result := range results {
+		savedResult, err := storage.IdempotentResult(result.Method, result.Key)
+		if err != nil {
+			t.Fatalf("unable to get result: %v", err)
+		}
+
+		if !reflect.DeepEqual(result, savedResult) {
+			t.Fatalf("wrong result, expected: %v, got: %v", result,
+				savedResult)
+		}
+	}
+
+	// Result with the same method and key should be replaced.
+	newResult := &db.IdempotentResult{
+		Method:      "CreateInvoice",
+		Key:         "1",
+		RequestHash: "hash4",
+		Response:    []byte{5},
+		Time:        4,
+	}
+	if err := storage.AddIdempotentResult(newResult); err != nil {
+		t.Fatalf("unable to add result: %v", err)
+	}
+
+	savedResult, err := storage.IdempotentResult("CreateInvoice", "1")
+	if err != nil {
+		t.Fatalf("unable to get result: %v", err)
+	}
+
+	if !reflect.DeepEqual(newResult, savedResult) {
+		t.Fatalf("result hasn't been replaced")
+	}
+
+	if err := storage.RemoveIdempotentResults(3); err != nil {
+		t.Fatalf("unable to remove results: %v", err)
+	}
+
+	for _, result := range results[1:] {
+		savedResult, err := storage.IdempotentResult(result.Method, result.Key)
+		if err != nil {
+			t.Fatalf("unable to get result: %v", err)
+		}
+
+		expectRemoved := result.Time < 3
+		if expectRemoved && savedResult != nil {
+			t.Fatalf("result(%v, %v) should be removed", result.Method,
+				result.Key)
+		} else if !expectRemoved && savedResult == nil {
+			t.Fatalf("result(%v, %v) shouldn't be removed", result.Method,
+				result.Key)
+		}
+	}
+
+	savedResult, err = storage.IdempotentResult("CreateInvoice", "1")
+	if err != nil {
+		t.Fatalf("unable to get result: %v", err)
+	}
+
+	if savedResult == nil {
+		t.Fatalf("replaced result shouldn't be removed")
+	}
+}
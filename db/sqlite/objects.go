@@ -80,3 +80,112 @@ type UserIDShortChanIDIndex struct {
 	ShortChannelID uint64 `gorm:"primary_key"`
 	UserID         string
 }
+
+type ChannelsSnapshot struct {
+	ID uint `gorm:"primary_key"`
+
+	Time        int64
+	NumChannels int
+	Capacity    int64
+
+	// ChannelIDs is the comma separated ids of opened channels.
+	ChannelIDs string
+}
+
+type GraphNodeState struct {
+	ID uint `gorm:"primary_key"`
+
+	NodeID string `gorm:"index"`
+	Alias  string
+
+	// Addresses is the comma separated network addresses of the node.
+	Addresses string
+
+	Time int64 `gorm:"index"`
+}
+
+type GraphChannelState struct {
+	ID uint `gorm:"primary_key"`
+
+	ShortChannelID uint64 `gorm:"index"`
+	ChannelID      string
+
+	Node1    string `gorm:"index"`
+	Node2    string `gorm:"index"`
+	Capacity int64
+
+	// Node1BaseFeeMsat and Node1FeeRatePPM are nil if first node hasn't
+	// announced its routing policy.
+	Node1BaseFeeMsat *int64
+	Node1FeeRatePPM  *int64
+
+	// Node2BaseFeeMsat and Node2FeeRatePPM are nil if second node hasn't
+	// announced its routing policy.
+	Node2BaseFeeMsat *int64
+	Node2FeeRatePPM  *int64
+
+	Closed       bool
+	ClosedHeight uint32
+	Time         int64 `gorm:"index"`
+}
+
+type IdempotentResult struct {
+	ID uint `gorm:"primary_key"`
+
+	Method string `gorm:"unique_index:idx_method_key"`
+	Key    string `gorm:"unique_index:idx_method_key"`
+
+	RequestHash string
+	Response    []byte
+	Time        int64 `gorm:"index"`
+}
+
+type FeePolicyUpdate struct {
+	ID uint `gorm:"primary_key"`
+
+	ChannelID string
+	NodeID    string
+
+	// OldBaseFeeMsat and OldFeeRatePPM are nil if the policy before the
+	// update was unknown.
+	OldBaseFeeMsat *int64
+	OldFeeRatePPM  *int64
+
+	NewBaseFeeMsat int64
+	NewFeeRatePPM  int64
+
+	LocalRatio  float64
+	VolumeRatio float64
+	Time        int64 `gorm:"index"`
+	DryRun      bool
+}
+
+type PeerEvent struct {
+	ID uint `gorm:"primary_key"`
+
+	NodeID string `gorm:"index"`
+	Type   string
+	Time   int64 `gorm:"index"`
+}
+
+type NodeAnnouncements struct {
+	ID uint `gorm:"primary_key"`
+
+	NodeID string `gorm:"unique_index:idx_node_day"`
+	Day    int64  `gorm:"unique_index:idx_node_day"`
+
+	Count            int
+	LastAnnouncement int64
+}
+
+type AnomalyEvent struct {
+	ID uint `gorm:"primary_key"`
+
+	Type        string
+	NodeID      string
+	Time        int64 `gorm:"index"`
+	Previous    float64
+	Current     float64
+	Change      float64
+	Description string
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"strings"
+)
+
+// AddChannelsSnapshot saves channels snapshot.
+//
+// NOTE: Part of the manager.SnapshotStorage interface.
+func (d *DB) AddChannelsSnapshot(snapshot *db.ChannelsSnapshot) error {
+	channelIDs := make([]string, len(snapshot.ChannelIDs))
+	for i, channelID := range snapshot.ChannelIDs {
+		channelIDs[i] = string(channelID)
+	}
+
+	return d.Create(&ChannelsSnapshot{
+		Time:        snapshot.Time,
+		NumChannels: snapshot.NumChannels,
+		Capacity:    int64(snapshot.Capacity),
+		ChannelIDs:  strings.Join(channelIDs, ","),
+	}).Error
+}
+
+// LastChannelsSnapshot returns the latest saved channels snapshot, nil is
+// returned if there is no snapshots.
+//
+// NOTE: Part of the manager.SnapshotStorage interface.
+func (d *DB) LastChannelsSnapshot() (*db.ChannelsSnapshot, error) {
+	snapshots, err := d.LastChannelsSnapshots(1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+
+	return snapshots[0], nil
+}
+
+// LastChannelsSnapshots returns the given number of the latest saved
+// channels snapshots, sorted from the oldest to the newest one.
+//
+// NOTE: Part of the manager.SnapshotStorage interface.
+func (d *DB) LastChannelsSnapshots(limit int) ([]*db.ChannelsSnapshot,
+	error) {
+
+	var records []ChannelsSnapshot
+	err := d.Order("id desc").Limit(limit).Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*db.ChannelsSnapshot, len(records))
+	for i, record := range records {
+		snapshot := &db.ChannelsSnapshot{
+			Time:        record.Time,
+			NumChannels: record.NumChannels,
+			Capacity:    btcutil.Amount(record.Capacity),
+		}
+
+		if record.ChannelIDs != "" {
+			for _, channelID := range strings.Split(record.ChannelIDs, ",") {
+				snapshot.ChannelIDs = append(snapshot.ChannelIDs,
+					lightning.ChannelID(channelID))
+			}
+		}
+
+		snapshots[len(records)-1-i] = snapshot
+	}
+
+	return snapshots, nil
+}
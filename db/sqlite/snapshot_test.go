@@ -0,0 +1,73 @@
+package sqlite
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"reflect"
+	"testing"
+)
+
+func TestChannelsSnapshotStorage(t *testing.T) {
+	storage, clear, err := MakeTestDB()
+	if err != nil {
+		t.Fatalf("unable to create test database: %v", err)
+	}
+	defer clear()
+
+	snapshot, err := storage.LastChannelsSnapshot()
+	if err != nil {
+		t.Fatalf("unable to get last snapshot: %v", err)
+	}
+
+	if snapshot != nil {
+		t.Fatalf("snapshot should be nil")
+	}
+
+	snapshots := []*db.ChannelsSnapshot{
+		{
+			Time:        1,
+			NumChannels: 2,
+			Capacity:    100,
+			ChannelIDs:  []lightning.ChannelID{"1:0", "2:0"},
+		},
+		{
+			Time:        2,
+			NumChannels: 0,
+			Capacity:    0,
+		},
+	}
+
+	for _, snapshot := range snapshots {
+		if err := storage.AddChannelsSnapshot(snapshot); err != nil {
+			t.Fatalf("unable to add snapshot: %v", err)
+		}
+
+		lastSnapshot, err := storage.LastChannelsSnapshot()
+		if err != nil {
+			t.Fatalf("unable to get last snapshot: %v", err)
+		}
+
+		if !reflect.DeepEqual(snapshot, lastSnapshot) {
+			t.Fatalf("wrong snapshot")
+		}
+	}
+
+	lastSnapshots, err := storage.LastChannelsSnapshots(10)
+	if err != nil {
+		t.Fatalf("unable to get last snapshots: %v", err)
+	}
+
+	if !reflect.DeepEqual(snapshots, lastSnapshots) {
+		t.Fatalf("wrong snapshots")
+	}
+
+	lastSnapshots, err = storage.LastChannelsSnapshots(1)
+	if err != nil {
+		t.Fatalf("unable to get last snapshots: %v", err)
+	}
+
+	if len(lastSnapshots) != 1 ||
+		!reflect.DeepEqual(snapshots[1], lastSnapshots[0]) {
+		t.Fatalf("wrong limited snapshots")
+	}
+}
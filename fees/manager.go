@@ -0,0 +1,317 @@
+package fees
+
+import (
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// volumePeriod is the period of time over which forwarding volume of
+	// the channel is calculated.
+	volumePeriod = time.Hour * 24 * 7
+
+	// significantRateChange is the relative change of fee rate, below which
+	// policy is not updated.
+	significantRateChange = 0.1
+)
+
+// Config is the config of fee policy manager.
+type Config struct {
+	// Client is the entity which gives us glimpse of information about
+	// lightning network, and is used to apply fee policies.
+	Client lightning.Client
+
+	// MetricsBackend is used to send metrics about state of hub in the
+	// monitoring subsystem.
+	MetricsBackend crypto.MetricsBackend
+
+	// Storage is used to persist the history of policy updates.
+	Storage Storage
+
+	Asset string
+
+	// DryRun if true, calculated policies are only logged and saved in
+	// the history, but not applied.
+	DryRun bool
+
+	// UpdatePeriod is the period of recalculation of channel fee policies.
+	UpdatePeriod time.Duration
+
+	// MinUpdateInterval is the minimal interval of time between two policy
+	// updates of the same channel. This is needed to avoid spamming the
+	// network with channel updates.
+	MinUpdateInterval time.Duration
+
+	// DefaultPolicy is the policy which is used for nodes which don't have
+	// their own policy.
+	DefaultPolicy *NodePolicy
+
+	// NodePolicies is the policies of particular nodes.
+	NodePolicies map[lightning.NodeID]*NodePolicy
+}
+
+// validate check that config is valid.
+func (c *Config) validate() error {
+	if c.Client == nil {
+		return errors.New("lightning client should be specified")
+	}
+
+	if c.MetricsBackend == nil {
+		return errors.New("metric backend should be specified")
+	}
+
+	if c.Storage == nil {
+		return errors.New("storage should be specified")
+	}
+
+	if c.Asset == "" {
+		return errors.New("asset should be specified")
+	}
+
+	if c.UpdatePeriod <= 0 {
+		return errors.New("update period should be positive")
+	}
+
+	if c.MinUpdateInterval <= 0 {
+		return errors.New("min update interval should be positive")
+	}
+
+	if c.DefaultPolicy == nil {
+		return errors.New("default policy should be specified")
+	}
+
+	if err := c.DefaultPolicy.validate(); err != nil {
+		return errors.Errorf("default policy is invalid: %v", err)
+	}
+
+	for nodeID, policy := range c.NodePolicies {
+		if err := policy.validate(); err != nil {
+			return errors.Errorf("node(%v) policy is invalid: %v",
+				nodeID, err)
+		}
+	}
+
+	return nil
+}
+
+// PolicyManager is the subsystem which updates routing fee policies of our
+// channels, based on local balance ratio, forwarding volume of the channel
+// and policy of the remote node.
+type PolicyManager struct {
+	started  int32
+	shutdown int32
+	wg       sync.WaitGroup
+	quit     chan struct{}
+
+	cfg *Config
+
+	// lastUpdates is the time of the last policy update of the channel,
+	// is used to rate limit channel updates.
+	lastUpdates map[lightning.ChannelID]int64
+}
+
+// NewPolicyManager creates new instance of fee policy manager.
+func NewPolicyManager(cfg *Config) (*PolicyManager, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Errorf("config is invalid: %v", err)
+	}
+
+	// Restore time of the recent updates, so that channels are not updated
+	// too often across restarts.
+	start := time.Now().Add(-cfg.MinUpdateInterval).Unix()
+	updates, err := cfg.Storage.FeePolicyUpdates(start, 0)
+	if err != nil {
+		return nil, errors.Errorf("unable to fetch recent policy "+
+			"updates: %v", err)
+	}
+
+	lastUpdates := make(map[lightning.ChannelID]int64)
+	for _, update := range updates {
+		lastUpdates[update.ChannelID] = update.Time
+	}
+
+	return &PolicyManager{
+		quit:        make(chan struct{}),
+		cfg:         cfg,
+		lastUpdates: lastUpdates,
+	}, nil
+}
+
+// Start launches goroutine which periodically updates fee policies of the
+// channels.
+func (pm *PolicyManager) Start() {
+	if !atomic.CompareAndSwapInt32(&pm.started, 0, 1) {
+		log.Warn("Fee policy manager already started")
+		return
+	}
+
+	pm.wg.Add(1)
+	go func() {
+		updateTicker := time.NewTicker(pm.cfg.UpdatePeriod)
+
+		defer func() {
+			log.Info("Stopped fee policy update goroutine")
+			pm.wg.Done()
+
+			updateTicker.Stop()
+		}()
+
+		log.Infof("Started fee policy update goroutine, dry run(%v)",
+			pm.cfg.DryRun)
+
+		for {
+			select {
+			case <-updateTicker.C:
+				if err := pm.updatePolicies(); err != nil {
+					log.Errorf("unable to update fee policies: %v", err)
+					continue
+				}
+			case <-pm.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop gracefully stops the fee policy manager.
+func (pm *PolicyManager) Stop(reason string) {
+	if !atomic.CompareAndSwapInt32(&pm.shutdown, 0, 1) {
+		log.Warn("Fee policy manager already shutdown")
+		return
+	}
+
+	close(pm.quit)
+	pm.wg.Wait()
+
+	log.Infof("Fee policy manager shutdown, reason(%v)", reason)
+}
+
+// History returns the list of the last policy updates, sorted from
+// the oldest to the newest one. If limit isn't zero, only the last limit
+// updates are returned.
+func (pm *PolicyManager) History(limit int) ([]*db.FeePolicyUpdate, error) {
+	return pm.cfg.Storage.FeePolicyUpdates(0, limit)
+}
+
+// nodePolicy returns policy of the given node, or default policy if node
+// doesn't have its own one.
+func (pm *PolicyManager) nodePolicy(nodeID lightning.NodeID) *NodePolicy {
+	if policy, ok := pm.cfg.NodePolicies[nodeID]; ok {
+		return policy
+	}
+
+	return pm.cfg.DefaultPolicy
+}
+
+// updatePolicies calculates fee policies of the active channels, and applies
+// them if they have changed significantly, and channel policy wasn't
+// updated recently.
+func (pm *PolicyManager) updatePolicies() error {
+	m := crypto.NewMetric(pm.cfg.Asset, common.GetFunctionName(),
+		pm.cfg.MetricsBackend)
+	defer m.Finish()
+
+	channels, err := pm.cfg.Client.Channels()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	policies, err := pm.cfg.Client.FeePolicies()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch fee policies: %v", err)
+	}
+
+	forwardPayments, err := pm.cfg.Client.ListForwardPayments()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch forward payments: %v", err)
+	}
+
+	now := time.Now().Unix()
+	start := now - int64(volumePeriod.Seconds())
+
+	// Aggregate volume which was forwarded from the channel, as far
+	// this volume is what drains the local balance.
+	volumes := make(map[lightning.ChannelID]btcutil.Amount)
+	for _, payment := range forwardPayments {
+		if payment.Time < start {
+			continue
+		}
+
+		volumes[payment.ToChannel] += payment.OutgoingAmount
+	}
+
+	for _, channel := range channels {
+		if !channel.IsActive() {
+			continue
+		}
+
+		lastUpdate, ok := pm.lastUpdates[channel.ChannelID]
+		if ok && now-lastUpdate < int64(pm.cfg.MinUpdateInterval.Seconds()) {
+			continue
+		}
+
+		state := channel.States[lightning.ChannelOpened].(*lightning.ChannelStateOpened)
+		overall := state.LocalBalance + state.RemoteBalance
+		if overall == 0 {
+			continue
+		}
+
+		localRatio := float64(state.LocalBalance) / float64(overall)
+		volumeRatio := float64(volumes[channel.ChannelID]) / float64(overall)
+
+		oldPolicy := policies[channel.ChannelID]
+		newPolicy := calculatePolicy(pm.nodePolicy(channel.NodeID),
+			localRatio, volumeRatio)
+
+		if !isSignificantChange(oldPolicy, newPolicy) {
+			continue
+		}
+
+		if !pm.cfg.DryRun {
+			if err := pm.cfg.Client.UpdateFeePolicy(channel.ChannelID,
+				newPolicy); err != nil {
+				m.AddError(metrics.HighSeverity)
+				log.Errorf("unable to update fee policy of channel(%v): %v",
+					channel.ChannelID, err)
+				continue
+			}
+		}
+
+		pm.lastUpdates[channel.ChannelID] = now
+
+		err := pm.cfg.Storage.AddFeePolicyUpdate(&db.FeePolicyUpdate{
+			ChannelID:   channel.ChannelID,
+			NodeID:      channel.NodeID,
+			Old:         oldPolicy,
+			New:         newPolicy,
+			LocalRatio:  localRatio,
+			VolumeRatio: volumeRatio,
+			Time:        now,
+			DryRun:      pm.cfg.DryRun,
+		})
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			log.Errorf("unable to save fee policy update of channel(%v): "+
+				"%v", channel.ChannelID, err)
+		}
+
+		log.Infof("Fee policy of channel(%v) with node(%v) updated, "+
+			"base fee(%v msat), fee rate(%v ppm), local ratio(%.2f), "+
+			"volume ratio(%.2f), dry run(%v)", channel.ChannelID,
+			channel.NodeID, newPolicy.BaseFeeMsat, newPolicy.FeeRatePPM,
+			localRatio, volumeRatio, pm.cfg.DryRun)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package fees
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/go-errors/errors"
+	"math"
+)
+
+// NodePolicy is the set of restrictions from which routing fee policy of
+// channels with the node is derived.
+type NodePolicy struct {
+	// BaseFeeMsat is the fixed fee in millisatoshis which is taken for
+	// every forwarded payment.
+	BaseFeeMsat int64
+
+	// MinFeeRatePPM is the fee rate which is used for channels with
+	// the biggest local balance and no forwarding activity.
+	MinFeeRatePPM int64
+
+	// MaxFeeRatePPM is the fee rate which is used for drained channels
+	// with high forwarding activity.
+	MaxFeeRatePPM int64
+}
+
+// validate check that node policy is valid.
+func (p *NodePolicy) validate() error {
+	if p.BaseFeeMsat < 0 {
+		return errors.New("base fee shouldn't be negative")
+	}
+
+	if p.MinFeeRatePPM < 0 {
+		return errors.New("min fee rate shouldn't be negative")
+	}
+
+	if p.MaxFeeRatePPM < p.MinFeeRatePPM {
+		return errors.New("max fee rate should be greater or equal to" +
+			" min fee rate")
+	}
+
+	return nil
+}
+
+// calculatePolicy returns the fee policy of the channel based on its local
+// balance ratio, and ratio of forwarded through the channel volume to the
+// channel balance.
+//
+// The less local balance channel has, the more expensive it is, so that
+// draining channels automatically become less attractive for routing.
+// Channels which are in high demand become more expensive as well.
+func calculatePolicy(policy *NodePolicy, localRatio,
+	volumeRatio float64) *lightning.FeePolicy {
+
+	minRate := float64(policy.MinFeeRatePPM)
+	maxRate := float64(policy.MaxFeeRatePPM)
+
+	rate := minRate + (maxRate-minRate)*(1-localRatio)
+	rate *= 1 + math.Min(volumeRatio, 1)
+
+	rate = math.Min(rate, maxRate)
+
+	return &lightning.FeePolicy{
+		BaseFeeMsat: policy.BaseFeeMsat,
+		FeeRatePPM:  int64(math.Round(rate)),
+	}
+}
+
+// isSignificantChange checks that difference between policies is big
+// enough to be worth of the policy update. This is needed to avoid
+// spamming the network with channel updates.
+func isSignificantChange(old, new *lightning.FeePolicy) bool {
+	if old == nil {
+		return true
+	}
+
+	if old.BaseFeeMsat != new.BaseFeeMsat {
+		return true
+	}
+
+	diff := math.Abs(float64(new.FeeRatePPM - old.FeeRatePPM))
+	return diff > float64(old.FeeRatePPM)*significantRateChange ||
+		(old.FeeRatePPM == 0 && new.FeeRatePPM != 0)
+}
@@ -0,0 +1,127 @@
+package fees
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"reflect"
+	"testing"
+)
+
+func TestCalculatePolicy(t *testing.T) {
+	policy := &NodePolicy{
+		BaseFeeMsat:   1000,
+		MinFeeRatePPM: 100,
+		MaxFeeRatePPM: 1000,
+	}
+
+	tests := []struct {
+		name        string
+		localRatio  float64
+		volumeRatio float64
+		rate        int64
+	}{
+		{
+			name:        "full local balance, no volume",
+			localRatio:  1,
+			volumeRatio: 0,
+			rate:        100,
+		},
+		{
+			name:        "drained channel, no volume",
+			localRatio:  0,
+			volumeRatio: 0,
+			rate:        1000,
+		},
+		{
+			name:        "half local balance, no volume",
+			localRatio:  0.5,
+			volumeRatio: 0,
+			rate:        550,
+		},
+		{
+			name:        "half local balance, half volume",
+			localRatio:  0.5,
+			volumeRatio: 0.5,
+			rate:        825,
+		},
+		{
+			name:        "full local balance, volume is capped",
+			localRatio:  1,
+			volumeRatio: 5,
+			rate:        200,
+		},
+		{
+			name:        "rate is capped by max rate",
+			localRatio:  0.2,
+			volumeRatio: 1,
+			rate:        1000,
+		},
+	}
+
+	for _, test := range tests {
+		newPolicy := calculatePolicy(policy, test.localRatio,
+			test.volumeRatio)
+
+		expected := &lightning.FeePolicy{
+			BaseFeeMsat: policy.BaseFeeMsat,
+			FeeRatePPM:  test.rate,
+		}
+
+		if !reflect.DeepEqual(newPolicy, expected) {
+			t.Fatalf("(%v) wrong policy: expected(%v), got(%v)",
+				test.name, expected, newPolicy)
+		}
+	}
+}
+
+func TestIsSignificantChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		old         *lightning.FeePolicy
+		new         *lightning.FeePolicy
+		significant bool
+	}{
+		{
+			name:        "unknown old policy",
+			old:         nil,
+			new:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 1},
+			significant: true,
+		},
+		{
+			name:        "base fee change",
+			old:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 100},
+			new:         &lightning.FeePolicy{BaseFeeMsat: 2, FeeRatePPM: 100},
+			significant: true,
+		},
+		{
+			name:        "rate change below threshold",
+			old:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 100},
+			new:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 109},
+			significant: false,
+		},
+		{
+			name:        "rate change equal to threshold",
+			old:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 100},
+			new:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 90},
+			significant: false,
+		},
+		{
+			name:        "rate change above threshold",
+			old:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 100},
+			new:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 111},
+			significant: true,
+		},
+		{
+			name:        "rate change from zero",
+			old:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 0},
+			new:         &lightning.FeePolicy{BaseFeeMsat: 1, FeeRatePPM: 1},
+			significant: true,
+		},
+	}
+
+	for _, test := range tests {
+		if isSignificantChange(test.old, test.new) != test.significant {
+			t.Fatalf("(%v) significant change should be %v", test.name,
+				test.significant)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package fees
+
+import (
+	"github.com/bitlum/hub/db"
+)
+
+// Storage is used to persist the history of channel fee policy updates.
+type Storage interface {
+	// AddFeePolicyUpdate saves the channel fee policy update.
+	AddFeePolicyUpdate(update *db.FeePolicyUpdate) error
+
+	// FeePolicyUpdates returns policy updates which were made at or after
+	// the given time, sorted from the oldest to the newest one. If limit
+	// isn't zero, only the last limit updates are returned.
+	FeePolicyUpdates(start int64, limit int) ([]*db.FeePolicyUpdate, error)
+}
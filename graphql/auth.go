@@ -0,0 +1,134 @@
+package graphql
+
+import (
+	"context"
+	"crypto/subtle"
+	"github.com/bitlum/hub/common"
+	"github.com/go-errors/errors"
+	"github.com/graphql-go/graphql"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// requestInfoKey is the key of the request info in the context of the
+// query execution.
+type requestInfoKey struct{}
+
+// requestInfo is the information about http request, which is needed to
+// authorize mutations.
+type requestInfo struct {
+	// token is the api token from the authorization header.
+	token string
+
+	// ip is the ip address of the client.
+	ip string
+
+	// queryOnce is used to authorize private queries once per request,
+	// so that the request is counted by the rate limiter only once,
+	// regardless of the number of private queries in it.
+	queryOnce sync.Once
+	queryErr  error
+}
+
+// withRequestInfo returns context with the info of the given request.
+func withRequestInfo(ctx context.Context, r *http.Request) context.Context {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return context.WithValue(ctx, requestInfoKey{}, &requestInfo{
+		token: strings.TrimSpace(strings.TrimPrefix(
+			r.Header.Get("Authorization"), "Bearer")),
+		ip: ip,
+	})
+}
+
+// protectMutation wraps mutation resolver with the check of the api token,
+// and the limit of the mutations rate of the client ip.
+func protectMutation(apiToken string, limiter *common.RateLimiter,
+	resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		if apiToken == "" {
+			return nil, errors.New("mutations are disabled")
+		}
+
+		info, err := getRequestInfo(rp)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := authorize(info, apiToken, limiter); err != nil {
+			return nil, err
+		}
+
+		return resolve(rp)
+	}
+}
+
+// protectQuery wraps resolver of the query, which exposes private
+// information about our node, such as public keys of peers and balances,
+// with the check of the api token, and the limit of the requests rate of
+// the client ip, which is checked once per request.
+func protectQuery(apiToken string, limiter *common.RateLimiter,
+	resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		if apiToken == "" {
+			return nil, errors.New("private queries are disabled")
+		}
+
+		info, err := getRequestInfo(rp)
+		if err != nil {
+			return nil, err
+		}
+
+		info.queryOnce.Do(func() {
+			info.queryErr = authorize(info, apiToken, limiter)
+		})
+		if info.queryErr != nil {
+			return nil, info.queryErr
+		}
+
+		return resolve(rp)
+	}
+}
+
+// getRequestInfo returns the info of the http request, in which the query
+// is executed.
+func getRequestInfo(rp graphql.ResolveParams) (*requestInfo, error) {
+	var info *requestInfo
+	if rp.Context != nil {
+		info, _ = rp.Context.Value(requestInfoKey{}).(*requestInfo)
+	}
+	if info == nil {
+		return nil, errors.New("request info not found")
+	}
+
+	return info, nil
+}
+
+// authorize checks the api token of the request, and the limit of the
+// requests rate of the client ip.
+func authorize(info *requestInfo, apiToken string,
+	limiter *common.RateLimiter) error {
+
+	// Rate is checked first, in order to limit the token guessing as
+	// well.
+	if !limiter.Allow(info.ip) {
+		return errors.Errorf("rate limit of ip(%v) exceeded, "+
+			"try again later", info.ip)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(info.token),
+		[]byte(apiToken)) != 1 {
+		return errors.New("invalid api token")
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package graphql
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/manager/stats"
+	"github.com/btcsuite/btcutil"
+)
+
+// Channel is our lightning network channel with the state of it.
+type Channel struct {
+	ChannelID string
+	NodeID    string
+	Alias     string
+	State     string
+	IsActive  bool
+	Initiator string
+
+	LocalBalance  int64
+	RemoteBalance int64
+	StuckBalance  int64
+	LockedBalance int64
+
+	CommitFee int64
+	OpenFee   int64
+	CloseFee  int64
+	SwipeFee  int64
+
+	OpeningTime int64
+	ClosingTime int64
+	CloseType   string
+}
+
+// Peer is the node with which we have channels or connection.
+type Peer struct {
+	NodeID      string
+	Alias       string
+	IsConnected bool
+
+	NumChannels       int
+	NumActiveChannels int
+
+	LocalBalance  int64
+	RemoteBalance int64
+}
+
+// NodeStats is the statistics of payments and channels with the node.
+type NodeStats struct {
+	Alias string
+	stats.NodeStats
+}
+
+// Balance is the funds of our node.
+type Balance struct {
+	Available int64
+	Pending   int64
+
+	LockedLocally  int64
+	LockedRemotely int64
+}
+
+// newChannel converts our channel, fields are populated from the states
+// which channel has passed, alias isn't populated.
+func newChannel(channel *lightning.Channel) *Channel {
+	c := &Channel{
+		ChannelID: string(channel.ChannelID),
+		NodeID:    string(channel.NodeID),
+		State:     string(channel.State),
+		IsActive:  channel.IsActive(),
+	}
+
+	if state, ok := channel.States[lightning.ChannelOpening]; ok {
+		s := state.(*lightning.ChannelStateOpening)
+		c.Initiator = string(s.Initiator)
+		c.OpenFee = int64(s.OpenFee)
+		c.CommitFee = int64(s.CommitFee)
+		c.OpeningTime = s.CreationTime
+		c.LocalBalance = int64(s.LocalBalance)
+		c.RemoteBalance = int64(s.RemoteBalance)
+	}
+
+	if state, ok := channel.States[lightning.ChannelOpened]; ok {
+		s := state.(*lightning.ChannelStateOpened)
+		c.CommitFee = int64(s.CommitFee)
+		c.StuckBalance = int64(s.StuckBalance)
+		c.LocalBalance = int64(s.LocalBalance)
+		c.RemoteBalance = int64(s.RemoteBalance)
+	}
+
+	if state, ok := channel.States[lightning.ChannelClosing]; ok {
+		s := state.(*lightning.ChannelStateClosing)
+		c.CloseFee = int64(s.CloseFee)
+		c.SwipeFee = int64(s.SwipeFee)
+		c.LockedBalance = int64(s.LockedBalance)
+		c.ClosingTime = s.CreationTime
+		c.CloseType = string(s.CloseType)
+		c.LocalBalance = int64(s.LocalBalance)
+		c.RemoteBalance = int64(s.RemoteBalance)
+	}
+
+	if state, ok := channel.States[lightning.ChannelClosed]; ok {
+		s := state.(*lightning.ChannelStateClosed)
+		c.CloseFee = int64(s.CloseFee)
+		c.LocalBalance = int64(s.LocalBalance)
+		c.RemoteBalance = 0
+		c.LockedBalance = 0
+	}
+
+	return c
+}
+
+// newPeers aggregates channels by the node, and adds connected nodes
+// without channels.
+func newPeers(channels []*lightning.Channel, connected []lightning.NodeID,
+	getAlias func(nodeID lightning.NodeID) string) map[lightning.NodeID]*Peer {
+
+	peers := make(map[lightning.NodeID]*Peer)
+	getPeer := func(nodeID lightning.NodeID) *Peer {
+		peer, ok := peers[nodeID]
+		if !ok {
+			peer = &Peer{
+				NodeID: string(nodeID),
+				Alias:  getAlias(nodeID),
+			}
+			peers[nodeID] = peer
+		}
+		return peer
+	}
+
+	for _, nodeID := range connected {
+		getPeer(nodeID).IsConnected = true
+	}
+
+	for _, channel := range channels {
+		if channel.State == lightning.ChannelClosed {
+			continue
+		}
+
+		peer := getPeer(channel.NodeID)
+		peer.NumChannels++
+		if channel.IsActive() {
+			peer.NumActiveChannels++
+		}
+
+		c := newChannel(channel)
+		peer.LocalBalance += c.LocalBalance
+		peer.RemoteBalance += c.RemoteBalance
+	}
+
+	return peers
+}
+
+// newBalance calculates funds locked in the channels which aren't closed.
+func newBalance(available, pending btcutil.Amount,
+	channels []*lightning.Channel) *Balance {
+
+	balance := &Balance{
+		Available: int64(available),
+		Pending:   int64(pending),
+	}
+
+	for _, channel := range channels {
+		if channel.State == lightning.ChannelClosed {
+			continue
+		}
+
+		c := newChannel(channel)
+		balance.LockedLocally += c.LocalBalance
+		balance.LockedRemotely += c.RemoteBalance
+	}
+
+	return balance
+}
@@ -3,6 +3,8 @@ package graphql
 import (
 	"errors"
 	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/manager/stats"
+	"github.com/bitlum/hub/topology"
 	"net"
 	"strconv"
 )
@@ -12,8 +14,58 @@ type Config struct {
 	ListenPort       string
 	SecureListenPort string
 
+	// TLSCertPath and TLSKeyPath are the paths of the certificate and key,
+	// which are used to serve GraphQL over https on the secure listen
+	// port. If they aren't specified, only http is served.
+	TLSCertPath string
+	TLSKeyPath  string
+
+	// TLSSelfSigned denotes that self-signed certificate and key should be
+	// generated if files don't exist.
+	TLSSelfSigned bool
+
+	// TLSExtraIPs and TLSExtraDomains are added to the self-signed
+	// certificate, in addition to the local addresses.
+	TLSExtraIPs     []string
+	TLSExtraDomains []string
+
+	// RedirectHTTP denotes that http requests should be redirected to
+	// https, instead of being served.
+	RedirectHTTP bool
+
+	// APIToken is the token which is required to execute mutations,
+	// private queries and subscriptions, if it isn't specified mutations
+	// and private queries are disabled, and subscriptions are public.
+	APIToken string
+
+	// MutationsPerMinute is the number of mutations which client ip is
+	// allowed to execute per minute.
+	MutationsPerMinute int
+
+	// QueriesPerMinute is the number of requests with private queries
+	// which client ip is allowed to execute per minute, request is
+	// counted once regardless of the number of private queries in it.
+	QueriesPerMinute int
+
 	Client   lightning.Client
 	GetAlias func(nodeID lightning.NodeID) string
+
+	// Streamer is used to receive payments and channel updates, which are
+	// sent to subscribers.
+	Streamer lightning.UpdatesStreamer
+
+	// FindNodesByAlias returns nodes which alias contains the given
+	// string.
+	FindNodesByAlias func(alias string) []lightning.NodeID
+
+	// GetNodeStats returns statistics of payments and channels with nodes
+	// over [start, end) range of time.
+	GetNodeStats func(start, end int64) (map[lightning.NodeID]stats.NodeStats,
+		error)
+
+	// GetNetworkStats returns the last calculated economics statistics of
+	// the lightning network, nil if it hasn't been calculated yet.
+	GetNetworkStats func() *topology.NetworkStats
 }
 
 func (c Config) validate() error {
@@ -50,6 +102,24 @@ func (c Config) validate() error {
 			" equal to listen port")
 	}
 
+	if (c.TLSCertPath == "") != (c.TLSKeyPath == "") {
+		return errors.New("tls cert and key paths should be specified " +
+			"together")
+	}
+
+	if c.RedirectHTTP && !c.tlsEnabled() {
+		return errors.New("http could be redirected only if tls is " +
+			"enabled")
+	}
+
+	if c.APIToken != "" && c.MutationsPerMinute <= 0 {
+		return errors.New("mutations per minute should be positive")
+	}
+
+	if c.APIToken != "" && c.QueriesPerMinute <= 0 {
+		return errors.New("queries per minute should be positive")
+	}
+
 	if c.Client == nil {
 		return errors.New("client should be specified")
 	}
@@ -58,6 +128,22 @@ func (c Config) validate() error {
 		return errors.New("get alias func should be specified")
 	}
 
+	if c.Streamer == nil {
+		return errors.New("streamer should be specified")
+	}
+
+	if c.FindNodesByAlias == nil {
+		return errors.New("find nodes by alias func should be specified")
+	}
+
+	if c.GetNodeStats == nil {
+		return errors.New("get node stats func should be specified")
+	}
+
+	if c.GetNetworkStats == nil {
+		return errors.New("get network stats func should be specified")
+	}
+
 	return nil
 }
 
@@ -75,3 +161,17 @@ func (c Config) listenAddr() string {
 	}
 	return net.JoinHostPort(c.ListenIP, c.ListenPort)
 }
+
+// secureListenAddr forms listen addr using defined `ListenIP` and
+// `SecureListenPort`
+func (c Config) secureListenAddr() string {
+	if c.ListenIP == "" {
+		return ":" + c.SecureListenPort
+	}
+	return net.JoinHostPort(c.ListenIP, c.SecureListenPort)
+}
+
+// tlsEnabled returns true if GraphQL should be served over https.
+func (c Config) tlsEnabled() bool {
+	return c.TLSCertPath != ""
+}
@@ -0,0 +1,308 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"github.com/go-errors/errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"strings"
+)
+
+const (
+	// defaultPageSize is the number of items which is returned if page
+	// size isn't specified.
+	defaultPageSize = 50
+
+	// maxPageSize is the maximum number of items which could be requested
+	// in one page.
+	maxPageSize = 1000
+)
+
+// Connection is the page of the list, with the information needed to
+// request the next one.
+type Connection struct {
+	Edges      []*Edge
+	PageInfo   *PageInfo
+	TotalCount int
+}
+
+// Edge is the item of the list with the cursor which points on it.
+type Edge struct {
+	Cursor string
+	Node   interface{}
+}
+
+// PageInfo describes the position of the page in the list.
+type PageInfo struct {
+	StartCursor     string
+	EndCursor       string
+	HasNextPage     bool
+	HasPreviousPage bool
+}
+
+// connectionItem is the item of the list and the key which uniquely
+// identifies it within the list.
+type connectionItem struct {
+	key  string
+	node interface{}
+}
+
+// encodeCursor converts the key of the item in the opaque cursor.
+func encodeCursor(kind, key string) string {
+	return base64.StdEncoding.EncodeToString([]byte(kind + ":" + key))
+}
+
+// decodeCursor converts the opaque cursor in the key of the item.
+func decodeCursor(kind, cursor string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", errors.Errorf("invalid cursor(%v): %v", cursor, err)
+	}
+
+	prefix := kind + ":"
+	if !strings.HasPrefix(string(data), prefix) {
+		return "", errors.Errorf("cursor(%v) doesn't belong to %v list",
+			cursor, kind)
+	}
+
+	return strings.TrimPrefix(string(data), prefix), nil
+}
+
+// connectionArgs returns pagination arguments, extended with the given
+// filter arguments.
+func connectionArgs(filters graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{
+			Description: "First is the number of items to return",
+			Type:        graphql.Int,
+		},
+		"after": &graphql.ArgumentConfig{
+			Description: "After is the cursor of the item, after which " +
+				"items are returned",
+			Type: graphql.String,
+		},
+	}
+
+	for name, arg := range filters {
+		args[name] = arg
+	}
+
+	return args
+}
+
+// pageSize returns the number of items in the page, which is requested by
+// "first" argument.
+func pageSize(args map[string]interface{}) (int, error) {
+	first := defaultPageSize
+	if value, ok := args["first"].(int); ok {
+		first = value
+	}
+
+	if first < 0 || first > maxPageSize {
+		return 0, errors.Errorf("first(%v) should be within [0, %v] "+
+			"range", first, maxPageSize)
+	}
+
+	return first, nil
+}
+
+// newConnection returns the page of the given ordered items, which goes
+// after the item pointed by cursor of "after" argument.
+func newConnection(kind string, items []*connectionItem,
+	args map[string]interface{}) (*Connection, error) {
+
+	first, err := pageSize(args)
+	if err != nil {
+		return nil, err
+	}
+
+	start := 0
+	if cursor, ok := args["after"].(string); ok && cursor != "" {
+		key, err := decodeCursor(kind, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		start = -1
+		for i, item := range items {
+			if item.key == key {
+				start = i + 1
+				break
+			}
+		}
+
+		if start == -1 {
+			return nil, errors.Errorf("item of cursor(%v) not found",
+				cursor)
+		}
+	}
+
+	return newPage(kind, items, start, first), nil
+}
+
+// newPage returns the page of the given ordered items, which starts from
+// the given position.
+func newPage(kind string, items []*connectionItem, start,
+	first int) *Connection {
+
+	end := start + first
+	if end > len(items) {
+		end = len(items)
+	}
+
+	connection := &Connection{
+		PageInfo: &PageInfo{
+			HasNextPage:     end < len(items),
+			HasPreviousPage: start > 0,
+		},
+		TotalCount: len(items),
+	}
+
+	for _, item := range items[start:end] {
+		connection.Edges = append(connection.Edges, &Edge{
+			Cursor: encodeCursor(kind, item.key),
+			Node:   item.node,
+		})
+	}
+
+	if len(connection.Edges) != 0 {
+		connection.PageInfo.StartCursor = connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = connection.Edges[len(connection.Edges)-1].Cursor
+	}
+
+	return connection
+}
+
+// isFieldRequested checks that field with the given name is selected in
+// the resolved field, fields of fragments are checked as well.
+func isFieldRequested(rp graphql.ResolveParams, name string) bool {
+	var check func(selectionSet *ast.SelectionSet) bool
+	check = func(selectionSet *ast.SelectionSet) bool {
+		if selectionSet == nil {
+			return false
+		}
+
+		for _, selection := range selectionSet.Selections {
+			switch s := selection.(type) {
+			case *ast.Field:
+				if s.Name != nil && s.Name.Value == name {
+					return true
+				}
+
+			case *ast.InlineFragment:
+				if check(s.SelectionSet) {
+					return true
+				}
+
+			case *ast.FragmentSpread:
+				definition := rp.Info.Fragments[s.Name.Value]
+				fragment, ok := definition.(*ast.FragmentDefinition)
+				if ok && check(fragment.SelectionSet) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+
+	for _, field := range rp.Info.FieldASTs {
+		if check(field.SelectionSet) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var typePageInfo = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "PageInfo",
+	Description: "PageInfo describes the position of the page in the list",
+	Fields: graphql.Fields{
+		"startCursor": &graphql.Field{
+			Description: "StartCursor is the cursor of the first item of" +
+				" the page",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*PageInfo).StartCursor, nil
+			},
+		},
+		"endCursor": &graphql.Field{
+			Description: "EndCursor is the cursor of the last item of" +
+				" the page, which is used to request the next page",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*PageInfo).EndCursor, nil
+			},
+		},
+		"hasNextPage": &graphql.Field{
+			Description: "HasNextPage is true if there are items after the" +
+				" page",
+			Type: graphql.NewNonNull(graphql.Boolean),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*PageInfo).HasNextPage, nil
+			},
+		},
+		"hasPreviousPage": &graphql.Field{
+			Description: "HasPreviousPage is true if there are items before" +
+				" the page",
+			Type: graphql.NewNonNull(graphql.Boolean),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*PageInfo).HasPreviousPage, nil
+			},
+		},
+	},
+})
+
+// newConnectionType creates the type of the list page of the given items.
+func newConnectionType(name string, nodeType *graphql.Object) *graphql.Object {
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Edge",
+		Description: "Edge is the item of the list with its cursor",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{
+				Description: "Cursor is the opaque pointer on the item",
+				Type:        graphql.NewNonNull(graphql.String),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					return rp.Source.(*Edge).Cursor, nil
+				},
+			},
+			"node": &graphql.Field{
+				Description: "Node is the item of the list",
+				Type:        graphql.NewNonNull(nodeType),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					return rp.Source.(*Edge).Node, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name:        name + "Connection",
+		Description: "Connection is the page of the list",
+		Fields: graphql.Fields{
+			"edges": &graphql.Field{
+				Description: "Edges is the items of the page",
+				Type:        graphql.NewNonNull(graphql.NewList(edgeType)),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					return rp.Source.(*Connection).Edges, nil
+				},
+			},
+			"pageInfo": &graphql.Field{
+				Description: "PageInfo is the position of the page in the list",
+				Type:        graphql.NewNonNull(typePageInfo),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					return rp.Source.(*Connection).PageInfo, nil
+				},
+			},
+			"totalCount": &graphql.Field{
+				Description: "TotalCount is the number of items in the" +
+					" list, which satisfy the filters",
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+					return rp.Source.(*Connection).TotalCount, nil
+				},
+			},
+		},
+	})
+}
@@ -37,12 +37,58 @@ query GetHubInfo {
 }
 
 # Q: Could I see the activity in the local network of the hub?
-# A: Yeah, you could see payments with obfuscated aliases.
+# A: Yeah, you could see payments with obfuscated aliases. Payments are
+# returned by pages, pass "endCursor" as "after" argument to get the next
+# one, and use "type", "startTime", "endTime", "minAmount", "maxAmount"
+# and "peer" arguments to filter them.
 #
 # Press "Run", and "GetPayments" to execute the
 # query.
 query GetPayments {
-  payments {
+  payments(first: 20) {
+    totalCount
+    pageInfo {
+      endCursor
+      hasNextPage
+    }
+    edges {
+      node {
+        fromPeer
+        toPeer
+        time
+        amount
+        type
+        status
+      }
+    }
+  }
+}
+
+# Q: Could I create an invoice to pay to hub?
+# A: Yes, if you have an api token, specify it in the
+# "Authorization: Bearer <token>" header, and execute the mutation.
+#
+# Press "Run", and "CreateInvoice" to execute the
+# mutation.
+mutation CreateInvoice {
+  createInvoice(amount: 1000, description: "donation") {
+    invoice
+    paymentHash
+    amount
+    expiry
+  }
+}
+
+# Q: Could I see the activity in the real time?
+# A: Yes, subscribe on the payments, forwards or channel state changes,
+# and events will be shown as soon as they happen. If api token is
+# configured, it should be sent as "authToken" in the connection init
+# payload.
+#
+# Press "Run", and "OnPaymentAdded" to execute the
+# subscription.
+subscription OnPaymentAdded {
+  paymentAdded {
     fromPeer
     toPeer
     time
@@ -7,8 +7,9 @@ import (
 )
 
 type graphiQLParams struct {
-	QueryPath string
-	FAQ       string
+	QueryPath         string
+	SubscriptionsPath string
+	FAQ               string
 }
 
 func renderGraphiQL(p graphiQLParams) ([]byte, error) {
@@ -49,6 +50,8 @@ const graphiQlTemplate = `<!DOCTYPE html>
   <script src="//unpkg.com/react-dom@15.6.1/dist/react-dom.min.js"></script>
   <script src="//unpkg.com/graphiql@0.11.11/graphiql.min.js"></script>
   <script src="//cdn.jsdelivr.net/fetch/2.0.1/fetch.min.js"></script>
+  <script src="//unpkg.com/subscriptions-transport-ws@0.9.15/browser/client.js"></script>
+  <script src="//unpkg.com/graphiql-subscriptions-fetcher@0.0.2/browser/client.js"></script>
 </head>
 <body>
   <script>
@@ -72,10 +75,19 @@ const graphiQlTemplate = `<!DOCTYPE html>
         }
       });}
 
+    // Subscriptions are served over websocket, and other requests over
+    // http.
+    var subscriptionsURL = (location.protocol === 'https:' ? 'wss://' :
+      'ws://') + location.host + '{{ .SubscriptionsPath }}';
+    var subscriptionsClient = new window.SubscriptionsTransportWs.SubscriptionClient(
+      subscriptionsURL, {reconnect: true});
+    var graphQLFetcher = window.GraphiQLSubscriptionsFetcher.graphQLFetcher(
+      subscriptionsClient, graphQLHttpFetcher);
+
     // Render <GraphiQL /> into the body.
     ReactDOM.render(
       React.createElement(GraphiQL, {
-        fetcher: graphQLHttpFetcher,
+        fetcher: graphQLFetcher,
         query: "{{ .FAQ }}",
       }),
       document.body
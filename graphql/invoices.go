@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"encoding/hex"
+	"github.com/lightningnetwork/lnd/zpay32"
+)
+
+// Invoice is the lightning network invoice.
+type Invoice struct {
+	Invoice         string
+	PaymentHash     string
+	Amount          int64
+	Description     string
+	CreationDate    int64
+	Expiry          int64
+	Destination     string
+	FallbackAddress string
+}
+
+// newInvoice converts decoded lightning network invoice.
+func newInvoice(paymentRequest string, invoice *zpay32.Invoice) *Invoice {
+	i := &Invoice{
+		Invoice:      paymentRequest,
+		CreationDate: invoice.Timestamp.Unix(),
+		Expiry:       int64(invoice.Expiry().Seconds()),
+	}
+
+	if invoice.PaymentHash != nil {
+		i.PaymentHash = hex.EncodeToString(invoice.PaymentHash[:])
+	}
+
+	if invoice.MilliSat != nil {
+		i.Amount = int64(invoice.MilliSat.ToSatoshis())
+	}
+
+	if invoice.Description != nil {
+		i.Description = *invoice.Description
+	}
+
+	if invoice.Destination != nil {
+		i.Destination = hex.EncodeToString(
+			invoice.Destination.SerializeCompressed())
+	}
+
+	if invoice.FallbackAddr != nil {
+		i.FallbackAddress = invoice.FallbackAddr.String()
+	}
+
+	return i
+}
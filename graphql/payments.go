@@ -1,5 +1,14 @@
 package graphql
 
+import (
+	"fmt"
+	"github.com/bitlum/hub/lightning"
+	"github.com/go-errors/errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
 type Payment struct {
 	FromNode string
 	ToNode   string
@@ -7,4 +16,159 @@ type Payment struct {
 	Status   string
 	Time     int64
 	Type     string
+
+	// id uniquely identifies payment among the payments with the same
+	// time.
+	id string
+}
+
+// ChannelState is the change of our channel state.
+type ChannelState struct {
+	ChannelID string
+	Node      string
+	PrevState string
+	State     string
+	Time      int64
+}
+
+// newPayment converts our incoming or outgoing payment.
+func newPayment(payment *lightning.Payment,
+	getAlias func(nodeID lightning.NodeID) string) *Payment {
+
+	var fromNode, toNode string
+	if payment.Direction == lightning.Outgoing {
+		fromNode = "bitlum.io"
+		toNode = getAlias(payment.Receiver)
+	} else if payment.Direction == lightning.Incoming {
+		toNode = "bitlum.io"
+		fromNode = getAlias(payment.Sender)
+	}
+
+	return &Payment{
+		FromNode: fromNode,
+		ToNode:   toNode,
+		Amount:   int64(payment.Amount),
+		// TODO(andrew.shvv) remove compatibility
+		Status: "successful",
+		Time:   payment.UpdatedAt,
+		Type:   strings.ToLower(string(payment.Direction)),
+		id: fmt.Sprintf("%v:%v", payment.Direction,
+			payment.PaymentHash),
+	}
+}
+
+// newForwardPayment converts payment which has been forwarded through our
+// node.
+func newForwardPayment(payment *lightning.ForwardPayment,
+	getAlias func(nodeID lightning.NodeID) string) *Payment {
+
+	return &Payment{
+		FromNode: getAlias(payment.FromNode),
+		ToNode:   getAlias(payment.ToNode),
+		Amount:   int64(payment.OutgoingAmount),
+		// TODO(andrew.shvv) remove compatibility
+		Status: "successful",
+		Time:   payment.Time,
+		// TODO(andrew.shvv) remove compatibility
+		Type: "forward",
+		id: fmt.Sprintf("forward:%v:%v:%v", payment.FromChannel,
+			payment.ToChannel, int64(payment.IncomingAmount)),
+	}
+}
+
+// paymentCursor is the position of the payment in the list of payments,
+// which are ordered by time, newest first, and by id within the same time.
+// Number of payments which precede it within the same time is used to
+// limit the number of payments which are fetched for the next page.
+type paymentCursor struct {
+	time int64
+	skip int
+	id   string
+}
+
+// before checks that cursor precedes the given payment in the list.
+func (c *paymentCursor) before(payment *Payment) bool {
+	if payment.Time != c.time {
+		return payment.Time < c.time
+	}
+
+	return payment.id > c.id
+}
+
+// key returns the key of the cursor, which is used to encode it.
+func (c *paymentCursor) key() string {
+	return fmt.Sprintf("%v:%v:%v", c.time, c.skip, c.id)
+}
+
+// decodePaymentCursor converts the opaque cursor in the payment position.
+func decodePaymentCursor(cursor string) (*paymentCursor, error) {
+	key, err := decodeCursor("payment", cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.SplitN(key, ":", 3)
+	if len(parts) != 3 {
+		return nil, errors.Errorf("invalid cursor(%v)", cursor)
+	}
+
+	time, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid cursor(%v) time: %v", cursor,
+			err)
+	}
+
+	skip, err := strconv.Atoi(parts[1])
+	if err != nil || skip < 0 {
+		return nil, errors.Errorf("invalid cursor(%v) position", cursor)
+	}
+
+	return &paymentCursor{
+		time: time,
+		skip: skip,
+		id:   parts[2],
+	}, nil
+}
+
+// newPaymentItems orders payments, newest first, and converts them to the
+// list items, which keys are the cursors of payments.
+func newPaymentItems(payments []*Payment) []*connectionItem {
+	sort.Slice(payments, func(i, j int) bool {
+		if payments[i].Time != payments[j].Time {
+			return payments[i].Time > payments[j].Time
+		}
+		return payments[i].id < payments[j].id
+	})
+
+	items := make([]*connectionItem, len(payments))
+	skip := 0
+	for i, payment := range payments {
+		if i > 0 && payment.Time == payments[i-1].Time {
+			skip++
+		} else {
+			skip = 0
+		}
+
+		cursor := &paymentCursor{
+			time: payment.Time,
+			skip: skip,
+			id:   payment.id,
+		}
+		items[i] = &connectionItem{key: cursor.key(), node: payment}
+	}
+
+	return items
+}
+
+// newChannelState converts update of our channel state.
+func newChannelState(update *lightning.UpdateChannelState,
+	getAlias func(nodeID lightning.NodeID) string) *ChannelState {
+
+	return &ChannelState{
+		ChannelID: string(update.ChannelID),
+		Node:      getAlias(update.NodeID),
+		PrevState: string(update.PrevState),
+		State:     string(update.State),
+		Time:      update.Time,
+	}
 }
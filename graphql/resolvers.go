@@ -2,10 +2,13 @@ package graphql
 
 import (
 	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/manager/stats"
+	"github.com/bitlum/hub/topology"
+	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
 	"github.com/graphql-go/graphql"
 	"sort"
-	"strings"
+	"time"
 )
 
 func getInfoResolver(client lightning.Client) graphql.FieldResolveFn {
@@ -16,66 +19,385 @@ func getInfoResolver(client lightning.Client) graphql.FieldResolveFn {
 }
 
 func getPaymentsResolver(client lightning.PaymentClient,
-	getAlias func(nodeID lightning.NodeID) string) graphql.FieldResolveFn {
+	getAlias func(nodeID lightning.NodeID) string,
+	findNodesByAlias func(alias string) []lightning.NodeID) graphql.FieldResolveFn {
 	return func(rp graphql.ResolveParams) (
 		interface{}, error) {
 
+		filter := lightning.PaymentsFilter{
+			Status: lightning.Completed,
+		}
+
+		if start, ok := rp.Args["startTime"].(int); ok {
+			filter.Start = int64(start)
+		}
+
+		if end, ok := rp.Args["endTime"].(int); ok {
+			filter.End = int64(end)
+		}
+
+		if filter.Start < 0 || filter.End < 0 ||
+			(filter.End != 0 && filter.Start >= filter.End) {
+			return nil, errors.Errorf("startTime(%v) and endTime(%v) "+
+				"should be positive, and startTime should be less than "+
+				"endTime", filter.Start, filter.End)
+		}
+
+		if minAmount, ok := rp.Args["minAmount"].(int); ok {
+			filter.MinAmount = btcutil.Amount(minAmount)
+		}
+
+		if maxAmount, ok := rp.Args["maxAmount"].(int); ok {
+			filter.MaxAmount = btcutil.Amount(maxAmount)
+		}
+
+		if filter.MinAmount < 0 || filter.MaxAmount < 0 ||
+			(filter.MaxAmount != 0 && filter.MinAmount > filter.MaxAmount) {
+			return nil, errors.Errorf("minAmount(%v) and maxAmount(%v) "+
+				"should be positive, and minAmount shouldn't be greater "+
+				"than maxAmount", int64(filter.MinAmount),
+				int64(filter.MaxAmount))
+		}
+
+		if peer, ok := rp.Args["peer"].(string); ok && peer != "" {
+			filter.Nodes = findNodesByAlias(peer)
+		}
+
+		paymentType, _ := rp.Args["type"].(string)
+		switch paymentType {
+		case "incoming":
+			filter.Direction = lightning.Incoming
+		case "outgoing":
+			filter.Direction = lightning.Outgoing
+		}
+
+		first, err := pageSize(rp.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		var cursor *paymentCursor
+		if after, ok := rp.Args["after"].(string); ok && after != "" {
+			cursor, err = decodePaymentCursor(after)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Total count requires all payments, otherwise only payments
+		// which could get in the page are fetched: the ones not newer
+		// than the cursor, including payments which precede the cursor
+		// within the same second, and one more to check the next page.
+		if !isFieldRequested(rp, "totalCount") {
+			filter.Limit = first + 2
+			if cursor != nil {
+				filter.Limit += cursor.skip
+				if filter.End == 0 || cursor.time+1 < filter.End {
+					filter.End = cursor.time + 1
+				}
+			}
+		}
+
 		var payments []*Payment
 
-		directPayments, err := client.ListPayments("", lightning.AllStatuses,
-			lightning.AllDirections, lightning.AllSystems)
+		if paymentType != "forward" {
+			incomingOutgoing, err := client.FilterPayments(filter)
+			if err != nil {
+				return nil, errors.Errorf("unable to list payments: %v", err)
+			}
+
+			for _, payment := range incomingOutgoing {
+				payments = append(payments, newPayment(payment, getAlias))
+			}
+		}
+
+		if paymentType == "" || paymentType == "forward" {
+			forwardPayments, err := client.FilterForwardPayments(filter)
+			if err != nil {
+				return nil, errors.Errorf("unable to list forward payments: %v",
+					err)
+			}
+
+			for _, payment := range forwardPayments {
+				payments = append(payments, newForwardPayment(payment,
+					getAlias))
+			}
+		}
+
+		items := newPaymentItems(payments)
+
+		start := 0
+		if cursor != nil {
+			start = len(items)
+			for i, item := range items {
+				if cursor.before(item.node.(*Payment)) {
+					start = i
+					break
+				}
+			}
+		}
+
+		return newPage("payment", items, start, first), nil
+	}
+}
+
+func getNetworkStatsResolver(
+	getNetworkStats func() *topology.NetworkStats) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		stats := getNetworkStats()
+		if stats == nil {
+			return nil, errors.New("network stats haven't been calculated yet")
+		}
+
+		return stats, nil
+	}
+}
+
+// getEventResolver returns the event which has been passed as the root
+// object on the subscription execution.
+func getEventResolver() graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		events, ok := rp.Info.RootValue.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("event is available only for " +
+				"subscriptions")
+		}
+
+		return events[rp.Info.FieldName], nil
+	}
+}
+
+func getChannelsResolver(client lightning.TopologyClient,
+	getAlias func(nodeID lightning.NodeID) string) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		channels, err := client.Channels()
+		if err != nil {
+			return nil, errors.Errorf("unable to fetch channels: %v", err)
+		}
+
+		state, _ := rp.Args["state"].(string)
+		nodeID, _ := rp.Args["nodeId"].(string)
+		active, filterActive := rp.Args["active"].(bool)
+
+		var filtered []*Channel
+		for _, channel := range channels {
+			if state != "" && string(channel.State) != state {
+				continue
+			}
+
+			if nodeID != "" && string(channel.NodeID) != nodeID {
+				continue
+			}
+
+			if filterActive && channel.IsActive() != active {
+				continue
+			}
+
+			c := newChannel(channel)
+			c.Alias = getAlias(channel.NodeID)
+			filtered = append(filtered, c)
+		}
+
+		// Newest channels go first.
+		sort.Slice(filtered, func(i, j int) bool {
+			if filtered[i].OpeningTime != filtered[j].OpeningTime {
+				return filtered[i].OpeningTime > filtered[j].OpeningTime
+			}
+			return filtered[i].ChannelID < filtered[j].ChannelID
+		})
+
+		items := make([]*connectionItem, len(filtered))
+		for i, channel := range filtered {
+			items[i] = &connectionItem{key: channel.ChannelID, node: channel}
+		}
+
+		return newConnection("channel", items, rp.Args)
+	}
+}
+
+func getPeersResolver(client lightning.TopologyClient,
+	getAlias func(nodeID lightning.NodeID) string) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		channels, err := client.Channels()
 		if err != nil {
-			return nil, errors.Errorf("unable to list payments: %v", err)
+			return nil, errors.Errorf("unable to fetch channels: %v", err)
 		}
 
-		forwardPayments, err := client.ListForwardPayments()
+		connected, err := client.ConnectedPeers()
 		if err != nil {
-			return nil, errors.Errorf("unable to list forward payments: %v",
+			return nil, errors.Errorf("unable to fetch connected peers: %v",
 				err)
 		}
 
-		for _, payment := range directPayments {
-			if payment.Status != lightning.Completed {
+		isConnected, filterConnected := rp.Args["connected"].(bool)
+		withChannels, filterChannels := rp.Args["withChannels"].(bool)
+
+		var filtered []*Peer
+		for _, peer := range newPeers(channels, connected, getAlias) {
+			if filterConnected && peer.IsConnected != isConnected {
 				continue
 			}
 
-			var fromNode, toNode string
-			if payment.Direction == lightning.Outgoing {
-				fromNode = "bitlum.io"
-				toNode = getAlias(payment.Receiver)
-			} else if payment.Direction == lightning.Incoming {
-				toNode = "bitlum.io"
-				fromNode = getAlias("random string")
+			if filterChannels && (peer.NumChannels != 0) != withChannels {
+				continue
 			}
 
-			payments = append(payments, &Payment{
-				FromNode: fromNode,
-				ToNode:   toNode,
-				Amount:   int64(payment.Amount),
-				// TODO(andrew.shvv) remove compatibility
-				Status: "successful",
-				Time:   payment.UpdatedAt,
-				Type:   strings.ToLower(string(payment.Direction)),
-			})
+			filtered = append(filtered, peer)
+		}
+
+		// Peers with the biggest capacity go first.
+		sort.Slice(filtered, func(i, j int) bool {
+			ci := filtered[i].LocalBalance + filtered[i].RemoteBalance
+			cj := filtered[j].LocalBalance + filtered[j].RemoteBalance
+			if ci != cj {
+				return ci > cj
+			}
+			return filtered[i].NodeID < filtered[j].NodeID
+		})
+
+		items := make([]*connectionItem, len(filtered))
+		for i, peer := range filtered {
+			items[i] = &connectionItem{key: peer.NodeID, node: peer}
 		}
 
-		for _, payment := range forwardPayments {
-			payments = append(payments, &Payment{
-				FromNode: getAlias(payment.FromNode),
-				ToNode:   getAlias(payment.ToNode),
-				Amount:   int64(payment.OutgoingAmount),
-				// TODO(andrew.shvv) remove compatibility
-				Status: "successful",
-				Time:   payment.Time,
-				// TODO(andrew.shvv) remove compatibility
-				Type: "forward",
+		return newConnection("peer", items, rp.Args)
+	}
+}
+
+func getNodeStatsResolver(
+	getNodeStats func(start, end int64) (map[lightning.NodeID]stats.NodeStats,
+		error),
+	getAlias func(nodeID lightning.NodeID) string) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		period, _ := rp.Args["period"].(string)
+		start, end, err := stats.PeriodRange(stats.Period(period),
+			time.Now().Unix())
+		if err != nil {
+			return nil, err
+		}
+
+		nodeStats, err := getNodeStats(start, end)
+		if err != nil {
+			return nil, errors.Errorf("unable to get node stats: %v", err)
+		}
+
+		nodeID, _ := rp.Args["nodeId"].(string)
+		withPayments, _ := rp.Args["withPayments"].(bool)
+
+		var filtered []*NodeStats
+		for id, stat := range nodeStats {
+			if nodeID != "" && string(id) != nodeID {
+				continue
+			}
+
+			numPayments := stat.NumSentPayments + stat.NumReceivedPayments +
+				stat.NumForwardSentPayments + stat.NumForwardReceivedPayments
+			if withPayments && numPayments == 0 {
+				continue
+			}
+
+			filtered = append(filtered, &NodeStats{
+				Alias:     getAlias(id),
+				NodeStats: stat,
 			})
 		}
 
-		sort.Slice(payments, func(i, j int) bool {
-			return payments[i].Time > payments[j].Time
+		// Nodes with the biggest payments volume go first.
+		sort.Slice(filtered, func(i, j int) bool {
+			vi := filtered[i].OverallSentSat + filtered[i].OverallSentForwardSat
+			vj := filtered[j].OverallSentSat + filtered[j].OverallSentForwardSat
+			if vi != vj {
+				return vi > vj
+			}
+			return filtered[i].NodeID < filtered[j].NodeID
 		})
 
-		return payments, nil
+		items := make([]*connectionItem, len(filtered))
+		for i, stat := range filtered {
+			items[i] = &connectionItem{key: string(stat.NodeID), node: stat}
+		}
+
+		return newConnection("node", items, rp.Args)
+	}
+}
+
+func getBalanceResolver(client lightning.Client) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		available, err := client.AvailableBalance()
+		if err != nil {
+			return nil, errors.Errorf("unable to get available balance: %v",
+				err)
+		}
+
+		pending, err := client.PendingBalance()
+		if err != nil {
+			return nil, errors.Errorf("unable to get pending balance: %v",
+				err)
+		}
+
+		channels, err := client.Channels()
+		if err != nil {
+			return nil, errors.Errorf("unable to fetch channels: %v", err)
+		}
+
+		return newBalance(available, pending, channels), nil
+	}
+}
+
+func getCreateInvoiceResolver(
+	client lightning.PaymentClient) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		amount, _ := rp.Args["amount"].(int)
+		if amount < 0 {
+			return nil, errors.Errorf("amount(%v) should be positive",
+				amount)
+		}
+
+		description, _ := rp.Args["description"].(string)
+
+		paymentRequest, invoice, err := client.CreateInvoice("bitlum",
+			btcutil.Amount(amount), description)
+		if err != nil {
+			return nil, errors.Errorf("unable to create invoice: %v", err)
+		}
+
+		return newInvoice(paymentRequest, invoice), nil
+	}
+}
+
+func getValidateInvoiceResolver(
+	client lightning.PaymentClient) graphql.FieldResolveFn {
+	return func(rp graphql.ResolveParams) (
+		interface{}, error) {
+
+		amount, _ := rp.Args["amount"].(int)
+		if amount < 0 {
+			return nil, errors.Errorf("amount(%v) should be positive",
+				amount)
+		}
+
+		paymentRequest, _ := rp.Args["invoice"].(string)
+
+		invoice, err := client.ValidateInvoice(paymentRequest,
+			btcutil.Amount(amount))
+		if err != nil {
+			return nil, errors.Errorf("invalid invoice: %v", err)
+		}
+
+		return newInvoice(paymentRequest, invoice), nil
 	}
 }
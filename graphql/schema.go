@@ -1,7 +1,9 @@
 package graphql
 
 import (
+	"github.com/bitlum/hub/common"
 	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/topology"
 	"github.com/graphql-go/graphql"
 )
 
@@ -179,8 +181,714 @@ var typePayment = graphql.NewObject(graphql.ObjectConfig{
 	},
 })
 
+var typeEconomicStats = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "EconomicStats",
+	Description: "",
+	Fields: graphql.Fields{
+		"numChannels": &graphql.Field{
+			Description: "NumChannels is number of opened channels",
+			Type:        graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.EconomicStats).NumChannels, nil
+			},
+		},
+		"numClosedChannels": &graphql.Field{
+			Description: "NumClosedChannels is number of channels which are" +
+				" known to be closed",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.EconomicStats).NumClosedChannels, nil
+			},
+		},
+		"averageCapacity": &graphql.Field{
+			Description: "AverageCapacity is the average capacity of opened" +
+				" channels in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*topology.EconomicStats).AverageCapacity), nil
+			},
+		},
+		"averageChannelAge": &graphql.Field{
+			Description: "AverageChannelAge is the average time in seconds" +
+				" since the open of currently opened channels",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.EconomicStats).AverageChannelAge, nil
+			},
+		},
+		"averageClosedLifetime": &graphql.Field{
+			Description: "AverageClosedLifetime is the average time in" +
+				" seconds between open and close of closed channels",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.EconomicStats).AverageClosedLifetime, nil
+			},
+		},
+		"averageOpenFee": &graphql.Field{
+			Description: "AverageOpenFee is the average miners fee of" +
+				" channel funding transaction in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*topology.EconomicStats).AverageOpenFee), nil
+			},
+		},
+		"averageCloseFee": &graphql.Field{
+			Description: "AverageCloseFee is the average miners fee of" +
+				" channel closing transaction in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*topology.EconomicStats).AverageCloseFee), nil
+			},
+		},
+		"averageBaseFeeMsat": &graphql.Field{
+			Description: "AverageBaseFeeMsat is the average base routing fee" +
+				" of the channels in millisatoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.EconomicStats).AverageBaseFeeMsat, nil
+			},
+		},
+		"averageFeeRatePPM": &graphql.Field{
+			Description: "AverageFeeRatePPM is the average proportional" +
+				" routing fee of the channels in parts per million",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.EconomicStats).AverageFeeRatePPM, nil
+			},
+		},
+	},
+})
+
+var typeNetworkStats = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "NetworkStats",
+	Description: "",
+	Fields: graphql.Fields{
+		"time": &graphql.Field{
+			Description: "Time is the time when statistics has been calculated",
+			Type:        graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.NetworkStats).Time, nil
+			},
+		},
+		"numNodes": &graphql.Field{
+			Description: "",
+			Type:        graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.NetworkStats).NumNodes, nil
+			},
+		},
+		"capacity": &graphql.Field{
+			Description: "Capacity is the overall capacity of the network in" +
+				" satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*topology.NetworkStats).Capacity), nil
+			},
+		},
+		"network": &graphql.Field{
+			Description: "Network is the statistics of all channels in the" +
+				" network",
+			Type: graphql.NewNonNull(typeEconomicStats),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.NetworkStats).Network, nil
+			},
+		},
+		"our": &graphql.Field{
+			Description: "Our is the statistics of our channels",
+			Type:        graphql.NewNonNull(typeEconomicStats),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*topology.NetworkStats).Our, nil
+			},
+		},
+	},
+})
+
+var typeChannelState = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "ChannelState",
+	Description: "ChannelState is the change of our channel state",
+	Fields: graphql.Fields{
+		"channelId": &graphql.Field{
+			Description: "ChannelID is the channel point of the channel",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*ChannelState).ChannelID, nil
+			},
+		},
+		"peer": &graphql.Field{
+			Description: "Peer is the node with which channel is opened",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*ChannelState).Node, nil
+			},
+		},
+		"prevState": &graphql.Field{
+			Description: "PrevState is the previous state of the channel," +
+				" empty if channel is new",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*ChannelState).PrevState, nil
+			},
+		},
+		"state": &graphql.Field{
+			Description: "State is the current state of the channel: " +
+				"opening, opened, closing or closed",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*ChannelState).State, nil
+			},
+		},
+		"time": &graphql.Field{
+			Description: "Time is the unix time of the state change",
+			Type:        graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*ChannelState).Time, nil
+			},
+		},
+	},
+})
+
+var typeChannel = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Channel",
+	Description: "Channel is our lightning network channel",
+	Fields: graphql.Fields{
+		"channelId": &graphql.Field{
+			Description: "ChannelID is the channel point of the channel",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).ChannelID, nil
+			},
+		},
+		"nodeId": &graphql.Field{
+			Description: "NodeID is the public key of the node with which" +
+				" channel is opened",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).NodeID, nil
+			},
+		},
+		"alias": &graphql.Field{
+			Description: "Alias is the name of the node with which channel" +
+				" is opened",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).Alias, nil
+			},
+		},
+		"state": &graphql.Field{
+			Description: "State is the current state of the channel:" +
+				" opening, opened, closing or closed",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).State, nil
+			},
+		},
+		"isActive": &graphql.Field{
+			Description: "IsActive is true if channel could be used to send" +
+				" payments",
+			Type: graphql.NewNonNull(graphql.Boolean),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).IsActive, nil
+			},
+		},
+		"initiator": &graphql.Field{
+			Description: "Initiator is the side which opened the channel," +
+				" local or remote",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).Initiator, nil
+			},
+		},
+		"localBalance": &graphql.Field{
+			Description: "LocalBalance is our funds in the channel, in" +
+				" satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).LocalBalance, nil
+			},
+		},
+		"remoteBalance": &graphql.Field{
+			Description: "RemoteBalance is funds of remote side in the" +
+				" channel, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).RemoteBalance, nil
+			},
+		},
+		"stuckBalance": &graphql.Field{
+			Description: "StuckBalance is funds locked in pending htlc, in" +
+				" satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).StuckBalance, nil
+			},
+		},
+		"lockedBalance": &graphql.Field{
+			Description: "LockedBalance is our funds locked in the closing" +
+				" channel, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).LockedBalance, nil
+			},
+		},
+		"commitFee": &graphql.Field{
+			Description: "CommitFee is the fee of the commitment" +
+				" transaction, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).CommitFee, nil
+			},
+		},
+		"openFee": &graphql.Field{
+			Description: "OpenFee is the fee of the funding transaction, in" +
+				" satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).OpenFee, nil
+			},
+		},
+		"closeFee": &graphql.Field{
+			Description: "CloseFee is the fee of the closing transaction, in" +
+				" satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).CloseFee, nil
+			},
+		},
+		"swipeFee": &graphql.Field{
+			Description: "SwipeFee is the fee of the transaction which" +
+				" swipes funds from the force closed channel, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).SwipeFee, nil
+			},
+		},
+		"openingTime": &graphql.Field{
+			Description: "OpeningTime is the unix time of the funding" +
+				" transaction",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).OpeningTime, nil
+			},
+		},
+		"closingTime": &graphql.Field{
+			Description: "ClosingTime is the unix time of the closing" +
+				" transaction, zero if channel is not closing",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).ClosingTime, nil
+			},
+		},
+		"closeType": &graphql.Field{
+			Description: "CloseType is the type of the channel close",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Channel).CloseType, nil
+			},
+		},
+	},
+})
+
+var typePeer = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Peer",
+	Description: "Peer is the node with which we have channels or connection",
+	Fields: graphql.Fields{
+		"nodeId": &graphql.Field{
+			Description: "NodeID is the public key of the node",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Peer).NodeID, nil
+			},
+		},
+		"alias": &graphql.Field{
+			Description: "Alias is the name of the node",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Peer).Alias, nil
+			},
+		},
+		"isConnected": &graphql.Field{
+			Description: "IsConnected is true if node is connected to us" +
+				" with tcp / ip connection",
+			Type: graphql.NewNonNull(graphql.Boolean),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Peer).IsConnected, nil
+			},
+		},
+		"numChannels": &graphql.Field{
+			Description: "NumChannels is the number of not closed channels" +
+				" with the node",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Peer).NumChannels, nil
+			},
+		},
+		"numActiveChannels": &graphql.Field{
+			Description: "NumActiveChannels is the number of channels which" +
+				" could be used to send payments",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Peer).NumActiveChannels, nil
+			},
+		},
+		"localBalance": &graphql.Field{
+			Description: "LocalBalance is our funds in the channels with" +
+				" node, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Peer).LocalBalance, nil
+			},
+		},
+		"remoteBalance": &graphql.Field{
+			Description: "RemoteBalance is funds of node in the channels, in" +
+				" satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Peer).RemoteBalance, nil
+			},
+		},
+	},
+})
+
+var typeNodeStats = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "NodeStats",
+	Description: "NodeStats is the statistics of payments and channels with the node",
+	Fields: graphql.Fields{
+		"nodeId": &graphql.Field{
+			Description: "NodeID is the public key of the node",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return string(rp.Source.(*NodeStats).NodeID), nil
+			},
+		},
+		"alias": &graphql.Field{
+			Description: "Alias is the name of the node",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*NodeStats).Alias, nil
+			},
+		},
+		"averageSentSat": &graphql.Field{
+			Description: "AverageSentSat is the average daily volume of" +
+				" payments sent from us to node, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).AverageSentSat), nil
+			},
+		},
+		"averageReceivedSat": &graphql.Field{
+			Description: "AverageReceivedSat is the average daily volume of" +
+				" payments received by us from node, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).AverageReceivedSat), nil
+			},
+		},
+		"averageSentForwardSat": &graphql.Field{
+			Description: "AverageSentForwardSat is the average daily volume" +
+				" of payments forwarded to node, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).AverageSentForwardSat), nil
+			},
+		},
+		"averageReceivedForwardSat": &graphql.Field{
+			Description: "AverageReceivedForwardSat is the average daily" +
+				" volume of payments received from node for forwarding, in" +
+				" satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).AverageReceivedForwardSat), nil
+			},
+		},
+		"overallSentSat": &graphql.Field{
+			Description: "OverallSentSat is the volume of payments sent from" +
+				" us to node over the period, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).OverallSentSat), nil
+			},
+		},
+		"overallReceivedSat": &graphql.Field{
+			Description: "OverallReceivedSat is the volume of payments" +
+				" received by us from node over the period, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).OverallReceivedSat), nil
+			},
+		},
+		"overallSentForwardSat": &graphql.Field{
+			Description: "OverallSentForwardSat is the volume of payments" +
+				" forwarded to node over the period, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).OverallSentForwardSat), nil
+			},
+		},
+		"overallReceivedForwardSat": &graphql.Field{
+			Description: "OverallReceivedForwardSat is the volume of" +
+				" payments received from node for forwarding over the" +
+				" period, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).OverallReceivedForwardSat), nil
+			},
+		},
+		"numSentPayments": &graphql.Field{
+			Description: "NumSentPayments is the number of payments sent" +
+				" from us to node over the period",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int(rp.Source.(*NodeStats).NumSentPayments), nil
+			},
+		},
+		"numReceivedPayments": &graphql.Field{
+			Description: "NumReceivedPayments is the number of payments" +
+				" received by us from node over the period",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int(rp.Source.(*NodeStats).NumReceivedPayments), nil
+			},
+		},
+		"numForwardSentPayments": &graphql.Field{
+			Description: "NumForwardSentPayments is the number of payments" +
+				" forwarded to node over the period",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int(rp.Source.(*NodeStats).NumForwardSentPayments), nil
+			},
+		},
+		"numForwardReceivedPayments": &graphql.Field{
+			Description: "NumForwardReceivedPayments is the number of" +
+				" payments received from node for forwarding over the" +
+				" period",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int(rp.Source.(*NodeStats).NumForwardReceivedPayments), nil
+			},
+		},
+		"percentileSentSat": &graphql.Field{
+			Description: "PercentileSentSat is the 95th percentile of amount" +
+				" of payments sent to node, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).PercentileSentSat), nil
+			},
+		},
+		"lockedLocallyActive": &graphql.Field{
+			Description: "LockedLocallyActive is our funds in active" +
+				" channels with node, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).LockedLocallyActive), nil
+			},
+		},
+		"lockedRemotelyActive": &graphql.Field{
+			Description: "LockedRemotelyActive is funds of node in active" +
+				" channels, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).LockedRemotelyActive), nil
+			},
+		},
+		"maxLockedLocallyActive": &graphql.Field{
+			Description: "MaxLockedLocallyActive is the biggest our balance" +
+				" in the active channel with node, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return int64(rp.Source.(*NodeStats).MaxLockedLocallyActive), nil
+			},
+		},
+		"numActiveChannels": &graphql.Field{
+			Description: "NumActiveChannels is the number of channels with" +
+				" node which could be used to send payments",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*NodeStats).NumActiveChannels, nil
+			},
+		},
+	},
+})
+
+var typeBalance = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Balance",
+	Description: "Balance is the funds of our node",
+	Fields: graphql.Fields{
+		"available": &graphql.Field{
+			Description: "Available is the confirmed funds in the wallet, in" +
+				" satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Balance).Available, nil
+			},
+		},
+		"pending": &graphql.Field{
+			Description: "Pending is the funds which are in the process of" +
+				" being accepted by blockchain, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Balance).Pending, nil
+			},
+		},
+		"lockedLocally": &graphql.Field{
+			Description: "LockedLocally is our funds in the not closed" +
+				" channels, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Balance).LockedLocally, nil
+			},
+		},
+		"lockedRemotely": &graphql.Field{
+			Description: "LockedRemotely is funds of remote side in the not" +
+				" closed channels, in satoshis",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Balance).LockedRemotely, nil
+			},
+		},
+	},
+})
+
+var typeChannelStateName = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "ChannelStateName",
+	Description: "ChannelStateName is the state of the channel",
+	Values: graphql.EnumValueConfigMap{
+		"OPENING": &graphql.EnumValueConfig{
+			Value: string(lightning.ChannelOpening),
+		},
+		"OPENED": &graphql.EnumValueConfig{
+			Value: string(lightning.ChannelOpened),
+		},
+		"CLOSING": &graphql.EnumValueConfig{
+			Value: string(lightning.ChannelClosing),
+		},
+		"CLOSED": &graphql.EnumValueConfig{
+			Value: string(lightning.ChannelClosed),
+		},
+	},
+})
+
+var typePeriod = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "Period",
+	Description: "Period is the range of time which ends now",
+	Values: graphql.EnumValueConfigMap{
+		"DAY": &graphql.EnumValueConfig{
+			Value: "day",
+		},
+		"WEEK": &graphql.EnumValueConfig{
+			Value: "week",
+		},
+		"MONTH": &graphql.EnumValueConfig{
+			Value: "month",
+		},
+		"THREE_MONTH": &graphql.EnumValueConfig{
+			Value: "three month",
+		},
+	},
+})
+
+var typePaymentType = graphql.NewEnum(graphql.EnumConfig{
+	Name:        "PaymentType",
+	Description: "",
+	Values: graphql.EnumValueConfigMap{
+		"INCOMING": &graphql.EnumValueConfig{
+			Value: "incoming",
+		},
+		"OUTGOING": &graphql.EnumValueConfig{
+			Value: "outgoing",
+		},
+		"FORWARD": &graphql.EnumValueConfig{
+			Value: "forward",
+		},
+	},
+})
+
+var typePaymentConnection = newConnectionType("Payment", typePayment)
+var typeChannelConnection = newConnectionType("Channel", typeChannel)
+var typePeerConnection = newConnectionType("Peer", typePeer)
+var typeNodeStatsConnection = newConnectionType("NodeStats", typeNodeStats)
+
+var typeInvoice = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Invoice",
+	Description: "",
+	Fields: graphql.Fields{
+		"invoice": &graphql.Field{
+			Description: "Invoice is the encoded lightning network invoice",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Invoice).Invoice, nil
+			},
+		},
+		"paymentHash": &graphql.Field{
+			Description: "PaymentHash is the hash of the payment preimage," +
+				" in hex",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Invoice).PaymentHash, nil
+			},
+		},
+		"amount": &graphql.Field{
+			Description: "Amount is the amount of the invoice in satoshis," +
+				" zero if payer chooses the amount",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Invoice).Amount, nil
+			},
+		},
+		"description": &graphql.Field{
+			Description: "",
+			Type:        graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Invoice).Description, nil
+			},
+		},
+		"creationDate": &graphql.Field{
+			Description: "CreationDate is the unix time of the invoice" +
+				" creation",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Invoice).CreationDate, nil
+			},
+		},
+		"expiry": &graphql.Field{
+			Description: "Expiry is the number of seconds after creation," +
+				" during which invoice is valid",
+			Type: graphql.NewNonNull(graphql.Int),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Invoice).Expiry, nil
+			},
+		},
+		"destination": &graphql.Field{
+			Description: "Destination is the public key of the node which" +
+				" is paid",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Invoice).Destination, nil
+			},
+		},
+		"fallbackAddress": &graphql.Field{
+			Description: "FallbackAddress is the on-chain address which" +
+				" could be paid instead",
+			Type: graphql.NewNonNull(graphql.String),
+			Resolve: func(rp graphql.ResolveParams) (interface{}, error) {
+				return rp.Source.(*Invoice).FallbackAddress, nil
+			},
+		},
+	},
+})
+
 func New(cfg Config) (
 	graphql.Schema, error) {
+
+	// Mutations are limited per client ip, in order to prevent the
+	// flooding of lightning node with invoices.
+	limiter := common.NewRateLimiter(float64(cfg.MutationsPerMinute)/60,
+		cfg.MutationsPerMinute)
+
+	// Private queries are limited separately, so that reading of the node
+	// state wouldn't exhaust the mutations limit.
+	queryLimiter := common.NewRateLimiter(float64(cfg.QueriesPerMinute)/60,
+		cfg.QueriesPerMinute)
+
 	return graphql.NewSchema(graphql.SchemaConfig{
 		Query: graphql.NewObject(graphql.ObjectConfig{
 			Name: "Query",
@@ -195,9 +903,211 @@ func New(cfg Config) (
 				},
 
 				"payments": &graphql.Field{
-					Description: "",
-					Type:        graphql.NewNonNull(graphql.NewList(typePayment)),
-					Resolve:     getPaymentsResolver(cfg.Client, cfg.GetAlias),
+					Description: "Payments is the completed incoming," +
+						" outgoing and forward payments, newest first",
+					Type: graphql.NewNonNull(typePaymentConnection),
+					Args: connectionArgs(graphql.FieldConfigArgument{
+						"type": &graphql.ArgumentConfig{
+							Description: "Type filters payments by type",
+							Type:        typePaymentType,
+						},
+						"startTime": &graphql.ArgumentConfig{
+							Description: "StartTime filters out payments " +
+								"which happened before the given unix time",
+							Type: graphql.Int,
+						},
+						"endTime": &graphql.ArgumentConfig{
+							Description: "EndTime filters out payments " +
+								"which happened at or after the given unix" +
+								" time",
+							Type: graphql.Int,
+						},
+						"minAmount": &graphql.ArgumentConfig{
+							Description: "MinAmount filters out payments " +
+								"less than the given amount, in satoshis",
+							Type: graphql.Int,
+						},
+						"maxAmount": &graphql.ArgumentConfig{
+							Description: "MaxAmount filters out payments " +
+								"greater than the given amount, in satoshis",
+							Type: graphql.Int,
+						},
+						"peer": &graphql.ArgumentConfig{
+							Description: "Peer filters payments by the " +
+								"alias of the sender or receiver, partial " +
+								"and case insensitive match is used",
+							Type: graphql.String,
+						},
+					}),
+					Resolve: getPaymentsResolver(cfg.Client, cfg.GetAlias,
+						cfg.FindNodesByAlias),
+				},
+
+				"networkStats": &graphql.Field{
+					Description: "NetworkStats is the economics statistics" +
+						" of the lightning network channels, which is used to" +
+						" benchmark our channels costs against the network",
+					Type:    typeNetworkStats,
+					Resolve: getNetworkStatsResolver(cfg.GetNetworkStats),
+				},
+
+				"channels": &graphql.Field{
+					Description: "Channels is our lightning network channels," +
+						" newest first, it requires api token",
+					Type: graphql.NewNonNull(typeChannelConnection),
+					Args: connectionArgs(graphql.FieldConfigArgument{
+						"state": &graphql.ArgumentConfig{
+							Description: "State filters channels by state",
+							Type:        typeChannelStateName,
+						},
+						"nodeId": &graphql.ArgumentConfig{
+							Description: "NodeID filters channels by node",
+							Type:        graphql.String,
+						},
+						"active": &graphql.ArgumentConfig{
+							Description: "Active filters channels by activity",
+							Type:        graphql.Boolean,
+						},
+					}),
+					Resolve: protectQuery(cfg.APIToken, queryLimiter,
+						getChannelsResolver(cfg.Client, cfg.GetAlias)),
+				},
+
+				"peers": &graphql.Field{
+					Description: "Peers is the nodes with which we have" +
+						" channels or connection, biggest capacity first," +
+						" it requires api token",
+					Type: graphql.NewNonNull(typePeerConnection),
+					Args: connectionArgs(graphql.FieldConfigArgument{
+						"connected": &graphql.ArgumentConfig{
+							Description: "Connected filters peers by " +
+								"connection",
+							Type: graphql.Boolean,
+						},
+						"withChannels": &graphql.ArgumentConfig{
+							Description: "WithChannels filters peers by " +
+								"existence of not closed channels",
+							Type: graphql.Boolean,
+						},
+					}),
+					Resolve: protectQuery(cfg.APIToken, queryLimiter,
+						getPeersResolver(cfg.Client, cfg.GetAlias)),
+				},
+
+				"nodeStats": &graphql.Field{
+					Description: "NodeStats is the statistics of payments" +
+						" and channels with nodes over the period, biggest" +
+						" payments volume first, it requires api token",
+					Type: graphql.NewNonNull(typeNodeStatsConnection),
+					Args: connectionArgs(graphql.FieldConfigArgument{
+						"period": &graphql.ArgumentConfig{
+							Description: "Period is the range of time " +
+								"over which stats are calculated",
+							Type:         typePeriod,
+							DefaultValue: "week",
+						},
+						"nodeId": &graphql.ArgumentConfig{
+							Description: "NodeID filters stats by node",
+							Type:        graphql.String,
+						},
+						"withPayments": &graphql.ArgumentConfig{
+							Description: "WithPayments filters out nodes " +
+								"without payments within the period",
+							Type: graphql.Boolean,
+						},
+					}),
+					Resolve: protectQuery(cfg.APIToken, queryLimiter,
+						getNodeStatsResolver(cfg.GetNodeStats,
+							cfg.GetAlias)),
+				},
+
+				"balance": &graphql.Field{
+					Description: "Balance is the funds of our node, it" +
+						" requires api token",
+					Type: graphql.NewNonNull(typeBalance),
+					Resolve: protectQuery(cfg.APIToken, queryLimiter,
+						getBalanceResolver(cfg.Client)),
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Description: "Mutations are the type of requests which change" +
+				" the state of objects, they require api token in the" +
+				" 'Authorization: Bearer <token>' header",
+			Fields: graphql.Fields{
+				"createInvoice": &graphql.Field{
+					Description: "CreateInvoice creates lightning network" +
+						" invoice, which is paid to our node",
+					Type: graphql.NewNonNull(typeInvoice),
+					Args: graphql.FieldConfigArgument{
+						"amount": &graphql.ArgumentConfig{
+							Description: "Amount is the amount of the " +
+								"invoice in satoshis, if not specified " +
+								"payer chooses the amount",
+							Type:         graphql.Int,
+							DefaultValue: 0,
+						},
+						"description": &graphql.ArgumentConfig{
+							Description: "Description is the " +
+								"description of the invoice, which is " +
+								"shown to the payer",
+							Type:         graphql.String,
+							DefaultValue: "",
+						},
+					},
+					Resolve: protectMutation(cfg.APIToken, limiter,
+						getCreateInvoiceResolver(cfg.Client)),
+				},
+
+				"validateInvoice": &graphql.Field{
+					Description: "ValidateInvoice checks that invoice" +
+						" belongs to our network and could be paid with" +
+						" the given amount, and decodes it",
+					Type: graphql.NewNonNull(typeInvoice),
+					Args: graphql.FieldConfigArgument{
+						"invoice": &graphql.ArgumentConfig{
+							Description: "Invoice is the encoded " +
+								"lightning invoice",
+							Type: graphql.NewNonNull(graphql.String),
+						},
+						"amount": &graphql.ArgumentConfig{
+							Description: "Amount is the amount which " +
+								"is going to be paid, in satoshis",
+							Type:         graphql.Int,
+							DefaultValue: 0,
+						},
+					},
+					Resolve: protectMutation(cfg.APIToken, limiter,
+						getValidateInvoiceResolver(cfg.Client)),
+				},
+			},
+		}),
+		Subscription: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Subscription",
+			Description: "Subscriptions are the type of requests which" +
+				" are used to receive events as soon as they happen, " +
+				"they are served over websocket connection",
+			Fields: graphql.Fields{
+				subscriptionPaymentAdded: &graphql.Field{
+					Description: "PaymentAdded is sent whenever we send" +
+						" or receive payment",
+					Type:    graphql.NewNonNull(typePayment),
+					Resolve: getEventResolver(),
+				},
+
+				subscriptionForwardAdded: &graphql.Field{
+					Description: "ForwardAdded is sent whenever payment" +
+						" is forwarded through our node",
+					Type:    graphql.NewNonNull(typePayment),
+					Resolve: getEventResolver(),
+				},
+
+				subscriptionChannelStateChanged: &graphql.Field{
+					Description: "ChannelStateChanged is sent whenever" +
+						" our channel changes its state",
+					Type:    graphql.NewNonNull(typeChannelState),
+					Resolve: getEventResolver(),
 				},
 			},
 		}),
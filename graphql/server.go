@@ -2,11 +2,14 @@ package graphql
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/bitlum/hub/common"
 	"github.com/graphql-go/graphql"
 	"html/template"
+	"net"
 	"net/http"
 	"sync"
 	"sync/atomic"
@@ -28,6 +31,13 @@ type Server struct {
 	schema graphql.Schema
 
 	httpServer *http.Server
+
+	// httpsServer serves GraphQL over tls, nil if tls isn't enabled.
+	httpsServer *http.Server
+
+	// certLoader keeps the certificate of https server, and allows to
+	// reload it without restart.
+	certLoader *common.CertLoader
 }
 
 func NewServer(c Config) (*Server, error) {
@@ -45,12 +55,14 @@ func NewServer(c Config) (*Server, error) {
 	}
 
 	const (
-		queryPath = "/query"
+		queryPath         = "/query"
+		subscriptionsPath = "/subscriptions"
 	)
 
 	graphiQLPage, err := renderGraphiQL(graphiQLParams{
-		QueryPath: queryPath,
-		FAQ:       template.JSEscapeString(faq),
+		QueryPath:         queryPath,
+		SubscriptionsPath: subscriptionsPath,
+		FAQ:               template.JSEscapeString(faq),
 	})
 	if err != nil {
 		return nil, err
@@ -64,14 +76,99 @@ func NewServer(c Config) (*Server, error) {
 	}))
 	mux.Handle(queryPath, &queryHandler{schema: schema})
 
-	httpServer := &http.Server{Addr: c.listenAddr(), Handler: mux}
+	quit := make(chan struct{})
+	mux.Handle(subscriptionsPath, newSubscriptionHandler(schema, c.Streamer,
+		c.GetAlias, c.APIToken, quit))
+
+	s := &Server{
+		cfg:    c,
+		schema: schema,
+		quit:   quit,
+	}
+
+	httpHandler := http.Handler(mux)
+	if c.tlsEnabled() {
+		if err := ensureCertPair(c); err != nil {
+			return nil, err
+		}
+
+		s.certLoader, err = common.NewCertLoader(c.TLSCertPath,
+			c.TLSKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		s.httpsServer = &http.Server{
+			Addr:    c.secureListenAddr(),
+			Handler: mux,
+			TLSConfig: &tls.Config{
+				GetCertificate: s.certLoader.GetCertificate,
+				MinVersion:     tls.VersionTLS12,
+			},
+		}
+
+		if c.RedirectHTTP {
+			httpHandler = newRedirectHandler(c.SecureListenPort)
+		}
+	}
+
+	s.httpServer = &http.Server{Addr: c.listenAddr(), Handler: httpHandler}
+
+	return s, nil
+}
+
+// ensureCertPair checks that certificate and key exist, and generates
+// self-signed ones if it is allowed.
+func ensureCertPair(c Config) error {
+	if common.FileExists(c.TLSCertPath) && common.FileExists(c.TLSKeyPath) {
+		return nil
+	}
+
+	if !c.TLSSelfSigned {
+		return fmt.Errorf("tls cert(%v) or key(%v) not found",
+			c.TLSCertPath, c.TLSKeyPath)
+	}
+
+	log.Infof("Generating self-signed TLS certificate(%v) and key(%v)",
+		c.TLSCertPath, c.TLSKeyPath)
+
+	err := common.GenCertPair(c.TLSCertPath, c.TLSKeyPath, c.TLSExtraIPs,
+		c.TLSExtraDomains)
+	if err != nil {
+		return fmt.Errorf("unable to generate tls cert: %v", err)
+	}
+
+	return nil
+}
+
+// newRedirectHandler creates handler which redirects http requests to the
+// same host and path, but with https scheme and secure port.
+func newRedirectHandler(securePort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+
+		target := "https://" + net.JoinHostPort(host, securePort) +
+			r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+	})
+}
+
+// ReloadCertificate reloads tls certificate and key from the files, in case
+// of error the previous certificate continues to be used.
+func (s *Server) ReloadCertificate() error {
+	if s.certLoader == nil {
+		return nil
+	}
+
+	if err := s.certLoader.Reload(); err != nil {
+		return err
+	}
 
-	return &Server{
-		cfg:        c,
-		schema:     schema,
-		quit:       make(chan struct{}),
-		httpServer: httpServer,
-	}, nil
+	log.Infof("TLS certificate(%v) has been reloaded", s.cfg.TLSCertPath)
+	return nil
 }
 
 // Start starts http server and subscriptions processing.
@@ -85,6 +182,11 @@ func (s *Server) Start() error {
 	s.stopWG.Add(1)
 	go s.listenAndServeHTTP()
 
+	if s.httpsServer != nil {
+		s.stopWG.Add(1)
+		go s.listenAndServeHTTPS()
+	}
+
 	// wait a little for goroutines to be completely run
 	time.Sleep(100 * time.Millisecond)
 
@@ -102,6 +204,11 @@ func (s *Server) Stop() (err error) {
 
 	close(s.quit)
 	err = s.httpServer.Shutdown(context.Background())
+	if s.httpsServer != nil {
+		if httpsErr := s.httpsServer.Shutdown(context.Background()); err == nil {
+			err = httpsErr
+		}
+	}
 	s.stopWG.Wait()
 	return err
 }
@@ -127,6 +234,29 @@ func (s *Server) listenAndServeHTTP() {
 	}
 }
 
+// listenAndServeHTTPS starts HTTPS server. Intended to be called as
+// goroutine.
+func (s *Server) listenAndServeHTTPS() {
+	log.Trace("Server.listenAndServeHTTPS()")
+	for {
+		log.Infof("Starting HTTPS server on `%s`", s.cfg.secureListenAddr())
+
+		// Certificate is taken from the tls config.
+		err := s.httpsServer.ListenAndServeTLS("", "")
+		if err != nil && err != http.ErrServerClosed {
+			log.Errorf("Unable to HTTPS listen and serve: %v", err)
+		}
+		select {
+		case <-s.quit:
+			log.Infof("HTTPS Server stopped")
+			s.stopWG.Done()
+			return
+		default:
+			time.Sleep(time.Second)
+		}
+	}
+}
+
 type queryHandler struct {
 	schema graphql.Schema
 }
@@ -154,6 +284,7 @@ func (h *queryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		RequestString:  params.Query,
 		VariableValues: params.Variables,
 		OperationName:  params.OperationName,
+		Context:        withRequestInfo(r.Context(), r),
 	})
 
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,520 @@
+package graphql
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"github.com/bitlum/hub/common/broadcast"
+	"github.com/bitlum/hub/lightning"
+	"github.com/go-errors/errors"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"golang.org/x/net/websocket"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	subscriptionPaymentAdded        = "paymentAdded"
+	subscriptionForwardAdded        = "forwardAdded"
+	subscriptionChannelStateChanged = "channelStateChanged"
+)
+
+const (
+	// maxSubscriptions is the number of active subscriptions which client
+	// is allowed to have within one connection.
+	maxSubscriptions = 10
+
+	// maxPendingEvents is the number of events which could wait to be sent
+	// to the subscriber, if subscriber is slower, subscription is
+	// terminated, so that events don't pile up in memory.
+	maxPendingEvents = 100
+
+	// sendTimeout is the time within which message should be written
+	// to the connection, otherwise connection is considered broken.
+	sendTimeout = time.Second * 10
+)
+
+// subscriptionsProtocol is the websocket sub-protocol of the apollo
+// subscriptions transport, which is supported by the most of graphql
+// clients, including graphiql.
+const subscriptionsProtocol = "graphql-ws"
+
+// Types of the subscriptions protocol messages.
+const (
+	msgConnectionInit      = "connection_init"
+	msgConnectionAck       = "connection_ack"
+	msgConnectionError     = "connection_error"
+	msgConnectionTerminate = "connection_terminate"
+	msgStart               = "start"
+	msgStop                = "stop"
+	msgData                = "data"
+	msgError               = "error"
+	msgComplete            = "complete"
+)
+
+// subscriptionMessage is the message of the subscriptions protocol.
+type subscriptionMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// connectionParams is the payload of the connection init message.
+type connectionParams struct {
+	// AuthToken is the api token, which is required if it is configured.
+	AuthToken string `json:"authToken"`
+}
+
+// subscriptionParams is the payload of the start message.
+type subscriptionParams struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// subscriptionHandler serves graphql subscriptions over websocket
+// connection, every subscription receives lightning updates, converts them
+// to the event of the subscribed type, and executes query with the event as
+// the root object.
+type subscriptionHandler struct {
+	schema   graphql.Schema
+	streamer lightning.UpdatesStreamer
+	getAlias func(nodeID lightning.NodeID) string
+
+	// apiToken is the token which client should send in the connection
+	// init message, if it is empty subscriptions are public.
+	apiToken string
+
+	// quit is used to close opened connections on the server stop,
+	// because http server shutdown doesn't close hijacked connections.
+	quit chan struct{}
+}
+
+// newSubscriptionHandler creates http handler which upgrades connection to
+// websocket and serves subscriptions in it.
+func newSubscriptionHandler(schema graphql.Schema,
+	streamer lightning.UpdatesStreamer,
+	getAlias func(nodeID lightning.NodeID) string, apiToken string,
+	quit chan struct{}) http.Handler {
+
+	h := &subscriptionHandler{
+		schema:   schema,
+		streamer: streamer,
+		getAlias: getAlias,
+		apiToken: apiToken,
+		quit:     quit,
+	}
+
+	return websocket.Server{
+		Handshake: h.handshake,
+		Handler:   h.serve,
+	}
+}
+
+// handshake checks that connection is opened from the same origin, so that
+// other sites couldn't subscribe on behalf of the browser user, and selects
+// subscriptions protocol. Non-browser clients don't send origin, and are
+// authorized by the api token in the connection init message.
+func (h *subscriptionHandler) handshake(config *websocket.Config,
+	r *http.Request) error {
+
+	if origin := r.Header.Get("Origin"); origin != "" {
+		originURL, err := url.Parse(origin)
+		if err != nil {
+			return errors.Errorf("unable to parse origin(%v): %v", origin,
+				err)
+		}
+
+		if originURL.Host != r.Host {
+			return errors.Errorf("origin(%v) isn't allowed", origin)
+		}
+	}
+
+	for _, protocol := range config.Protocol {
+		if protocol == subscriptionsProtocol {
+			config.Protocol = []string{subscriptionsProtocol}
+			return nil
+		}
+	}
+
+	return errors.Errorf("unsupported websocket protocols(%v)",
+		config.Protocol)
+}
+
+// authorize checks the api token of the connection init message.
+func (h *subscriptionHandler) authorize(payload json.RawMessage) error {
+	if h.apiToken == "" {
+		return nil
+	}
+
+	var params connectionParams
+	if len(payload) != 0 {
+		if err := json.Unmarshal(payload, &params); err != nil {
+			return errors.Errorf("unable to decode payload: %v", err)
+		}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(params.AuthToken),
+		[]byte(h.apiToken)) != 1 {
+		return errors.New("invalid api token")
+	}
+
+	return nil
+}
+
+// subscriptionConn is the websocket connection with its subscriptions.
+type subscriptionConn struct {
+	conn *websocket.Conn
+
+	// sendMtx is used to prevent concurrent writes from subscription
+	// goroutines.
+	sendMtx sync.Mutex
+
+	// subscriptions is the subscriptions by their id.
+	subscriptions map[string]*subscription
+
+	wg sync.WaitGroup
+}
+
+// subscription is the subscription on lightning updates.
+type subscription struct {
+	receiver *broadcast.Receiver
+
+	// done is closed when subscription stops sending events, either
+	// because it is stopped by client or because subscriber is too slow.
+	done chan struct{}
+}
+
+// isDone returns true if subscription stopped sending events.
+func (s *subscription) isDone() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// numActive returns the number of subscriptions which are still sending
+// events, and removes the finished ones.
+func (c *subscriptionConn) numActive() int {
+	for id, sub := range c.subscriptions {
+		if sub.isDone() {
+			delete(c.subscriptions, id)
+		}
+	}
+
+	return len(c.subscriptions)
+}
+
+// send sends message to the client.
+func (c *subscriptionConn) send(id, msgType string,
+	payload interface{}) error {
+
+	msg := &subscriptionMessage{
+		ID:   id,
+		Type: msgType,
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Errorf("unable to encode payload: %v", err)
+		}
+		msg.Payload = data
+	}
+
+	c.sendMtx.Lock()
+	defer c.sendMtx.Unlock()
+
+	// Client which doesn't read messages shouldn't block subscription
+	// goroutines forever.
+	err := c.conn.SetWriteDeadline(time.Now().Add(sendTimeout))
+	if err != nil {
+		return errors.Errorf("unable to set write deadline: %v", err)
+	}
+
+	return websocket.JSON.Send(c.conn, msg)
+}
+
+// serve handles protocol messages until connection is closed.
+func (h *subscriptionHandler) serve(conn *websocket.Conn) {
+	log.Tracef("subscriptionHandler.serve() remote=%v", conn.Request().RemoteAddr)
+
+	c := &subscriptionConn{
+		conn:          conn,
+		subscriptions: make(map[string]*subscription),
+	}
+
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+
+		for _, sub := range c.subscriptions {
+			sub.receiver.Stop()
+		}
+		c.wg.Wait()
+
+		conn.Close()
+	}()
+
+	// Unblock receive on the server stop.
+	go func() {
+		select {
+		case <-h.quit:
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	// Subscriptions could be started only after the connection init
+	// message, which carries the api token.
+	authorized := false
+
+	for {
+		var msg subscriptionMessage
+		if err := websocket.JSON.Receive(conn, &msg); err != nil {
+			log.Debugf("Unable to receive subscription message: %v", err)
+			return
+		}
+
+		switch msg.Type {
+		case msgConnectionInit:
+			if err := h.authorize(msg.Payload); err != nil {
+				c.send("", msgConnectionError, map[string]string{
+					"message": err.Error(),
+				})
+				return
+			}
+			authorized = true
+
+			if err := c.send("", msgConnectionAck, nil); err != nil {
+				log.Errorf("Unable to send connection ack: %v", err)
+				return
+			}
+
+		case msgStart:
+			if !authorized {
+				c.send(msg.ID, msgError, gqlerrors.FormatErrors(
+					errors.New("connection isn't initialized")))
+				continue
+			}
+
+			if c.numActive() >= maxSubscriptions {
+				c.send(msg.ID, msgError, gqlerrors.FormatErrors(
+					errors.Errorf("number of subscriptions exceeds "+
+						"limit(%v)", maxSubscriptions)))
+				continue
+			}
+
+			if _, ok := c.subscriptions[msg.ID]; ok {
+				c.send(msg.ID, msgError, gqlerrors.FormatErrors(
+					errors.Errorf("subscription(%v) already exists",
+						msg.ID)))
+				continue
+			}
+
+			sub, err := h.subscribe(c, msg.ID, msg.Payload)
+			if err != nil {
+				c.send(msg.ID, msgError, gqlerrors.FormatErrors(err))
+				continue
+			}
+
+			c.subscriptions[msg.ID] = sub
+
+		case msgStop:
+			if sub, ok := c.subscriptions[msg.ID]; ok {
+				sub.receiver.Stop()
+				delete(c.subscriptions, msg.ID)
+			}
+
+		case msgConnectionTerminate:
+			return
+
+		default:
+			c.send(msg.ID, msgConnectionError, map[string]string{
+				"message": fmt.Sprintf("unknown message type(%v)", msg.Type),
+			})
+		}
+	}
+}
+
+// subscribe validates subscription query, registers on lightning updates
+// and starts goroutines which send events until receiver is stopped.
+func (h *subscriptionHandler) subscribe(c *subscriptionConn, id string,
+	payload json.RawMessage) (*subscription, error) {
+
+	var params subscriptionParams
+	if err := json.Unmarshal(payload, &params); err != nil {
+		return nil, errors.Errorf("unable to decode payload: %v", err)
+	}
+
+	fieldName, err := h.subscriptionField(params)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &subscription{
+		receiver: h.streamer.RegisterOnUpdates(),
+		done:     make(chan struct{}),
+	}
+
+	// Updates are read from the receiver without delay, and only events
+	// of the subscribed field are kept, because receiver buffers all
+	// lightning updates, including graph ones, until they are read.
+	events := make(chan interface{}, maxPendingEvents)
+
+	// overflowed is set before events are closed, if subscriber was too
+	// slow.
+	overflowed := false
+
+	c.wg.Add(1)
+	go func() {
+		defer func() {
+			close(events)
+			c.wg.Done()
+		}()
+
+		for update := range sub.receiver.Read() {
+			event := h.convertUpdate(fieldName, update)
+			if event == nil {
+				continue
+			}
+
+			select {
+			case events <- event:
+			default:
+				log.Errorf("Subscription(%v) is too slow, terminating "+
+					"it", id)
+				overflowed = true
+				sub.receiver.Stop()
+				return
+			}
+		}
+	}()
+
+	c.wg.Add(1)
+	go func() {
+		defer func() {
+			close(sub.done)
+			c.wg.Done()
+		}()
+
+		for event := range events {
+			res := graphql.Do(graphql.Params{
+				Schema:         h.schema,
+				RequestString:  params.Query,
+				VariableValues: params.Variables,
+				OperationName:  params.OperationName,
+				RootObject: map[string]interface{}{
+					fieldName: event,
+				},
+			})
+
+			if err := c.send(id, msgData, res); err != nil {
+				log.Errorf("Unable to send subscription(%v) data: %v",
+					id, err)
+			}
+		}
+
+		if overflowed {
+			c.send(id, msgError, gqlerrors.FormatErrors(errors.Errorf(
+				"more than %v events are pending, subscription is "+
+					"terminated", maxPendingEvents)))
+			return
+		}
+
+		// Subscription is stopped either by the client or on connection
+		// close, in the latter case send will fail silently.
+		c.send(id, msgComplete, nil)
+	}()
+
+	return sub, nil
+}
+
+// subscriptionField parses and validates subscription query, and returns
+// the name of the subscribed field.
+func (h *subscriptionHandler) subscriptionField(
+	params subscriptionParams) (string, error) {
+
+	document, err := parser.Parse(parser.ParseParams{
+		Source: params.Query,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	result := graphql.ValidateDocument(&h.schema, document, nil)
+	if !result.IsValid {
+		return "", errors.Errorf("invalid query: %v", result.Errors)
+	}
+
+	for _, definition := range document.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		if params.OperationName != "" && (operation.Name == nil ||
+			operation.Name.Value != params.OperationName) {
+			continue
+		}
+
+		if operation.Operation != ast.OperationTypeSubscription {
+			return "", errors.New("only subscriptions could be served " +
+				"over websocket")
+		}
+
+		selections := operation.SelectionSet.Selections
+		if len(selections) != 1 {
+			return "", errors.New("subscription should select exactly " +
+				"one field")
+		}
+
+		field, ok := selections[0].(*ast.Field)
+		if !ok {
+			return "", errors.New("subscription should select field")
+		}
+
+		return field.Name.Value, nil
+	}
+
+	return "", errors.Errorf("operation(%v) not found", params.OperationName)
+}
+
+// convertUpdate converts lightning update in the event of subscribed field,
+// nil is returned if update doesn't correspond to the field.
+func (h *subscriptionHandler) convertUpdate(fieldName string,
+	update interface{}) interface{} {
+
+	switch u := update.(type) {
+	case *lightning.UpdatePayment:
+		if fieldName != subscriptionPaymentAdded ||
+			u.Status != lightning.Completed {
+			return nil
+		}
+
+		return newPayment(u.Payment, h.getAlias)
+
+	case *lightning.UpdateForwardPayment:
+		if fieldName != subscriptionForwardAdded {
+			return nil
+		}
+
+		return newForwardPayment(u.ForwardPayment, h.getAlias)
+
+	case *lightning.UpdateChannelState:
+		if fieldName != subscriptionChannelStateChanged {
+			return nil
+		}
+
+		return newChannelState(u, h.getAlias)
+	}
+
+	return nil
+}
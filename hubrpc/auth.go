@@ -0,0 +1,333 @@
+package hubrpc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/bitlum/hub/common"
+	"github.com/go-errors/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/macaroon.v2"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Permission is the level of access to the hub rpc methods, every level
+// includes the lower ones.
+type Permission string
+
+const (
+	// PermissionReadOnly allows to call methods which don't change the
+	// state of the hub.
+	PermissionReadOnly Permission = "readonly"
+
+	// PermissionInvoice allows to create invoices in addition to the read
+	// only methods.
+	PermissionInvoice Permission = "invoice"
+
+	// PermissionAdmin allows to call all methods, including the ones which
+	// send funds.
+	PermissionAdmin Permission = "admin"
+)
+
+// permissionRanks is used to compare permission levels.
+var permissionRanks = map[Permission]int{
+	PermissionReadOnly: 1,
+	PermissionInvoice:  2,
+	PermissionAdmin:    3,
+}
+
+// Permissions is the list of permission levels, macaroon is generated for
+// every one of them.
+var Permissions = []Permission{
+	PermissionReadOnly,
+	PermissionInvoice,
+	PermissionAdmin,
+}
+
+// methodPermissions is the permission level which is required to call the
+// method, methods which aren't listed require admin permission.
+var methodPermissions = map[string]Permission{
+	"/hubrpc.Hub/CreateInvoice":       PermissionInvoice,
+	"/hubrpc.Hub/ValidateInvoice":     PermissionReadOnly,
+	"/hubrpc.Hub/Balance":             PermissionReadOnly,
+	"/hubrpc.Hub/EstimateFee":         PermissionReadOnly,
+	"/hubrpc.Hub/SendPayment":         PermissionAdmin,
+	"/hubrpc.Hub/PaymentByID":         PermissionReadOnly,
+	"/hubrpc.Hub/PaymentByInvoice":    PermissionReadOnly,
+	"/hubrpc.Hub/ListPayments":        PermissionReadOnly,
+	"/hubrpc.Hub/CheckNodeStats":      PermissionReadOnly,
+	"/hubrpc.Hub/Budget":              PermissionReadOnly,
+	"/hubrpc.Hub/FeePolicyUpdates":    PermissionReadOnly,
+	"/hubrpc.Hub/ChannelsChange":      PermissionReadOnly,
+	"/hubrpc.Hub/NetworkGraph":        PermissionReadOnly,
+	"/hubrpc.Hub/NodeCapacityHistory": PermissionReadOnly,
+	"/hubrpc.Hub/NetworkAnomalies":    PermissionReadOnly,
+	"/hubrpc.Hub/NetworkStats":        PermissionReadOnly,
+	"/hubrpc.Hub/PaymentTimeSeries":   PermissionReadOnly,
+	"/hubrpc.Hub/ProfitabilityReport": PermissionReadOnly,
+	"/hubrpc.Hub/ExportLedger":        PermissionReadOnly,
+}
+
+const (
+	// macaroonMetadataKey is the key of the request metadata, which
+	// contains hex encoded macaroon.
+	macaroonMetadataKey = "macaroon"
+
+	// macaroonLocation is the location of the hub macaroons.
+	macaroonLocation = "hub"
+
+	// permissionCaveatPrefix is the prefix of the macaroon caveat, which
+	// contains the permission level.
+	permissionCaveatPrefix = "permission "
+
+	// rootKeyFilename is the name of the file in which root key of the
+	// macaroons is stored.
+	rootKeyFilename = "macaroons.key"
+
+	// rootKeySize is the size of the macaroons root key.
+	rootKeySize = 32
+)
+
+// MacaroonFilename returns the name of the file in which macaroon of the
+// given permission level is stored.
+func MacaroonFilename(permission Permission) string {
+	return string(permission) + ".macaroon"
+}
+
+// Auth is used to generate macaroons and to check the permission level of
+// the incoming requests.
+type Auth struct {
+	rootKey []byte
+}
+
+// NewAuth loads the macaroons root key from the given directory, and
+// generates the root key and macaroons of all permission levels if the root
+// key doesn't exist. Changing of the root key invalidates all previously
+// generated macaroons, which is used to revoke them.
+func NewAuth(dir string) (*Auth, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Errorf("unable to create macaroon dir: %v", err)
+	}
+
+	rootKeyPath := filepath.Join(dir, rootKeyFilename)
+	if common.FileExists(rootKeyPath) {
+		rootKey, err := ioutil.ReadFile(rootKeyPath)
+		if err != nil {
+			return nil, errors.Errorf("unable to read root key: %v", err)
+		}
+
+		a := &Auth{rootKey: rootKey}
+		if err := a.writeMissingMacaroons(dir, false); err != nil {
+			return nil, err
+		}
+
+		return a, nil
+	}
+
+	rootKey := make([]byte, rootKeySize)
+	if _, err := rand.Read(rootKey); err != nil {
+		return nil, errors.Errorf("unable to generate root key: %v", err)
+	}
+
+	if err := ioutil.WriteFile(rootKeyPath, rootKey, 0600); err != nil {
+		return nil, errors.Errorf("unable to write root key: %v", err)
+	}
+
+	// Macaroons of the previous root key are invalid and should be
+	// overwritten.
+	a := &Auth{rootKey: rootKey}
+	if err := a.writeMissingMacaroons(dir, true); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// writeMissingMacaroons writes macaroons of all permission levels, which
+// don't exist in the given directory.
+func (a *Auth) writeMissingMacaroons(dir string, overwrite bool) error {
+	for _, permission := range Permissions {
+		path := filepath.Join(dir, MacaroonFilename(permission))
+		if !overwrite && common.FileExists(path) {
+			continue
+		}
+
+		mac, err := a.NewMacaroon(permission)
+		if err != nil {
+			return err
+		}
+
+		data, err := mac.MarshalBinary()
+		if err != nil {
+			return errors.Errorf("unable to encode macaroon: %v", err)
+		}
+
+		log.Infof("Writing %v macaroon to %v", permission, path)
+
+		if err := ioutil.WriteFile(path, data, 0600); err != nil {
+			return errors.Errorf("unable to write macaroon: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// NewMacaroon creates macaroon of the given permission level.
+func (a *Auth) NewMacaroon(permission Permission) (*macaroon.Macaroon, error) {
+	if _, ok := permissionRanks[permission]; !ok {
+		return nil, errors.Errorf("unknown permission(%v)", permission)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Errorf("unable to generate macaroon id: %v", err)
+	}
+
+	mac, err := macaroon.New(a.rootKey, id, macaroonLocation,
+		macaroon.LatestVersion)
+	if err != nil {
+		return nil, errors.Errorf("unable to create macaroon: %v", err)
+	}
+
+	err = mac.AddFirstPartyCaveat([]byte(permissionCaveatPrefix +
+		string(permission)))
+	if err != nil {
+		return nil, errors.Errorf("unable to add permission caveat: %v",
+			err)
+	}
+
+	return mac, nil
+}
+
+// checkPermission checks that macaroon is signed with our root key, and
+// grants the required permission level.
+func (a *Auth) checkPermission(mac *macaroon.Macaroon,
+	required Permission) error {
+
+	var granted Permission
+	err := mac.Verify(a.rootKey, func(caveat string) error {
+		if !strings.HasPrefix(caveat, permissionCaveatPrefix) {
+			return errors.Errorf("unknown caveat(%v)", caveat)
+		}
+
+		permission := Permission(strings.TrimPrefix(caveat,
+			permissionCaveatPrefix))
+		if _, ok := permissionRanks[permission]; !ok {
+			return errors.Errorf("unknown permission(%v)", permission)
+		}
+
+		// Caveats could only restrict the macaroon, so the lowest
+		// permission is used.
+		if granted == "" || permissionRanks[permission] < permissionRanks[granted] {
+			granted = permission
+		}
+
+		return nil
+	}, nil)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "invalid macaroon: %v",
+			err)
+	}
+
+	if permissionRanks[granted] < permissionRanks[required] {
+		return status.Errorf(codes.PermissionDenied, "permission(%v) "+
+			"is required, macaroon grants(%v)", required, granted)
+	}
+
+	return nil
+}
+
+// authorize checks that request metadata contains macaroon which grants
+// permission to call the given method.
+func (a *Auth) authorize(ctx context.Context, method string) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(macaroonMetadataKey)) != 1 {
+		return status.Errorf(codes.Unauthenticated, "expected 1 macaroon")
+	}
+
+	data, err := hex.DecodeString(md.Get(macaroonMetadataKey)[0])
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "unable to decode "+
+			"macaroon: %v", err)
+	}
+
+	mac := &macaroon.Macaroon{}
+	if err := mac.UnmarshalBinary(data); err != nil {
+		return status.Errorf(codes.Unauthenticated, "unable to decode "+
+			"macaroon: %v", err)
+	}
+
+	required, ok := methodPermissions[method]
+	if !ok {
+		required = PermissionAdmin
+	}
+
+	return a.checkPermission(mac, required)
+}
+
+// macaroonHash returns the hash of the request macaroon, which identifies
+// the client credential, false is returned if request doesn't have it.
+func macaroonHash(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(macaroonMetadataKey)) != 1 {
+		return "", false
+	}
+
+	hash := sha256.Sum256([]byte(md.Get(macaroonMetadataKey)[0]))
+	return hex.EncodeToString(hash[:]), true
+}
+
+// UnaryServerInterceptor rejects requests which don't have the macaroon
+// with sufficient permission level.
+func (a *Auth) UnaryServerInterceptor(ctx context.Context, req interface{},
+	info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+	error) {
+
+	if err := a.authorize(ctx, info.FullMethod); err != nil {
+		log.Errorf("command(%v), unauthorized: %v", info.FullMethod, err)
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// MacaroonCredential is the per rpc credential, which adds macaroon to the
+// request metadata.
+type MacaroonCredential struct {
+	Macaroon *macaroon.Macaroon
+
+	// Insecure allows to send macaroon over the connection without tls.
+	Insecure bool
+}
+
+// RequireTransportSecurity returns true if macaroon should be sent only
+// over tls connection.
+//
+// NOTE: Part of the credentials.PerRPCCredentials interface.
+func (c MacaroonCredential) RequireTransportSecurity() bool {
+	return !c.Insecure
+}
+
+// GetRequestMetadata returns the request metadata with hex encoded
+// macaroon.
+//
+// NOTE: Part of the credentials.PerRPCCredentials interface.
+func (c MacaroonCredential) GetRequestMetadata(ctx context.Context,
+	uri ...string) (map[string]string, error) {
+
+	data, err := c.Macaroon.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		macaroonMetadataKey: hex.EncodeToString(data),
+	}, nil
+}
@@ -2,54 +2,227 @@ package hubrpc
 
 import (
 	"fmt"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/net/context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strconv"
 )
 
 const (
-	ErrAssetNotSupported = iota + 1
-	ErrNetworkNotSupported
-	ErrInvalidArgument
+	// ErrorDomain is the domain of google.rpc.ErrorInfo which hub attaches
+	// to the gRPC status of the failed request.
+	ErrorDomain = "hub"
 
-	// ErrInternal...
-	ErrInternal
+	// ErrorFieldKey is the key of the error info metadata, which is the
+	// name of the request field which caused the error.
+	ErrorFieldKey = "field"
+
+	// ErrorRetryableKey is the key of the error info metadata, which is
+	// "true" if the same request might succeed if it is retried later.
+	ErrorRetryableKey = "retryable"
 )
 
+// reasonCodes maps error reasons on the gRPC status codes.
+var reasonCodes = map[ErrorReason]codes.Code{
+	ErrorReason_REASON_ASSET_NOT_SUPPORTED:    codes.InvalidArgument,
+	ErrorReason_REASON_NETWORK_NOT_SUPPORTED:  codes.FailedPrecondition,
+	ErrorReason_REASON_INVALID_ARGUMENT:       codes.InvalidArgument,
+	ErrorReason_REASON_INTERNAL:               codes.Internal,
+	ErrorReason_REASON_NOT_FOUND:              codes.NotFound,
+	ErrorReason_REASON_UNAVAILABLE:            codes.Unavailable,
+	ErrorReason_REASON_NOT_IMPLEMENTED:        codes.Unimplemented,
+	ErrorReason_REASON_IDEMPOTENCY_KEY_REUSED: codes.FailedPrecondition,
+	ErrorReason_REASON_REQUEST_IN_PROGRESS:    codes.Aborted,
+	ErrorReason_REASON_RATE_LIMITED:           codes.ResourceExhausted,
+}
+
+// Error is the error of the hub rpc method, it is sent to the client as gRPC
+// status with the code corresponding to the reason, and with
+// google.rpc.ErrorInfo in the status details.
 type Error struct {
-	code     int
-	errMsg   string
-	internal bool
+	reason    ErrorReason
+	field     string
+	retryable bool
+	errMsg    string
 }
 
 func (e Error) Error() string {
 	return e.errMsg
 }
 
+// GRPCStatus returns gRPC status of the error with google.rpc.ErrorInfo in
+// the status details, reason of which is the name of the error reason.
+//
+// NOTE: Used by gRPC server to convert the error returned by the method.
+func (e Error) GRPCStatus() *status.Status {
+	code, ok := reasonCodes[e.reason]
+	if !ok {
+		code = codes.Unknown
+	}
+
+	metadata := map[string]string{
+		ErrorRetryableKey: strconv.FormatBool(e.retryable),
+	}
+	if e.field != "" {
+		metadata[ErrorFieldKey] = e.field
+	}
+
+	s := status.New(code, e.errMsg)
+	withDetails, err := s.WithDetails(&errdetails.ErrorInfo{
+		Reason:   e.reason.String(),
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return s
+	}
+
+	return withDetails
+}
+
+// ErrorInfoFromError returns google.rpc.ErrorInfo which hub has attached to
+// the status of the error received by the client, returns false if error
+// isn't the gRPC status or it hasn't the error info of the hub domain.
+func ErrorInfoFromError(err error) (*errdetails.ErrorInfo, bool) {
+	s, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+
+	for _, detail := range s.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.Domain == ErrorDomain {
+			return info, true
+		}
+	}
+
+	return nil, false
+}
+
+// GatewayErrorHandler writes the error of the REST gateway request as
+// GatewayError, so that REST clients receive the details which hub has
+// attached to the gRPC status.
+//
+// NOTE: Should be used as proto error handler of the gateway mux.
+func GatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux,
+	marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request,
+	err error) {
+
+	s, ok := status.FromError(err)
+	if !ok {
+		s = status.New(codes.Unknown, err.Error())
+	}
+
+	body := &GatewayError{
+		Error:   s.Message(),
+		Code:    int32(s.Code()),
+		Details: s.Proto().Details,
+	}
+
+	data, err := marshaler.Marshal(body)
+	if err != nil {
+		log.Errorf("unable to marshal gateway error: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	// Gateway maps resource exhausted code on the forbidden status, but
+	// rate limited clients should retry the request later.
+	httpStatus := runtime.HTTPStatusFromCode(s.Code())
+	if s.Code() == codes.ResourceExhausted {
+		httpStatus = http.StatusTooManyRequests
+	}
+
+	w.Header().Del("Trailer")
+	w.Header().Set("Content-Type", marshaler.ContentType())
+	w.WriteHeader(httpStatus)
+	if _, err := w.Write(data); err != nil {
+		log.Errorf("unable to write gateway error: %v", err)
+	}
+}
+
 func newErrNetworkNotSupported(network, operation string) Error {
 	return Error{
-		code: ErrNetworkNotSupported,
-		errMsg: fmt.Sprintf("%v: operation \"%v\" isn't supported for network %v",
-			ErrNetworkNotSupported, operation, network),
+		reason: ErrorReason_REASON_NETWORK_NOT_SUPPORTED,
+		errMsg: fmt.Sprintf("operation \"%v\" isn't supported for network %v",
+			operation, network),
 	}
 }
 
 func newErrAssetNotSupported(asset, media string) Error {
 	return Error{
-		code: ErrAssetNotSupported,
-		errMsg: fmt.Sprintf("%v: asset(%v) is not supported for media(%v)",
-			ErrAssetNotSupported, asset, media),
+		reason: ErrorReason_REASON_ASSET_NOT_SUPPORTED,
+		field:  "asset",
+		errMsg: fmt.Sprintf("asset(%v) is not supported for media(%v)",
+			asset, media),
 	}
 }
 
 func newErrInternal(desc string) Error {
 	return Error{
-		code:   ErrInternal,
-		errMsg: fmt.Sprintf("%v: internal error: %v", ErrInternal, desc),
+		reason: ErrorReason_REASON_INTERNAL,
+		errMsg: fmt.Sprintf("internal error: %v", desc),
+	}
+}
+
+func newErrInvalidArgument(field, format string, params ...interface{}) Error {
+	return Error{
+		reason: ErrorReason_REASON_INVALID_ARGUMENT,
+		field:  field,
+		errMsg: fmt.Sprintf("invalid argument '%v': %v", field,
+			fmt.Sprintf(format, params...)),
+	}
+}
+
+func newErrNotFound(field, format string, params ...interface{}) Error {
+	return Error{
+		reason: ErrorReason_REASON_NOT_FOUND,
+		field:  field,
+		errMsg: fmt.Sprintf(format, params...),
+	}
+}
+
+func newErrUnavailable(format string, params ...interface{}) Error {
+	return Error{
+		reason:    ErrorReason_REASON_UNAVAILABLE,
+		retryable: true,
+		errMsg:    fmt.Sprintf(format, params...),
+	}
+}
+
+func newErrNotImplemented(method string) Error {
+	return Error{
+		reason: ErrorReason_REASON_NOT_IMPLEMENTED,
+		errMsg: fmt.Sprintf("method(%v) is not implemented", method),
+	}
+}
+
+func newErrIdempotencyKeyReused(key string) Error {
+	return Error{
+		reason: ErrorReason_REASON_IDEMPOTENCY_KEY_REUSED,
+		field:  "idempotency_key",
+		errMsg: fmt.Sprintf("idempotency key(%v) has already been used "+
+			"with different parameters", key),
+	}
+}
+
+func newErrRequestInProgress(key string) Error {
+	return Error{
+		reason:    ErrorReason_REASON_REQUEST_IN_PROGRESS,
+		field:     "idempotency_key",
+		retryable: true,
+		errMsg: fmt.Sprintf("request with idempotency key(%v) is in "+
+			"progress", key),
 	}
 }
 
-func newErrInvalidArgument(argName string) Error {
+func newErrRateLimited(desc string) Error {
 	return Error{
-		code: ErrInvalidArgument,
-		errMsg: fmt.Sprintf("%v: invalid argument '%v'", ErrInvalidArgument,
-			argName),
+		reason:    ErrorReason_REASON_RATE_LIMITED,
+		retryable: true,
+		errMsg:    fmt.Sprintf("rate limited: %v", desc),
 	}
 }
@@ -0,0 +1,132 @@
+package hubrpc
+
+import (
+	"encoding/json"
+	"github.com/go-errors/errors"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"golang.org/x/net/context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestErrorInfoFromError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		reason   string
+		metadata map[string]string
+	}{
+		{
+			name:   "field error",
+			err:    newErrInvalidArgument("limit", "shouldn't be negative"),
+			reason: "REASON_INVALID_ARGUMENT",
+			metadata: map[string]string{
+				ErrorFieldKey:     "limit",
+				ErrorRetryableKey: "false",
+			},
+		},
+		{
+			name:   "retryable error",
+			err:    newErrRateLimited("too many requests"),
+			reason: "REASON_RATE_LIMITED",
+			metadata: map[string]string{
+				ErrorRetryableKey: "true",
+			},
+		},
+		{
+			name: "error without info",
+			err:  errors.New("failure"),
+		},
+	}
+
+	for _, test := range tests {
+		// Error is received by the client as gRPC status.
+		err := test.err
+		if e, ok := err.(Error); ok {
+			err = e.GRPCStatus().Err()
+		}
+
+		info, ok := ErrorInfoFromError(err)
+		if test.reason == "" {
+			if ok {
+				t.Fatalf("(%v) error info shouldn't be found", test.name)
+			}
+			continue
+		}
+
+		if !ok {
+			t.Fatalf("(%v) error info not found", test.name)
+		}
+
+		if info.Reason != test.reason || info.Domain != ErrorDomain {
+			t.Fatalf("(%v) wrong error info: %v(%v)", test.name,
+				info.Reason, info.Domain)
+		}
+
+		if !reflect.DeepEqual(info.Metadata, test.metadata) {
+			t.Fatalf("(%v) wrong metadata: %v", test.name, info.Metadata)
+		}
+	}
+}
+
+func TestGatewayErrorHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		reason string
+	}{
+		{
+			name:   "hub error",
+			err:    newErrRateLimited("too many requests").GRPCStatus().Err(),
+			status: http.StatusTooManyRequests,
+			reason: "REASON_RATE_LIMITED",
+		},
+		{
+			name:   "error without info",
+			err:    errors.New("failure"),
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		GatewayErrorHandler(context.Background(), runtime.NewServeMux(),
+			&runtime.JSONPb{}, w, nil, test.err)
+
+		if w.Code != test.status {
+			t.Fatalf("(%v) wrong status: %v", test.name, w.Code)
+		}
+
+		var body struct {
+			Error   string
+			Details []struct {
+				Type   string `json:"@type"`
+				Reason string
+				Domain string
+			}
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("(%v) unable to decode body: %v", test.name, err)
+		}
+
+		if body.Error == "" {
+			t.Fatalf("(%v) error description is empty", test.name)
+		}
+
+		reason := ""
+		for _, detail := range body.Details {
+			if detail.Type == "type.googleapis.com/google.rpc.ErrorInfo" &&
+				detail.Domain == ErrorDomain {
+				reason = detail.Reason
+			}
+		}
+
+		if reason != test.reason {
+			t.Fatalf("(%v) wrong reason: %v, body: %s", test.name, reason,
+				w.Body.Bytes())
+		}
+	}
+}
@@ -1,3 +1,46 @@
 #!/usr/bin/env bash
 
-protoc --go_out=plugins=grpc:. hubrpc.proto
\ No newline at end of file
+GOOGLEAPIS=$GOPATH/src/github.com/grpc-ecosystem/grpc-gateway/third_party/googleapis
+
+protoc -I. -I$GOOGLEAPIS --go_out=plugins=grpc:. hubrpc.proto
+protoc -I. -I$GOOGLEAPIS --grpc-gateway_out=logtostderr=true:. hubrpc.proto
+protoc -I. -I$GOOGLEAPIS --swagger_out=logtostderr=true:. hubrpc.proto
+
+# Swagger generator emits only messages which are used by the REST methods,
+# so the error body of the gateway is rendered through the temporary service,
+# and its definitions are merged in the spec.
+cat > gateway_error.proto <<EOF
+syntax = "proto3";
+
+import "google/api/annotations.proto";
+import "hubrpc.proto";
+
+package hubrpc;
+
+service GatewayErrors {
+    rpc Error (GatewayError) returns (GatewayError) {
+        option (google.api.http) = {
+            get: "/error"
+        };
+    }
+}
+EOF
+protoc -I. -I$GOOGLEAPIS --swagger_out=logtostderr=true:. gateway_error.proto
+jq -s '.[0].definitions += .[1].definitions | .[0]' hubrpc.swagger.json \
+    gateway_error.swagger.json > hubrpc.swagger.json.tmp
+mv hubrpc.swagger.json.tmp hubrpc.swagger.json
+rm gateway_error.proto gateway_error.swagger.json
+
+# Embed OpenAPI spec in the binary, so that it could be served by the REST
+# gateway. Backquotes of the spec are concatenated, because they can't be
+# used in the raw string.
+{
+    echo "// Code generated by generate.sh. DO NOT EDIT."
+    echo
+    echo "package hubrpc"
+    echo
+    echo "// SwaggerJSON is the OpenAPI specification of the hub REST gateway."
+    echo "const SwaggerJSON = \`"
+    sed 's/`/` + "`" + `/g' hubrpc.swagger.json
+    echo "\`"
+} > hubrpc.swagger.go
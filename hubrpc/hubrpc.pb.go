@@ -10,6 +10,10 @@ It is generated from these files:
 It has these top-level messages:
 	EmptyRequest
 	EmptyResponse
+	BudgetRequest
+	BudgetResponse
+	FeePolicyUpdatesRequest
+	FeePolicyUpdatesResponse
 	CheckNodeStatsRequest
 	CheckNodeStatsResponse
 	CreateInvoiceRequest
@@ -29,12 +33,33 @@ It has these top-level messages:
 	ListPaymentsResponse
 	NodeIdentificator
 	Payment
+	ChannelsChangeRequest
+	ChannelsChangeResponse
+	NetworkGraphRequest
+	NetworkGraphResponse
+	NodeCapacityHistoryRequest
+	NodeCapacityHistoryResponse
+	NetworkAnomaliesRequest
+	NetworkAnomaliesResponse
+	NetworkStatsRequest
+	EconomicStats
+	NetworkStatsResponse
+	PaymentTimeSeriesRequest
+	PaymentTimeSeriesResponse
+	ProfitabilityReportRequest
+	Profitability
+	ProfitabilityReportResponse
+	ExportLedgerRequest
+	ExportLedgerResponse
+	GatewayError
 */
 package hubrpc
 
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import _ "google.golang.org/genproto/googleapis/api/annotations"
+import google_protobuf "github.com/golang/protobuf/ptypes/any"
 
 import (
 	context "golang.org/x/net/context"
@@ -211,6 +236,10 @@ const (
 	// ByVolume is used to sort nodes by overall volume including forward
 	// volume.
 	SortType_BY_VOLUME SortType = 3
+	//
+	// ByReceived is used to sort nodes by volume of payments we have
+	// received from this node.
+	SortType_BY_RECEIVED SortType = 4
 )
 
 var SortType_name = map[int32]string{
@@ -218,12 +247,14 @@ var SortType_name = map[int32]string{
 	1: "BY_SENT_NUM",
 	2: "BY_IDLENESS",
 	3: "BY_VOLUME",
+	4: "BY_RECEIVED",
 }
 var SortType_value = map[string]int32{
 	"SORT_NONE":   0,
 	"BY_SENT_NUM": 1,
 	"BY_IDLENESS": 2,
 	"BY_VOLUME":   3,
+	"BY_RECEIVED": 4,
 }
 
 func (x SortType) String() string {
@@ -269,6 +300,122 @@ func (x Period) String() string {
 }
 func (Period) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
+type BucketSize int32
+
+const (
+	BucketSize_BUCKET_NONE BucketSize = 0
+	//
+	// BucketHour is used to aggregate time series in hour buckets.
+	BucketSize_BUCKET_HOUR BucketSize = 1
+	//
+	// BucketDay is used to aggregate time series in day buckets.
+	BucketSize_BUCKET_DAY BucketSize = 2
+	//
+	// BucketWeek is used to aggregate time series in week buckets.
+	BucketSize_BUCKET_WEEK BucketSize = 3
+)
+
+var BucketSize_name = map[int32]string{
+	0: "BUCKET_NONE",
+	1: "BUCKET_HOUR",
+	2: "BUCKET_DAY",
+	3: "BUCKET_WEEK",
+}
+var BucketSize_value = map[string]int32{
+	"BUCKET_NONE": 0,
+	"BUCKET_HOUR": 1,
+	"BUCKET_DAY":  2,
+	"BUCKET_WEEK": 3,
+}
+
+func (x BucketSize) String() string {
+	return proto.EnumName(BucketSize_name, int32(x))
+}
+func (BucketSize) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+// ErrorReason is the stable reason of the failed request, which allows
+// clients to handle errors without parsing of the error message. Name of the
+// reason is sent as the reason of google.rpc.ErrorInfo with the "hub"
+// domain, which is attached to the details of the gRPC status. The name of
+// the request field which caused the error is sent in the "field" metadata,
+// and "retryable" metadata is "true" if the same request might succeed if it
+// is retried later.
+type ErrorReason int32
+
+const (
+	ErrorReason_REASON_NONE ErrorReason = 0
+	//
+	// REASON_ASSET_NOT_SUPPORTED means that asset of the request isn't
+	// supported by the media.
+	ErrorReason_REASON_ASSET_NOT_SUPPORTED ErrorReason = 1
+	//
+	// REASON_NETWORK_NOT_SUPPORTED means that operation isn't supported for
+	// the blockchain network hub is working on.
+	ErrorReason_REASON_NETWORK_NOT_SUPPORTED ErrorReason = 2
+	//
+	// REASON_INVALID_ARGUMENT means that request field, which is specified
+	// in the error info, has invalid value.
+	ErrorReason_REASON_INVALID_ARGUMENT ErrorReason = 3
+	//
+	// REASON_INTERNAL means that hub failed to process the valid request.
+	ErrorReason_REASON_INTERNAL ErrorReason = 4
+	//
+	// REASON_NOT_FOUND means that entity specified in the request field
+	// doesn't exist.
+	ErrorReason_REASON_NOT_FOUND ErrorReason = 5
+	//
+	// REASON_UNAVAILABLE means that requested data isn't ready yet, and
+	// request should be retried later.
+	ErrorReason_REASON_UNAVAILABLE ErrorReason = 6
+	//
+	// REASON_NOT_IMPLEMENTED means that method isn't implemented yet.
+	ErrorReason_REASON_NOT_IMPLEMENTED ErrorReason = 7
+	//
+	// REASON_IDEMPOTENCY_KEY_REUSED means that idempotency key has already
+	// been used by the request with different parameters.
+	ErrorReason_REASON_IDEMPOTENCY_KEY_REUSED ErrorReason = 8
+	//
+	// REASON_REQUEST_IN_PROGRESS means that request with the same
+	// idempotency key is still being processed.
+	ErrorReason_REASON_REQUEST_IN_PROGRESS ErrorReason = 9
+	//
+	// REASON_RATE_LIMITED means that client has exceeded the rate limit of
+	// requests, or hub is busy with the concurrent expensive requests.
+	ErrorReason_REASON_RATE_LIMITED ErrorReason = 10
+)
+
+var ErrorReason_name = map[int32]string{
+	0:  "REASON_NONE",
+	1:  "REASON_ASSET_NOT_SUPPORTED",
+	2:  "REASON_NETWORK_NOT_SUPPORTED",
+	3:  "REASON_INVALID_ARGUMENT",
+	4:  "REASON_INTERNAL",
+	5:  "REASON_NOT_FOUND",
+	6:  "REASON_UNAVAILABLE",
+	7:  "REASON_NOT_IMPLEMENTED",
+	8:  "REASON_IDEMPOTENCY_KEY_REUSED",
+	9:  "REASON_REQUEST_IN_PROGRESS",
+	10: "REASON_RATE_LIMITED",
+}
+var ErrorReason_value = map[string]int32{
+	"REASON_NONE":                   0,
+	"REASON_ASSET_NOT_SUPPORTED":    1,
+	"REASON_NETWORK_NOT_SUPPORTED":  2,
+	"REASON_INVALID_ARGUMENT":       3,
+	"REASON_INTERNAL":               4,
+	"REASON_NOT_FOUND":              5,
+	"REASON_UNAVAILABLE":            6,
+	"REASON_NOT_IMPLEMENTED":        7,
+	"REASON_IDEMPOTENCY_KEY_REUSED": 8,
+	"REASON_REQUEST_IN_PROGRESS":    9,
+	"REASON_RATE_LIMITED":           10,
+}
+
+func (x ErrorReason) String() string {
+	return proto.EnumName(ErrorReason_name, int32(x))
+}
+func (ErrorReason) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
 type EmptyRequest struct {
 }
 
@@ -285,17 +432,282 @@ func (m *EmptyResponse) String() string            { return proto.CompactTextStr
 func (*EmptyResponse) ProtoMessage()               {}
 func (*EmptyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+type BudgetRequest struct {
+}
+
+func (m *BudgetRequest) Reset()                    { *m = BudgetRequest{} }
+func (m *BudgetRequest) String() string            { return proto.CompactTextString(m) }
+func (*BudgetRequest) ProtoMessage()               {}
+func (*BudgetRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type BudgetResponse struct {
+	Budgets []*BudgetResponse_OperationBudget `protobuf:"bytes,1,rep,name=budgets" json:"budgets,omitempty"`
+}
+
+func (m *BudgetResponse) Reset()                    { *m = BudgetResponse{} }
+func (m *BudgetResponse) String() string            { return proto.CompactTextString(m) }
+func (*BudgetResponse) ProtoMessage()               {}
+func (*BudgetResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *BudgetResponse) GetBudgets() []*BudgetResponse_OperationBudget {
+	if m != nil {
+		return m.Budgets
+	}
+	return nil
+}
+
+type BudgetResponse_OperationBudget struct {
+	// Operation is the name of channel operation, i.e. open, close.
+	Operation string `protobuf:"bytes,1,opt,name=operation" json:"operation,omitempty"`
+	// Limit is the maximum number of funds we allow to spend on the
+	// operation during the day. (In USD)
+	Limit float64 `protobuf:"fixed64,2,opt,name=limit" json:"limit,omitempty"`
+	// Spent is the number of funds spent on the operation during the
+	// last day, including pending operations. (In USD)
+	Spent float64 `protobuf:"fixed64,3,opt,name=spent" json:"spent,omitempty"`
+	// Pending is the estimated number of funds of operations which were
+	// made by us, but actual fee of which is not known yet. (In USD)
+	Pending float64 `protobuf:"fixed64,4,opt,name=pending" json:"pending,omitempty"`
+	// Remaining is the number of funds we still could spend on the
+	// operation during the day. (In USD)
+	Remaining float64 `protobuf:"fixed64,5,opt,name=remaining" json:"remaining,omitempty"`
+	// Postponed is the number of operations which were postponed during
+	// the last day because of the budget exhaustion.
+	Postponed int32 `protobuf:"varint,6,opt,name=postponed" json:"postponed,omitempty"`
+}
+
+func (m *BudgetResponse_OperationBudget) Reset()         { *m = BudgetResponse_OperationBudget{} }
+func (m *BudgetResponse_OperationBudget) String() string { return proto.CompactTextString(m) }
+func (*BudgetResponse_OperationBudget) ProtoMessage()    {}
+func (*BudgetResponse_OperationBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{3, 0}
+}
+
+func (m *BudgetResponse_OperationBudget) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *BudgetResponse_OperationBudget) GetLimit() float64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *BudgetResponse_OperationBudget) GetSpent() float64 {
+	if m != nil {
+		return m.Spent
+	}
+	return 0
+}
+
+func (m *BudgetResponse_OperationBudget) GetPending() float64 {
+	if m != nil {
+		return m.Pending
+	}
+	return 0
+}
+
+func (m *BudgetResponse_OperationBudget) GetRemaining() float64 {
+	if m != nil {
+		return m.Remaining
+	}
+	return 0
+}
+
+func (m *BudgetResponse_OperationBudget) GetPostponed() int32 {
+	if m != nil {
+		return m.Postponed
+	}
+	return 0
+}
+
+type FeePolicyUpdatesRequest struct {
+	// Limit limits output to the given number of the latest updates.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *FeePolicyUpdatesRequest) Reset()                    { *m = FeePolicyUpdatesRequest{} }
+func (m *FeePolicyUpdatesRequest) String() string            { return proto.CompactTextString(m) }
+func (*FeePolicyUpdatesRequest) ProtoMessage()               {}
+func (*FeePolicyUpdatesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *FeePolicyUpdatesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type FeePolicyUpdatesResponse struct {
+	Updates []*FeePolicyUpdatesResponse_PolicyUpdate `protobuf:"bytes,1,rep,name=updates" json:"updates,omitempty"`
+}
+
+func (m *FeePolicyUpdatesResponse) Reset()                    { *m = FeePolicyUpdatesResponse{} }
+func (m *FeePolicyUpdatesResponse) String() string            { return proto.CompactTextString(m) }
+func (*FeePolicyUpdatesResponse) ProtoMessage()               {}
+func (*FeePolicyUpdatesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *FeePolicyUpdatesResponse) GetUpdates() []*FeePolicyUpdatesResponse_PolicyUpdate {
+	if m != nil {
+		return m.Updates
+	}
+	return nil
+}
+
+type FeePolicyUpdatesResponse_FeePolicy struct {
+	// BaseFeeMsat is the fixed fee in millisatoshis which is taken for
+	// every forwarded payment.
+	BaseFeeMsat int64 `protobuf:"varint,1,opt,name=base_fee_msat,json=baseFeeMsat" json:"base_fee_msat,omitempty"`
+	// FeeRate is the proportional fee in parts per million of the
+	// forwarded amount.
+	FeeRate int64 `protobuf:"varint,2,opt,name=fee_rate,json=feeRate" json:"fee_rate,omitempty"`
+}
+
+func (m *FeePolicyUpdatesResponse_FeePolicy) Reset()         { *m = FeePolicyUpdatesResponse_FeePolicy{} }
+func (m *FeePolicyUpdatesResponse_FeePolicy) String() string { return proto.CompactTextString(m) }
+func (*FeePolicyUpdatesResponse_FeePolicy) ProtoMessage()    {}
+func (*FeePolicyUpdatesResponse_FeePolicy) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{5, 0}
+}
+
+func (m *FeePolicyUpdatesResponse_FeePolicy) GetBaseFeeMsat() int64 {
+	if m != nil {
+		return m.BaseFeeMsat
+	}
+	return 0
+}
+
+func (m *FeePolicyUpdatesResponse_FeePolicy) GetFeeRate() int64 {
+	if m != nil {
+		return m.FeeRate
+	}
+	return 0
+}
+
+type FeePolicyUpdatesResponse_PolicyUpdate struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	NodeId    string `protobuf:"bytes,2,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	// OldPolicy is the policy before the update, not set if it was
+	// unknown.
+	OldPolicy *FeePolicyUpdatesResponse_FeePolicy `protobuf:"bytes,3,opt,name=old_policy,json=oldPolicy" json:"old_policy,omitempty"`
+	// NewPolicy is the policy after the update.
+	NewPolicy *FeePolicyUpdatesResponse_FeePolicy `protobuf:"bytes,4,opt,name=new_policy,json=newPolicy" json:"new_policy,omitempty"`
+	// LocalRatio is the ratio of local balance to the channel balance
+	// at the moment of update.
+	LocalRatio float64 `protobuf:"fixed64,5,opt,name=local_ratio,json=localRatio" json:"local_ratio,omitempty"`
+	// VolumeRatio is the ratio of forwarded through the channel volume
+	// to the channel balance at the moment of update.
+	VolumeRatio float64 `protobuf:"fixed64,6,opt,name=volume_ratio,json=volumeRatio" json:"volume_ratio,omitempty"`
+	// Time is the time of the update.
+	Time int64 `protobuf:"varint,7,opt,name=time" json:"time,omitempty"`
+	// DryRun is true if update was not applied.
+	DryRun bool `protobuf:"varint,8,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) Reset()         { *m = FeePolicyUpdatesResponse_PolicyUpdate{} }
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) String() string { return proto.CompactTextString(m) }
+func (*FeePolicyUpdatesResponse_PolicyUpdate) ProtoMessage()    {}
+func (*FeePolicyUpdatesResponse_PolicyUpdate) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{5, 1}
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) GetOldPolicy() *FeePolicyUpdatesResponse_FeePolicy {
+	if m != nil {
+		return m.OldPolicy
+	}
+	return nil
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) GetNewPolicy() *FeePolicyUpdatesResponse_FeePolicy {
+	if m != nil {
+		return m.NewPolicy
+	}
+	return nil
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) GetLocalRatio() float64 {
+	if m != nil {
+		return m.LocalRatio
+	}
+	return 0
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) GetVolumeRatio() float64 {
+	if m != nil {
+		return m.VolumeRatio
+	}
+	return 0
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *FeePolicyUpdatesResponse_PolicyUpdate) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type CheckNodeStatsRequest struct {
-	Period   Period   `protobuf:"varint,1,opt,name=period,enum=hubrpc.Period" json:"period,omitempty"`
-	Node     string   `protobuf:"bytes,2,opt,name=node" json:"node,omitempty"`
+	Period Period `protobuf:"varint,1,opt,name=period,enum=hubrpc.Period" json:"period,omitempty"`
+	// Node is the domain or public key of the node, stats of which should
+	// be returned. Node is looked up first, and than filters are applied to
+	// it, empty list is returned if node doesn't pass the filters.
+	Node string `protobuf:"bytes,2,opt,name=node" json:"node,omitempty"`
+	// Limit limits output to the given number of nodes, all nodes are
+	// returned if zero.
 	Limit    int32    `protobuf:"varint,3,opt,name=limit" json:"limit,omitempty"`
 	SortType SortType `protobuf:"varint,4,opt,name=sort_type,json=sortType,enum=hubrpc.SortType" json:"sort_type,omitempty"`
+	// Offset is the number of nodes which should be skipped, is used
+	// alongside with limit for pagination.
+	Offset int32 `protobuf:"varint,5,opt,name=offset" json:"offset,omitempty"`
+	// ImportantOnly filters out nodes which are not important for us.
+	ImportantOnly bool `protobuf:"varint,6,opt,name=important_only,json=importantOnly" json:"important_only,omitempty"`
+	// AvailableOnly filters out nodes to which we couldn't send payment
+	// directly.
+	AvailableOnly bool `protobuf:"varint,7,opt,name=available_only,json=availableOnly" json:"available_only,omitempty"`
+	// MinVolume filters out nodes average daily payment volume of which,
+	// i.e. sent, received, sent forward and received forward, is less than
+	// the given one. (In USD)
+	MinVolume float64 `protobuf:"fixed64,8,opt,name=min_volume,json=minVolume" json:"min_volume,omitempty"`
+	// Start and End is the custom [start, end) range of time over which
+	// statistic should be aggregated, if start is specified period is
+	// ignored. Current time is used as end if it is not specified.
+	Start int64 `protobuf:"varint,9,opt,name=start" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,10,opt,name=end" json:"end,omitempty"`
+	// (optional) BucketSize is the size of the payment time series bucket,
+	// if specified payment time series over the [start, end) range is
+	// returned for every node.
+	BucketSize BucketSize `protobuf:"varint,11,opt,name=bucket_size,json=bucketSize,enum=hubrpc.BucketSize" json:"bucket_size,omitempty"`
 }
 
 func (m *CheckNodeStatsRequest) Reset()                    { *m = CheckNodeStatsRequest{} }
 func (m *CheckNodeStatsRequest) String() string            { return proto.CompactTextString(m) }
 func (*CheckNodeStatsRequest) ProtoMessage()               {}
-func (*CheckNodeStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*CheckNodeStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *CheckNodeStatsRequest) GetPeriod() Period {
 	if m != nil {
@@ -325,14 +737,66 @@ func (m *CheckNodeStatsRequest) GetSortType() SortType {
 	return SortType_SORT_NONE
 }
 
+func (m *CheckNodeStatsRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsRequest) GetImportantOnly() bool {
+	if m != nil {
+		return m.ImportantOnly
+	}
+	return false
+}
+
+func (m *CheckNodeStatsRequest) GetAvailableOnly() bool {
+	if m != nil {
+		return m.AvailableOnly
+	}
+	return false
+}
+
+func (m *CheckNodeStatsRequest) GetMinVolume() float64 {
+	if m != nil {
+		return m.MinVolume
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsRequest) GetBucketSize() BucketSize {
+	if m != nil {
+		return m.BucketSize
+	}
+	return BucketSize_BUCKET_NONE
+}
+
 type CheckNodeStatsResponse struct {
 	Statuses []*CheckNodeStatsResponse_NodeStatus `protobuf:"bytes,1,rep,name=statuses" json:"statuses,omitempty"`
+	// Total is number of nodes which satisfy the filters, before the
+	// offset and limit are applied.
+	Total int32 `protobuf:"varint,2,opt,name=total" json:"total,omitempty"`
 }
 
 func (m *CheckNodeStatsResponse) Reset()                    { *m = CheckNodeStatsResponse{} }
 func (m *CheckNodeStatsResponse) String() string            { return proto.CompactTextString(m) }
 func (*CheckNodeStatsResponse) ProtoMessage()               {}
-func (*CheckNodeStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*CheckNodeStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *CheckNodeStatsResponse) GetStatuses() []*CheckNodeStatsResponse_NodeStatus {
 	if m != nil {
@@ -341,29 +805,43 @@ func (m *CheckNodeStatsResponse) GetStatuses() []*CheckNodeStatsResponse_NodeSta
 	return nil
 }
 
+func (m *CheckNodeStatsResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
 type CheckNodeStatsResponse_NodeStatus struct {
 	// Domain is the name given by us to this node.
 	Domain string `protobuf:"bytes,1,opt,name=domain" json:"domain,omitempty"`
 	// PubKey is a identificator of node in lightning network.
 	PubKey string `protobuf:"bytes,2,opt,name=pub_key,json=pubKey" json:"pub_key,omitempty"`
 	// Available shows whether or not we could send payment directly to
-	// the node.
+	// the node, i.e. node is connected, we have active channel with
+	// it, and local balance of the channel is enough to send 95th
+	// percentile payment.
 	Available bool `protobuf:"varint,3,opt,name=available" json:"available,omitempty"`
-	// Anomalies will show message about anomalies which are related to
-	// this node, such as not all funds being active, or node being
-	// offline, or not enough funds being locked within channels, and
-	// other.
-	Anomalies    []string                                         `protobuf:"bytes,4,rep,name=anomalies" json:"anomalies,omitempty"`
+	// Anomalies will show anomalies which are related to this node,
+	// such as not all funds being active, or node being offline, or not
+	// enough funds being locked within channels, and other.
+	Anomalies    []*CheckNodeStatsResponse_NodeStatus_Anomaly     `protobuf:"bytes,4,rep,name=anomalies" json:"anomalies,omitempty"`
 	RankStats    *CheckNodeStatsResponse_NodeStatus_RankStats     `protobuf:"bytes,5,opt,name=rank_stats,json=rankStats" json:"rank_stats,omitempty"`
 	PaymentStats *CheckNodeStatsResponse_NodeStatus_PaymentsStats `protobuf:"bytes,6,opt,name=payment_stats,json=paymentStats" json:"payment_stats,omitempty"`
 	ChannelStats *CheckNodeStatsResponse_NodeStatus_ChannelStats  `protobuf:"bytes,7,opt,name=channel_stats,json=channelStats" json:"channel_stats,omitempty"`
+	HealthStats  *CheckNodeStatsResponse_NodeStatus_HealthStats   `protobuf:"bytes,8,opt,name=health_stats,json=healthStats" json:"health_stats,omitempty"`
+	// Important shows whether or not node is important for us.
+	Important bool `protobuf:"varint,9,opt,name=important" json:"important,omitempty"`
+	// Series is the payment time series of the node, returned only if
+	// bucket size is specified in the request.
+	Series []*PaymentTimeSeriesResponse_Bucket `protobuf:"bytes,10,rep,name=series" json:"series,omitempty"`
 }
 
 func (m *CheckNodeStatsResponse_NodeStatus) Reset()         { *m = CheckNodeStatsResponse_NodeStatus{} }
 func (m *CheckNodeStatsResponse_NodeStatus) String() string { return proto.CompactTextString(m) }
 func (*CheckNodeStatsResponse_NodeStatus) ProtoMessage()    {}
 func (*CheckNodeStatsResponse_NodeStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{3, 0}
+	return fileDescriptor0, []int{7, 0}
 }
 
 func (m *CheckNodeStatsResponse_NodeStatus) GetDomain() string {
@@ -387,7 +865,7 @@ func (m *CheckNodeStatsResponse_NodeStatus) GetAvailable() bool {
 	return false
 }
 
-func (m *CheckNodeStatsResponse_NodeStatus) GetAnomalies() []string {
+func (m *CheckNodeStatsResponse_NodeStatus) GetAnomalies() []*CheckNodeStatsResponse_NodeStatus_Anomaly {
 	if m != nil {
 		return m.Anomalies
 	}
@@ -415,6 +893,27 @@ func (m *CheckNodeStatsResponse_NodeStatus) GetChannelStats() *CheckNodeStatsRes
 	return nil
 }
 
+func (m *CheckNodeStatsResponse_NodeStatus) GetHealthStats() *CheckNodeStatsResponse_NodeStatus_HealthStats {
+	if m != nil {
+		return m.HealthStats
+	}
+	return nil
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus) GetImportant() bool {
+	if m != nil {
+		return m.Important
+	}
+	return false
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus) GetSeries() []*PaymentTimeSeriesResponse_Bucket {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
 type CheckNodeStatsResponse_NodeStatus_ChannelStats struct {
 	// LockedLocallyActive is number of funds aggregated from all channels,
 	// which could be used for send the payments. (In USD)
@@ -440,7 +939,7 @@ func (m *CheckNodeStatsResponse_NodeStatus_ChannelStats) String() string {
 }
 func (*CheckNodeStatsResponse_NodeStatus_ChannelStats) ProtoMessage() {}
 func (*CheckNodeStatsResponse_NodeStatus_ChannelStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{3, 0, 0}
+	return fileDescriptor0, []int{7, 0, 0}
 }
 
 func (m *CheckNodeStatsResponse_NodeStatus_ChannelStats) GetLockedLocallyActive() float64 {
@@ -493,6 +992,12 @@ type CheckNodeStatsResponse_NodeStatus_PaymentsStats struct {
 	NumSent                int32   `protobuf:"varint,7,opt,name=num_sent,json=numSent" json:"num_sent,omitempty"`
 	NumReceivedForward     int32   `protobuf:"varint,8,opt,name=num_received_forward,json=numReceivedForward" json:"num_received_forward,omitempty"`
 	NumSentForward         int32   `protobuf:"varint,9,opt,name=num_sent_forward,json=numSentForward" json:"num_sent_forward,omitempty"`
+	// AverageReceived is the number of funds which were received by
+	// us from this node, i.e. payments to our invoices which arrived
+	// through channels with this node. (In USD)
+	AverageReceived float64 `protobuf:"fixed64,10,opt,name=average_received,json=averageReceived" json:"average_received,omitempty"`
+	OverallReceived float64 `protobuf:"fixed64,11,opt,name=overall_received,json=overallReceived" json:"overall_received,omitempty"`
+	NumReceived     int32   `protobuf:"varint,12,opt,name=num_received,json=numReceived" json:"num_received,omitempty"`
 }
 
 func (m *CheckNodeStatsResponse_NodeStatus_PaymentsStats) Reset() {
@@ -503,7 +1008,7 @@ func (m *CheckNodeStatsResponse_NodeStatus_PaymentsStats) String() string {
 }
 func (*CheckNodeStatsResponse_NodeStatus_PaymentsStats) ProtoMessage() {}
 func (*CheckNodeStatsResponse_NodeStatus_PaymentsStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{3, 0, 1}
+	return fileDescriptor0, []int{7, 0, 1}
 }
 
 func (m *CheckNodeStatsResponse_NodeStatus_PaymentsStats) GetAverageSentForward() float64 {
@@ -569,11 +1074,33 @@ func (m *CheckNodeStatsResponse_NodeStatus_PaymentsStats) GetNumSentForward() in
 	return 0
 }
 
+func (m *CheckNodeStatsResponse_NodeStatus_PaymentsStats) GetAverageReceived() float64 {
+	if m != nil {
+		return m.AverageReceived
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_PaymentsStats) GetOverallReceived() float64 {
+	if m != nil {
+		return m.OverallReceived
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_PaymentsStats) GetNumReceived() int32 {
+	if m != nil {
+		return m.NumReceived
+	}
+	return 0
+}
+
 type CheckNodeStatsResponse_NodeStatus_RankStats struct {
-	RankPaymentsSentNum    int64 `protobuf:"varint,1,opt,name=rank_payments_sent_num,json=rankPaymentsSentNum" json:"rank_payments_sent_num,omitempty"`
-	RankPaymentsSentVolume int64 `protobuf:"varint,2,opt,name=rank_payments_sent_volume,json=rankPaymentsSentVolume" json:"rank_payments_sent_volume,omitempty"`
-	RankIdle               int64 `protobuf:"varint,3,opt,name=rank_idle,json=rankIdle" json:"rank_idle,omitempty"`
-	RankForwardActivity    int64 `protobuf:"varint,4,opt,name=rank_forward_activity,json=rankForwardActivity" json:"rank_forward_activity,omitempty"`
+	RankPaymentsSentNum        int64 `protobuf:"varint,1,opt,name=rank_payments_sent_num,json=rankPaymentsSentNum" json:"rank_payments_sent_num,omitempty"`
+	RankPaymentsSentVolume     int64 `protobuf:"varint,2,opt,name=rank_payments_sent_volume,json=rankPaymentsSentVolume" json:"rank_payments_sent_volume,omitempty"`
+	RankIdle                   int64 `protobuf:"varint,3,opt,name=rank_idle,json=rankIdle" json:"rank_idle,omitempty"`
+	RankForwardActivity        int64 `protobuf:"varint,4,opt,name=rank_forward_activity,json=rankForwardActivity" json:"rank_forward_activity,omitempty"`
+	RankPaymentsReceivedVolume int64 `protobuf:"varint,5,opt,name=rank_payments_received_volume,json=rankPaymentsReceivedVolume" json:"rank_payments_received_volume,omitempty"`
 }
 
 func (m *CheckNodeStatsResponse_NodeStatus_RankStats) Reset() {
@@ -584,7 +1111,7 @@ func (m *CheckNodeStatsResponse_NodeStatus_RankStats) String() string {
 }
 func (*CheckNodeStatsResponse_NodeStatus_RankStats) ProtoMessage() {}
 func (*CheckNodeStatsResponse_NodeStatus_RankStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{3, 0, 2}
+	return fileDescriptor0, []int{7, 0, 2}
 }
 
 func (m *CheckNodeStatsResponse_NodeStatus_RankStats) GetRankPaymentsSentNum() int64 {
@@ -615,48 +1142,187 @@ func (m *CheckNodeStatsResponse_NodeStatus_RankStats) GetRankForwardActivity() i
 	return 0
 }
 
-type CreateInvoiceRequest struct {
-	//
-	// (optional) Amount is the amount which should be received on this
-	// receipt, in bitcoin.
-	Amount string `protobuf:"bytes,1,opt,name=amount" json:"amount,omitempty"`
-	//
-	// (optional) Description description will be placed in the invoice itself,
-	// which would allow user to see what he paid for later in the wallet.
-	Description string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+func (m *CheckNodeStatsResponse_NodeStatus_RankStats) GetRankPaymentsReceivedVolume() int64 {
+	if m != nil {
+		return m.RankPaymentsReceivedVolume
+	}
+	return 0
 }
 
-func (m *CreateInvoiceRequest) Reset()                    { *m = CreateInvoiceRequest{} }
-func (m *CreateInvoiceRequest) String() string            { return proto.CompactTextString(m) }
-func (*CreateInvoiceRequest) ProtoMessage()               {}
-func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+type CheckNodeStatsResponse_NodeStatus_Anomaly struct {
+	// Code is the type of anomaly, i.e. inactive_channels,
+	// stuck_balance, remote_only_liquidity, pending_close,
+	// insufficient_capacity, max_flow,
+	// important_node_disconnected.
+	Code string `protobuf:"bytes,1,opt,name=code" json:"code,omitempty"`
+	// Severity is the level of anomaly importance, i.e. info,
+	// warning, critical.
+	Severity    string `protobuf:"bytes,2,opt,name=severity" json:"severity,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+}
 
-func (m *CreateInvoiceRequest) GetAmount() string {
+func (m *CheckNodeStatsResponse_NodeStatus_Anomaly) Reset() {
+	*m = CheckNodeStatsResponse_NodeStatus_Anomaly{}
+}
+func (m *CheckNodeStatsResponse_NodeStatus_Anomaly) String() string {
+	return proto.CompactTextString(m)
+}
+func (*CheckNodeStatsResponse_NodeStatus_Anomaly) ProtoMessage() {}
+func (*CheckNodeStatsResponse_NodeStatus_Anomaly) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{7, 0, 3}
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_Anomaly) GetCode() string {
 	if m != nil {
-		return m.Amount
+		return m.Code
 	}
 	return ""
 }
 
-func (m *CreateInvoiceRequest) GetDescription() string {
+func (m *CheckNodeStatsResponse_NodeStatus_Anomaly) GetSeverity() string {
+	if m != nil {
+		return m.Severity
+	}
+	return ""
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_Anomaly) GetDescription() string {
 	if m != nil {
 		return m.Description
 	}
 	return ""
 }
 
-type CreateInvoiceResponse struct {
-	//
-	// When this invoice was created.
-	// NOTE: Only returns for lightning network media.
-	CreationDate int64 `protobuf:"varint,1,opt,name=creation_date,json=creationDate" json:"creation_date,omitempty"`
-	//
-	// Invoice it is lightning network invoice, which is the string which
-	// contains amount, description, destination, and other info which is
-	// needed for sender to successfully send payment.
-	Invoice string `protobuf:"bytes,2,opt,name=invoice" json:"invoice,omitempty"`
-	//
-	// Invoice expiry time in seconds. Default is 3600 (1 hour).
+type CheckNodeStatsResponse_NodeStatus_HealthStats struct {
+	// Connected shows whether or not node is connected to us with
+	// tcp / ip connection.
+	Connected bool `protobuf:"varint,1,opt,name=connected" json:"connected,omitempty"`
+	// Uptime is the ratio of time during which node was connected
+	// to us, within the last week.
+	Uptime float64 `protobuf:"fixed64,2,opt,name=uptime" json:"uptime,omitempty"`
+	// LastSeen is the last time when node was connected to us,
+	// zero if node has never been seen.
+	LastSeen int64 `protobuf:"varint,3,opt,name=last_seen,json=lastSeen" json:"last_seen,omitempty"`
+	// Disconnects is number of node disconnects within the last
+	// week.
+	Disconnects int32 `protobuf:"varint,4,opt,name=disconnects" json:"disconnects,omitempty"`
+	// LastAnnouncement is the time of the last gossip announcement
+	// received from the node, zero if unknown.
+	LastAnnouncement int64 `protobuf:"varint,5,opt,name=last_announcement,json=lastAnnouncement" json:"last_announcement,omitempty"`
+	// AnnouncementsPerDay is the average number of gossip
+	// announcements received from the node per day, which shows
+	// the spam rate of the node.
+	AnnouncementsPerDay float64 `protobuf:"fixed64,6,opt,name=announcements_per_day,json=announcementsPerDay" json:"announcements_per_day,omitempty"`
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_HealthStats) Reset() {
+	*m = CheckNodeStatsResponse_NodeStatus_HealthStats{}
+}
+func (m *CheckNodeStatsResponse_NodeStatus_HealthStats) String() string {
+	return proto.CompactTextString(m)
+}
+func (*CheckNodeStatsResponse_NodeStatus_HealthStats) ProtoMessage() {}
+func (*CheckNodeStatsResponse_NodeStatus_HealthStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{7, 0, 4}
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_HealthStats) GetConnected() bool {
+	if m != nil {
+		return m.Connected
+	}
+	return false
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_HealthStats) GetUptime() float64 {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_HealthStats) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_HealthStats) GetDisconnects() int32 {
+	if m != nil {
+		return m.Disconnects
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_HealthStats) GetLastAnnouncement() int64 {
+	if m != nil {
+		return m.LastAnnouncement
+	}
+	return 0
+}
+
+func (m *CheckNodeStatsResponse_NodeStatus_HealthStats) GetAnnouncementsPerDay() float64 {
+	if m != nil {
+		return m.AnnouncementsPerDay
+	}
+	return 0
+}
+
+type CreateInvoiceRequest struct {
+	//
+	// (optional) Amount is the amount which should be received on this
+	// receipt, in bitcoin.
+	Amount string `protobuf:"bytes,1,opt,name=amount" json:"amount,omitempty"`
+	//
+	// (optional) Description description will be placed in the invoice itself,
+	// which would allow user to see what he paid for later in the wallet.
+	Description string `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	//
+	// (optional) IdempotencyKey is the unique key of the request, generated
+	// by the client. Repeated request with the same key returns the
+	// originally created invoice, and request with the same key but with
+	// different parameters is rejected.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey" json:"idempotency_key,omitempty"`
+}
+
+func (m *CreateInvoiceRequest) Reset()                    { *m = CreateInvoiceRequest{} }
+func (m *CreateInvoiceRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateInvoiceRequest) ProtoMessage()               {}
+func (*CreateInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *CreateInvoiceRequest) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *CreateInvoiceRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *CreateInvoiceRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
+type CreateInvoiceResponse struct {
+	//
+	// When this invoice was created.
+	// NOTE: Only returns for lightning network media.
+	CreationDate int64 `protobuf:"varint,1,opt,name=creation_date,json=creationDate" json:"creation_date,omitempty"`
+	//
+	// Invoice it is lightning network invoice, which is the string which
+	// contains amount, description, destination, and other info which is
+	// needed for sender to successfully send payment.
+	Invoice string `protobuf:"bytes,2,opt,name=invoice" json:"invoice,omitempty"`
+	//
+	// Invoice expiry time in seconds. Default is 3600 (1 hour).
 	// NOTE: Only returns for lightning network media.
 	Expiry int64 `protobuf:"varint,3,opt,name=expiry" json:"expiry,omitempty"`
 }
@@ -664,7 +1330,7 @@ type CreateInvoiceResponse struct {
 func (m *CreateInvoiceResponse) Reset()                    { *m = CreateInvoiceResponse{} }
 func (m *CreateInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateInvoiceResponse) ProtoMessage()               {}
-func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*CreateInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *CreateInvoiceResponse) GetCreationDate() int64 {
 	if m != nil {
@@ -693,7 +1359,7 @@ type BalanceRequest struct {
 func (m *BalanceRequest) Reset()                    { *m = BalanceRequest{} }
 func (m *BalanceRequest) String() string            { return proto.CompactTextString(m) }
 func (*BalanceRequest) ProtoMessage()               {}
-func (*BalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*BalanceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 type Balance struct {
 	//
@@ -708,7 +1374,7 @@ type Balance struct {
 func (m *Balance) Reset()                    { *m = Balance{} }
 func (m *Balance) String() string            { return proto.CompactTextString(m) }
 func (*Balance) ProtoMessage()               {}
-func (*Balance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*Balance) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *Balance) GetAvailable() string {
 	if m != nil {
@@ -735,7 +1401,7 @@ type ValidateInvoiceResponse struct {
 func (m *ValidateInvoiceResponse) Reset()                    { *m = ValidateInvoiceResponse{} }
 func (m *ValidateInvoiceResponse) String() string            { return proto.CompactTextString(m) }
 func (*ValidateInvoiceResponse) ProtoMessage()               {}
-func (*ValidateInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*ValidateInvoiceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *ValidateInvoiceResponse) GetInvoice() *Invoice {
 	if m != nil {
@@ -771,7 +1437,7 @@ type Invoice struct {
 func (m *Invoice) Reset()                    { *m = Invoice{} }
 func (m *Invoice) String() string            { return proto.CompactTextString(m) }
 func (*Invoice) ProtoMessage()               {}
-func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*Invoice) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *Invoice) GetMemo() string {
 	if m != nil {
@@ -822,7 +1488,7 @@ type BalanceResponse struct {
 func (m *BalanceResponse) Reset()                    { *m = BalanceResponse{} }
 func (m *BalanceResponse) String() string            { return proto.CompactTextString(m) }
 func (*BalanceResponse) ProtoMessage()               {}
-func (*BalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*BalanceResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *BalanceResponse) GetBalances() []*Balance {
 	if m != nil {
@@ -846,7 +1512,7 @@ type ValidateInvoiceRequest struct {
 func (m *ValidateInvoiceRequest) Reset()                    { *m = ValidateInvoiceRequest{} }
 func (m *ValidateInvoiceRequest) String() string            { return proto.CompactTextString(m) }
 func (*ValidateInvoiceRequest) ProtoMessage()               {}
-func (*ValidateInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*ValidateInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *ValidateInvoiceRequest) GetInvoice() string {
 	if m != nil {
@@ -877,7 +1543,7 @@ type EstimateFeeRequest struct {
 func (m *EstimateFeeRequest) Reset()                    { *m = EstimateFeeRequest{} }
 func (m *EstimateFeeRequest) String() string            { return proto.CompactTextString(m) }
 func (*EstimateFeeRequest) ProtoMessage()               {}
-func (*EstimateFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*EstimateFeeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *EstimateFeeRequest) GetAmount() string {
 	if m != nil {
@@ -903,7 +1569,7 @@ type EstimateFeeResponse struct {
 func (m *EstimateFeeResponse) Reset()                    { *m = EstimateFeeResponse{} }
 func (m *EstimateFeeResponse) String() string            { return proto.CompactTextString(m) }
 func (*EstimateFeeResponse) ProtoMessage()               {}
-func (*EstimateFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*EstimateFeeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *EstimateFeeResponse) GetMediaFee() string {
 	if m != nil {
@@ -922,12 +1588,18 @@ type SendPaymentRequest struct {
 	// contains amount, description, destination, and other info which is
 	// needed for sender to successfully send payment.
 	Invoice string `protobuf:"bytes,2,opt,name=invoice" json:"invoice,omitempty"`
+	//
+	// (optional) IdempotencyKey is the unique key of the request, generated
+	// by the client. Repeated request with the same key returns the result
+	// of the original payment instead of sending it again, and request with
+	// the same key but with different parameters is rejected.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey" json:"idempotency_key,omitempty"`
 }
 
 func (m *SendPaymentRequest) Reset()                    { *m = SendPaymentRequest{} }
 func (m *SendPaymentRequest) String() string            { return proto.CompactTextString(m) }
 func (*SendPaymentRequest) ProtoMessage()               {}
-func (*SendPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*SendPaymentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *SendPaymentRequest) GetAmount() string {
 	if m != nil {
@@ -943,6 +1615,13 @@ func (m *SendPaymentRequest) GetInvoice() string {
 	return ""
 }
 
+func (m *SendPaymentRequest) GetIdempotencyKey() string {
+	if m != nil {
+		return m.IdempotencyKey
+	}
+	return ""
+}
+
 type PaymentByIDRequest struct {
 	//
 	// PaymentID is the payment id which was created by service itself,
@@ -953,7 +1632,7 @@ type PaymentByIDRequest struct {
 func (m *PaymentByIDRequest) Reset()                    { *m = PaymentByIDRequest{} }
 func (m *PaymentByIDRequest) String() string            { return proto.CompactTextString(m) }
 func (*PaymentByIDRequest) ProtoMessage()               {}
-func (*PaymentByIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*PaymentByIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *PaymentByIDRequest) GetPaymentId() string {
 	if m != nil {
@@ -973,7 +1652,7 @@ type PaymentByInvoiceRequest struct {
 func (m *PaymentByInvoiceRequest) Reset()                    { *m = PaymentByInvoiceRequest{} }
 func (m *PaymentByInvoiceRequest) String() string            { return proto.CompactTextString(m) }
 func (*PaymentByInvoiceRequest) ProtoMessage()               {}
-func (*PaymentByInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*PaymentByInvoiceRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *PaymentByInvoiceRequest) GetInvoice() string {
 	if m != nil {
@@ -999,7 +1678,7 @@ type ListPaymentsRequest struct {
 func (m *ListPaymentsRequest) Reset()                    { *m = ListPaymentsRequest{} }
 func (m *ListPaymentsRequest) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsRequest) ProtoMessage()               {}
-func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *ListPaymentsRequest) GetStatus() PaymentStatus {
 	if m != nil {
@@ -1029,7 +1708,7 @@ type ListPaymentsResponse struct {
 func (m *ListPaymentsResponse) Reset()                    { *m = ListPaymentsResponse{} }
 func (m *ListPaymentsResponse) String() string            { return proto.CompactTextString(m) }
 func (*ListPaymentsResponse) ProtoMessage()               {}
-func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *ListPaymentsResponse) GetPayments() []*Payment {
 	if m != nil {
@@ -1048,7 +1727,7 @@ type NodeIdentificator struct {
 func (m *NodeIdentificator) Reset()                    { *m = NodeIdentificator{} }
 func (m *NodeIdentificator) String() string            { return proto.CompactTextString(m) }
 func (*NodeIdentificator) ProtoMessage()               {}
-func (*NodeIdentificator) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*NodeIdentificator) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 type isNodeIdentificator_Identificator interface{ isNodeIdentificator_Identificator() }
 
@@ -1187,7 +1866,7 @@ type Payment struct {
 func (m *Payment) Reset()                    { *m = Payment{} }
 func (m *Payment) String() string            { return proto.CompactTextString(m) }
 func (*Payment) ProtoMessage()               {}
-func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*Payment) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *Payment) GetPaymentId() string {
 	if m != nil {
@@ -1210,57 +1889,1281 @@ func (m *Payment) GetStatus() PaymentStatus {
 	return PaymentStatus_STATUS_NONE
 }
 
-func (m *Payment) GetDirection() PaymentDirection {
+func (m *Payment) GetDirection() PaymentDirection {
+	if m != nil {
+		return m.Direction
+	}
+	return PaymentDirection_DIRECTION_NONE
+}
+
+func (m *Payment) GetSystem() PaymentSystem {
+	if m != nil {
+		return m.System
+	}
+	return PaymentSystem_SYSTEM_NONE
+}
+
+func (m *Payment) GetInvoice() string {
+	if m != nil {
+		return m.Invoice
+	}
+	return ""
+}
+
+func (m *Payment) GetPaymentHash() string {
+	if m != nil {
+		return m.PaymentHash
+	}
+	return ""
+}
+
+func (m *Payment) GetAmount() string {
+	if m != nil {
+		return m.Amount
+	}
+	return ""
+}
+
+func (m *Payment) GetMediaFee() string {
+	if m != nil {
+		return m.MediaFee
+	}
+	return ""
+}
+
+type ChannelsChangeRequest struct {
+	// Limit limits output to the given number of the latest reports, at
+	// most 100 reports are returned, which is also used if limit is zero.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *ChannelsChangeRequest) Reset()                    { *m = ChannelsChangeRequest{} }
+func (m *ChannelsChangeRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChannelsChangeRequest) ProtoMessage()               {}
+func (*ChannelsChangeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *ChannelsChangeRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ChannelsChangeResponse struct {
+	Reports []*ChannelsChangeResponse_Report `protobuf:"bytes,1,rep,name=reports" json:"reports,omitempty"`
+}
+
+func (m *ChannelsChangeResponse) Reset()                    { *m = ChannelsChangeResponse{} }
+func (m *ChannelsChangeResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChannelsChangeResponse) ProtoMessage()               {}
+func (*ChannelsChangeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *ChannelsChangeResponse) GetReports() []*ChannelsChangeResponse_Report {
+	if m != nil {
+		return m.Reports
+	}
+	return nil
+}
+
+type ChannelsChangeResponse_Snapshot struct {
+	// Time is the time when snapshot has been taken.
+	Time int64 `protobuf:"varint,1,opt,name=time" json:"time,omitempty"`
+	// NumChannels is number of opened channels.
+	NumChannels int32 `protobuf:"varint,2,opt,name=num_channels,json=numChannels" json:"num_channels,omitempty"`
+	// Capacity is the overall balance locked in opened channels.
+	Capacity string `protobuf:"bytes,3,opt,name=capacity" json:"capacity,omitempty"`
+}
+
+func (m *ChannelsChangeResponse_Snapshot) Reset()         { *m = ChannelsChangeResponse_Snapshot{} }
+func (m *ChannelsChangeResponse_Snapshot) String() string { return proto.CompactTextString(m) }
+func (*ChannelsChangeResponse_Snapshot) ProtoMessage()    {}
+func (*ChannelsChangeResponse_Snapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26, 0}
+}
+
+func (m *ChannelsChangeResponse_Snapshot) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ChannelsChangeResponse_Snapshot) GetNumChannels() int32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+func (m *ChannelsChangeResponse_Snapshot) GetCapacity() string {
+	if m != nil {
+		return m.Capacity
+	}
+	return ""
+}
+
+type ChannelsChangeResponse_ClosedChannel struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	NodeId    string `protobuf:"bytes,2,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	// Alias is the name of the node, if it is known.
+	Alias string `protobuf:"bytes,3,opt,name=alias" json:"alias,omitempty"`
+	// Capacity is the balance which was locked in the channel before
+	// close.
+	Capacity string `protobuf:"bytes,4,opt,name=capacity" json:"capacity,omitempty"`
+	// CloseType is the way in which channel has been closed, i.e.
+	// cooperative, local_force, remote_force, breach.
+	CloseType string `protobuf:"bytes,5,opt,name=close_type,json=closeType" json:"close_type,omitempty"`
+	// Initiator is the side which closed the channel, empty if unknown.
+	Initiator string `protobuf:"bytes,6,opt,name=initiator" json:"initiator,omitempty"`
+}
+
+func (m *ChannelsChangeResponse_ClosedChannel) Reset()         { *m = ChannelsChangeResponse_ClosedChannel{} }
+func (m *ChannelsChangeResponse_ClosedChannel) String() string { return proto.CompactTextString(m) }
+func (*ChannelsChangeResponse_ClosedChannel) ProtoMessage()    {}
+func (*ChannelsChangeResponse_ClosedChannel) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26, 1}
+}
+
+func (m *ChannelsChangeResponse_ClosedChannel) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ChannelsChangeResponse_ClosedChannel) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *ChannelsChangeResponse_ClosedChannel) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *ChannelsChangeResponse_ClosedChannel) GetCapacity() string {
+	if m != nil {
+		return m.Capacity
+	}
+	return ""
+}
+
+func (m *ChannelsChangeResponse_ClosedChannel) GetCloseType() string {
+	if m != nil {
+		return m.CloseType
+	}
+	return ""
+}
+
+func (m *ChannelsChangeResponse_ClosedChannel) GetInitiator() string {
+	if m != nil {
+		return m.Initiator
+	}
+	return ""
+}
+
+type ChannelsChangeResponse_Report struct {
+	Previous *ChannelsChangeResponse_Snapshot `protobuf:"bytes,1,opt,name=previous" json:"previous,omitempty"`
+	Current  *ChannelsChangeResponse_Snapshot `protobuf:"bytes,2,opt,name=current" json:"current,omitempty"`
+	// CountDrop is the relative drop of channels count, negative if
+	// number of channels has increased.
+	CountDrop float64 `protobuf:"fixed64,3,opt,name=count_drop,json=countDrop" json:"count_drop,omitempty"`
+	// CapacityDrop is the relative drop of channels capacity, negative
+	// if capacity has increased.
+	CapacityDrop float64 `protobuf:"fixed64,4,opt,name=capacity_drop,json=capacityDrop" json:"capacity_drop,omitempty"`
+	// Alert is true if drop of count or capacity exceeds threshold.
+	Alert          bool                                    `protobuf:"varint,5,opt,name=alert" json:"alert,omitempty"`
+	ClosedChannels []*ChannelsChangeResponse_ClosedChannel `protobuf:"bytes,6,rep,name=closed_channels,json=closedChannels" json:"closed_channels,omitempty"`
+}
+
+func (m *ChannelsChangeResponse_Report) Reset()         { *m = ChannelsChangeResponse_Report{} }
+func (m *ChannelsChangeResponse_Report) String() string { return proto.CompactTextString(m) }
+func (*ChannelsChangeResponse_Report) ProtoMessage()    {}
+func (*ChannelsChangeResponse_Report) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26, 2}
+}
+
+func (m *ChannelsChangeResponse_Report) GetPrevious() *ChannelsChangeResponse_Snapshot {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
+func (m *ChannelsChangeResponse_Report) GetCurrent() *ChannelsChangeResponse_Snapshot {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+func (m *ChannelsChangeResponse_Report) GetCountDrop() float64 {
+	if m != nil {
+		return m.CountDrop
+	}
+	return 0
+}
+
+func (m *ChannelsChangeResponse_Report) GetCapacityDrop() float64 {
+	if m != nil {
+		return m.CapacityDrop
+	}
+	return 0
+}
+
+func (m *ChannelsChangeResponse_Report) GetAlert() bool {
+	if m != nil {
+		return m.Alert
+	}
+	return false
+}
+
+func (m *ChannelsChangeResponse_Report) GetClosedChannels() []*ChannelsChangeResponse_ClosedChannel {
+	if m != nil {
+		return m.ClosedChannels
+	}
+	return nil
+}
+
+type NetworkGraphRequest struct {
+	// Time is the moment of time for which graph should be returned,
+	// current time is used if not specified.
+	Time int64 `protobuf:"varint,1,opt,name=time" json:"time,omitempty"`
+	// Limit limits output to the given number of nodes with the biggest
+	// capacity.
+	Limit int32 `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *NetworkGraphRequest) Reset()                    { *m = NetworkGraphRequest{} }
+func (m *NetworkGraphRequest) String() string            { return proto.CompactTextString(m) }
+func (*NetworkGraphRequest) ProtoMessage()               {}
+func (*NetworkGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *NetworkGraphRequest) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *NetworkGraphRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type NetworkGraphResponse struct {
+	Time        int64 `protobuf:"varint,1,opt,name=time" json:"time,omitempty"`
+	NumNodes    int32 `protobuf:"varint,2,opt,name=num_nodes,json=numNodes" json:"num_nodes,omitempty"`
+	NumChannels int32 `protobuf:"varint,3,opt,name=num_channels,json=numChannels" json:"num_channels,omitempty"`
+	// Capacity is the overall capacity of the network.
+	Capacity string `protobuf:"bytes,4,opt,name=capacity" json:"capacity,omitempty"`
+	// Nodes is the list of nodes which have channels, sorted by capacity.
+	Nodes []*NetworkGraphResponse_Node `protobuf:"bytes,5,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *NetworkGraphResponse) Reset()                    { *m = NetworkGraphResponse{} }
+func (m *NetworkGraphResponse) String() string            { return proto.CompactTextString(m) }
+func (*NetworkGraphResponse) ProtoMessage()               {}
+func (*NetworkGraphResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *NetworkGraphResponse) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *NetworkGraphResponse) GetNumNodes() int32 {
+	if m != nil {
+		return m.NumNodes
+	}
+	return 0
+}
+
+func (m *NetworkGraphResponse) GetNumChannels() int32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+func (m *NetworkGraphResponse) GetCapacity() string {
+	if m != nil {
+		return m.Capacity
+	}
+	return ""
+}
+
+func (m *NetworkGraphResponse) GetNodes() []*NetworkGraphResponse_Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type NetworkGraphResponse_Node struct {
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	Alias  string `protobuf:"bytes,2,opt,name=alias" json:"alias,omitempty"`
+	// NumChannels is number of node channels.
+	NumChannels int32 `protobuf:"varint,3,opt,name=num_channels,json=numChannels" json:"num_channels,omitempty"`
+	// Capacity is the overall capacity of node channels.
+	Capacity string `protobuf:"bytes,4,opt,name=capacity" json:"capacity,omitempty"`
+}
+
+func (m *NetworkGraphResponse_Node) Reset()                    { *m = NetworkGraphResponse_Node{} }
+func (m *NetworkGraphResponse_Node) String() string            { return proto.CompactTextString(m) }
+func (*NetworkGraphResponse_Node) ProtoMessage()               {}
+func (*NetworkGraphResponse_Node) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28, 0} }
+
+func (m *NetworkGraphResponse_Node) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *NetworkGraphResponse_Node) GetAlias() string {
+	if m != nil {
+		return m.Alias
+	}
+	return ""
+}
+
+func (m *NetworkGraphResponse_Node) GetNumChannels() int32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+func (m *NetworkGraphResponse_Node) GetCapacity() string {
+	if m != nil {
+		return m.Capacity
+	}
+	return ""
+}
+
+type NodeCapacityHistoryRequest struct {
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	// Start is the beginning of the period.
+	Start int64 `protobuf:"varint,2,opt,name=start" json:"start,omitempty"`
+	// End is the end of the period, current time is used if not specified.
+	End int64 `protobuf:"varint,3,opt,name=end" json:"end,omitempty"`
+}
+
+func (m *NodeCapacityHistoryRequest) Reset()                    { *m = NodeCapacityHistoryRequest{} }
+func (m *NodeCapacityHistoryRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeCapacityHistoryRequest) ProtoMessage()               {}
+func (*NodeCapacityHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *NodeCapacityHistoryRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *NodeCapacityHistoryRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *NodeCapacityHistoryRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type NodeCapacityHistoryResponse struct {
+	Points []*NodeCapacityHistoryResponse_Point `protobuf:"bytes,1,rep,name=points" json:"points,omitempty"`
+}
+
+func (m *NodeCapacityHistoryResponse) Reset()                    { *m = NodeCapacityHistoryResponse{} }
+func (m *NodeCapacityHistoryResponse) String() string            { return proto.CompactTextString(m) }
+func (*NodeCapacityHistoryResponse) ProtoMessage()               {}
+func (*NodeCapacityHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *NodeCapacityHistoryResponse) GetPoints() []*NodeCapacityHistoryResponse_Point {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+type NodeCapacityHistoryResponse_Point struct {
+	// Time is the time since which node channels have the given
+	// capacity.
+	Time        int64  `protobuf:"varint,1,opt,name=time" json:"time,omitempty"`
+	NumChannels int32  `protobuf:"varint,2,opt,name=num_channels,json=numChannels" json:"num_channels,omitempty"`
+	Capacity    string `protobuf:"bytes,3,opt,name=capacity" json:"capacity,omitempty"`
+}
+
+func (m *NodeCapacityHistoryResponse_Point) Reset()         { *m = NodeCapacityHistoryResponse_Point{} }
+func (m *NodeCapacityHistoryResponse_Point) String() string { return proto.CompactTextString(m) }
+func (*NodeCapacityHistoryResponse_Point) ProtoMessage()    {}
+func (*NodeCapacityHistoryResponse_Point) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{30, 0}
+}
+
+func (m *NodeCapacityHistoryResponse_Point) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *NodeCapacityHistoryResponse_Point) GetNumChannels() int32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+func (m *NodeCapacityHistoryResponse_Point) GetCapacity() string {
+	if m != nil {
+		return m.Capacity
+	}
+	return ""
+}
+
+type NetworkAnomaliesRequest struct {
+	// Limit limits output to the given number of the latest anomalies.
+	Limit int32 `protobuf:"varint,1,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *NetworkAnomaliesRequest) Reset()                    { *m = NetworkAnomaliesRequest{} }
+func (m *NetworkAnomaliesRequest) String() string            { return proto.CompactTextString(m) }
+func (*NetworkAnomaliesRequest) ProtoMessage()               {}
+func (*NetworkAnomaliesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *NetworkAnomaliesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type NetworkAnomaliesResponse struct {
+	Anomalies []*NetworkAnomaliesResponse_Anomaly `protobuf:"bytes,1,rep,name=anomalies" json:"anomalies,omitempty"`
+}
+
+func (m *NetworkAnomaliesResponse) Reset()                    { *m = NetworkAnomaliesResponse{} }
+func (m *NetworkAnomaliesResponse) String() string            { return proto.CompactTextString(m) }
+func (*NetworkAnomaliesResponse) ProtoMessage()               {}
+func (*NetworkAnomaliesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *NetworkAnomaliesResponse) GetAnomalies() []*NetworkAnomaliesResponse_Anomaly {
+	if m != nil {
+		return m.Anomalies
+	}
+	return nil
+}
+
+type NetworkAnomaliesResponse_Anomaly struct {
+	// Type is the type of anomaly, i.e. network_capacity,
+	// node_capacity, node_uptime.
+	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// NodeId is the node to which anomaly is related, empty for network
+	// wide anomalies.
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	// Domain is the name given by us to the node, if it is known.
+	Domain string `protobuf:"bytes,3,opt,name=domain" json:"domain,omitempty"`
+	Time   int64  `protobuf:"varint,4,opt,name=time" json:"time,omitempty"`
+	// Previous is the value at the beginning of the window, i.e.
+	// capacity in satoshis or online percentage.
+	Previous float64 `protobuf:"fixed64,5,opt,name=previous" json:"previous,omitempty"`
+	// Current is the current value.
+	Current float64 `protobuf:"fixed64,6,opt,name=current" json:"current,omitempty"`
+	// Change is the relative change of capacity, or absolute change of
+	// online percentage. Capacity change of the node which appeared
+	// within the window is 1.
+	Change      float64 `protobuf:"fixed64,7,opt,name=change" json:"change,omitempty"`
+	Description string  `protobuf:"bytes,8,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) Reset()         { *m = NetworkAnomaliesResponse_Anomaly{} }
+func (m *NetworkAnomaliesResponse_Anomaly) String() string { return proto.CompactTextString(m) }
+func (*NetworkAnomaliesResponse_Anomaly) ProtoMessage()    {}
+func (*NetworkAnomaliesResponse_Anomaly) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{32, 0}
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) GetPrevious() float64 {
+	if m != nil {
+		return m.Previous
+	}
+	return 0
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) GetCurrent() float64 {
+	if m != nil {
+		return m.Current
+	}
+	return 0
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) GetChange() float64 {
+	if m != nil {
+		return m.Change
+	}
+	return 0
+}
+
+func (m *NetworkAnomaliesResponse_Anomaly) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+type NetworkStatsRequest struct {
+}
+
+func (m *NetworkStatsRequest) Reset()                    { *m = NetworkStatsRequest{} }
+func (m *NetworkStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*NetworkStatsRequest) ProtoMessage()               {}
+func (*NetworkStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+type EconomicStats struct {
+	// NumChannels is number of opened channels.
+	NumChannels int32 `protobuf:"varint,1,opt,name=num_channels,json=numChannels" json:"num_channels,omitempty"`
+	// NumClosedChannels is number of channels which are known to be closed.
+	NumClosedChannels int32  `protobuf:"varint,2,opt,name=num_closed_channels,json=numClosedChannels" json:"num_closed_channels,omitempty"`
+	AverageCapacity   string `protobuf:"bytes,3,opt,name=average_capacity,json=averageCapacity" json:"average_capacity,omitempty"`
+	// AverageChannelAge is the average time in seconds since the open of
+	// currently opened channels.
+	AverageChannelAge int64 `protobuf:"varint,4,opt,name=average_channel_age,json=averageChannelAge" json:"average_channel_age,omitempty"`
+	// AverageClosedLifetime is the average time in seconds between open
+	// and close of closed channels.
+	AverageClosedLifetime int64 `protobuf:"varint,5,opt,name=average_closed_lifetime,json=averageClosedLifetime" json:"average_closed_lifetime,omitempty"`
+	// AverageOpenFee is the average miners fee of channel funding
+	// transaction.
+	AverageOpenFee string `protobuf:"bytes,6,opt,name=average_open_fee,json=averageOpenFee" json:"average_open_fee,omitempty"`
+	// AverageCloseFee is the average miners fee of channel closing
+	// transaction.
+	AverageCloseFee    string `protobuf:"bytes,7,opt,name=average_close_fee,json=averageCloseFee" json:"average_close_fee,omitempty"`
+	AverageBaseFeeMsat int64  `protobuf:"varint,8,opt,name=average_base_fee_msat,json=averageBaseFeeMsat" json:"average_base_fee_msat,omitempty"`
+	AverageFeeRatePpm  int64  `protobuf:"varint,9,opt,name=average_fee_rate_ppm,json=averageFeeRatePpm" json:"average_fee_rate_ppm,omitempty"`
+}
+
+func (m *EconomicStats) Reset()                    { *m = EconomicStats{} }
+func (m *EconomicStats) String() string            { return proto.CompactTextString(m) }
+func (*EconomicStats) ProtoMessage()               {}
+func (*EconomicStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *EconomicStats) GetNumChannels() int32 {
+	if m != nil {
+		return m.NumChannels
+	}
+	return 0
+}
+
+func (m *EconomicStats) GetNumClosedChannels() int32 {
+	if m != nil {
+		return m.NumClosedChannels
+	}
+	return 0
+}
+
+func (m *EconomicStats) GetAverageCapacity() string {
+	if m != nil {
+		return m.AverageCapacity
+	}
+	return ""
+}
+
+func (m *EconomicStats) GetAverageChannelAge() int64 {
+	if m != nil {
+		return m.AverageChannelAge
+	}
+	return 0
+}
+
+func (m *EconomicStats) GetAverageClosedLifetime() int64 {
+	if m != nil {
+		return m.AverageClosedLifetime
+	}
+	return 0
+}
+
+func (m *EconomicStats) GetAverageOpenFee() string {
+	if m != nil {
+		return m.AverageOpenFee
+	}
+	return ""
+}
+
+func (m *EconomicStats) GetAverageCloseFee() string {
+	if m != nil {
+		return m.AverageCloseFee
+	}
+	return ""
+}
+
+func (m *EconomicStats) GetAverageBaseFeeMsat() int64 {
+	if m != nil {
+		return m.AverageBaseFeeMsat
+	}
+	return 0
+}
+
+func (m *EconomicStats) GetAverageFeeRatePpm() int64 {
+	if m != nil {
+		return m.AverageFeeRatePpm
+	}
+	return 0
+}
+
+type NetworkStatsResponse struct {
+	// Time is the time when statistics has been calculated.
+	Time     int64 `protobuf:"varint,1,opt,name=time" json:"time,omitempty"`
+	NumNodes int32 `protobuf:"varint,2,opt,name=num_nodes,json=numNodes" json:"num_nodes,omitempty"`
+	// Capacity is the overall capacity of the network.
+	Capacity string `protobuf:"bytes,3,opt,name=capacity" json:"capacity,omitempty"`
+	// Network is the statistics of all channels in the network.
+	Network *EconomicStats `protobuf:"bytes,4,opt,name=network" json:"network,omitempty"`
+	// Our is the statistics of our channels.
+	Our *EconomicStats `protobuf:"bytes,5,opt,name=our" json:"our,omitempty"`
+}
+
+func (m *NetworkStatsResponse) Reset()                    { *m = NetworkStatsResponse{} }
+func (m *NetworkStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*NetworkStatsResponse) ProtoMessage()               {}
+func (*NetworkStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *NetworkStatsResponse) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *NetworkStatsResponse) GetNumNodes() int32 {
+	if m != nil {
+		return m.NumNodes
+	}
+	return 0
+}
+
+func (m *NetworkStatsResponse) GetCapacity() string {
+	if m != nil {
+		return m.Capacity
+	}
+	return ""
+}
+
+func (m *NetworkStatsResponse) GetNetwork() *EconomicStats {
+	if m != nil {
+		return m.Network
+	}
+	return nil
+}
+
+func (m *NetworkStatsResponse) GetOur() *EconomicStats {
+	if m != nil {
+		return m.Our
+	}
+	return nil
+}
+
+type PaymentTimeSeriesRequest struct {
+	// (optional) Node is public key or node name, by which series should be
+	// filtered, by default series of all nodes are returned.
+	Node string `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+	// Start and End is the [start, end) range of time, current time is used
+	// as end if it is not specified. If start is not specified, range
+	// covers the thirty buckets before the end.
+	Start int64 `protobuf:"varint,2,opt,name=start" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,3,opt,name=end" json:"end,omitempty"`
+	// BucketSize is the size of series bucket, day is used by default.
+	BucketSize BucketSize `protobuf:"varint,4,opt,name=bucket_size,json=bucketSize,enum=hubrpc.BucketSize" json:"bucket_size,omitempty"`
+}
+
+func (m *PaymentTimeSeriesRequest) Reset()                    { *m = PaymentTimeSeriesRequest{} }
+func (m *PaymentTimeSeriesRequest) String() string            { return proto.CompactTextString(m) }
+func (*PaymentTimeSeriesRequest) ProtoMessage()               {}
+func (*PaymentTimeSeriesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *PaymentTimeSeriesRequest) GetNode() string {
+	if m != nil {
+		return m.Node
+	}
+	return ""
+}
+
+func (m *PaymentTimeSeriesRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesRequest) GetBucketSize() BucketSize {
+	if m != nil {
+		return m.BucketSize
+	}
+	return BucketSize_BUCKET_NONE
+}
+
+type PaymentTimeSeriesResponse struct {
+	Series []*PaymentTimeSeriesResponse_Series `protobuf:"bytes,1,rep,name=series" json:"series,omitempty"`
+}
+
+func (m *PaymentTimeSeriesResponse) Reset()                    { *m = PaymentTimeSeriesResponse{} }
+func (m *PaymentTimeSeriesResponse) String() string            { return proto.CompactTextString(m) }
+func (*PaymentTimeSeriesResponse) ProtoMessage()               {}
+func (*PaymentTimeSeriesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *PaymentTimeSeriesResponse) GetSeries() []*PaymentTimeSeriesResponse_Series {
+	if m != nil {
+		return m.Series
+	}
+	return nil
+}
+
+type PaymentTimeSeriesResponse_Bucket struct {
+	// Start is the beginning of the bucket, bucket ends where the next
+	// one starts.
+	Start int64 `protobuf:"varint,1,opt,name=start" json:"start,omitempty"`
+	// SentSat is number of funds which were sent from us to node,
+	// in satoshis.
+	SentSat int64 `protobuf:"varint,2,opt,name=sent_sat,json=sentSat" json:"sent_sat,omitempty"`
+	// SentForwardSat is number of funds which were forwarded to node,
+	// in satoshis.
+	SentForwardSat int64 `protobuf:"varint,3,opt,name=sent_forward_sat,json=sentForwardSat" json:"sent_forward_sat,omitempty"`
+	// ReceivedForwardSat is number of funds which were received from
+	// node for forwarding, in satoshis.
+	ReceivedForwardSat int64 `protobuf:"varint,4,opt,name=received_forward_sat,json=receivedForwardSat" json:"received_forward_sat,omitempty"`
+	NumSent            int32 `protobuf:"varint,5,opt,name=num_sent,json=numSent" json:"num_sent,omitempty"`
+	NumSentForward     int32 `protobuf:"varint,6,opt,name=num_sent_forward,json=numSentForward" json:"num_sent_forward,omitempty"`
+	NumReceivedForward int32 `protobuf:"varint,7,opt,name=num_received_forward,json=numReceivedForward" json:"num_received_forward,omitempty"`
+	// ReceivedSat is number of funds which were received by us from
+	// node, in satoshis.
+	ReceivedSat int64 `protobuf:"varint,8,opt,name=received_sat,json=receivedSat" json:"received_sat,omitempty"`
+	NumReceived int32 `protobuf:"varint,9,opt,name=num_received,json=numReceived" json:"num_received,omitempty"`
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) Reset()         { *m = PaymentTimeSeriesResponse_Bucket{} }
+func (m *PaymentTimeSeriesResponse_Bucket) String() string { return proto.CompactTextString(m) }
+func (*PaymentTimeSeriesResponse_Bucket) ProtoMessage()    {}
+func (*PaymentTimeSeriesResponse_Bucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{37, 0}
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetSentSat() int64 {
+	if m != nil {
+		return m.SentSat
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetSentForwardSat() int64 {
+	if m != nil {
+		return m.SentForwardSat
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetReceivedForwardSat() int64 {
+	if m != nil {
+		return m.ReceivedForwardSat
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetNumSent() int32 {
+	if m != nil {
+		return m.NumSent
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetNumSentForward() int32 {
+	if m != nil {
+		return m.NumSentForward
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetNumReceivedForward() int32 {
+	if m != nil {
+		return m.NumReceivedForward
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetReceivedSat() int64 {
+	if m != nil {
+		return m.ReceivedSat
+	}
+	return 0
+}
+
+func (m *PaymentTimeSeriesResponse_Bucket) GetNumReceived() int32 {
+	if m != nil {
+		return m.NumReceived
+	}
+	return 0
+}
+
+type PaymentTimeSeriesResponse_Series struct {
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	// Domain is the name given by us to the node, if it is known.
+	Domain  string                              `protobuf:"bytes,2,opt,name=domain" json:"domain,omitempty"`
+	Buckets []*PaymentTimeSeriesResponse_Bucket `protobuf:"bytes,3,rep,name=buckets" json:"buckets,omitempty"`
+}
+
+func (m *PaymentTimeSeriesResponse_Series) Reset()         { *m = PaymentTimeSeriesResponse_Series{} }
+func (m *PaymentTimeSeriesResponse_Series) String() string { return proto.CompactTextString(m) }
+func (*PaymentTimeSeriesResponse_Series) ProtoMessage()    {}
+func (*PaymentTimeSeriesResponse_Series) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{37, 1}
+}
+
+func (m *PaymentTimeSeriesResponse_Series) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *PaymentTimeSeriesResponse_Series) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *PaymentTimeSeriesResponse_Series) GetBuckets() []*PaymentTimeSeriesResponse_Bucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+type ProfitabilityReportRequest struct {
+	// Start and End is the [start, end) range of time, current time is used
+	// as end if it is not specified, and whole history is used if start is
+	// not specified.
+	Start int64 `protobuf:"varint,1,opt,name=start" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end" json:"end,omitempty"`
+}
+
+func (m *ProfitabilityReportRequest) Reset()                    { *m = ProfitabilityReportRequest{} }
+func (m *ProfitabilityReportRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProfitabilityReportRequest) ProtoMessage()               {}
+func (*ProfitabilityReportRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ProfitabilityReportRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *ProfitabilityReportRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type Profitability struct {
+	// ChannelID is the channel which economy is described, empty if
+	// economy describes the peer or the whole node.
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	// NodeID is the peer which economy is described, empty if economy
+	// describes the whole node.
+	NodeId string `protobuf:"bytes,2,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	// Domain is the name given by us to the node, if it is known.
+	Domain string `protobuf:"bytes,3,opt,name=domain" json:"domain,omitempty"`
+	// Capacity is the number of funds locked in channels, in satoshis.
+	CapacitySat int64 `protobuf:"varint,4,opt,name=capacity_sat,json=capacitySat" json:"capacity_sat,omitempty"`
+	// EarnedForwardFee is the fee earned by forwarding payments, in
+	// satoshis.
+	EarnedForwardFeeSat int64 `protobuf:"varint,5,opt,name=earned_forward_fee_sat,json=earnedForwardFeeSat" json:"earned_forward_fee_sat,omitempty"`
+	// PaymentFee is the routing fee paid for sending payments, including
+	// re-balancing payments, in satoshis.
+	PaymentFeeSat int64 `protobuf:"varint,6,opt,name=payment_fee_sat,json=paymentFeeSat" json:"payment_fee_sat,omitempty"`
+	// OpenFee, CloseFee and SwipeFee is the on-chain fees of channel
+	// management, in satoshis.
+	OpenFeeSat   int64 `protobuf:"varint,7,opt,name=open_fee_sat,json=openFeeSat" json:"open_fee_sat,omitempty"`
+	CloseFeeSat  int64 `protobuf:"varint,8,opt,name=close_fee_sat,json=closeFeeSat" json:"close_fee_sat,omitempty"`
+	SwipeFeeSat  int64 `protobuf:"varint,9,opt,name=swipe_fee_sat,json=swipeFeeSat" json:"swipe_fee_sat,omitempty"`
+	SentSat      int64 `protobuf:"varint,10,opt,name=sent_sat,json=sentSat" json:"sent_sat,omitempty"`
+	ReceivedSat  int64 `protobuf:"varint,11,opt,name=received_sat,json=receivedSat" json:"received_sat,omitempty"`
+	ForwardedSat int64 `protobuf:"varint,12,opt,name=forwarded_sat,json=forwardedSat" json:"forwarded_sat,omitempty"`
+	// Profit is the difference between earned and spent fees, in satoshis.
+	ProfitSat int64 `protobuf:"varint,13,opt,name=profit_sat,json=profitSat" json:"profit_sat,omitempty"`
+	// ROI is the profit relative to the capacity.
+	Roi float64 `protobuf:"fixed64,14,opt,name=roi" json:"roi,omitempty"`
+	// QualityRatio is the fee spent to move 1 sat.
+	QualityRatio float64 `protobuf:"fixed64,15,opt,name=quality_ratio,json=qualityRatio" json:"quality_ratio,omitempty"`
+}
+
+func (m *Profitability) Reset()                    { *m = Profitability{} }
+func (m *Profitability) String() string            { return proto.CompactTextString(m) }
+func (*Profitability) ProtoMessage()               {}
+func (*Profitability) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *Profitability) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *Profitability) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *Profitability) GetDomain() string {
+	if m != nil {
+		return m.Domain
+	}
+	return ""
+}
+
+func (m *Profitability) GetCapacitySat() int64 {
+	if m != nil {
+		return m.CapacitySat
+	}
+	return 0
+}
+
+func (m *Profitability) GetEarnedForwardFeeSat() int64 {
+	if m != nil {
+		return m.EarnedForwardFeeSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetPaymentFeeSat() int64 {
+	if m != nil {
+		return m.PaymentFeeSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetOpenFeeSat() int64 {
+	if m != nil {
+		return m.OpenFeeSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetCloseFeeSat() int64 {
+	if m != nil {
+		return m.CloseFeeSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetSwipeFeeSat() int64 {
+	if m != nil {
+		return m.SwipeFeeSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetSentSat() int64 {
+	if m != nil {
+		return m.SentSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetReceivedSat() int64 {
+	if m != nil {
+		return m.ReceivedSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetForwardedSat() int64 {
+	if m != nil {
+		return m.ForwardedSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetProfitSat() int64 {
+	if m != nil {
+		return m.ProfitSat
+	}
+	return 0
+}
+
+func (m *Profitability) GetRoi() float64 {
+	if m != nil {
+		return m.Roi
+	}
+	return 0
+}
+
+func (m *Profitability) GetQualityRatio() float64 {
+	if m != nil {
+		return m.QualityRatio
+	}
+	return 0
+}
+
+type ProfitabilityReportResponse struct {
+	Start int64 `protobuf:"varint,1,opt,name=start" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end" json:"end,omitempty"`
+	// Channels and Peers is sorted by profit, the least profitable first.
+	Channels []*Profitability `protobuf:"bytes,3,rep,name=channels" json:"channels,omitempty"`
+	Peers    []*Profitability `protobuf:"bytes,4,rep,name=peers" json:"peers,omitempty"`
+	// Total is the economy of the whole node.
+	Total *Profitability `protobuf:"bytes,5,opt,name=total" json:"total,omitempty"`
+}
+
+func (m *ProfitabilityReportResponse) Reset()                    { *m = ProfitabilityReportResponse{} }
+func (m *ProfitabilityReportResponse) String() string            { return proto.CompactTextString(m) }
+func (*ProfitabilityReportResponse) ProtoMessage()               {}
+func (*ProfitabilityReportResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *ProfitabilityReportResponse) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *ProfitabilityReportResponse) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *ProfitabilityReportResponse) GetChannels() []*Profitability {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *ProfitabilityReportResponse) GetPeers() []*Profitability {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+func (m *ProfitabilityReportResponse) GetTotal() *Profitability {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+type ExportLedgerRequest struct {
+	// Start and End is the [start, end) range of time, current time is used
+	// as end if it is not specified, and whole history is used if start is
+	// not specified.
+	Start int64 `protobuf:"varint,1,opt,name=start" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end" json:"end,omitempty"`
+	// Fiat denotes should the USD value of entries be calculated, with the
+	// bitcoin price of the event day.
+	Fiat bool `protobuf:"varint,3,opt,name=fiat" json:"fiat,omitempty"`
+	// Offset is the number of entries which should be skipped, is used
+	// alongside with limit for pagination.
+	Offset int32 `protobuf:"varint,4,opt,name=offset" json:"offset,omitempty"`
+	// Limit limits output to the given number of entries, the maximum of
+	// 2000 entries is used if zero or if it exceeds the maximum.
+	Limit int32 `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
+}
+
+func (m *ExportLedgerRequest) Reset()                    { *m = ExportLedgerRequest{} }
+func (m *ExportLedgerRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExportLedgerRequest) ProtoMessage()               {}
+func (*ExportLedgerRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *ExportLedgerRequest) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *ExportLedgerRequest) GetEnd() int64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *ExportLedgerRequest) GetFiat() bool {
+	if m != nil {
+		return m.Fiat
+	}
+	return false
+}
+
+func (m *ExportLedgerRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ExportLedgerRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ExportLedgerResponse struct {
+	// Csv is the ledger in csv format, every posting of the entry is
+	// written as the separate row. Header is written only in the first
+	// page, so that pages could be concatenated in the single file.
+	Csv        string `protobuf:"bytes,1,opt,name=csv" json:"csv,omitempty"`
+	NumEntries int32  `protobuf:"varint,2,opt,name=num_entries,json=numEntries" json:"num_entries,omitempty"`
+	// Total is number of entries in the ledger, before the offset and
+	// limit are applied.
+	Total int32 `protobuf:"varint,3,opt,name=total" json:"total,omitempty"`
+}
+
+func (m *ExportLedgerResponse) Reset()                    { *m = ExportLedgerResponse{} }
+func (m *ExportLedgerResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExportLedgerResponse) ProtoMessage()               {}
+func (*ExportLedgerResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *ExportLedgerResponse) GetCsv() string {
 	if m != nil {
-		return m.Direction
+		return m.Csv
 	}
-	return PaymentDirection_DIRECTION_NONE
+	return ""
 }
 
-func (m *Payment) GetSystem() PaymentSystem {
+func (m *ExportLedgerResponse) GetNumEntries() int32 {
 	if m != nil {
-		return m.System
+		return m.NumEntries
 	}
-	return PaymentSystem_SYSTEM_NONE
+	return 0
 }
 
-func (m *Payment) GetInvoice() string {
+func (m *ExportLedgerResponse) GetTotal() int32 {
 	if m != nil {
-		return m.Invoice
+		return m.Total
 	}
-	return ""
+	return 0
 }
 
-func (m *Payment) GetPaymentHash() string {
+// GatewayError is the body of the failed response of the REST gateway.
+type GatewayError struct {
+	// Error is the description of the error.
+	Error string `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	// Code is the gRPC status code of the error.
+	Code int32 `protobuf:"varint,2,opt,name=code" json:"code,omitempty"`
+	// Details is the details of the gRPC status, hub attaches
+	// google.rpc.ErrorInfo with the "hub" domain, which is rendered as
+	// {"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason":
+	// "REASON_RATE_LIMITED", "domain": "hub", "metadata": {"retryable":
+	// "true"}}. Details are empty if error happened in the gateway itself.
+	Details []*google_protobuf.Any `protobuf:"bytes,3,rep,name=details" json:"details,omitempty"`
+}
+
+func (m *GatewayError) Reset()                    { *m = GatewayError{} }
+func (m *GatewayError) String() string            { return proto.CompactTextString(m) }
+func (*GatewayError) ProtoMessage()               {}
+func (*GatewayError) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *GatewayError) GetError() string {
 	if m != nil {
-		return m.PaymentHash
+		return m.Error
 	}
 	return ""
 }
 
-func (m *Payment) GetAmount() string {
+func (m *GatewayError) GetCode() int32 {
 	if m != nil {
-		return m.Amount
+		return m.Code
 	}
-	return ""
+	return 0
 }
 
-func (m *Payment) GetMediaFee() string {
+func (m *GatewayError) GetDetails() []*google_protobuf.Any {
 	if m != nil {
-		return m.MediaFee
+		return m.Details
 	}
-	return ""
+	return nil
 }
 
 func init() {
 	proto.RegisterType((*EmptyRequest)(nil), "hubrpc.EmptyRequest")
 	proto.RegisterType((*EmptyResponse)(nil), "hubrpc.EmptyResponse")
+	proto.RegisterType((*BudgetRequest)(nil), "hubrpc.BudgetRequest")
+	proto.RegisterType((*BudgetResponse)(nil), "hubrpc.BudgetResponse")
+	proto.RegisterType((*BudgetResponse_OperationBudget)(nil), "hubrpc.BudgetResponse.OperationBudget")
+	proto.RegisterType((*FeePolicyUpdatesRequest)(nil), "hubrpc.FeePolicyUpdatesRequest")
+	proto.RegisterType((*FeePolicyUpdatesResponse)(nil), "hubrpc.FeePolicyUpdatesResponse")
+	proto.RegisterType((*FeePolicyUpdatesResponse_FeePolicy)(nil), "hubrpc.FeePolicyUpdatesResponse.FeePolicy")
+	proto.RegisterType((*FeePolicyUpdatesResponse_PolicyUpdate)(nil), "hubrpc.FeePolicyUpdatesResponse.PolicyUpdate")
 	proto.RegisterType((*CheckNodeStatsRequest)(nil), "hubrpc.CheckNodeStatsRequest")
 	proto.RegisterType((*CheckNodeStatsResponse)(nil), "hubrpc.CheckNodeStatsResponse")
 	proto.RegisterType((*CheckNodeStatsResponse_NodeStatus)(nil), "hubrpc.CheckNodeStatsResponse.NodeStatus")
 	proto.RegisterType((*CheckNodeStatsResponse_NodeStatus_ChannelStats)(nil), "hubrpc.CheckNodeStatsResponse.NodeStatus.ChannelStats")
 	proto.RegisterType((*CheckNodeStatsResponse_NodeStatus_PaymentsStats)(nil), "hubrpc.CheckNodeStatsResponse.NodeStatus.PaymentsStats")
 	proto.RegisterType((*CheckNodeStatsResponse_NodeStatus_RankStats)(nil), "hubrpc.CheckNodeStatsResponse.NodeStatus.RankStats")
+	proto.RegisterType((*CheckNodeStatsResponse_NodeStatus_Anomaly)(nil), "hubrpc.CheckNodeStatsResponse.NodeStatus.Anomaly")
+	proto.RegisterType((*CheckNodeStatsResponse_NodeStatus_HealthStats)(nil), "hubrpc.CheckNodeStatsResponse.NodeStatus.HealthStats")
 	proto.RegisterType((*CreateInvoiceRequest)(nil), "hubrpc.CreateInvoiceRequest")
 	proto.RegisterType((*CreateInvoiceResponse)(nil), "hubrpc.CreateInvoiceResponse")
 	proto.RegisterType((*BalanceRequest)(nil), "hubrpc.BalanceRequest")
@@ -1278,12 +3181,41 @@ func init() {
 	proto.RegisterType((*ListPaymentsResponse)(nil), "hubrpc.ListPaymentsResponse")
 	proto.RegisterType((*NodeIdentificator)(nil), "hubrpc.NodeIdentificator")
 	proto.RegisterType((*Payment)(nil), "hubrpc.Payment")
+	proto.RegisterType((*ChannelsChangeRequest)(nil), "hubrpc.ChannelsChangeRequest")
+	proto.RegisterType((*ChannelsChangeResponse)(nil), "hubrpc.ChannelsChangeResponse")
+	proto.RegisterType((*ChannelsChangeResponse_Snapshot)(nil), "hubrpc.ChannelsChangeResponse.Snapshot")
+	proto.RegisterType((*ChannelsChangeResponse_ClosedChannel)(nil), "hubrpc.ChannelsChangeResponse.ClosedChannel")
+	proto.RegisterType((*ChannelsChangeResponse_Report)(nil), "hubrpc.ChannelsChangeResponse.Report")
+	proto.RegisterType((*NetworkGraphRequest)(nil), "hubrpc.NetworkGraphRequest")
+	proto.RegisterType((*NetworkGraphResponse)(nil), "hubrpc.NetworkGraphResponse")
+	proto.RegisterType((*NetworkGraphResponse_Node)(nil), "hubrpc.NetworkGraphResponse.Node")
+	proto.RegisterType((*NodeCapacityHistoryRequest)(nil), "hubrpc.NodeCapacityHistoryRequest")
+	proto.RegisterType((*NodeCapacityHistoryResponse)(nil), "hubrpc.NodeCapacityHistoryResponse")
+	proto.RegisterType((*NodeCapacityHistoryResponse_Point)(nil), "hubrpc.NodeCapacityHistoryResponse.Point")
+	proto.RegisterType((*NetworkAnomaliesRequest)(nil), "hubrpc.NetworkAnomaliesRequest")
+	proto.RegisterType((*NetworkAnomaliesResponse)(nil), "hubrpc.NetworkAnomaliesResponse")
+	proto.RegisterType((*NetworkAnomaliesResponse_Anomaly)(nil), "hubrpc.NetworkAnomaliesResponse.Anomaly")
+	proto.RegisterType((*NetworkStatsRequest)(nil), "hubrpc.NetworkStatsRequest")
+	proto.RegisterType((*EconomicStats)(nil), "hubrpc.EconomicStats")
+	proto.RegisterType((*NetworkStatsResponse)(nil), "hubrpc.NetworkStatsResponse")
+	proto.RegisterType((*PaymentTimeSeriesRequest)(nil), "hubrpc.PaymentTimeSeriesRequest")
+	proto.RegisterType((*PaymentTimeSeriesResponse)(nil), "hubrpc.PaymentTimeSeriesResponse")
+	proto.RegisterType((*PaymentTimeSeriesResponse_Bucket)(nil), "hubrpc.PaymentTimeSeriesResponse.Bucket")
+	proto.RegisterType((*PaymentTimeSeriesResponse_Series)(nil), "hubrpc.PaymentTimeSeriesResponse.Series")
+	proto.RegisterType((*ProfitabilityReportRequest)(nil), "hubrpc.ProfitabilityReportRequest")
+	proto.RegisterType((*Profitability)(nil), "hubrpc.Profitability")
+	proto.RegisterType((*ProfitabilityReportResponse)(nil), "hubrpc.ProfitabilityReportResponse")
+	proto.RegisterType((*ExportLedgerRequest)(nil), "hubrpc.ExportLedgerRequest")
+	proto.RegisterType((*ExportLedgerResponse)(nil), "hubrpc.ExportLedgerResponse")
+	proto.RegisterType((*GatewayError)(nil), "hubrpc.GatewayError")
 	proto.RegisterEnum("hubrpc.Media", Media_name, Media_value)
 	proto.RegisterEnum("hubrpc.PaymentStatus", PaymentStatus_name, PaymentStatus_value)
 	proto.RegisterEnum("hubrpc.PaymentDirection", PaymentDirection_name, PaymentDirection_value)
 	proto.RegisterEnum("hubrpc.PaymentSystem", PaymentSystem_name, PaymentSystem_value)
 	proto.RegisterEnum("hubrpc.SortType", SortType_name, SortType_value)
 	proto.RegisterEnum("hubrpc.Period", Period_name, Period_value)
+	proto.RegisterEnum("hubrpc.BucketSize", BucketSize_name, BucketSize_value)
+	proto.RegisterEnum("hubrpc.ErrorReason", ErrorReason_name, ErrorReason_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1330,6 +3262,51 @@ type HubClient interface {
 	// CheckNodeStats return statistical data about node, and sort nodes by
 	// internal ranking algorithm.
 	CheckNodeStats(ctx context.Context, in *CheckNodeStatsRequest, opts ...grpc.CallOption) (*CheckNodeStatsResponse, error)
+	//
+	// Budget returns the state of the daily budget of channel operations,
+	// fee of which is paid by us.
+	Budget(ctx context.Context, in *BudgetRequest, opts ...grpc.CallOption) (*BudgetResponse, error)
+	//
+	// FeePolicyUpdates returns the history of routing fee policy updates
+	// of our channels.
+	FeePolicyUpdates(ctx context.Context, in *FeePolicyUpdatesRequest, opts ...grpc.CallOption) (*FeePolicyUpdatesResponse, error)
+	//
+	// ChannelsChange returns the history of channels count and capacity
+	// changes, with the channels which were closed between checks.
+	ChannelsChange(ctx context.Context, in *ChannelsChangeRequest, opts ...grpc.CallOption) (*ChannelsChangeResponse, error)
+	//
+	// NetworkGraph returns the state of the lightning network graph at the
+	// given moment of time.
+	NetworkGraph(ctx context.Context, in *NetworkGraphRequest, opts ...grpc.CallOption) (*NetworkGraphResponse, error)
+	//
+	// NodeCapacityHistory returns the history of changes of the node
+	// channels capacity within the given period.
+	NodeCapacityHistory(ctx context.Context, in *NodeCapacityHistoryRequest, opts ...grpc.CallOption) (*NodeCapacityHistoryResponse, error)
+	//
+	// NetworkAnomalies returns the history of detected anomalies of the
+	// lightning network, such as sudden capacity changes or drops of
+	// online percentage of known nodes.
+	NetworkAnomalies(ctx context.Context, in *NetworkAnomaliesRequest, opts ...grpc.CallOption) (*NetworkAnomaliesResponse, error)
+	//
+	// NetworkStats returns the economics statistics of the lightning
+	// network channels, such as average channel lifetime, open / close
+	// fees and routing fees, alongside with the same statistics of our
+	// channels.
+	NetworkStats(ctx context.Context, in *NetworkStatsRequest, opts ...grpc.CallOption) (*NetworkStatsResponse, error)
+	//
+	// PaymentTimeSeries returns payments activity with nodes, aggregated in
+	// buckets of the given size within the given range of time.
+	PaymentTimeSeries(ctx context.Context, in *PaymentTimeSeriesRequest, opts ...grpc.CallOption) (*PaymentTimeSeriesResponse, error)
+	//
+	// ProfitabilityReport returns the economy of our channels and peers
+	// within the given range of time: forward fees earned, routing fees
+	// paid, on-chain channel management fees, ROI and quality ratio.
+	ProfitabilityReport(ctx context.Context, in *ProfitabilityReportRequest, opts ...grpc.CallOption) (*ProfitabilityReportResponse, error)
+	//
+	// ExportLedger returns chronological double-entry ledger of incoming,
+	// outgoing and forwarded payments, and on-chain channel management
+	// fees in csv format.
+	ExportLedger(ctx context.Context, in *ExportLedgerRequest, opts ...grpc.CallOption) (*ExportLedgerResponse, error)
 }
 
 type hubClient struct {
@@ -1421,6 +3398,96 @@ func (c *hubClient) CheckNodeStats(ctx context.Context, in *CheckNodeStatsReques
 	return out, nil
 }
 
+func (c *hubClient) Budget(ctx context.Context, in *BudgetRequest, opts ...grpc.CallOption) (*BudgetResponse, error) {
+	out := new(BudgetResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/Budget", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) FeePolicyUpdates(ctx context.Context, in *FeePolicyUpdatesRequest, opts ...grpc.CallOption) (*FeePolicyUpdatesResponse, error) {
+	out := new(FeePolicyUpdatesResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/FeePolicyUpdates", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ChannelsChange(ctx context.Context, in *ChannelsChangeRequest, opts ...grpc.CallOption) (*ChannelsChangeResponse, error) {
+	out := new(ChannelsChangeResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/ChannelsChange", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) NetworkGraph(ctx context.Context, in *NetworkGraphRequest, opts ...grpc.CallOption) (*NetworkGraphResponse, error) {
+	out := new(NetworkGraphResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/NetworkGraph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) NodeCapacityHistory(ctx context.Context, in *NodeCapacityHistoryRequest, opts ...grpc.CallOption) (*NodeCapacityHistoryResponse, error) {
+	out := new(NodeCapacityHistoryResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/NodeCapacityHistory", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) NetworkAnomalies(ctx context.Context, in *NetworkAnomaliesRequest, opts ...grpc.CallOption) (*NetworkAnomaliesResponse, error) {
+	out := new(NetworkAnomaliesResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/NetworkAnomalies", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) NetworkStats(ctx context.Context, in *NetworkStatsRequest, opts ...grpc.CallOption) (*NetworkStatsResponse, error) {
+	out := new(NetworkStatsResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/NetworkStats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) PaymentTimeSeries(ctx context.Context, in *PaymentTimeSeriesRequest, opts ...grpc.CallOption) (*PaymentTimeSeriesResponse, error) {
+	out := new(PaymentTimeSeriesResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/PaymentTimeSeries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ProfitabilityReport(ctx context.Context, in *ProfitabilityReportRequest, opts ...grpc.CallOption) (*ProfitabilityReportResponse, error) {
+	out := new(ProfitabilityReportResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/ProfitabilityReport", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ExportLedger(ctx context.Context, in *ExportLedgerRequest, opts ...grpc.CallOption) (*ExportLedgerResponse, error) {
+	out := new(ExportLedgerResponse)
+	err := grpc.Invoke(ctx, "/hubrpc.Hub/ExportLedger", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Hub service
 
 type HubServer interface {
@@ -1457,6 +3524,51 @@ type HubServer interface {
 	// CheckNodeStats return statistical data about node, and sort nodes by
 	// internal ranking algorithm.
 	CheckNodeStats(context.Context, *CheckNodeStatsRequest) (*CheckNodeStatsResponse, error)
+	//
+	// Budget returns the state of the daily budget of channel operations,
+	// fee of which is paid by us.
+	Budget(context.Context, *BudgetRequest) (*BudgetResponse, error)
+	//
+	// FeePolicyUpdates returns the history of routing fee policy updates
+	// of our channels.
+	FeePolicyUpdates(context.Context, *FeePolicyUpdatesRequest) (*FeePolicyUpdatesResponse, error)
+	//
+	// ChannelsChange returns the history of channels count and capacity
+	// changes, with the channels which were closed between checks.
+	ChannelsChange(context.Context, *ChannelsChangeRequest) (*ChannelsChangeResponse, error)
+	//
+	// NetworkGraph returns the state of the lightning network graph at the
+	// given moment of time.
+	NetworkGraph(context.Context, *NetworkGraphRequest) (*NetworkGraphResponse, error)
+	//
+	// NodeCapacityHistory returns the history of changes of the node
+	// channels capacity within the given period.
+	NodeCapacityHistory(context.Context, *NodeCapacityHistoryRequest) (*NodeCapacityHistoryResponse, error)
+	//
+	// NetworkAnomalies returns the history of detected anomalies of the
+	// lightning network, such as sudden capacity changes or drops of
+	// online percentage of known nodes.
+	NetworkAnomalies(context.Context, *NetworkAnomaliesRequest) (*NetworkAnomaliesResponse, error)
+	//
+	// NetworkStats returns the economics statistics of the lightning
+	// network channels, such as average channel lifetime, open / close
+	// fees and routing fees, alongside with the same statistics of our
+	// channels.
+	NetworkStats(context.Context, *NetworkStatsRequest) (*NetworkStatsResponse, error)
+	//
+	// PaymentTimeSeries returns payments activity with nodes, aggregated in
+	// buckets of the given size within the given range of time.
+	PaymentTimeSeries(context.Context, *PaymentTimeSeriesRequest) (*PaymentTimeSeriesResponse, error)
+	//
+	// ProfitabilityReport returns the economy of our channels and peers
+	// within the given range of time: forward fees earned, routing fees
+	// paid, on-chain channel management fees, ROI and quality ratio.
+	ProfitabilityReport(context.Context, *ProfitabilityReportRequest) (*ProfitabilityReportResponse, error)
+	//
+	// ExportLedger returns chronological double-entry ledger of incoming,
+	// outgoing and forwarded payments, and on-chain channel management
+	// fees in csv format.
+	ExportLedger(context.Context, *ExportLedgerRequest) (*ExportLedgerResponse, error)
 }
 
 func RegisterHubServer(s *grpc.Server, srv HubServer) {
@@ -1625,6 +3737,186 @@ func _Hub_CheckNodeStats_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Hub_Budget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BudgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).Budget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/Budget",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).Budget(ctx, req.(*BudgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_FeePolicyUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeePolicyUpdatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).FeePolicyUpdates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/FeePolicyUpdates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).FeePolicyUpdates(ctx, req.(*FeePolicyUpdatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_ChannelsChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelsChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ChannelsChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/ChannelsChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ChannelsChange(ctx, req.(*ChannelsChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_NetworkGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).NetworkGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/NetworkGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).NetworkGraph(ctx, req.(*NetworkGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_NodeCapacityHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeCapacityHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).NodeCapacityHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/NodeCapacityHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).NodeCapacityHistory(ctx, req.(*NodeCapacityHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_NetworkAnomalies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkAnomaliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).NetworkAnomalies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/NetworkAnomalies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).NetworkAnomalies(ctx, req.(*NetworkAnomaliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_NetworkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NetworkStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).NetworkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/NetworkStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).NetworkStats(ctx, req.(*NetworkStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_PaymentTimeSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentTimeSeriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).PaymentTimeSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/PaymentTimeSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).PaymentTimeSeries(ctx, req.(*PaymentTimeSeriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_ProfitabilityReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProfitabilityReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ProfitabilityReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/ProfitabilityReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ProfitabilityReport(ctx, req.(*ProfitabilityReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_ExportLedger_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportLedgerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ExportLedger(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hubrpc.Hub/ExportLedger",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ExportLedger(ctx, req.(*ExportLedgerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Hub_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hubrpc.Hub",
 	HandlerType: (*HubServer)(nil),
@@ -1665,6 +3957,46 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CheckNodeStats",
 			Handler:    _Hub_CheckNodeStats_Handler,
 		},
+		{
+			MethodName: "Budget",
+			Handler:    _Hub_Budget_Handler,
+		},
+		{
+			MethodName: "FeePolicyUpdates",
+			Handler:    _Hub_FeePolicyUpdates_Handler,
+		},
+		{
+			MethodName: "ChannelsChange",
+			Handler:    _Hub_ChannelsChange_Handler,
+		},
+		{
+			MethodName: "NetworkGraph",
+			Handler:    _Hub_NetworkGraph_Handler,
+		},
+		{
+			MethodName: "NodeCapacityHistory",
+			Handler:    _Hub_NodeCapacityHistory_Handler,
+		},
+		{
+			MethodName: "NetworkAnomalies",
+			Handler:    _Hub_NetworkAnomalies_Handler,
+		},
+		{
+			MethodName: "NetworkStats",
+			Handler:    _Hub_NetworkStats_Handler,
+		},
+		{
+			MethodName: "PaymentTimeSeries",
+			Handler:    _Hub_PaymentTimeSeries_Handler,
+		},
+		{
+			MethodName: "ProfitabilityReport",
+			Handler:    _Hub_ProfitabilityReport_Handler,
+		},
+		{
+			MethodName: "ExportLedger",
+			Handler:    _Hub_ExportLedger_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "hubrpc.proto",
@@ -1673,108 +4005,260 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("hubrpc.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1644 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x58, 0x6b, 0x6e, 0xe3, 0xc8,
-	0x11, 0x5e, 0x5a, 0xb2, 0x24, 0x96, 0x64, 0x59, 0xe9, 0xb1, 0x65, 0xad, 0x66, 0x66, 0xd7, 0xcb,
-	0x00, 0x81, 0xd7, 0xc1, 0x0e, 0x02, 0x3b, 0x98, 0x3c, 0x10, 0x04, 0x90, 0x25, 0x7a, 0xcc, 0x1d,
-	0x59, 0x32, 0x28, 0x79, 0x36, 0x83, 0x04, 0x20, 0x5a, 0x62, 0xcf, 0x98, 0x30, 0x1f, 0x0a, 0x1f,
-	0x4e, 0x74, 0x80, 0xdc, 0x21, 0x07, 0xc8, 0xbf, 0x1c, 0x20, 0x67, 0x08, 0x10, 0x20, 0xb7, 0x48,
-	0xae, 0x11, 0xb0, 0xbb, 0x9a, 0x22, 0x25, 0x39, 0xeb, 0x9d, 0x7f, 0xea, 0xfa, 0xea, 0xfb, 0xaa,
-	0xaa, 0x5f, 0xd5, 0x14, 0x34, 0xee, 0x92, 0x59, 0xb8, 0x98, 0xbf, 0x5a, 0x84, 0x41, 0x1c, 0x90,
-	0x8a, 0x18, 0x69, 0x4d, 0x68, 0xe8, 0xde, 0x22, 0x5e, 0x9a, 0xec, 0x8f, 0x09, 0x8b, 0x62, 0x6d,
-	0x1f, 0xf6, 0x70, 0x1c, 0x2d, 0x02, 0x3f, 0x62, 0xda, 0x5f, 0x15, 0x38, 0xec, 0xdf, 0xb1, 0xf9,
-	0xfd, 0x28, 0xb0, 0xd9, 0x24, 0xa6, 0x71, 0x84, 0xae, 0xe4, 0x27, 0x50, 0x59, 0xb0, 0xd0, 0x09,
-	0xec, 0x8e, 0x72, 0xac, 0x9c, 0x34, 0xcf, 0x9a, 0xaf, 0x30, 0xc2, 0x0d, 0xb7, 0x9a, 0x88, 0x12,
-	0x02, 0x65, 0x3f, 0xb0, 0x59, 0x67, 0xe7, 0x58, 0x39, 0x51, 0x4d, 0xfe, 0x9b, 0x1c, 0xc0, 0xae,
-	0xeb, 0x78, 0x4e, 0xdc, 0x29, 0x1d, 0x2b, 0x27, 0xbb, 0xa6, 0x18, 0x90, 0x6f, 0x40, 0x8d, 0x82,
-	0x30, 0xb6, 0xe2, 0xe5, 0x82, 0x75, 0xca, 0x5c, 0xb4, 0x25, 0x45, 0x27, 0x41, 0x18, 0x4f, 0x97,
-	0x0b, 0x66, 0xd6, 0x22, 0xfc, 0xa5, 0xfd, 0x13, 0xa0, 0xbd, 0x9e, 0x9a, 0xc8, 0x9a, 0xe8, 0x50,
-	0x8b, 0x62, 0x1a, 0x27, 0x11, 0x8b, 0x3a, 0xca, 0x71, 0xe9, 0xa4, 0x7e, 0xf6, 0xb5, 0x14, 0xda,
-	0xce, 0x78, 0x25, 0x2d, 0x49, 0x64, 0x66, 0xd4, 0xee, 0x7f, 0x55, 0x80, 0x15, 0x40, 0xda, 0x50,
-	0xb1, 0x03, 0x8f, 0x3a, 0x3e, 0xaf, 0x58, 0x35, 0x71, 0x44, 0x8e, 0xa0, 0xba, 0x48, 0x66, 0xd6,
-	0x3d, 0x5b, 0x62, 0x91, 0x95, 0x45, 0x32, 0x7b, 0xcb, 0x96, 0xe4, 0x05, 0xa8, 0xf4, 0x81, 0x3a,
-	0x2e, 0x9d, 0xb9, 0x8c, 0x97, 0x5a, 0x33, 0x57, 0x06, 0x8e, 0xfa, 0x81, 0x47, 0x5d, 0x87, 0x45,
-	0x9d, 0xf2, 0x71, 0xe9, 0x44, 0x35, 0x57, 0x06, 0x62, 0x02, 0x84, 0xd4, 0xbf, 0xb7, 0xd2, 0x64,
-	0xa2, 0xce, 0xee, 0xb1, 0x72, 0x52, 0x3f, 0x3b, 0x7f, 0x72, 0x11, 0xaf, 0x4c, 0xea, 0xdf, 0x0b,
-	0x50, 0x0d, 0xe5, 0x4f, 0xf2, 0x07, 0xd8, 0x5b, 0xd0, 0xa5, 0xc7, 0xfc, 0x18, 0x65, 0x2b, 0x5c,
-	0xf6, 0x17, 0x4f, 0x97, 0xbd, 0x11, 0xf4, 0x48, 0x38, 0x34, 0x50, 0x4d, 0xa8, 0xff, 0x1e, 0xf6,
-	0xe6, 0x77, 0xd4, 0xf7, 0x99, 0x8b, 0xea, 0x55, 0xae, 0xfe, 0xfa, 0xe9, 0xea, 0x7d, 0x41, 0x47,
-	0xf1, 0x79, 0x6e, 0xd4, 0xfd, 0x8f, 0x02, 0x8d, 0x3c, 0x4c, 0xce, 0xe0, 0xd0, 0x0d, 0xe6, 0xf7,
-	0xcc, 0xb6, 0xdc, 0x60, 0x4e, 0x5d, 0x77, 0x69, 0xd1, 0x79, 0xec, 0x3c, 0x30, 0xbe, 0x36, 0x8a,
-	0xf9, 0x4c, 0x80, 0x43, 0x81, 0xf5, 0x38, 0x44, 0x7e, 0x0e, 0x6d, 0xe4, 0x84, 0xcc, 0x0b, 0x62,
-	0xb6, 0x22, 0xed, 0x70, 0xd2, 0x81, 0x40, 0x4d, 0x04, 0x37, 0x58, 0x32, 0x52, 0xf0, 0xc0, 0x42,
-	0xea, 0xba, 0x7c, 0x49, 0x33, 0x16, 0x86, 0x1a, 0x0b, 0x8c, 0xbc, 0x86, 0xa3, 0xf5, 0x58, 0x92,
-	0x56, 0xe6, 0xb4, 0xc3, 0x62, 0x30, 0xe4, 0x75, 0xff, 0x56, 0x82, 0xbd, 0xc2, 0x2c, 0x93, 0x9f,
-	0xc1, 0x01, 0x4d, 0xc1, 0x8f, 0xcc, 0x8a, 0xd2, 0xa5, 0xfb, 0x10, 0x84, 0x7f, 0xa2, 0xa1, 0x8d,
-	0x85, 0x12, 0xc4, 0x26, 0xcc, 0x8f, 0x2f, 0x05, 0x42, 0x7e, 0x09, 0x1d, 0xc9, 0x08, 0xd9, 0x9c,
-	0x39, 0x0f, 0xcc, 0xce, 0x58, 0xa2, 0xd2, 0x36, 0xe2, 0x26, 0xc2, 0x92, 0xf9, 0x15, 0x34, 0xf2,
-	0xb1, 0xb0, 0xc2, 0x7a, 0x2e, 0x46, 0x9a, 0x0e, 0x16, 0x52, 0x4c, 0x47, 0x54, 0x45, 0x10, 0x5b,
-	0x4b, 0x47, 0x32, 0x36, 0xd2, 0xd9, 0x15, 0xe9, 0x20, 0xbe, 0x25, 0x9d, 0x7c, 0x2c, 0xbe, 0x5f,
-	0x15, 0xb3, 0x9e, 0x8b, 0x41, 0x3e, 0x87, 0x9a, 0x9f, 0x78, 0x02, 0xae, 0xf2, 0xdb, 0xa4, 0xea,
-	0x27, 0x9e, 0xcc, 0x34, 0x85, 0x36, 0x62, 0xd6, 0xb8, 0x1b, 0xf1, 0x13, 0x6f, 0x3d, 0xde, 0x09,
-	0xb4, 0xa4, 0x58, 0xe6, 0xad, 0x72, 0xef, 0x26, 0x8a, 0xa2, 0x67, 0xf7, 0x5f, 0x0a, 0xa8, 0xd9,
-	0x19, 0x23, 0xe7, 0xd0, 0xe6, 0x87, 0x15, 0xcf, 0x43, 0x24, 0x14, 0xfc, 0xc4, 0xe3, 0x8b, 0x54,
-	0x32, 0x9f, 0xa5, 0x68, 0xb6, 0xaa, 0xcc, 0x8f, 0x47, 0x89, 0x47, 0x7e, 0x05, 0x9f, 0x6f, 0x21,
-	0x3d, 0x04, 0x6e, 0xe2, 0x89, 0x0d, 0x59, 0x32, 0xdb, 0xeb, 0xbc, 0x77, 0x1c, 0x25, 0xcf, 0x81,
-	0x9f, 0x6a, 0xcb, 0xb1, 0xf1, 0x62, 0x29, 0x99, 0xb5, 0xd4, 0x60, 0xd8, 0x2e, 0x4b, 0x4f, 0x06,
-	0x07, 0xb1, 0x00, 0xb1, 0xc5, 0x9d, 0x78, 0xc9, 0x57, 0x08, 0x73, 0xc1, 0x32, 0x7a, 0x08, 0x69,
-	0x37, 0x70, 0xd0, 0x0f, 0x19, 0x8d, 0x99, 0xe1, 0x3f, 0x04, 0xce, 0x9c, 0xc9, 0x4b, 0xbe, 0x0d,
-	0x15, 0xea, 0x05, 0x89, 0x1f, 0xcb, 0x2b, 0x4f, 0x8c, 0xc8, 0x31, 0xd4, 0x6d, 0x16, 0xcd, 0x43,
-	0x67, 0x11, 0x3b, 0x81, 0x8f, 0xd7, 0x5e, 0xde, 0xa4, 0xf9, 0x70, 0xb8, 0xa6, 0x88, 0x77, 0xf3,
-	0x8f, 0x61, 0x6f, 0x9e, 0x02, 0x4e, 0xe0, 0x5b, 0x36, 0x8d, 0x19, 0x4e, 0x51, 0x43, 0x1a, 0x07,
-	0x34, 0x66, 0xa4, 0x03, 0x55, 0x47, 0xf0, 0x50, 0x5b, 0x0e, 0xd3, 0x8c, 0xd8, 0x9f, 0x17, 0x4e,
-	0xb8, 0xc4, 0xba, 0x71, 0xa4, 0xb5, 0xa0, 0x79, 0x41, 0x5d, 0xea, 0x67, 0xb9, 0x6b, 0x3d, 0xa8,
-	0xa2, 0xa5, 0x78, 0x11, 0x8b, 0x4a, 0x72, 0x17, 0x71, 0x07, 0xaa, 0x0b, 0xe6, 0xdb, 0x8e, 0xff,
-	0x51, 0x06, 0xc3, 0xa1, 0x36, 0x80, 0xa3, 0x77, 0xd4, 0x75, 0xec, 0x2d, 0x65, 0x7c, 0xbd, 0xca,
-	0x50, 0xe1, 0xf7, 0xdc, 0xbe, 0xbc, 0xe7, 0xa4, 0xa7, 0xc4, 0xb5, 0x7f, 0x28, 0x50, 0x45, 0x63,
-	0xda, 0x0d, 0x3d, 0xe6, 0x05, 0x98, 0x04, 0xff, 0x9d, 0x76, 0xc3, 0x07, 0xea, 0x26, 0xb2, 0x54,
-	0x31, 0xd8, 0x9c, 0xa7, 0xd2, 0x96, 0x79, 0x5a, 0xcd, 0x46, 0x39, 0x3f, 0x1b, 0x29, 0xf9, 0x03,
-	0x75, 0xdd, 0x19, 0x9d, 0xdf, 0x5b, 0xd4, 0xb6, 0x43, 0x7e, 0xce, 0x54, 0xb3, 0x21, 0x8d, 0x3d,
-	0xdb, 0x0e, 0x71, 0x11, 0x63, 0xc7, 0xe7, 0x7a, 0xfc, 0x70, 0x89, 0x45, 0x94, 0x26, 0xed, 0xb7,
-	0xb0, 0x9f, 0x4d, 0x2a, 0xd6, 0xfd, 0x53, 0xa8, 0xcd, 0x84, 0x49, 0xb6, 0xd6, 0xac, 0x70, 0xe9,
-	0x9a, 0x39, 0x68, 0xdf, 0x42, 0x7b, 0x63, 0xfe, 0xc4, 0xc6, 0xea, 0x14, 0xa7, 0xaf, 0xb8, 0xc0,
-	0xb8, 0xe5, 0x76, 0xf2, 0x5b, 0x4e, 0xbb, 0x04, 0xa2, 0x47, 0xb1, 0xe3, 0xd1, 0x98, 0x5d, 0xb2,
-	0xef, 0xdd, 0xa0, 0x8f, 0x6e, 0x20, 0xed, 0x0c, 0x9e, 0x15, 0x74, 0xb0, 0xae, 0xe7, 0xa0, 0x7a,
-	0xcc, 0x76, 0xa8, 0xf5, 0x81, 0xc9, 0x94, 0x6a, 0xdc, 0x70, 0xc9, 0x58, 0x1a, 0x7b, 0xc2, 0x7c,
-	0x1b, 0x4f, 0xe2, 0xa7, 0xc7, 0x3e, 0x07, 0x82, 0x1a, 0x17, 0x4b, 0x63, 0x20, 0x75, 0x5e, 0x02,
-	0xc8, 0xb6, 0xec, 0xd8, 0x72, 0x7b, 0xa2, 0xc5, 0xb0, 0xb5, 0x73, 0x38, 0x5a, 0x91, 0x9e, 0x38,
-	0x8b, 0xda, 0xdf, 0x15, 0x78, 0x36, 0x74, 0xa2, 0x58, 0x5e, 0x1e, 0x92, 0xf1, 0x0d, 0x54, 0xc4,
-	0xf3, 0x06, 0x5f, 0x6d, 0x87, 0xd9, 0xab, 0x6d, 0xd5, 0xca, 0x93, 0xc8, 0x44, 0x27, 0xf2, 0x1a,
-	0x54, 0xdb, 0x09, 0xd9, 0x3c, 0x3b, 0xe5, 0xcd, 0xb3, 0xce, 0x1a, 0x63, 0x20, 0x71, 0x73, 0xe5,
-	0xca, 0xc3, 0x2c, 0xa3, 0x98, 0x79, 0x7c, 0xd7, 0x6e, 0x09, 0xc3, 0x41, 0x13, 0x9d, 0xb4, 0x3e,
-	0x1c, 0x14, 0x93, 0x5d, 0x6d, 0x36, 0x79, 0x3b, 0xae, 0x6f, 0x36, 0xb9, 0x16, 0x99, 0x83, 0xf6,
-	0x11, 0x7e, 0x94, 0xbe, 0x25, 0x0c, 0x9b, 0xf9, 0xb1, 0xf3, 0xc1, 0x99, 0xd3, 0x38, 0x08, 0x89,
-	0x06, 0x8d, 0xf4, 0xc5, 0x69, 0xc9, 0x07, 0x1a, 0x9f, 0xa6, 0xab, 0xcf, 0x4c, 0x48, 0xad, 0x37,
-	0xe2, 0x99, 0xf6, 0x12, 0x54, 0xee, 0xe3, 0x53, 0xbc, 0x78, 0x53, 0x87, 0x5a, 0x6a, 0x1a, 0x51,
-	0x8f, 0x5d, 0xec, 0xc3, 0x9e, 0x93, 0xd7, 0xd4, 0xfe, 0xbd, 0x03, 0x55, 0x0c, 0xff, 0x3d, 0x6b,
-	0x97, 0xc2, 0xc9, 0x22, 0xdd, 0xfe, 0xb6, 0x45, 0x63, 0xbc, 0xd4, 0x55, 0xb4, 0xf4, 0xf2, 0xab,
-	0x51, 0xfa, 0xc1, 0xab, 0x51, 0xfe, 0x94, 0xd5, 0xd8, 0x7d, 0xc2, 0x6a, 0xe4, 0x77, 0x55, 0xa5,
-	0x78, 0x36, 0xbf, 0x02, 0xf9, 0xe4, 0xb3, 0xee, 0x68, 0x74, 0xc7, 0x1b, 0xae, 0x6a, 0xd6, 0xd1,
-	0x76, 0x45, 0xa3, 0xbb, 0xdc, 0xa1, 0xa8, 0x15, 0x0e, 0x45, 0xe1, 0x7c, 0xa9, 0xc5, 0xf3, 0x75,
-	0xfa, 0x1a, 0x76, 0xaf, 0xd3, 0xdf, 0xa4, 0x09, 0x70, 0xad, 0x0f, 0x8c, 0x9e, 0x35, 0x1a, 0x8f,
-	0xf4, 0xd6, 0x67, 0xe9, 0xf8, 0x62, 0x38, 0xee, 0xbf, 0xed, 0x5f, 0xf5, 0x8c, 0x51, 0x4b, 0x21,
-	0x7b, 0xa0, 0x0e, 0x8d, 0x37, 0x57, 0xd3, 0x91, 0x31, 0x7a, 0xd3, 0xda, 0x39, 0xbd, 0xcd, 0xde,
-	0x4a, 0xf8, 0x44, 0xdf, 0x87, 0xfa, 0x64, 0xda, 0x9b, 0xde, 0x4e, 0xa4, 0x40, 0x1d, 0xaa, 0xdf,
-	0xf5, 0x8c, 0x69, 0xea, 0xae, 0xa4, 0x83, 0x1b, 0x7d, 0x34, 0xe0, 0xdc, 0x54, 0xaa, 0x3f, 0xbe,
-	0xbe, 0x19, 0xea, 0x53, 0x7d, 0xd0, 0x2a, 0x11, 0x80, 0xca, 0x65, 0xcf, 0x18, 0xea, 0x83, 0x56,
-	0xf9, 0xf4, 0x02, 0x5a, 0xeb, 0xd3, 0x49, 0x08, 0x34, 0x07, 0x86, 0xa9, 0xf7, 0xa7, 0xc6, 0x78,
-	0x24, 0xc5, 0x1b, 0x50, 0x33, 0x46, 0xfd, 0xf1, 0xb5, 0x50, 0x6f, 0x40, 0x6d, 0x7c, 0x3b, 0x7d,
-	0x33, 0x16, 0xa9, 0xfd, 0x66, 0x95, 0x9a, 0x98, 0xd5, 0x34, 0xb5, 0xf7, 0x93, 0xa9, 0x7e, 0x5d,
-	0x60, 0x4f, 0x75, 0x73, 0xd4, 0x1b, 0x0a, 0xb6, 0xfe, 0x3b, 0x1c, 0xed, 0x9c, 0x7e, 0x0b, 0x35,
-	0xf9, 0xc5, 0x93, 0x26, 0x3a, 0x19, 0x9b, 0x53, 0x49, 0xdb, 0x87, 0xfa, 0xc5, 0x7b, 0x6b, 0xa2,
-	0x8f, 0xa6, 0xd6, 0xe8, 0xf6, 0xba, 0xa5, 0xa0, 0xc1, 0x18, 0x0c, 0xf5, 0x91, 0x3e, 0x99, 0x88,
-	0xca, 0x2e, 0xde, 0x5b, 0xef, 0xc6, 0xc3, 0xdb, 0x6b, 0xbd, 0x55, 0x3a, 0xbd, 0x82, 0x8a, 0xf8,
-	0x24, 0x4b, 0x3d, 0x6f, 0x74, 0xd3, 0x18, 0x0f, 0xa4, 0x56, 0x15, 0x4a, 0x83, 0xde, 0xfb, 0x96,
-	0x42, 0x6a, 0x50, 0xfe, 0x4e, 0xd7, 0xdf, 0xb6, 0x76, 0x88, 0x0a, 0xbb, 0xd7, 0xe3, 0xd1, 0xf4,
-	0xaa, 0x55, 0x4a, 0xdd, 0xa7, 0x57, 0xa6, 0xae, 0x5b, 0xc2, 0x50, 0x3e, 0xfb, 0xcb, 0x2e, 0x94,
-	0xae, 0x92, 0x19, 0x19, 0xc2, 0x5e, 0xa1, 0xb7, 0x93, 0x17, 0xd9, 0x1b, 0x7f, 0xcb, 0x23, 0xa2,
-	0xfb, 0xf2, 0x11, 0x14, 0x0f, 0xb9, 0x09, 0xfb, 0x6b, 0x4d, 0x82, 0x7c, 0x21, 0x19, 0xdb, 0xbb,
-	0x47, 0xf7, 0xcb, 0x47, 0x71, 0xd4, 0xfc, 0xf5, 0xaa, 0xf7, 0xb7, 0xd7, 0xdb, 0x13, 0x6a, 0x1c,
-	0x6d, 0xd8, 0x91, 0x7b, 0x09, 0xf5, 0x5c, 0x83, 0x20, 0x5d, 0xe9, 0xb7, 0xd9, 0x7d, 0xba, 0xcf,
-	0xb7, 0x62, 0x59, 0x0e, 0xf5, 0x5c, 0xd3, 0x58, 0xe9, 0x6c, 0x76, 0x92, 0xee, 0xfa, 0xad, 0x96,
-	0x72, 0x73, 0x8d, 0x62, 0xc5, 0xdd, 0xec, 0x1e, 0x9b, 0xdc, 0x41, 0xb6, 0x7b, 0xb3, 0x7e, 0x41,
-	0xbe, 0xdc, 0x14, 0x28, 0xce, 0xe8, 0x86, 0x8a, 0x01, 0x8d, 0xfc, 0x95, 0x4c, 0xb2, 0x52, 0xb7,
-	0x74, 0x95, 0xee, 0x8b, 0xed, 0x20, 0x4e, 0xc4, 0x18, 0x9a, 0xc5, 0x6f, 0x3f, 0xf2, 0xf2, 0xb1,
-	0x6f, 0x42, 0x21, 0xf7, 0xc5, 0xff, 0xff, 0x64, 0x9c, 0x55, 0xf8, 0x9f, 0x18, 0xe7, 0xff, 0x0b,
-	0x00, 0x00, 0xff, 0xff, 0xdd, 0x76, 0x53, 0x61, 0xd4, 0x10, 0x00, 0x00,
+	// 4071 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x3a, 0x4d, 0x73, 0x23, 0x49,
+	0x56, 0x5b, 0x92, 0xf5, 0xf5, 0x24, 0x4b, 0xea, 0xb4, 0xdb, 0x56, 0x57, 0x7f, 0x8c, 0x5d, 0x13,
+	0x3b, 0xd3, 0xe3, 0x61, 0xec, 0x1d, 0x37, 0xcc, 0x00, 0x41, 0xb0, 0x2b, 0x5b, 0xe5, 0xb6, 0xb6,
+	0x6d, 0xc9, 0x94, 0xe4, 0x9e, 0x69, 0x86, 0xa1, 0x22, 0xad, 0x4a, 0xdb, 0x15, 0x2d, 0x55, 0xd5,
+	0x56, 0x95, 0xdc, 0xab, 0xdd, 0x98, 0x20, 0x62, 0x0f, 0x5c, 0x38, 0x70, 0xe0, 0xcc, 0x2f, 0xe0,
+	0x40, 0xec, 0x01, 0x96, 0x03, 0x1c, 0x38, 0x13, 0x04, 0x11, 0x33, 0x3f, 0x01, 0x82, 0x3b, 0x57,
+	0x2e, 0x10, 0xf9, 0x55, 0x5f, 0x92, 0xdc, 0xee, 0x21, 0x38, 0x49, 0xf9, 0xbe, 0xf3, 0xe5, 0xcb,
+	0x97, 0x99, 0xef, 0x15, 0xd4, 0xae, 0xa7, 0x17, 0xbe, 0x37, 0xda, 0xf5, 0x7c, 0x37, 0x74, 0x51,
+	0x91, 0x8f, 0xd4, 0x47, 0x57, 0xae, 0x7b, 0x35, 0x26, 0x7b, 0xd8, 0xb3, 0xf7, 0xb0, 0xe3, 0xb8,
+	0x21, 0x0e, 0x6d, 0xd7, 0x09, 0x38, 0x95, 0xfa, 0x40, 0x60, 0xd9, 0xe8, 0x62, 0x7a, 0xb9, 0x87,
+	0x9d, 0x19, 0x47, 0x69, 0x75, 0xa8, 0xe9, 0x13, 0x2f, 0x9c, 0x19, 0xe4, 0x67, 0x53, 0x12, 0x84,
+	0x5a, 0x03, 0x56, 0xc5, 0x38, 0xf0, 0x5c, 0x27, 0x20, 0x14, 0x70, 0x30, 0xb5, 0xae, 0x48, 0x28,
+	0x29, 0xfe, 0x3c, 0x07, 0x75, 0x09, 0xe1, 0x34, 0xe8, 0x27, 0x50, 0xba, 0x60, 0x90, 0xa0, 0xa5,
+	0x6c, 0xe5, 0x9f, 0x56, 0xf7, 0x3f, 0xd8, 0x15, 0x56, 0xa6, 0x09, 0x77, 0xfb, 0x1e, 0xf1, 0x99,
+	0x65, 0x02, 0x2e, 0xd9, 0xd4, 0x5f, 0x2b, 0xd0, 0xc8, 0x20, 0xd1, 0x23, 0xa8, 0xb8, 0x12, 0xd4,
+	0x52, 0xb6, 0x94, 0xa7, 0x15, 0x23, 0x06, 0xa0, 0x75, 0x28, 0x8c, 0xed, 0x89, 0x1d, 0xb6, 0x72,
+	0x5b, 0xca, 0x53, 0xc5, 0xe0, 0x03, 0x0a, 0x0d, 0x3c, 0xe2, 0x84, 0xad, 0x3c, 0x87, 0xb2, 0x01,
+	0x6a, 0x41, 0xc9, 0x23, 0x8e, 0x65, 0x3b, 0x57, 0xad, 0x15, 0x06, 0x97, 0x43, 0xaa, 0xc3, 0x27,
+	0x13, 0x6c, 0x3b, 0x14, 0x57, 0x60, 0xb8, 0x18, 0x40, 0xb1, 0x9e, 0x1b, 0x84, 0x9e, 0xeb, 0x10,
+	0xab, 0x55, 0xdc, 0x52, 0x9e, 0x16, 0x8c, 0x18, 0xa0, 0xed, 0xc1, 0xe6, 0x11, 0x21, 0x67, 0xee,
+	0xd8, 0x1e, 0xcd, 0xce, 0x3d, 0x0b, 0x87, 0x24, 0x10, 0x3e, 0x8a, 0x8d, 0x53, 0x18, 0x13, 0x1f,
+	0x68, 0xff, 0x93, 0x87, 0xd6, 0x3c, 0x87, 0xf0, 0xe1, 0x73, 0x28, 0x4d, 0x39, 0x48, 0xf8, 0xf0,
+	0x13, 0xe9, 0xc3, 0x65, 0x2c, 0xbb, 0x49, 0xa8, 0x21, 0xb9, 0xd5, 0x9f, 0x42, 0x25, 0xe2, 0x40,
+	0x1a, 0xac, 0x5e, 0xe0, 0x80, 0x98, 0x97, 0x84, 0x98, 0x93, 0x00, 0x73, 0x83, 0xf2, 0x46, 0x95,
+	0x02, 0x8f, 0x08, 0x39, 0x0d, 0x70, 0x88, 0x1e, 0x40, 0x99, 0xa2, 0x7d, 0x1c, 0x12, 0xe6, 0xcc,
+	0xbc, 0x51, 0xba, 0x24, 0xc4, 0xc0, 0x21, 0x51, 0xff, 0x35, 0x07, 0xb5, 0xa4, 0x16, 0xf4, 0x18,
+	0x60, 0x74, 0x8d, 0x1d, 0x87, 0x8c, 0x4d, 0xdb, 0x92, 0x8b, 0x22, 0x20, 0x5d, 0x0b, 0x6d, 0x42,
+	0xc9, 0x71, 0x2d, 0x42, 0x71, 0x39, 0x86, 0x2b, 0xd2, 0x61, 0xd7, 0x42, 0x5d, 0x00, 0x77, 0x6c,
+	0x99, 0x1e, 0x93, 0xc5, 0x16, 0xa7, 0xba, 0xbf, 0xf3, 0xd6, 0x09, 0x46, 0x08, 0xa3, 0xe2, 0x8e,
+	0x2d, 0x31, 0xa5, 0x2e, 0x80, 0x43, 0xde, 0x48, 0x51, 0x2b, 0xef, 0x2e, 0xca, 0x21, 0x6f, 0x84,
+	0xa8, 0xf7, 0xa0, 0x3a, 0x76, 0x47, 0x78, 0x6c, 0xb2, 0x98, 0x12, 0xeb, 0x0f, 0x0c, 0x64, 0x50,
+	0x08, 0xda, 0x86, 0xda, 0x8d, 0x3b, 0x9e, 0x4e, 0x88, 0xa0, 0x28, 0x32, 0x8a, 0x2a, 0x87, 0x71,
+	0x12, 0x04, 0x2b, 0xa1, 0x3d, 0x21, 0xad, 0x12, 0xf3, 0x1c, 0xfb, 0x4f, 0xdd, 0x60, 0xf9, 0x33,
+	0xd3, 0x9f, 0x3a, 0xad, 0xf2, 0x96, 0xf2, 0xb4, 0x6c, 0x14, 0x2d, 0x7f, 0x66, 0x4c, 0x1d, 0xed,
+	0xbf, 0x73, 0x70, 0xff, 0xf0, 0x9a, 0x8c, 0x5e, 0xf7, 0x5c, 0x8b, 0x0c, 0x42, 0x1c, 0x46, 0x11,
+	0xf3, 0x01, 0x14, 0x3d, 0xe2, 0xdb, 0x2e, 0x77, 0x6a, 0x7d, 0xbf, 0x2e, 0x67, 0x74, 0xc6, 0xa0,
+	0x86, 0xc0, 0x52, 0x75, 0xd4, 0xa5, 0xc2, 0xbd, 0xec, 0x7f, 0x1c, 0x6d, 0xf9, 0x44, 0xb4, 0xa1,
+	0x4f, 0xa0, 0x12, 0xb8, 0x7e, 0x68, 0x86, 0x33, 0x8f, 0x30, 0x37, 0xd5, 0xf7, 0x9b, 0x52, 0xe8,
+	0xc0, 0xf5, 0xc3, 0xe1, 0xcc, 0x23, 0x46, 0x39, 0x10, 0xff, 0xd0, 0x06, 0x14, 0xdd, 0xcb, 0xcb,
+	0x80, 0x84, 0xcc, 0x0d, 0x05, 0x43, 0x8c, 0xd0, 0x0f, 0xa1, 0x6e, 0x4f, 0x3c, 0xd7, 0x0f, 0xb1,
+	0x13, 0x9a, 0xae, 0x33, 0x9e, 0x31, 0x27, 0x94, 0x8d, 0xd5, 0x08, 0xda, 0x77, 0xc6, 0x33, 0x4a,
+	0x86, 0x6f, 0xb0, 0x3d, 0xc6, 0x17, 0x63, 0xc2, 0xc9, 0x4a, 0x9c, 0x2c, 0x82, 0x32, 0xb2, 0xc7,
+	0x00, 0x13, 0xdb, 0x31, 0xb9, 0x03, 0x99, 0x73, 0x14, 0xa3, 0x32, 0xb1, 0x9d, 0x97, 0x0c, 0xc0,
+	0xb6, 0x6f, 0x88, 0xfd, 0xb0, 0x55, 0x61, 0xde, 0xe4, 0x03, 0xd4, 0x84, 0x3c, 0x71, 0xac, 0x16,
+	0x30, 0x18, 0xfd, 0x8b, 0x9e, 0x41, 0xf5, 0x62, 0x3a, 0x7a, 0x4d, 0x42, 0x33, 0xb0, 0x7f, 0x41,
+	0x5a, 0x55, 0x36, 0x3b, 0x14, 0x27, 0x1d, 0x8a, 0x1a, 0xd8, 0xbf, 0x20, 0x06, 0x5c, 0x44, 0xff,
+	0xb5, 0x7f, 0x69, 0xc2, 0x46, 0xd6, 0xf9, 0x62, 0xf3, 0xe9, 0x50, 0x0e, 0x42, 0x1c, 0x4e, 0x83,
+	0x68, 0xf7, 0x7d, 0x24, 0x85, 0x2d, 0xe6, 0xd8, 0x95, 0x90, 0x69, 0x60, 0x44, 0xac, 0xd4, 0xfc,
+	0xd0, 0x0d, 0xf1, 0x98, 0xad, 0x4e, 0xc1, 0xe0, 0x03, 0xf5, 0xbb, 0x06, 0x40, 0x4c, 0x4e, 0x1d,
+	0x6d, 0xb9, 0x34, 0xc3, 0x88, 0xed, 0x23, 0x46, 0x34, 0x68, 0xbc, 0xe9, 0x85, 0xf9, 0x9a, 0xcc,
+	0xe4, 0xde, 0xf1, 0xa6, 0x17, 0x2f, 0xc8, 0x8c, 0x66, 0xa1, 0xc8, 0x89, 0x6c, 0x89, 0xcb, 0x46,
+	0x0c, 0x40, 0x7d, 0xa8, 0x60, 0xc7, 0x9d, 0xe0, 0xb1, 0x4d, 0x82, 0xd6, 0x0a, 0xb3, 0xfd, 0xd3,
+	0x3b, 0xdb, 0xbe, 0xdb, 0x66, 0xac, 0x33, 0x23, 0x96, 0x81, 0x0c, 0x00, 0x1f, 0x3b, 0xaf, 0x4d,
+	0x3a, 0xab, 0x80, 0x05, 0x43, 0x75, 0xff, 0xd9, 0xdd, 0x25, 0x1a, 0xd8, 0x79, 0xcd, 0x91, 0x15,
+	0x5f, 0xfe, 0x45, 0x7f, 0x02, 0xab, 0x1e, 0x9e, 0x4d, 0x88, 0x13, 0x0a, 0xb1, 0x45, 0x26, 0xf6,
+	0xf3, 0xbb, 0x8b, 0x3d, 0xe3, 0xec, 0x01, 0x27, 0xa8, 0x09, 0x69, 0x5c, 0xfa, 0x57, 0xb0, 0x2a,
+	0x93, 0x12, 0x97, 0x5e, 0x62, 0xd2, 0x3f, 0xbb, 0xbb, 0xf4, 0x43, 0xce, 0x2e, 0x84, 0x8f, 0x12,
+	0x23, 0xf4, 0x25, 0xd4, 0xae, 0x09, 0x1e, 0x87, 0xd7, 0x42, 0x76, 0x99, 0xc9, 0xfe, 0x9d, 0xbb,
+	0xcb, 0x3e, 0x66, 0xdc, 0x1c, 0x5d, 0xbd, 0x8e, 0x07, 0x74, 0x5d, 0xa3, 0x3d, 0xc4, 0x02, 0xbe,
+	0x6c, 0xc4, 0x00, 0xf4, 0x13, 0x28, 0x06, 0xc4, 0xa7, 0x8b, 0x0a, 0x6c, 0x51, 0x9f, 0x46, 0x09,
+	0x81, 0x4f, 0x7d, 0x68, 0x4f, 0xc8, 0x80, 0x11, 0x44, 0x4a, 0x79, 0xdc, 0x1b, 0x82, 0x4f, 0xfd,
+	0x4f, 0x05, 0x6a, 0xc9, 0x89, 0xa1, 0x7d, 0xb8, 0x3f, 0x76, 0x47, 0xaf, 0x89, 0x65, 0xb2, 0x14,
+	0x37, 0x9e, 0x99, 0x78, 0x14, 0xda, 0x37, 0x84, 0x05, 0xa2, 0x62, 0xac, 0x71, 0xe4, 0x09, 0xc7,
+	0xb5, 0x19, 0x0a, 0xfd, 0x36, 0x6c, 0x08, 0x1e, 0x9f, 0x4c, 0xdc, 0x90, 0xc4, 0x4c, 0xfc, 0xdc,
+	0x5d, 0xe7, 0x58, 0x43, 0x20, 0xe7, 0xb8, 0xa4, 0x26, 0xf7, 0x86, 0xf8, 0x78, 0x3c, 0x6e, 0xe5,
+	0x93, 0x5c, 0x42, 0x55, 0x9f, 0xe3, 0xd0, 0x67, 0xb0, 0x99, 0xd5, 0x25, 0xd9, 0xf8, 0xb1, 0x7d,
+	0x3f, 0xad, 0x4c, 0xf0, 0xa9, 0x7f, 0xbd, 0x02, 0xab, 0xa9, 0xf8, 0x40, 0x3f, 0x82, 0x75, 0x4c,
+	0x91, 0x57, 0xc4, 0x0c, 0x68, 0xd0, 0x5d, 0xba, 0xfe, 0x1b, 0xec, 0x5b, 0x62, 0xa2, 0x48, 0xe0,
+	0x06, 0xc4, 0x09, 0x8f, 0x38, 0x06, 0xfd, 0x2e, 0xb4, 0x24, 0x87, 0x4f, 0x46, 0xc4, 0xbe, 0x21,
+	0x56, 0xc4, 0xc5, 0x67, 0xba, 0x21, 0xf0, 0x86, 0x40, 0x4b, 0xce, 0x6d, 0xa8, 0x25, 0x75, 0x89,
+	0x19, 0x56, 0x13, 0x3a, 0xa8, 0x39, 0x62, 0x22, 0x69, 0x73, 0xf8, 0xac, 0x90, 0xc0, 0x65, 0xcc,
+	0x91, 0x1c, 0x73, 0xe6, 0xf0, 0x63, 0x6a, 0x43, 0xe0, 0x17, 0x98, 0x93, 0xd4, 0x25, 0x8f, 0xac,
+	0x84, 0x0e, 0x7a, 0xe0, 0x3b, 0xd3, 0x09, 0x47, 0x97, 0x58, 0xa6, 0x2a, 0x39, 0xd3, 0x89, 0xb4,
+	0x94, 0xa2, 0xe6, 0x74, 0x96, 0x19, 0x19, 0x72, 0xa6, 0x93, 0xac, 0xbe, 0xa7, 0xd0, 0x94, 0xc2,
+	0x22, 0xea, 0x0a, 0xa3, 0xae, 0x0b, 0xa1, 0x92, 0xf2, 0x23, 0x68, 0x66, 0x5d, 0xcc, 0x72, 0xba,
+	0x62, 0x34, 0x32, 0xae, 0xa5, 0xa4, 0xd9, 0xe9, 0xb3, 0x24, 0xaf, 0x18, 0x8d, 0xcc, 0xb4, 0xe9,
+	0x7c, 0x93, 0x16, 0xb7, 0x6a, 0x4c, 0x77, 0x35, 0x61, 0xa9, 0xfa, 0x97, 0x39, 0xa8, 0x44, 0x69,
+	0x09, 0x3d, 0x83, 0x0d, 0x96, 0xdf, 0x44, 0x0a, 0x09, 0xb8, 0xe9, 0xce, 0x74, 0x22, 0xee, 0x46,
+	0x6b, 0x14, 0x1b, 0x85, 0x13, 0x71, 0xc2, 0xde, 0x74, 0x82, 0x7e, 0x0f, 0x1e, 0x2c, 0x60, 0x12,
+	0xc7, 0x18, 0xbf, 0x34, 0x6d, 0x64, 0xf9, 0xc4, 0x99, 0xf6, 0x10, 0x58, 0x22, 0x34, 0x6d, 0x4b,
+	0xa4, 0xef, 0xbc, 0x51, 0xa6, 0x80, 0xae, 0x35, 0x26, 0x74, 0x4b, 0x32, 0xa4, 0xf0, 0x1c, 0xdf,
+	0x5b, 0x76, 0xc8, 0xef, 0x35, 0xc2, 0x16, 0xe1, 0xbf, 0xb6, 0x40, 0xa1, 0x36, 0x3c, 0x4e, 0xdb,
+	0x12, 0xad, 0x96, 0xb0, 0xa7, 0xc0, 0x78, 0xd5, 0xa4, 0x3d, 0xd2, 0x17, 0xdc, 0x26, 0xf5, 0x2b,
+	0x28, 0x89, 0xcc, 0x4f, 0x2f, 0x14, 0x23, 0x7a, 0xa1, 0xe0, 0x87, 0x11, 0xfb, 0x8f, 0x54, 0x28,
+	0x07, 0xe4, 0x86, 0xf8, 0xd4, 0x10, 0x7e, 0x16, 0x45, 0x63, 0xb4, 0x05, 0x55, 0x8b, 0x04, 0x23,
+	0xdf, 0xf6, 0xd8, 0xbd, 0x3c, 0xcf, 0xd0, 0x49, 0x90, 0xfa, 0xef, 0x0a, 0x54, 0x8f, 0xd3, 0x79,
+	0x6e, 0xe4, 0x3a, 0x0e, 0x19, 0x85, 0x84, 0xef, 0xc0, 0xb2, 0x11, 0x03, 0xe8, 0x71, 0x38, 0xf5,
+	0xd8, 0x0d, 0x8a, 0x6f, 0x33, 0x31, 0xa2, 0x6e, 0x1b, 0xe3, 0x20, 0x34, 0x03, 0x42, 0x1c, 0xe9,
+	0x36, 0x0a, 0x18, 0x10, 0xe2, 0x30, 0x23, 0xec, 0x40, 0x08, 0x09, 0x98, 0xb3, 0x0a, 0x46, 0x12,
+	0x84, 0x3e, 0x86, 0x7b, 0x8c, 0x9d, 0x3e, 0x86, 0xa6, 0xce, 0x88, 0x50, 0x2f, 0x08, 0xc7, 0x34,
+	0x29, 0xa2, 0x9d, 0x80, 0xd3, 0x55, 0x48, 0xd2, 0x05, 0xa6, 0x47, 0x7c, 0xd3, 0xc2, 0x33, 0xb1,
+	0x79, 0xd6, 0x52, 0xc8, 0x33, 0xe2, 0x77, 0xf0, 0x4c, 0x9b, 0xc1, 0xfa, 0xa1, 0x4f, 0x70, 0x48,
+	0xba, 0xce, 0x8d, 0x6b, 0x8f, 0x88, 0xbc, 0xc8, 0x6d, 0x40, 0x11, 0x4f, 0xdc, 0xa9, 0x13, 0xca,
+	0xe3, 0x9d, 0x8f, 0xb2, 0x7e, 0xcb, 0xcd, 0xf9, 0x0d, 0x7d, 0x08, 0x0d, 0xdb, 0x22, 0x13, 0xcf,
+	0x0d, 0x89, 0x33, 0x9a, 0xb1, 0x8b, 0x00, 0xf7, 0x6e, 0x3d, 0x01, 0x7e, 0x41, 0x66, 0x9a, 0x03,
+	0xf7, 0x33, 0xaa, 0xc5, 0x35, 0xe6, 0x7d, 0x58, 0x1d, 0x51, 0x84, 0xed, 0x3a, 0xa6, 0x85, 0x43,
+	0x22, 0x22, 0xba, 0x26, 0x81, 0x1d, 0x1c, 0x12, 0xfa, 0x18, 0xb2, 0x39, 0x9f, 0x30, 0x42, 0x0e,
+	0xa9, 0xe9, 0xe4, 0xe7, 0x9e, 0xed, 0xcf, 0x84, 0xbf, 0xc5, 0x48, 0x6b, 0x42, 0xfd, 0x00, 0x8f,
+	0xb1, 0x13, 0x4d, 0x52, 0x6b, 0x43, 0x49, 0x40, 0xd2, 0xb7, 0x13, 0xf1, 0x20, 0x88, 0x00, 0xc9,
+	0x97, 0x97, 0x50, 0x26, 0x86, 0x5a, 0x07, 0x36, 0x5f, 0xe2, 0xb1, 0x6d, 0x2d, 0x98, 0xc6, 0x47,
+	0xb1, 0x85, 0x0a, 0x3b, 0x6d, 0x1b, 0xf2, 0xec, 0x93, 0x94, 0x12, 0xaf, 0xfd, 0x46, 0x81, 0x92,
+	0x00, 0xd2, 0x48, 0x9e, 0x90, 0x89, 0x2b, 0x23, 0x99, 0xfe, 0xa7, 0x37, 0xb2, 0x1b, 0x3c, 0x9e,
+	0xca, 0xa9, 0xf2, 0xc1, 0xbc, 0x9f, 0xf2, 0x0b, 0xfc, 0x14, 0x7b, 0x63, 0x25, 0xe9, 0x0d, 0xca,
+	0x7c, 0x89, 0xc7, 0xe3, 0x0b, 0x3c, 0x7a, 0x6d, 0x62, 0xcb, 0xf2, 0x59, 0x54, 0x55, 0x8c, 0x9a,
+	0x04, 0xb6, 0x2d, 0xcb, 0x17, 0xab, 0x1d, 0xda, 0x0e, 0x7f, 0xbd, 0x16, 0xa3, 0xd5, 0x96, 0x20,
+	0xed, 0x0f, 0xa1, 0x11, 0x39, 0x55, 0xcc, 0xfb, 0x63, 0x28, 0x5f, 0x70, 0x90, 0xbc, 0x85, 0x46,
+	0x13, 0x97, 0xa4, 0x11, 0x81, 0xf6, 0x53, 0xd8, 0x98, 0xf3, 0x1f, 0x8f, 0xc0, 0x56, 0xda, 0x7d,
+	0xe9, 0x05, 0x16, 0xb1, 0x99, 0x4b, 0xc6, 0xa6, 0x76, 0x04, 0x48, 0x0f, 0x42, 0x7b, 0x82, 0x43,
+	0x72, 0x44, 0xde, 0x1a, 0xc9, 0x4b, 0x03, 0x48, 0xdb, 0x87, 0xb5, 0x94, 0x1c, 0x31, 0xaf, 0x87,
+	0x50, 0x99, 0x10, 0xcb, 0xc6, 0xf4, 0x15, 0x2a, 0x64, 0x95, 0x19, 0xe0, 0x88, 0x10, 0xcd, 0x05,
+	0x34, 0x20, 0x8e, 0x25, 0x12, 0xd5, 0xf7, 0xd6, 0x7d, 0xf7, 0xdd, 0xf3, 0x0c, 0x90, 0x50, 0x76,
+	0x30, 0xeb, 0x76, 0xa4, 0xc2, 0xc7, 0x00, 0xf2, 0x86, 0x1a, 0x3f, 0x6c, 0x05, 0xa4, 0x6b, 0x69,
+	0xcf, 0x60, 0x33, 0x66, 0xba, 0xa3, 0xbb, 0xb5, 0xbf, 0x51, 0x60, 0xed, 0xc4, 0x0e, 0xc2, 0x38,
+	0x09, 0x73, 0x8e, 0x4f, 0xa0, 0xc8, 0x9f, 0x0c, 0xe2, 0xad, 0x77, 0x3f, 0x73, 0xb5, 0x13, 0xef,
+	0x0a, 0x41, 0x84, 0x3e, 0x83, 0x8a, 0x65, 0xfb, 0x64, 0x14, 0xe5, 0x8d, 0xfa, 0x7e, 0x2b, 0xc3,
+	0xd1, 0x91, 0x78, 0x23, 0x26, 0x65, 0x6a, 0x66, 0x41, 0x48, 0x26, 0xad, 0xfc, 0x62, 0x35, 0x0c,
+	0x69, 0x08, 0x22, 0xed, 0x10, 0xd6, 0xd3, 0xc6, 0xc6, 0x51, 0x29, 0x4f, 0x9a, 0x6c, 0x54, 0xca,
+	0x45, 0x8b, 0x08, 0xb4, 0x2b, 0xb8, 0xd7, 0x63, 0x2f, 0x7e, 0xe2, 0x84, 0xf6, 0xa5, 0x3d, 0xc2,
+	0xa1, 0xeb, 0x23, 0x0d, 0x6a, 0xac, 0x2a, 0x20, 0x9f, 0x37, 0xcc, 0x4d, 0xc7, 0x3f, 0x30, 0x80,
+	0x42, 0xcf, 0xf8, 0x23, 0xe7, 0x31, 0x54, 0x18, 0x8d, 0x83, 0xc5, 0x49, 0x40, 0x09, 0xca, 0x14,
+	0xd4, 0xc3, 0x13, 0x72, 0xd0, 0x80, 0x55, 0x3b, 0x29, 0x53, 0xfb, 0xb7, 0x1c, 0x94, 0x84, 0xfa,
+	0xb7, 0xac, 0x1d, 0x45, 0xf3, 0xda, 0x88, 0x65, 0xe2, 0x50, 0x1c, 0xd6, 0x15, 0x01, 0x69, 0x27,
+	0x57, 0x23, 0xff, 0xce, 0xab, 0xb1, 0xf2, 0x7d, 0x56, 0xa3, 0x70, 0x87, 0xd5, 0x48, 0x46, 0x55,
+	0x31, 0x1d, 0xe8, 0xdb, 0x20, 0x5f, 0x3f, 0xe6, 0x35, 0x0e, 0xae, 0xd9, 0x0d, 0xae, 0x62, 0x54,
+	0x05, 0xec, 0x18, 0x07, 0xd7, 0x89, 0xdd, 0x53, 0x4e, 0xed, 0x9e, 0xd4, 0x46, 0xac, 0x64, 0x36,
+	0xe2, 0x27, 0x70, 0x5f, 0xbc, 0x16, 0x02, 0xfa, 0x7b, 0x45, 0x6e, 0x2f, 0x66, 0xfd, 0x5d, 0x01,
+	0x36, 0xb2, 0xf4, 0x22, 0x62, 0x7e, 0x0c, 0x25, 0x9f, 0xd0, 0x77, 0x8c, 0x0c, 0x98, 0x1f, 0xc6,
+	0xaf, 0xa5, 0x45, 0x0c, 0xbb, 0x06, 0xa3, 0x36, 0x24, 0x97, 0xfa, 0x35, 0x94, 0x07, 0x0e, 0xf6,
+	0x82, 0x6b, 0x37, 0x8c, 0xea, 0x2b, 0x4a, 0xa2, 0xbe, 0x22, 0xee, 0x7c, 0xe2, 0x9d, 0x16, 0xb4,
+	0x72, 0xd1, 0x9d, 0x4f, 0x2a, 0xa0, 0x57, 0x98, 0x11, 0xf6, 0xf0, 0x88, 0x5e, 0x61, 0x78, 0x1e,
+	0x88, 0xc6, 0xea, 0xdf, 0x2b, 0xb0, 0x7a, 0x38, 0x76, 0x03, 0x62, 0x09, 0xf2, 0xef, 0x5d, 0xd6,
+	0x5a, 0x87, 0x02, 0x1e, 0xdb, 0x38, 0x10, 0x2a, 0xf8, 0x20, 0xa5, 0x7b, 0x25, 0xad, 0x9b, 0x69,
+	0xa2, 0xaa, 0x79, 0x59, 0xa6, 0x20, 0x34, 0x51, 0x08, 0xab, 0xc2, 0xd0, 0x37, 0xa1, 0x63, 0x87,
+	0x36, 0x8d, 0x71, 0xb1, 0xf0, 0x31, 0x40, 0xfd, 0xe7, 0x1c, 0x14, 0xb9, 0xaf, 0xd0, 0x21, 0x94,
+	0x3d, 0x9f, 0xdc, 0xd8, 0xae, 0xc8, 0x22, 0xd5, 0xfd, 0x0f, 0xdf, 0xe2, 0x64, 0xe9, 0x51, 0x23,
+	0x62, 0x44, 0x6d, 0x28, 0x8d, 0xa6, 0xbe, 0x4f, 0xc4, 0x81, 0xf0, 0x0e, 0x32, 0x24, 0x1f, 0x9b,
+	0x0f, 0x8d, 0x2d, 0xd3, 0xf2, 0x5d, 0x4f, 0xbc, 0x7d, 0x2a, 0x0c, 0xd2, 0xf1, 0x5d, 0x8f, 0x9d,
+	0xb4, 0x62, 0xea, 0x9c, 0x82, 0x3f, 0x79, 0x6a, 0x12, 0xc8, 0x88, 0x98, 0x17, 0x89, 0xcf, 0xef,
+	0x67, 0x65, 0x83, 0x0f, 0xd0, 0x39, 0x34, 0x98, 0x5f, 0xac, 0x78, 0x9d, 0x8b, 0x2c, 0x9a, 0x7e,
+	0xeb, 0x2d, 0x46, 0xa6, 0x96, 0xd6, 0xa8, 0x8f, 0x92, 0xc3, 0x40, 0xfb, 0x31, 0xac, 0xf5, 0x48,
+	0xf8, 0xc6, 0xf5, 0x5f, 0x3f, 0xf7, 0xb1, 0x77, 0x2d, 0x83, 0x7c, 0x51, 0x98, 0xa5, 0x4a, 0xcc,
+	0x71, 0xe0, 0xe7, 0x60, 0x3d, 0x2d, 0x41, 0x84, 0xfd, 0x22, 0x11, 0x0f, 0xa1, 0x42, 0x23, 0x95,
+	0x86, 0x8b, 0x0c, 0x53, 0xfa, 0xf6, 0xa2, 0x39, 0x32, 0x98, 0x0b, 0xe3, 0xfc, 0xed, 0x61, 0x9c,
+	0x0d, 0xa5, 0xcf, 0xa1, 0xc0, 0xe5, 0x16, 0x98, 0x5b, 0xb6, 0xa5, 0x5b, 0x16, 0x19, 0xc7, 0x0a,
+	0x12, 0x06, 0xa7, 0x57, 0x43, 0x58, 0xa1, 0xc3, 0x64, 0x58, 0x2b, 0x8b, 0xc3, 0x3a, 0x97, 0x0c,
+	0xeb, 0xff, 0x9b, 0xb9, 0xda, 0xd7, 0xa0, 0x52, 0xad, 0x87, 0x62, 0x7c, 0x6c, 0x07, 0xa1, 0xeb,
+	0xcb, 0xbe, 0xc3, 0xad, 0xb6, 0xf0, 0x92, 0x60, 0x6e, 0x41, 0x49, 0x30, 0x1f, 0x95, 0x04, 0xb5,
+	0x7f, 0x54, 0xe0, 0xe1, 0x42, 0xf9, 0x62, 0x75, 0xda, 0x50, 0xf4, 0x5c, 0xdb, 0x09, 0xe7, 0x0a,
+	0x7c, 0xb7, 0x30, 0xed, 0x9e, 0x51, 0x0e, 0x43, 0x30, 0xaa, 0x7f, 0x0c, 0x05, 0x06, 0xf8, 0x7f,
+	0xc8, 0x49, 0xb4, 0x99, 0x20, 0xd6, 0xad, 0x2d, 0x2b, 0x71, 0xb7, 0xe7, 0xdf, 0xdf, 0xe4, 0xa0,
+	0x35, 0xcf, 0x21, 0x26, 0x7b, 0x94, 0x2c, 0x0a, 0x2a, 0xe9, 0xfa, 0xd1, 0x32, 0xa6, 0x05, 0xb5,
+	0x40, 0xf5, 0x5b, 0x25, 0xf5, 0x50, 0x64, 0x39, 0x4b, 0x5c, 0xaf, 0xe9, 0xff, 0xe5, 0x89, 0x31,
+	0x2e, 0x72, 0xe6, 0x53, 0x45, 0x4e, 0xe9, 0xb9, 0x95, 0x84, 0xe7, 0xd4, 0x44, 0x2a, 0xe3, 0xb5,
+	0x8d, 0x68, 0x4c, 0x8f, 0x41, 0x99, 0xa1, 0xf8, 0x5b, 0x4c, 0x0e, 0xa9, 0x86, 0x11, 0xdb, 0xf7,
+	0xec, 0x00, 0x54, 0x0c, 0x31, 0xca, 0xbe, 0xb3, 0xca, 0x73, 0xef, 0x2c, 0xed, 0x7e, 0x94, 0x01,
+	0x92, 0x15, 0x78, 0xed, 0x6f, 0xf3, 0xb0, 0xaa, 0x8f, 0x5c, 0xc7, 0x9d, 0xd8, 0x23, 0x86, 0x98,
+	0x5b, 0x52, 0x65, 0x7e, 0x49, 0x77, 0x61, 0x8d, 0x91, 0x64, 0x12, 0x15, 0x5f, 0xfc, 0x7b, 0x94,
+	0x32, 0x95, 0x7d, 0x92, 0x35, 0x90, 0x4c, 0x28, 0xc8, 0x1a, 0x88, 0x0c, 0x45, 0x2a, 0x3a, 0x22,
+	0x15, 0x67, 0x13, 0xbe, 0x92, 0x9e, 0xbb, 0x27, 0xa9, 0x39, 0xa6, 0x7d, 0x45, 0x68, 0xf5, 0x2c,
+	0xa2, 0xe7, 0xe6, 0x8c, 0xed, 0x4b, 0xc2, 0xbc, 0xcd, 0xdf, 0xbd, 0xf7, 0x25, 0x0f, 0xc3, 0x9e,
+	0x08, 0x24, 0x2d, 0xe0, 0x48, 0x3e, 0xd7, 0x23, 0x0e, 0xbb, 0x1b, 0xf0, 0x93, 0xa7, 0x2e, 0xe0,
+	0x7d, 0x8f, 0x38, 0x47, 0x84, 0xa0, 0x1d, 0xb8, 0x97, 0xd2, 0xc0, 0x48, 0x4b, 0x69, 0xeb, 0x29,
+	0x9c, 0xd2, 0x7e, 0x0a, 0x52, 0x9d, 0x99, 0x6e, 0x40, 0x95, 0x99, 0x2d, 0xb2, 0x04, 0x77, 0x90,
+	0xe8, 0x43, 0xed, 0xc5, 0x45, 0x3b, 0xd9, 0x8f, 0x32, 0x3d, 0x6f, 0x22, 0x7a, 0x01, 0x52, 0xf5,
+	0x11, 0x6f, 0x4d, 0x9d, 0x79, 0x13, 0xed, 0x9f, 0x94, 0x28, 0x13, 0xa7, 0xcb, 0xf9, 0xef, 0x9c,
+	0x89, 0x6f, 0xd9, 0x99, 0x68, 0x0f, 0x4a, 0x0e, 0x57, 0x22, 0x9a, 0x4d, 0xd1, 0xcd, 0x2d, 0x15,
+	0x2d, 0x86, 0xa4, 0x42, 0x1f, 0x42, 0xde, 0x9d, 0xfa, 0xad, 0xc2, 0x6d, 0xc4, 0x94, 0x42, 0xfb,
+	0x0b, 0x05, 0x5a, 0x0b, 0xaa, 0xb9, 0xd1, 0x81, 0xe4, 0x24, 0xea, 0x32, 0xb2, 0xd1, 0x73, 0x97,
+	0x5c, 0x98, 0x6d, 0x8f, 0xac, 0xdc, 0xa9, 0x3d, 0xf2, 0xeb, 0x15, 0x78, 0xb0, 0xb4, 0xb6, 0x9c,
+	0x28, 0x47, 0x2b, 0x77, 0x2d, 0x47, 0x8b, 0xa1, 0x2c, 0x47, 0x7f, 0x9b, 0x83, 0x22, 0x57, 0x1d,
+	0xcf, 0x43, 0x49, 0xce, 0xe3, 0x01, 0xad, 0x3a, 0xd1, 0x0e, 0x41, 0x74, 0x4b, 0x2f, 0xd1, 0xf1,
+	0x00, 0x87, 0x34, 0x46, 0x93, 0x05, 0x46, 0x46, 0xc2, 0xe7, 0x5b, 0x0f, 0xe2, 0x0a, 0x23, 0xa5,
+	0xfc, 0x11, 0xac, 0x67, 0x8b, 0x97, 0x8c, 0x9a, 0x6f, 0x1b, 0xe4, 0xa7, 0xab, 0x97, 0x03, 0x9c,
+	0xae, 0x86, 0x16, 0xd2, 0xd5, 0xd0, 0x45, 0xb5, 0xcd, 0xe2, 0xc2, 0xda, 0xe6, 0xb2, 0xba, 0x69,
+	0x69, 0x69, 0xdd, 0x74, 0x1b, 0x6a, 0x11, 0x75, 0xbc, 0x2f, 0xaa, 0x12, 0x46, 0x2d, 0xcb, 0x96,
+	0x36, 0x2b, 0xf3, 0xa5, 0xcd, 0x6f, 0xa0, 0xc8, 0xdd, 0xbc, 0xfc, 0x00, 0x8d, 0x53, 0x71, 0x2e,
+	0x95, 0x8a, 0x0f, 0x68, 0xd3, 0x9e, 0x2e, 0x07, 0x3d, 0xc9, 0xdf, 0xad, 0xc3, 0x20, 0x19, 0xb5,
+	0x0e, 0xa8, 0x67, 0xbe, 0x7b, 0x69, 0x87, 0xf8, 0xc2, 0x1e, 0xdb, 0xe1, 0x4c, 0x5c, 0xe4, 0xe3,
+	0x83, 0x6b, 0xc1, 0x32, 0x8b, 0x70, 0xcd, 0xc5, 0x47, 0xf7, 0xaf, 0x68, 0xfd, 0x3e, 0x29, 0xe6,
+	0x7b, 0xdf, 0xc7, 0x97, 0x1d, 0x3b, 0xdb, 0x10, 0xdd, 0x38, 0x13, 0xd1, 0x50, 0x95, 0x30, 0xea,
+	0xec, 0x67, 0xb0, 0x41, 0xb0, 0xef, 0x24, 0xc2, 0x86, 0x26, 0xa1, 0x00, 0xf3, 0xa0, 0xc8, 0x1b,
+	0x6b, 0x1c, 0x2b, 0x96, 0xef, 0x88, 0x10, 0xca, 0xf4, 0x01, 0x34, 0xe4, 0x5b, 0x4c, 0x52, 0x17,
+	0x19, 0xb5, 0x6c, 0x77, 0x09, 0xba, 0x2d, 0xa8, 0xc9, 0xdc, 0xca, 0x88, 0x78, 0xb3, 0x18, 0x5c,
+	0x9e, 0x58, 0x29, 0x85, 0x06, 0xab, 0x51, 0x4e, 0x4d, 0xc6, 0xc3, 0x48, 0x24, 0x54, 0x41, 0x13,
+	0xbc, 0xb1, 0xbd, 0x98, 0x86, 0x67, 0xc6, 0x2a, 0x03, 0x0a, 0x9a, 0xe4, 0x26, 0x82, 0xf4, 0x26,
+	0xca, 0x46, 0x5c, 0x75, 0x3e, 0xe2, 0x68, 0x6d, 0x8b, 0x4f, 0x50, 0xd0, 0xd4, 0x78, 0x61, 0x2c,
+	0x02, 0x0e, 0x30, 0x7f, 0x6e, 0xb3, 0xd5, 0x62, 0x14, 0xab, 0xfc, 0x3d, 0xcd, 0x21, 0x14, 0xdd,
+	0x84, 0xbc, 0xef, 0xda, 0xad, 0x3a, 0x3b, 0x95, 0xe9, 0x5f, 0x2a, 0xf5, 0x67, 0x53, 0x4c, 0x17,
+	0x56, 0xb4, 0xd1, 0x1b, 0xfc, 0x11, 0x20, 0x80, 0xac, 0x8f, 0xae, 0x7d, 0xab, 0xc0, 0xc3, 0x85,
+	0xb1, 0x24, 0x12, 0xd0, 0x1d, 0x83, 0x09, 0x7d, 0x0a, 0xe5, 0xc4, 0x0d, 0x35, 0x9f, 0x4c, 0xc1,
+	0x69, 0xf1, 0x11, 0x19, 0xfa, 0x18, 0x0a, 0x1e, 0x21, 0xbe, 0x6c, 0x9f, 0x2e, 0xa1, 0xe7, 0x34,
+	0x94, 0x98, 0xf7, 0x78, 0x33, 0xf9, 0x3d, 0x43, 0xcc, 0x68, 0xb4, 0x3f, 0x83, 0x35, 0xfd, 0xe7,
+	0x74, 0x1a, 0x27, 0xc4, 0xba, 0x22, 0xfe, 0x3b, 0x6e, 0x0c, 0x7a, 0x06, 0x5c, 0xda, 0x22, 0xd5,
+	0x95, 0x0d, 0xf6, 0x3f, 0xd1, 0xa7, 0x5f, 0x49, 0xf5, 0xe9, 0xa3, 0x5b, 0x62, 0x21, 0x79, 0x4b,
+	0x34, 0x61, 0x3d, 0x6d, 0x80, 0xf0, 0x66, 0x13, 0xf2, 0xa3, 0xe0, 0x46, 0xec, 0x2c, 0xfa, 0x97,
+	0x7e, 0x0b, 0x41, 0x93, 0x0d, 0x71, 0x42, 0x96, 0xe5, 0xf9, 0x09, 0x09, 0xce, 0x74, 0xa2, 0x73,
+	0x48, 0xdc, 0xdc, 0xce, 0x27, 0x9a, 0xdb, 0xda, 0x35, 0xd4, 0x9e, 0xe3, 0x90, 0xbc, 0xc1, 0x33,
+	0xdd, 0xf7, 0x5d, 0x9f, 0x52, 0x11, 0xfa, 0x47, 0x88, 0xe6, 0x83, 0xa8, 0xc9, 0xc0, 0xa5, 0xb2,
+	0xff, 0x68, 0x17, 0x4a, 0x16, 0x09, 0xb1, 0x1d, 0xad, 0xd3, 0xfa, 0x2e, 0xff, 0x4c, 0x69, 0x57,
+	0x7e, 0xa6, 0xb4, 0xdb, 0x76, 0x66, 0x86, 0x24, 0xda, 0xf9, 0x0c, 0x0a, 0xa7, 0xc4, 0xb2, 0x31,
+	0xaa, 0x03, 0x9c, 0xea, 0x9d, 0x6e, 0xdb, 0xec, 0xf5, 0x7b, 0x7a, 0xf3, 0x07, 0x74, 0x7c, 0x70,
+	0xd2, 0x3f, 0x7c, 0x71, 0x78, 0xdc, 0xee, 0xf6, 0x9a, 0x0a, 0x5a, 0x85, 0xca, 0x49, 0xf7, 0xf9,
+	0xf1, 0xb0, 0xd7, 0xed, 0x3d, 0x6f, 0xe6, 0x76, 0xce, 0xa3, 0xe6, 0xa0, 0x68, 0xc0, 0x37, 0xa0,
+	0x3a, 0x18, 0xb6, 0x87, 0xe7, 0x03, 0x29, 0xa0, 0x0a, 0xa5, 0x2f, 0xda, 0xdd, 0x21, 0x25, 0x57,
+	0xe8, 0xe0, 0x4c, 0xef, 0x75, 0x18, 0x2f, 0x15, 0x75, 0xd8, 0x3f, 0x3d, 0x3b, 0xd1, 0x87, 0x7a,
+	0xa7, 0x99, 0x47, 0x00, 0xc5, 0xa3, 0x76, 0xf7, 0x44, 0xef, 0x34, 0x57, 0x76, 0x0e, 0xa0, 0x99,
+	0x2d, 0xf7, 0x20, 0x04, 0xf5, 0x4e, 0xd7, 0xd0, 0x0f, 0x87, 0xdd, 0x7e, 0x4f, 0x0a, 0xaf, 0x41,
+	0xb9, 0xdb, 0x3b, 0xec, 0x9f, 0x72, 0xe9, 0x35, 0x28, 0xf7, 0xcf, 0x87, 0xcf, 0xfb, 0xdc, 0xb4,
+	0x3f, 0x88, 0x4d, 0xe3, 0x55, 0x1f, 0x6a, 0xda, 0xab, 0xc1, 0x50, 0x3f, 0x4d, 0x71, 0x0f, 0x75,
+	0xa3, 0xd7, 0x3e, 0xe1, 0xdc, 0xfa, 0x97, 0x62, 0x94, 0xdb, 0xf9, 0x0a, 0xca, 0xf2, 0x3b, 0x0e,
+	0x6a, 0xe8, 0xa0, 0x6f, 0x0c, 0x25, 0x5b, 0x03, 0xaa, 0x07, 0xaf, 0xcc, 0x81, 0xde, 0x1b, 0x9a,
+	0xbd, 0xf3, 0xd3, 0xa6, 0x22, 0x00, 0xdd, 0xce, 0x89, 0xde, 0xd3, 0x07, 0x03, 0x3e, 0xb3, 0x83,
+	0x57, 0xe6, 0xcb, 0xfe, 0xc9, 0xf9, 0xa9, 0xde, 0xcc, 0x0b, 0xbc, 0xa1, 0x1f, 0xea, 0xdd, 0x97,
+	0x6c, 0x7a, 0xc7, 0x50, 0xe4, 0x5f, 0x9e, 0x50, 0xd4, 0x99, 0x6e, 0x74, 0xfb, 0x1d, 0x29, 0xbc,
+	0x04, 0xf9, 0x4e, 0xfb, 0x55, 0x53, 0x41, 0x65, 0x58, 0xf9, 0x42, 0xd7, 0x5f, 0x34, 0x73, 0xa8,
+	0x02, 0x85, 0xd3, 0x7e, 0x6f, 0x78, 0xcc, 0x25, 0x0d, 0x8f, 0x0d, 0x5d, 0x37, 0x39, 0x60, 0x65,
+	0xa7, 0x0f, 0x10, 0xdf, 0x38, 0x98, 0xa2, 0xf3, 0xc3, 0x17, 0x7a, 0xca, 0x54, 0x0e, 0x38, 0xee,
+	0x9f, 0x1b, 0x4d, 0x85, 0x2d, 0x27, 0x07, 0x50, 0x2d, 0xb9, 0x04, 0x01, 0x53, 0x96, 0xdf, 0xf9,
+	0x87, 0x1c, 0x54, 0x59, 0xb0, 0x19, 0x04, 0x07, 0xae, 0x43, 0x09, 0x0c, 0xbd, 0x3d, 0x88, 0x5d,
+	0xfe, 0x04, 0x54, 0x01, 0x68, 0x0f, 0x06, 0x4c, 0xd3, 0xd0, 0x1c, 0x9c, 0x9f, 0x9d, 0xf5, 0x0d,
+	0xba, 0x8c, 0x0a, 0xda, 0x82, 0x47, 0x92, 0x41, 0x1f, 0x7e, 0xd1, 0x37, 0x5e, 0x64, 0x28, 0x72,
+	0xe8, 0x21, 0x6c, 0x0a, 0x8a, 0x6e, 0xef, 0x65, 0xfb, 0xa4, 0xdb, 0x31, 0xdb, 0xc6, 0xf3, 0xf3,
+	0x53, 0xbd, 0x37, 0x6c, 0xe6, 0xd1, 0x1a, 0x34, 0x22, 0xa4, 0x58, 0x8c, 0x15, 0xb4, 0x0e, 0xcd,
+	0xc8, 0x88, 0xa1, 0x79, 0xd4, 0x3f, 0xef, 0x75, 0x9a, 0x05, 0xb4, 0x01, 0x48, 0x40, 0xcf, 0x7b,
+	0xed, 0x97, 0xed, 0xee, 0x49, 0xfb, 0xe0, 0x44, 0x6f, 0x16, 0x91, 0x0a, 0x1b, 0x09, 0xea, 0x2e,
+	0x8d, 0x30, 0x2a, 0x5c, 0xef, 0x34, 0x4b, 0x68, 0x1b, 0x1e, 0x4b, 0xf1, 0x1d, 0xfd, 0xf4, 0xac,
+	0x3f, 0xd4, 0x7b, 0x87, 0xaf, 0xcc, 0x17, 0x3a, 0x5d, 0x9e, 0xf3, 0x81, 0xde, 0x69, 0x96, 0x13,
+	0x13, 0x34, 0xf4, 0x3f, 0x3a, 0xd7, 0x07, 0x43, 0xb3, 0xdb, 0x33, 0xcf, 0x8c, 0xfe, 0x73, 0x83,
+	0x2e, 0x6e, 0x05, 0x6d, 0xc2, 0x9a, 0xc4, 0xb7, 0x87, 0xba, 0x79, 0xd2, 0x3d, 0xed, 0x52, 0xd9,
+	0xb0, 0xff, 0x5f, 0x35, 0xc8, 0x1f, 0x4f, 0x2f, 0x10, 0x81, 0xd5, 0x54, 0x07, 0x09, 0x3d, 0x8a,
+	0x6a, 0x2a, 0x0b, 0x7a, 0x5a, 0xea, 0xe3, 0x25, 0x58, 0xf1, 0x89, 0xe0, 0xe6, 0xaf, 0xbe, 0xfb,
+	0x8f, 0xbf, 0xca, 0xdd, 0xfb, 0x7d, 0x65, 0x47, 0xab, 0xed, 0xdd, 0x7c, 0xba, 0x27, 0x2a, 0x95,
+	0x01, 0xf2, 0xa1, 0x91, 0xe9, 0x51, 0xa0, 0x27, 0x52, 0xd4, 0xe2, 0xe6, 0x85, 0xfa, 0xde, 0x52,
+	0xbc, 0x50, 0xf6, 0x84, 0x29, 0x6b, 0xa1, 0x8d, 0xa4, 0xa6, 0xbd, 0x5f, 0x8a, 0x7f, 0xdf, 0xa0,
+	0x7e, 0xdc, 0x9a, 0xda, 0xc8, 0x76, 0x4f, 0x84, 0x8e, 0xcd, 0x39, 0xb8, 0x90, 0xbd, 0xc6, 0x64,
+	0xaf, 0xa2, 0x2a, 0x95, 0x2d, 0x3a, 0x2d, 0xe8, 0x08, 0xaa, 0x89, 0xa6, 0x06, 0x52, 0xa3, 0x0b,
+	0xfd, 0x5c, 0xc7, 0x44, 0x7d, 0xb8, 0x10, 0x27, 0x52, 0xee, 0x00, 0xaa, 0x89, 0x46, 0x47, 0x2c,
+	0x67, 0xbe, 0xfb, 0xa1, 0x66, 0x0b, 0xec, 0x73, 0x1e, 0x96, 0xf5, 0x76, 0xf4, 0x35, 0x54, 0x13,
+	0xcd, 0x8c, 0x58, 0xe8, 0x7c, 0x87, 0x63, 0x5e, 0xe8, 0x36, 0x13, 0xfa, 0x10, 0x3d, 0x48, 0x4a,
+	0xdc, 0xfb, 0x65, 0x5c, 0x4a, 0xff, 0x06, 0x75, 0xa0, 0x99, 0x6d, 0x7b, 0xa0, 0xf7, 0xe6, 0x75,
+	0xa4, 0x97, 0x30, 0xab, 0x08, 0x99, 0x50, 0x4b, 0x76, 0x16, 0x50, 0xe4, 0xa6, 0x05, 0xcd, 0x11,
+	0xf5, 0xd1, 0x62, 0xa4, 0x58, 0xa1, 0x75, 0x66, 0x73, 0x1d, 0xa5, 0xbd, 0x70, 0x0d, 0xf5, 0xf4,
+	0x77, 0x38, 0xe8, 0xf1, 0xb2, 0xef, 0x73, 0xb8, 0x92, 0x27, 0xb7, 0x7f, 0xbe, 0x23, 0xfd, 0x8d,
+	0x1a, 0x54, 0x0d, 0x7b, 0x32, 0xee, 0xb1, 0xaf, 0x7f, 0xd0, 0xe7, 0x50, 0x14, 0x5f, 0xa7, 0xde,
+	0xcf, 0x7e, 0xe2, 0xca, 0x25, 0x6f, 0x2c, 0xfe, 0xf2, 0x15, 0x9d, 0x43, 0x33, 0xfb, 0x6d, 0x62,
+	0xec, 0xc9, 0x25, 0x9f, 0x91, 0xaa, 0x5b, 0x6f, 0xfb, 0xac, 0x11, 0xf5, 0xe9, 0xcc, 0x93, 0x55,
+	0xd0, 0xe4, 0xcc, 0x17, 0x14, 0xf3, 0xd5, 0x27, 0xcb, 0xd0, 0x42, 0x60, 0x17, 0x6a, 0xc9, 0xfa,
+	0x61, 0xbc, 0x56, 0x0b, 0x8a, 0xa6, 0xea, 0xa3, 0xc5, 0x48, 0x21, 0xea, 0x4f, 0x61, 0x6d, 0x41,
+	0x6d, 0x0d, 0x69, 0xb7, 0x16, 0xde, 0xb8, 0xe0, 0xf7, 0xef, 0x50, 0x9c, 0xa3, 0x2e, 0xcd, 0xd6,
+	0xb2, 0x62, 0x97, 0x2e, 0x29, 0xa6, 0xa9, 0x5b, 0xcb, 0x09, 0xe6, 0x3c, 0xc0, 0x43, 0x29, 0xeb,
+	0x81, 0x54, 0x20, 0x3d, 0x5a, 0x8c, 0x14, 0xa2, 0xbe, 0x84, 0x7b, 0x73, 0x6f, 0x29, 0xb4, 0x75,
+	0xcb, 0x33, 0x8b, 0x0b, 0xdd, 0x7e, 0xeb, 0x43, 0x8c, 0xfa, 0x76, 0xc1, 0x65, 0x39, 0xf6, 0xed,
+	0xf2, 0x57, 0x99, 0xfa, 0xfe, 0xad, 0x34, 0xb1, 0x13, 0x92, 0xf7, 0xc6, 0xd8, 0x09, 0x0b, 0xae,
+	0xb3, 0xea, 0xa3, 0xc5, 0x48, 0x2e, 0xea, 0xa2, 0xc8, 0xae, 0x73, 0xcf, 0xfe, 0x77, 0x00, 0x99,
+	0x14, 0xcb, 0xac, 0xb9, 0x2e, 0x00, 0x00,
 }
@@ -0,0 +1,1402 @@
+// Code generated by generate.sh. DO NOT EDIT.
+
+package hubrpc
+
+// SwaggerJSON is the OpenAPI specification of the hub REST gateway.
+const SwaggerJSON = `
+{
+  "swagger": "2.0",
+  "info": {
+    "title": "hubrpc.proto",
+    "version": "version not set"
+  },
+  "schemes": [
+    "http",
+    "https"
+  ],
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "paths": {
+    "/v1/balance": {
+      "get": {
+        "summary": "Balance is used to get info about number of funds locked in channels.",
+        "operationId": "Balance",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/hubrpcBalanceResponse"
+            }
+          }
+        },
+        "tags": [
+          "Hub"
+        ]
+      }
+    },
+    "/v1/invoices": {
+      "post": {
+        "summary": "CreateInvoice is used to create lightning network invoice in which\nwill be used to receive money from external lightning network entity.",
+        "operationId": "CreateInvoice",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/hubrpcCreateInvoiceResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/hubrpcCreateInvoiceRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Hub"
+        ]
+      }
+    },
+    "/v1/invoices/{invoice}": {
+      "get": {
+        "summary": "ValidateInvoice is used to validate invoice on proper network and amount.",
+        "operationId": "ValidateInvoice",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/hubrpcValidateInvoiceResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "invoice",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          },
+          {
+            "name": "amount",
+            "description": "(optional) Amount is the amount which should be received on this\nreceipt, in bitcoin.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Hub"
+        ]
+      }
+    },
+    "/v1/nodes/stats": {
+      "get": {
+        "summary": "CheckNodeStats return statistical data about node, and sort nodes by\ninternal ranking algorithm.",
+        "operationId": "CheckNodeStats",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/hubrpcCheckNodeStatsResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "period",
+            "description": " - DAY: Day is used to aggregate statistic over one day period.\n - WEEK: Week is used to aggregate statistic over one week period.\n - MONTH: Month is used to aggregate statistic over one month period.\n - THREE_MONTH: ThreeMonth is used to aggregate statistic over three month period.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "PERIOD_NONE",
+              "DAY",
+              "WEEK",
+              "MONTH",
+              "THREE_MONTH"
+            ],
+            "default": "PERIOD_NONE"
+          },
+          {
+            "name": "node",
+            "description": "Node is the domain or public key of the node, stats of which should\nbe returned. Node is looked up first, and than filters are applied to\nit, empty list is returned if node doesn't pass the filters.",
+            "in": "query",
+            "required": false,
+            "type": "string"
+          },
+          {
+            "name": "limit",
+            "description": "Limit limits output to the given number of nodes, all nodes are\nreturned if zero.",
+            "in": "query",
+            "required": false,
+            "type": "integer",
+            "format": "int32"
+          },
+          {
+            "name": "sort_type",
+            "description": " - BY_SENT_NUM: BySentNum is used to sort nodes by number of payment we have sent to\nthis node.\n - BY_IDLENESS: ByIdleness is used to sort nodes by capacity / volume ration, i.e. if\nwe have a lot of funds locked with node, but don't have activity node\nwill be ranked as idle.\n - BY_VOLUME: ByVolume is used to sort nodes by overall volume including forward\nvolume.\n - BY_RECEIVED: ByReceived is used to sort nodes by volume of payments we have\nreceived from this node.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "SORT_NONE",
+              "BY_SENT_NUM",
+              "BY_IDLENESS",
+              "BY_VOLUME",
+              "BY_RECEIVED"
+            ],
+            "default": "SORT_NONE"
+          },
+          {
+            "name": "offset",
+            "description": "Offset is the number of nodes which should be skipped, is used\nalongside with limit for pagination.",
+            "in": "query",
+            "required": false,
+            "type": "integer",
+            "format": "int32"
+          },
+          {
+            "name": "important_only",
+            "description": "ImportantOnly filters out nodes which are not important for us.",
+            "in": "query",
+            "required": false,
+            "type": "boolean",
+            "format": "boolean"
+          },
+          {
+            "name": "available_only",
+            "description": "AvailableOnly filters out nodes to which we couldn't send payment\ndirectly.",
+            "in": "query",
+            "required": false,
+            "type": "boolean",
+            "format": "boolean"
+          },
+          {
+            "name": "min_volume",
+            "description": "MinVolume filters out nodes average daily payment volume of which,\ni.e. sent, received, sent forward and received forward, is less than\nthe given one. (In USD).",
+            "in": "query",
+            "required": false,
+            "type": "number",
+            "format": "double"
+          },
+          {
+            "name": "start",
+            "description": "Start and End is the custom [start, end) range of time over which\nstatistic should be aggregated, if start is specified period is\nignored. Current time is used as end if it is not specified.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "end",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "format": "int64"
+          },
+          {
+            "name": "bucket_size",
+            "description": "(optional) BucketSize is the size of the payment time series bucket,\nif specified payment time series over the [start, end) range is\nreturned for every node.\n\n - BUCKET_HOUR: BucketHour is used to aggregate time series in hour buckets.\n - BUCKET_DAY: BucketDay is used to aggregate time series in day buckets.\n - BUCKET_WEEK: BucketWeek is used to aggregate time series in week buckets.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "BUCKET_NONE",
+              "BUCKET_HOUR",
+              "BUCKET_DAY",
+              "BUCKET_WEEK"
+            ],
+            "default": "BUCKET_NONE"
+          }
+        ],
+        "tags": [
+          "Hub"
+        ]
+      }
+    },
+    "/v1/payments": {
+      "get": {
+        "summary": "ListPayments returns list of payment which were registered by the\nsystem.",
+        "operationId": "ListPayments",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/hubrpcListPaymentsResponse"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "status",
+            "description": "(optional) Status denotes the stage of the processing the payment.\n\n - WAITING: WAITING means that payment has been created and waiting to be approved\nfor sending.\n - PENDING: PENDING means that service is seeing the payment, but it not yet approved\nfrom the its POV.\n - COMPLETED: COMPLETED in case of outgoing/incoming payment this means that we\nsent/received the transaction in/from the network and it was confirmed\nnumber of times service believe sufficient. In case of the forward\ntransaction it means that we succesfully routed it through and\nearned fee for that.\n - FAILED: FAILED means that services has tryied to send payment for couple of\ntimes, but without success, and now service gave up.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "STATUS_NONE",
+              "WAITING",
+              "PENDING",
+              "COMPLETED",
+              "FAILED"
+            ],
+            "default": "STATUS_NONE"
+          },
+          {
+            "name": "direction",
+            "description": "(optional) Direction denotes the direction of the payment.\n\n - INCOMING: INCOMING type of payment which service has received from someone else\nin the media.\n - OUTGOING: OUTGOING type of payment which service has sent to someone else in the\nmedia.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "DIRECTION_NONE",
+              "INCOMING",
+              "OUTGOING"
+            ],
+            "default": "DIRECTION_NONE"
+          },
+          {
+            "name": "system",
+            "description": "(optional) PaymentSystem denotes is that payment belongs to business\nlogic of payment server or it was originated by user / third-party\nservice.\n\n - INTERNAL: INTERNAL type of payment usually services the purpose of payment\nserver itself for stabilisation of system.\n - EXTERNAL: EXTERNAL type of payment which was originated by user / third-party\nservices, this is what usually interesting for external viewer. This\ntype of payment changes balance.",
+            "in": "query",
+            "required": false,
+            "type": "string",
+            "enum": [
+              "SYSTEM_NONE",
+              "INTERNAL",
+              "EXTERNAL"
+            ],
+            "default": "SYSTEM_NONE"
+          }
+        ],
+        "tags": [
+          "Hub"
+        ]
+      },
+      "post": {
+        "summary": "SendPayment sends payment to the given invoice,\nensures in the validity of the invoice.",
+        "operationId": "SendPayment",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/hubrpcPayment"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/hubrpcSendPaymentRequest"
+            }
+          }
+        ],
+        "tags": [
+          "Hub"
+        ]
+      }
+    },
+    "/v1/payments/{payment_id}": {
+      "get": {
+        "summary": "PaymentByID is used to fetch the information about payment, by the\ngiven payment id.",
+        "operationId": "PaymentByID",
+        "responses": {
+          "200": {
+            "description": "",
+            "schema": {
+              "$ref": "#/definitions/hubrpcPayment"
+            }
+          }
+        },
+        "parameters": [
+          {
+            "name": "payment_id",
+            "in": "path",
+            "required": true,
+            "type": "string"
+          }
+        ],
+        "tags": [
+          "Hub"
+        ]
+      }
+    }
+  },
+  "definitions": {
+    "BudgetResponseOperationBudget": {
+      "type": "object",
+      "properties": {
+        "operation": {
+          "type": "string",
+          "description": "Operation is the name of channel operation, i.e. open, close."
+        },
+        "limit": {
+          "type": "number",
+          "format": "double",
+          "title": "Limit is the maximum number of funds we allow to spend on the\noperation during the day. (In USD)"
+        },
+        "spent": {
+          "type": "number",
+          "format": "double",
+          "title": "Spent is the number of funds spent on the operation during the\nlast day, including pending operations. (In USD)"
+        },
+        "pending": {
+          "type": "number",
+          "format": "double",
+          "title": "Pending is the estimated number of funds of operations which were\nmade by us, but actual fee of which is not known yet. (In USD)"
+        },
+        "remaining": {
+          "type": "number",
+          "format": "double",
+          "title": "Remaining is the number of funds we still could spend on the\noperation during the day. (In USD)"
+        },
+        "postponed": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Postponed is the number of operations which were postponed during\nthe last day because of the budget exhaustion."
+        }
+      }
+    },
+    "ChannelsChangeResponseClosedChannel": {
+      "type": "object",
+      "properties": {
+        "channel_id": {
+          "type": "string"
+        },
+        "node_id": {
+          "type": "string"
+        },
+        "alias": {
+          "type": "string",
+          "description": "Alias is the name of the node, if it is known."
+        },
+        "capacity": {
+          "type": "string",
+          "description": "Capacity is the balance which was locked in the channel before\nclose."
+        },
+        "close_type": {
+          "type": "string",
+          "description": "CloseType is the way in which channel has been closed, i.e.\ncooperative, local_force, remote_force, breach."
+        },
+        "initiator": {
+          "type": "string",
+          "description": "Initiator is the side which closed the channel, empty if unknown."
+        }
+      }
+    },
+    "ChannelsChangeResponseReport": {
+      "type": "object",
+      "properties": {
+        "previous": {
+          "$ref": "#/definitions/ChannelsChangeResponseSnapshot"
+        },
+        "current": {
+          "$ref": "#/definitions/ChannelsChangeResponseSnapshot"
+        },
+        "count_drop": {
+          "type": "number",
+          "format": "double",
+          "description": "CountDrop is the relative drop of channels count, negative if\nnumber of channels has increased."
+        },
+        "capacity_drop": {
+          "type": "number",
+          "format": "double",
+          "description": "CapacityDrop is the relative drop of channels capacity, negative\nif capacity has increased."
+        },
+        "alert": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Alert is true if drop of count or capacity exceeds threshold."
+        },
+        "closed_channels": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ChannelsChangeResponseClosedChannel"
+          }
+        }
+      }
+    },
+    "ChannelsChangeResponseSnapshot": {
+      "type": "object",
+      "properties": {
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "description": "Time is the time when snapshot has been taken."
+        },
+        "num_channels": {
+          "type": "integer",
+          "format": "int32",
+          "description": "NumChannels is number of opened channels."
+        },
+        "capacity": {
+          "type": "string",
+          "description": "Capacity is the overall balance locked in opened channels."
+        }
+      }
+    },
+    "CheckNodeStatsResponseNodeStatus": {
+      "type": "object",
+      "properties": {
+        "domain": {
+          "type": "string",
+          "description": "Domain is the name given by us to this node."
+        },
+        "pub_key": {
+          "type": "string",
+          "description": "PubKey is a identificator of node in lightning network."
+        },
+        "available": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Available shows whether or not we could send payment directly to\nthe node, i.e. node is connected, we have active channel with\nit, and local balance of the channel is enough to send 95th\npercentile payment."
+        },
+        "anomalies": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/CheckNodeStatsResponseNodeStatusAnomaly"
+          },
+          "description": "Anomalies will show anomalies which are related to this node,\nsuch as not all funds being active, or node being offline, or not\nenough funds being locked within channels, and other."
+        },
+        "rank_stats": {
+          "$ref": "#/definitions/NodeStatusRankStats"
+        },
+        "payment_stats": {
+          "$ref": "#/definitions/NodeStatusPaymentsStats"
+        },
+        "channel_stats": {
+          "$ref": "#/definitions/NodeStatusChannelStats"
+        },
+        "health_stats": {
+          "$ref": "#/definitions/NodeStatusHealthStats"
+        },
+        "important": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Important shows whether or not node is important for us."
+        },
+        "series": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PaymentTimeSeriesResponseBucket"
+          },
+          "description": "Series is the payment time series of the node, returned only if\nbucket size is specified in the request."
+        }
+      }
+    },
+    "CheckNodeStatsResponseNodeStatusAnomaly": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "type": "string",
+          "description": "Code is the type of anomaly, i.e. inactive_channels,\nstuck_balance, remote_only_liquidity, pending_close,\ninsufficient_capacity, max_flow,\nimportant_node_disconnected."
+        },
+        "severity": {
+          "type": "string",
+          "description": "Severity is the level of anomaly importance, i.e. info,\nwarning, critical."
+        },
+        "description": {
+          "type": "string"
+        }
+      }
+    },
+    "FeePolicyUpdatesResponseFeePolicy": {
+      "type": "object",
+      "properties": {
+        "base_fee_msat": {
+          "type": "string",
+          "format": "int64",
+          "description": "BaseFeeMsat is the fixed fee in millisatoshis which is taken for\nevery forwarded payment."
+        },
+        "fee_rate": {
+          "type": "string",
+          "format": "int64",
+          "description": "FeeRate is the proportional fee in parts per million of the\nforwarded amount."
+        }
+      }
+    },
+    "FeePolicyUpdatesResponsePolicyUpdate": {
+      "type": "object",
+      "properties": {
+        "channel_id": {
+          "type": "string"
+        },
+        "node_id": {
+          "type": "string"
+        },
+        "old_policy": {
+          "$ref": "#/definitions/FeePolicyUpdatesResponseFeePolicy",
+          "description": "OldPolicy is the policy before the update, not set if it was\nunknown."
+        },
+        "new_policy": {
+          "$ref": "#/definitions/FeePolicyUpdatesResponseFeePolicy",
+          "description": "NewPolicy is the policy after the update."
+        },
+        "local_ratio": {
+          "type": "number",
+          "format": "double",
+          "description": "LocalRatio is the ratio of local balance to the channel balance\nat the moment of update."
+        },
+        "volume_ratio": {
+          "type": "number",
+          "format": "double",
+          "description": "VolumeRatio is the ratio of forwarded through the channel volume\nto the channel balance at the moment of update."
+        },
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "description": "Time is the time of the update."
+        },
+        "dry_run": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "DryRun is true if update was not applied."
+        }
+      }
+    },
+    "NetworkGraphResponseNode": {
+      "type": "object",
+      "properties": {
+        "node_id": {
+          "type": "string"
+        },
+        "alias": {
+          "type": "string"
+        },
+        "num_channels": {
+          "type": "integer",
+          "format": "int32",
+          "description": "NumChannels is number of node channels."
+        },
+        "capacity": {
+          "type": "string",
+          "description": "Capacity is the overall capacity of node channels."
+        }
+      }
+    },
+    "NodeCapacityHistoryResponsePoint": {
+      "type": "object",
+      "properties": {
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "description": "Time is the time since which node channels have the given\ncapacity."
+        },
+        "num_channels": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "capacity": {
+          "type": "string"
+        }
+      }
+    },
+    "NodeStatusChannelStats": {
+      "type": "object",
+      "properties": {
+        "locked_locally_active": {
+          "type": "number",
+          "format": "double",
+          "title": "LockedLocallyActive is number of funds aggregated from all channels,\nwhich could be used for send the payments. (In USD)"
+        },
+        "locked_remotely_active": {
+          "type": "number",
+          "format": "double",
+          "title": "LockedRemotelyActive is number of funds aggregated from all channels,\nwhich could be used for receiving the payments. (In USD)"
+        },
+        "locked_locally_overall": {
+          "type": "number",
+          "format": "double",
+          "title": "LockedLocallyOverall is number of funds aggregated from all channels,\nwhich are locked on local side. This number include pending, as well\nas active funds. (In USD)"
+        },
+        "locked_remotely_overall": {
+          "type": "number",
+          "format": "double",
+          "title": "LockedRemotelyOverall is number of funds aggregated from all channels,\nwhich are locked on remote side. This number include pending, as well\nas active funds. (In USD)"
+        }
+      }
+    },
+    "NodeStatusHealthStats": {
+      "type": "object",
+      "properties": {
+        "connected": {
+          "type": "boolean",
+          "format": "boolean",
+          "description": "Connected shows whether or not node is connected to us with\ntcp / ip connection."
+        },
+        "uptime": {
+          "type": "number",
+          "format": "double",
+          "description": "Uptime is the ratio of time during which node was connected\nto us, within the last week."
+        },
+        "last_seen": {
+          "type": "string",
+          "format": "int64",
+          "description": "LastSeen is the last time when node was connected to us,\nzero if node has never been seen."
+        },
+        "disconnects": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Disconnects is number of node disconnects within the last\nweek."
+        },
+        "last_announcement": {
+          "type": "string",
+          "format": "int64",
+          "description": "LastAnnouncement is the time of the last gossip announcement\nreceived from the node, zero if unknown."
+        },
+        "announcements_per_day": {
+          "type": "number",
+          "format": "double",
+          "description": "AnnouncementsPerDay is the average number of gossip\nannouncements received from the node per day, which shows\nthe spam rate of the node."
+        }
+      }
+    },
+    "NodeStatusPaymentsStats": {
+      "type": "object",
+      "properties": {
+        "average_sent_forward": {
+          "type": "number",
+          "format": "double",
+          "description": "NOTE: This metric will be non-zero if we have direct channel with node.",
+          "title": "AverageSentForwardSat is the number of funds which were sent\nto this node generated by forwarding activity in the network. (In USD)"
+        },
+        "average_received_forward": {
+          "type": "number",
+          "format": "double",
+          "description": "NOTE: This metric will be non-zero if we have direct channel with node.",
+          "title": "AverageReceivedForwardSat is the number of funds which were received\nfrom this node generated by forwarding activity in the network. (In USD)"
+        },
+        "average_sent": {
+          "type": "number",
+          "format": "double",
+          "title": "AverageSent is the number of funds which were sens from\nus to remote node, calculated over given period of time.\nFor example: average sent funds during the day, calculated over\nweek period. (In USD)"
+        },
+        "overall_sent_forward": {
+          "type": "number",
+          "format": "double"
+        },
+        "overall_received_forward": {
+          "type": "number",
+          "format": "double"
+        },
+        "overall_sent": {
+          "type": "number",
+          "format": "double"
+        },
+        "num_sent": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "num_received_forward": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "num_sent_forward": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "average_received": {
+          "type": "number",
+          "format": "double",
+          "title": "AverageReceived is the number of funds which were received by\nus from this node, i.e. payments to our invoices which arrived\nthrough channels with this node. (In USD)"
+        },
+        "overall_received": {
+          "type": "number",
+          "format": "double"
+        },
+        "num_received": {
+          "type": "integer",
+          "format": "int32"
+        }
+      }
+    },
+    "NodeStatusRankStats": {
+      "type": "object",
+      "properties": {
+        "rank_payments_sent_num": {
+          "type": "string",
+          "format": "int64"
+        },
+        "rank_payments_sent_volume": {
+          "type": "string",
+          "format": "int64"
+        },
+        "rank_idle": {
+          "type": "string",
+          "format": "int64"
+        },
+        "rank_forward_activity": {
+          "type": "string",
+          "format": "int64"
+        },
+        "rank_payments_received_volume": {
+          "type": "string",
+          "format": "int64"
+        }
+      }
+    },
+    "PaymentTimeSeriesResponseBucket": {
+      "type": "object",
+      "properties": {
+        "start": {
+          "type": "string",
+          "format": "int64",
+          "description": "Start is the beginning of the bucket, bucket ends where the next\none starts."
+        },
+        "sent_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "SentSat is number of funds which were sent from us to node,\nin satoshis."
+        },
+        "sent_forward_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "SentForwardSat is number of funds which were forwarded to node,\nin satoshis."
+        },
+        "received_forward_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "ReceivedForwardSat is number of funds which were received from\nnode for forwarding, in satoshis."
+        },
+        "num_sent": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "num_sent_forward": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "num_received_forward": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "received_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "ReceivedSat is number of funds which were received by us from\nnode, in satoshis."
+        },
+        "num_received": {
+          "type": "integer",
+          "format": "int32"
+        }
+      }
+    },
+    "PaymentTimeSeriesResponseSeries": {
+      "type": "object",
+      "properties": {
+        "node_id": {
+          "type": "string"
+        },
+        "domain": {
+          "type": "string",
+          "description": "Domain is the name given by us to the node, if it is known."
+        },
+        "buckets": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PaymentTimeSeriesResponseBucket"
+          }
+        }
+      }
+    },
+    "hubrpcBalance": {
+      "type": "object",
+      "properties": {
+        "available": {
+          "type": "string",
+          "description": "Available is the number of funds which could be used by this account\nto send funds to someone else."
+        },
+        "pending": {
+          "type": "string",
+          "description": "Pending funds in pending payment channels."
+        }
+      }
+    },
+    "hubrpcBalanceResponse": {
+      "type": "object",
+      "properties": {
+        "balances": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/hubrpcBalance"
+          }
+        }
+      }
+    },
+    "hubrpcBudgetResponse": {
+      "type": "object",
+      "properties": {
+        "budgets": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/BudgetResponseOperationBudget"
+          }
+        }
+      }
+    },
+    "hubrpcChannelsChangeResponse": {
+      "type": "object",
+      "properties": {
+        "reports": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ChannelsChangeResponseReport"
+          }
+        }
+      }
+    },
+    "hubrpcCheckNodeStatsResponse": {
+      "type": "object",
+      "properties": {
+        "statuses": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/CheckNodeStatsResponseNodeStatus"
+          }
+        },
+        "total": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Total is number of nodes which satisfy the filters, before the\noffset and limit are applied."
+        }
+      }
+    },
+    "hubrpcCreateInvoiceRequest": {
+      "type": "object",
+      "properties": {
+        "amount": {
+          "type": "string",
+          "description": "(optional) Amount is the amount which should be received on this\nreceipt, in bitcoin."
+        },
+        "description": {
+          "type": "string",
+          "description": "(optional) Description description will be placed in the invoice itself,\nwhich would allow user to see what he paid for later in the wallet."
+        },
+        "idempotency_key": {
+          "type": "string",
+          "description": "(optional) IdempotencyKey is the unique key of the request, generated\nby the client. Repeated request with the same key returns the\noriginally created invoice, and request with the same key but with\ndifferent parameters is rejected."
+        }
+      }
+    },
+    "hubrpcCreateInvoiceResponse": {
+      "type": "object",
+      "properties": {
+        "creation_date": {
+          "type": "string",
+          "format": "int64",
+          "description": "When this invoice was created.\nNOTE: Only returns for lightning network media."
+        },
+        "invoice": {
+          "type": "string",
+          "description": "Invoice it is lightning network invoice, which is the string which\ncontains amount, description, destination, and other info which is\nneeded for sender to successfully send payment."
+        },
+        "expiry": {
+          "type": "string",
+          "format": "int64",
+          "description": "Invoice expiry time in seconds. Default is 3600 (1 hour).\nNOTE: Only returns for lightning network media."
+        }
+      }
+    },
+    "hubrpcEconomicStats": {
+      "type": "object",
+      "properties": {
+        "num_channels": {
+          "type": "integer",
+          "format": "int32",
+          "description": "NumChannels is number of opened channels."
+        },
+        "num_closed_channels": {
+          "type": "integer",
+          "format": "int32",
+          "description": "NumClosedChannels is number of channels which are known to be closed."
+        },
+        "average_capacity": {
+          "type": "string"
+        },
+        "average_channel_age": {
+          "type": "string",
+          "format": "int64",
+          "description": "AverageChannelAge is the average time in seconds since the open of\ncurrently opened channels."
+        },
+        "average_closed_lifetime": {
+          "type": "string",
+          "format": "int64",
+          "description": "AverageClosedLifetime is the average time in seconds between open\nand close of closed channels."
+        },
+        "average_open_fee": {
+          "type": "string",
+          "description": "AverageOpenFee is the average miners fee of channel funding\ntransaction."
+        },
+        "average_close_fee": {
+          "type": "string",
+          "description": "AverageCloseFee is the average miners fee of channel closing\ntransaction."
+        },
+        "average_base_fee_msat": {
+          "type": "string",
+          "format": "int64"
+        },
+        "average_fee_rate_ppm": {
+          "type": "string",
+          "format": "int64"
+        }
+      }
+    },
+    "hubrpcEstimateFeeResponse": {
+      "type": "object",
+      "properties": {
+        "media_fee": {
+          "type": "string",
+          "description": "MediaFee is the fee which is taken by the lightning\nnetwork in order to propagate the payment."
+        }
+      }
+    },
+    "hubrpcExportLedgerResponse": {
+      "type": "object",
+      "properties": {
+        "csv": {
+          "type": "string",
+          "description": "Csv is the ledger in csv format, every posting of the entry is\nwritten as the separate row. Header is written only in the first\npage, so that pages could be concatenated in the single file."
+        },
+        "num_entries": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "total": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Total is number of entries in the ledger, before the offset and\nlimit are applied."
+        }
+      }
+    },
+    "hubrpcFeePolicyUpdatesResponse": {
+      "type": "object",
+      "properties": {
+        "updates": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FeePolicyUpdatesResponsePolicyUpdate"
+          }
+        }
+      }
+    },
+    "hubrpcInvoice": {
+      "type": "object",
+      "properties": {
+        "memo": {
+          "type": "string",
+          "description": "An optional memo to attach along with the invoice. Used for record keeping\npurposes for the invoice's creator, and will also be set in the\ndescription field of the encoded payment request if the\ndescription_hash field is not being used."
+        },
+        "value": {
+          "type": "string",
+          "description": "The value of this invoice in bitcoins."
+        },
+        "creation_date": {
+          "type": "string",
+          "format": "int64",
+          "description": "When this invoice was created."
+        },
+        "expiry": {
+          "type": "string",
+          "format": "int64",
+          "description": "Invoice expiry time in seconds. Default is 3600 (1 hour)."
+        },
+        "fallback_addr": {
+          "type": "string",
+          "description": "Fallback on-chain address in case of lightning network payment fail."
+        },
+        "destination": {
+          "type": "string",
+          "description": "Lightning Network public key of receiving node."
+        }
+      }
+    },
+    "hubrpcListPaymentsResponse": {
+      "type": "object",
+      "properties": {
+        "payments": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/hubrpcPayment"
+          }
+        }
+      }
+    },
+    "hubrpcNetworkAnomaliesResponse": {
+      "type": "object",
+      "properties": {
+        "anomalies": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/hubrpcNetworkAnomaliesResponseAnomaly"
+          }
+        }
+      }
+    },
+    "hubrpcNetworkAnomaliesResponseAnomaly": {
+      "type": "object",
+      "properties": {
+        "type": {
+          "type": "string",
+          "description": "Type is the type of anomaly, i.e. network_capacity,\nnode_capacity, node_uptime."
+        },
+        "node_id": {
+          "type": "string",
+          "description": "NodeId is the node to which anomaly is related, empty for network\nwide anomalies."
+        },
+        "domain": {
+          "type": "string",
+          "description": "Domain is the name given by us to the node, if it is known."
+        },
+        "time": {
+          "type": "string",
+          "format": "int64"
+        },
+        "previous": {
+          "type": "number",
+          "format": "double",
+          "description": "Previous is the value at the beginning of the window, i.e.\ncapacity in satoshis or online percentage."
+        },
+        "current": {
+          "type": "number",
+          "format": "double",
+          "description": "Current is the current value."
+        },
+        "change": {
+          "type": "number",
+          "format": "double",
+          "description": "Change is the relative change of capacity, or absolute change of\nonline percentage. Capacity change of the node which appeared\nwithin the window is 1."
+        },
+        "description": {
+          "type": "string"
+        }
+      }
+    },
+    "hubrpcNetworkGraphResponse": {
+      "type": "object",
+      "properties": {
+        "time": {
+          "type": "string",
+          "format": "int64"
+        },
+        "num_nodes": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "num_channels": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "capacity": {
+          "type": "string",
+          "description": "Capacity is the overall capacity of the network."
+        },
+        "nodes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NetworkGraphResponseNode"
+          },
+          "description": "Nodes is the list of nodes which have channels, sorted by capacity."
+        }
+      }
+    },
+    "hubrpcNetworkStatsResponse": {
+      "type": "object",
+      "properties": {
+        "time": {
+          "type": "string",
+          "format": "int64",
+          "description": "Time is the time when statistics has been calculated."
+        },
+        "num_nodes": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "capacity": {
+          "type": "string",
+          "description": "Capacity is the overall capacity of the network."
+        },
+        "network": {
+          "$ref": "#/definitions/hubrpcEconomicStats",
+          "description": "Network is the statistics of all channels in the network."
+        },
+        "our": {
+          "$ref": "#/definitions/hubrpcEconomicStats",
+          "description": "Our is the statistics of our channels."
+        }
+      }
+    },
+    "hubrpcNodeCapacityHistoryResponse": {
+      "type": "object",
+      "properties": {
+        "points": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/NodeCapacityHistoryResponsePoint"
+          }
+        }
+      }
+    },
+    "hubrpcPayment": {
+      "type": "object",
+      "properties": {
+        "payment_id": {
+          "type": "string",
+          "description": "PaymentID it is unique identificator of the payment generated inside\nthe system."
+        },
+        "updated_at": {
+          "type": "string",
+          "format": "int64",
+          "description": "UpdatedAt denotes the time when payment object has been last updated."
+        },
+        "status": {
+          "$ref": "#/definitions/hubrpcPaymentStatus",
+          "description": "Status denotes the stage of the processing the payment."
+        },
+        "direction": {
+          "$ref": "#/definitions/hubrpcPaymentDirection",
+          "description": "Direction denotes the direction of the payment, whether\npayment is going form us to someone else, or form someone else to us."
+        },
+        "system": {
+          "$ref": "#/definitions/hubrpcPaymentSystem",
+          "description": "System denotes is that payment belongs to business logic of\npayment server or it was originated by user / third-party service."
+        },
+        "invoice": {
+          "type": "string",
+          "description": "Invoice it is lightning network invoice, which is the string which\ncontains amount, description, destination, and other info which is\nneeded for sender to successfully send payment."
+        },
+        "payment_hash": {
+          "type": "string",
+          "description": "PaymentHash is the uniq identificator of the payment."
+        },
+        "amount": {
+          "type": "string",
+          "description": "Amount is the number of funds which receiver gets at the end in bitcoin."
+        },
+        "media_fee": {
+          "type": "string",
+          "description": "MediaFee is the fee which is taken by the lightning\nnetwork in order to propagate the payment."
+        }
+      }
+    },
+    "hubrpcPaymentDirection": {
+      "type": "string",
+      "enum": [
+        "DIRECTION_NONE",
+        "INCOMING",
+        "OUTGOING"
+      ],
+      "default": "DIRECTION_NONE",
+      "description": "PaymentDirection denotes the direction of the payment, whether payment is\n\tgoing form us to someone else, or form someone else to us.\n\n - INCOMING: INCOMING type of payment which service has received from someone else\nin the media.\n - OUTGOING: OUTGOING type of payment which service has sent to someone else in the\nmedia."
+    },
+    "hubrpcPaymentStatus": {
+      "type": "string",
+      "enum": [
+        "STATUS_NONE",
+        "WAITING",
+        "PENDING",
+        "COMPLETED",
+        "FAILED"
+      ],
+      "default": "STATUS_NONE",
+      "description": "PaymentStatus denotes the stage of the processing the payment.\n\n - WAITING: WAITING means that payment has been created and waiting to be approved\nfor sending.\n - PENDING: PENDING means that service is seeing the payment, but it not yet approved\nfrom the its POV.\n - COMPLETED: COMPLETED in case of outgoing/incoming payment this means that we\nsent/received the transaction in/from the network and it was confirmed\nnumber of times service believe sufficient. In case of the forward\ntransaction it means that we succesfully routed it through and\nearned fee for that.\n - FAILED: FAILED means that services has tryied to send payment for couple of\ntimes, but without success, and now service gave up."
+    },
+    "hubrpcPaymentSystem": {
+      "type": "string",
+      "enum": [
+        "SYSTEM_NONE",
+        "INTERNAL",
+        "EXTERNAL"
+      ],
+      "default": "SYSTEM_NONE",
+      "description": "PaymentSystemSystem denotes is that payment belongs to business logic of\npayment server or it was originated by user / third-party service.\n\n - INTERNAL: INTERNAL type of payment usually services the purpose of payment\nserver itself for stabilisation of system.\n - EXTERNAL: EXTERNAL type of payment which was originated by user / third-party\nservices, this is what usually interesting for external viewer. This\ntype of payment changes balance."
+    },
+    "hubrpcPaymentTimeSeriesResponse": {
+      "type": "object",
+      "properties": {
+        "series": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/PaymentTimeSeriesResponseSeries"
+          }
+        }
+      }
+    },
+    "hubrpcProfitability": {
+      "type": "object",
+      "properties": {
+        "channel_id": {
+          "type": "string",
+          "description": "ChannelID is the channel which economy is described, empty if\neconomy describes the peer or the whole node."
+        },
+        "node_id": {
+          "type": "string",
+          "description": "NodeID is the peer which economy is described, empty if economy\ndescribes the whole node."
+        },
+        "domain": {
+          "type": "string",
+          "description": "Domain is the name given by us to the node, if it is known."
+        },
+        "capacity_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "Capacity is the number of funds locked in channels, in satoshis."
+        },
+        "earned_forward_fee_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "EarnedForwardFee is the fee earned by forwarding payments, in\nsatoshis."
+        },
+        "payment_fee_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "PaymentFee is the routing fee paid for sending payments, including\nre-balancing payments, in satoshis."
+        },
+        "open_fee_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "OpenFee, CloseFee and SwipeFee is the on-chain fees of channel\nmanagement, in satoshis."
+        },
+        "close_fee_sat": {
+          "type": "string",
+          "format": "int64"
+        },
+        "swipe_fee_sat": {
+          "type": "string",
+          "format": "int64"
+        },
+        "sent_sat": {
+          "type": "string",
+          "format": "int64"
+        },
+        "received_sat": {
+          "type": "string",
+          "format": "int64"
+        },
+        "forwarded_sat": {
+          "type": "string",
+          "format": "int64"
+        },
+        "profit_sat": {
+          "type": "string",
+          "format": "int64",
+          "description": "Profit is the difference between earned and spent fees, in satoshis."
+        },
+        "roi": {
+          "type": "number",
+          "format": "double",
+          "description": "ROI is the profit relative to the capacity."
+        },
+        "quality_ratio": {
+          "type": "number",
+          "format": "double",
+          "description": "QualityRatio is the fee spent to move 1 sat."
+        }
+      }
+    },
+    "hubrpcProfitabilityReportResponse": {
+      "type": "object",
+      "properties": {
+        "start": {
+          "type": "string",
+          "format": "int64"
+        },
+        "end": {
+          "type": "string",
+          "format": "int64"
+        },
+        "channels": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/hubrpcProfitability"
+          },
+          "description": "Channels and Peers is sorted by profit, the least profitable first."
+        },
+        "peers": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/hubrpcProfitability"
+          }
+        },
+        "total": {
+          "$ref": "#/definitions/hubrpcProfitability",
+          "description": "Total is the economy of the whole node."
+        }
+      }
+    },
+    "hubrpcSendPaymentRequest": {
+      "type": "object",
+      "properties": {
+        "amount": {
+          "type": "string",
+          "description": "Amount is number of money which should be given to the another entity,\nin bitcoin."
+        },
+        "invoice": {
+          "type": "string",
+          "description": "Invoice it is lightning network invoice, which is the string which\ncontains amount, description, destination, and other info which is\nneeded for sender to successfully send payment."
+        },
+        "idempotency_key": {
+          "type": "string",
+          "description": "(optional) IdempotencyKey is the unique key of the request, generated\nby the client. Repeated request with the same key returns the result\nof the original payment instead of sending it again, and request with\nthe same key but with different parameters is rejected."
+        }
+      }
+    },
+    "hubrpcValidateInvoiceResponse": {
+      "type": "object",
+      "properties": {
+        "invoice": {
+          "$ref": "#/definitions/hubrpcInvoice",
+          "description": "Invoice it is lightning network invoice, which is the string which\ncontains amount, description, destination, and other info which is\nneeded for sender to successfully send payment."
+        }
+      }
+    },
+    "hubrpcGatewayError": {
+      "type": "object",
+      "properties": {
+        "error": {
+          "type": "string",
+          "description": "Error is the description of the error."
+        },
+        "code": {
+          "type": "integer",
+          "format": "int32",
+          "description": "Code is the gRPC status code of the error."
+        },
+        "details": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/protobufAny"
+          },
+          "description": "Details is the details of the gRPC status, hub attaches\ngoogle.rpc.ErrorInfo with the \"hub\" domain, which is rendered as\n{\"@type\": \"type.googleapis.com/google.rpc.ErrorInfo\", \"reason\":\n\"REASON_RATE_LIMITED\", \"domain\": \"hub\", \"metadata\": {\"retryable\":\n\"true\"}}. Details are empty if error happened in the gateway itself."
+        }
+      },
+      "description": "GatewayError is the body of the failed response of the REST gateway."
+    },
+    "protobufAny": {
+      "type": "object",
+      "properties": {
+        "type_url": {
+          "type": "string",
+          "description": "A URL/resource name that uniquely identifies the type of the serialized\nprotocol buffer message. This string must contain at least\none \"/\" character. The last segment of the URL's path must represent\nthe fully qualified name of the type (as in\n` + "`" + `path/google.protobuf.Duration` + "`" + `). The name should be in a canonical form\n(e.g., leading \".\" is not accepted).\n\nIn practice, teams usually precompile into the binary all types that they\nexpect it to use in the context of Any. However, for URLs which use the\nscheme ` + "`" + `http` + "`" + `, ` + "`" + `https` + "`" + `, or no scheme, one can optionally set up a type\nserver that maps type URLs to message definitions as follows:\n\n* If no scheme is provided, ` + "`" + `https` + "`" + ` is assumed.\n* An HTTP GET on the URL must yield a [google.protobuf.Type][]\n  value in binary format, or produce an error.\n* Applications are allowed to cache lookup results based on the\n  URL, or have them precompiled into a binary to avoid any\n  lookup. Therefore, binary compatibility needs to be preserved\n  on changes to types. (Use versioned type names to manage\n  breaking changes.)\n\nNote: this functionality is not currently available in the official\nprotobuf release, and it is not used for type URLs beginning with\ntype.googleapis.com.\n\nSchemes other than ` + "`" + `http` + "`" + `, ` + "`" + `https` + "`" + ` (or the empty scheme) might be\nused with implementation specific semantics."
+        },
+        "value": {
+          "type": "string",
+          "format": "byte",
+          "description": "Must be a valid serialized protocol buffer of the above specified type."
+        }
+      },
+      "description": "` + "`" + `Any` + "`" + ` contains an arbitrary serialized protocol buffer message along with a\nURL that describes the type of the serialized message.\n\nProtobuf library provides support to pack/unpack Any values in the form\nof utility functions or additional generated methods of the Any type.\n\nExample 1: Pack and unpack a message in C++.\n\n    Foo foo = ...;\n    Any any;\n    any.PackFrom(foo);\n    ...\n    if (any.UnpackTo(&foo)) {\n      ...\n    }\n\nExample 2: Pack and unpack a message in Java.\n\n    Foo foo = ...;\n    Any any = Any.pack(foo);\n    ...\n    if (any.is(Foo.class)) {\n      foo = any.unpack(Foo.class);\n    }\n\n Example 3: Pack and unpack a message in Python.\n\n    foo = Foo(...)\n    any = Any()\n    any.Pack(foo)\n    ...\n    if any.Is(Foo.DESCRIPTOR):\n      any.Unpack(foo)\n      ...\n\n Example 4: Pack and unpack a message in Go\n\n     foo := &pb.Foo{...}\n     any, err := ptypes.MarshalAny(foo)\n     ...\n     foo := &pb.Foo{}\n     if err := ptypes.UnmarshalAny(any, foo); err != nil {\n       ...\n     }\n\nThe pack methods provided by protobuf library will by default use\n'type.googleapis.com/full.type.name' as the type URL and the unpack\nmethods only use the fully qualified type name after the last '/'\nin the type URL, for example \"foo.bar.com/x/y.z\" will yield type\nname \"y.z\".\n\n\nJSON\n====\nThe JSON representation of an ` + "`" + `Any` + "`" + ` value uses the regular\nrepresentation of the deserialized, embedded message, with an\nadditional field ` + "`" + `@type` + "`" + ` which contains the type URL. Example:\n\n    package google.profile;\n    message Person {\n      string first_name = 1;\n      string last_name = 2;\n    }\n\n    {\n      \"@type\": \"type.googleapis.com/google.profile.Person\",\n      \"firstName\": <string>,\n      \"lastName\": <string>\n    }\n\nIf the embedded message type is well-known and has a custom JSON\nrepresentation, that representation will be embedded adding a field\n` + "`" + `value` + "`" + ` which holds the custom JSON in addition to the ` + "`" + `@type` + "`" + `\nfield. Example (for message [google.protobuf.Duration][]):\n\n    {\n      \"@type\": \"type.googleapis.com/google.protobuf.Duration\",\n      \"value\": \"1.212s\"\n    }"
+    }
+  }
+}
+`
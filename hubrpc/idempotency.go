@@ -0,0 +1,181 @@
+package hubrpc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/bitlum/hub/db"
+	"github.com/go-errors/errors"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"sync"
+	"time"
+)
+
+// maxIdempotencyKeyLength is the maximum length of the idempotency key,
+// which could be specified by the client.
+const maxIdempotencyKeyLength = 256
+
+// IdempotencyStorage is used to persist results of the requests with
+// idempotency key, so that they are not executed twice after restart.
+type IdempotencyStorage interface {
+	// IdempotentResult returns the result of the method request with the
+	// given idempotency key, nil is returned if there is no such result.
+	IdempotentResult(method, key string) (*db.IdempotentResult, error)
+
+	// AddIdempotentResult saves the result of the request.
+	AddIdempotentResult(result *db.IdempotentResult) error
+
+	// RemoveIdempotentResults removes results of requests which were
+	// executed before the given time.
+	RemoveIdempotentResults(before int64) error
+}
+
+// idempotency ensures that request with idempotency key is executed only
+// once within TTL, and the original result is returned on retries.
+type idempotency struct {
+	storage IdempotencyStorage
+	ttl     time.Duration
+
+	// pending is the set of requests which are being executed, is used to
+	// reject concurrent retries.
+	mutex   sync.Mutex
+	pending map[string]struct{}
+}
+
+// newIdempotency creates new idempotency guard, if storage is nil requests
+// are executed without checking of the idempotency key.
+func newIdempotency(storage IdempotencyStorage,
+	ttl time.Duration) *idempotency {
+
+	return &idempotency{
+		storage: storage,
+		ttl:     ttl,
+		pending: make(map[string]struct{}),
+	}
+}
+
+// hashRequest returns hash of the serialised request parameters.
+func hashRequest(req proto.Message) (string, error) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return "", errors.Errorf("unable to encode request: %v", err)
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// scopeKey returns the idempotency key within the scope of the client
+// credential, so that clients with different macaroons couldn't receive
+// results of each other. If macaroons are disabled all clients share the
+// same scope.
+func scopeKey(ctx context.Context, key string) string {
+	if hash, ok := macaroonHash(ctx); ok {
+		return hash + ":" + key
+	}
+
+	return key
+}
+
+// execute executes the request, or if request with the same idempotency key
+// has already been executed by the same client credential within TTL,
+// decodes its result in the given response. Request with the same key but
+// different parameters is rejected.
+func (i *idempotency) execute(ctx context.Context, method, key string,
+	req proto.Message, resp proto.Message,
+	execute func() (proto.Message, error)) (proto.Message, error) {
+
+	if key == "" || i.storage == nil {
+		return execute()
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, newErrInvalidArgument("idempotency_key", "length "+
+			"of the key shouldn't be greater than %v",
+			maxIdempotencyKeyLength)
+	}
+
+	// Client sees only its own key, scoped key is used for storage.
+	clientKey := key
+	key = scopeKey(ctx, key)
+
+	// Key is the part of the request, but it is the same for the requests
+	// which are compared, so the whole request is hashed.
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		return nil, newErrInternal(err.Error())
+	}
+
+	pendingKey := method + ":" + key
+
+	i.mutex.Lock()
+	if _, ok := i.pending[pendingKey]; ok {
+		i.mutex.Unlock()
+		return nil, newErrRequestInProgress(clientKey)
+	}
+	i.pending[pendingKey] = struct{}{}
+	i.mutex.Unlock()
+
+	defer func() {
+		i.mutex.Lock()
+		delete(i.pending, pendingKey)
+		i.mutex.Unlock()
+	}()
+
+	now := time.Now()
+
+	result, err := i.storage.IdempotentResult(method, key)
+	if err != nil {
+		return nil, newErrInternal(err.Error())
+	}
+
+	// Expired results are ignored, they will be removed on the next save.
+	if result != nil && now.Sub(time.Unix(result.Time, 0)) <= i.ttl {
+		if result.RequestHash != requestHash {
+			return nil, newErrIdempotencyKeyReused(clientKey)
+		}
+
+		if err := proto.Unmarshal(result.Response, resp); err != nil {
+			return nil, newErrInternal(fmt.Sprintf("unable to decode "+
+				"stored response: %v", err))
+		}
+
+		log.Infof("Request(%v) with idempotency key(%v) has already been "+
+			"executed, returning original result", method, key)
+
+		return resp, nil
+	}
+
+	newResp, err := execute()
+	if err != nil {
+		return nil, err
+	}
+
+	// Request has already been executed, so failure to save the result
+	// shouldn't fail it.
+	data, err := proto.Marshal(newResp)
+	if err != nil {
+		log.Errorf("unable to encode result of request(%v) with "+
+			"idempotency key(%v): %v", method, key, err)
+		return newResp, nil
+	}
+
+	if err := i.storage.RemoveIdempotentResults(now.Add(-i.ttl).Unix()); err != nil {
+		log.Errorf("unable to remove expired idempotent results: %v", err)
+	}
+
+	err = i.storage.AddIdempotentResult(&db.IdempotentResult{
+		Method:      method,
+		Key:         key,
+		RequestHash: requestHash,
+		Response:    data,
+		Time:        now.Unix(),
+	})
+	if err != nil {
+		log.Errorf("unable to save result of request(%v) with idempotency "+
+			"key(%v): %v", method, key, err)
+	}
+
+	return newResp, nil
+}
@@ -0,0 +1,289 @@
+package hubrpc
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/metrics/rpc"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockIdempotencyStorage is the in-memory storage of the idempotent
+// results.
+type mockIdempotencyStorage struct {
+	mutex   sync.Mutex
+	results map[string]*db.IdempotentResult
+}
+
+func newMockIdempotencyStorage() *mockIdempotencyStorage {
+	return &mockIdempotencyStorage{
+		results: make(map[string]*db.IdempotentResult),
+	}
+}
+
+func (s *mockIdempotencyStorage) IdempotentResult(method,
+	key string) (*db.IdempotentResult, error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.results[method+":"+key], nil
+}
+
+func (s *mockIdempotencyStorage) AddIdempotentResult(
+	result *db.IdempotentResult) error {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.results[result.Method+":"+result.Key] = result
+	return nil
+}
+
+func (s *mockIdempotencyStorage) RemoveIdempotentResults(before int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key, result := range s.results {
+		if result.Time < before {
+			delete(s.results, key)
+		}
+	}
+	return nil
+}
+
+// expire moves results of requests back in time by the given duration.
+func (s *mockIdempotencyStorage) expire(d time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, result := range s.results {
+		result.Time -= int64(d.Seconds())
+	}
+}
+
+// withMacaroon returns context of the request with the given macaroon.
+func withMacaroon(macaroon string) context.Context {
+	return metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(macaroonMetadataKey, macaroon))
+}
+
+// checkReason checks that error is the hub rpc error with the given reason.
+func checkReason(t *testing.T, name string, err error, reason ErrorReason) {
+	e, ok := err.(Error)
+	if !ok || e.reason != reason {
+		t.Fatalf("(%v) expected error with reason(%v), got: %v", name,
+			reason, err)
+	}
+}
+
+func TestIdempotencyExecute(t *testing.T) {
+	storage := newMockIdempotencyStorage()
+	i := newIdempotency(storage, time.Hour)
+
+	numExecuted := 0
+	execute := func(req *CreateInvoiceRequest,
+		ctx context.Context) (*CreateInvoiceResponse, error) {
+
+		result, err := i.execute(ctx, "CreateInvoice", req.IdempotencyKey,
+			req, &CreateInvoiceResponse{}, func() (proto.Message, error) {
+				numExecuted++
+				return &CreateInvoiceResponse{
+					Invoice: req.Description + string(rune('0'+numExecuted)),
+				}, nil
+			})
+		if err != nil {
+			return nil, err
+		}
+
+		return result.(*CreateInvoiceResponse), nil
+	}
+
+	req := &CreateInvoiceRequest{
+		Amount:         "0.1",
+		Description:    "a",
+		IdempotencyKey: "key",
+	}
+	ctx := withMacaroon("first")
+
+	resp, err := execute(req, ctx)
+	if err != nil {
+		t.Fatalf("unable to execute request: %v", err)
+	}
+
+	// Retry should return the original result without execution.
+	retryResp, err := execute(req, ctx)
+	if err != nil {
+		t.Fatalf("unable to retry request: %v", err)
+	}
+
+	if numExecuted != 1 || retryResp.Invoice != resp.Invoice {
+		t.Fatalf("request was executed again: %v, %v", numExecuted,
+			retryResp.Invoice)
+	}
+
+	// Reuse of the key with different parameters should be rejected.
+	_, err = execute(&CreateInvoiceRequest{
+		Amount:         "0.2",
+		Description:    "a",
+		IdempotencyKey: "key",
+	}, ctx)
+	checkReason(t, "key reuse", err, ErrorReason_REASON_IDEMPOTENCY_KEY_REUSED)
+
+	// The same key of the other credential is independent.
+	otherResp, err := execute(req, withMacaroon("second"))
+	if err != nil {
+		t.Fatalf("unable to execute request of other credential: %v", err)
+	}
+
+	if numExecuted != 2 || otherResp.Invoice == resp.Invoice {
+		t.Fatalf("request of other credential wasn't executed: %v, %v",
+			numExecuted, otherResp.Invoice)
+	}
+
+	// Request without key is always executed.
+	if _, err := execute(&CreateInvoiceRequest{}, ctx); err != nil {
+		t.Fatalf("unable to execute request without key: %v", err)
+	}
+
+	if _, err := execute(&CreateInvoiceRequest{}, ctx); err != nil {
+		t.Fatalf("unable to execute request without key: %v", err)
+	}
+
+	if numExecuted != 4 {
+		t.Fatalf("request without key wasn't executed: %v", numExecuted)
+	}
+
+	// After TTL the key could be used again, even with other parameters.
+	storage.expire(time.Hour + time.Second)
+
+	expiredResp, err := execute(&CreateInvoiceRequest{
+		Amount:         "0.2",
+		Description:    "a",
+		IdempotencyKey: "key",
+	}, ctx)
+	if err != nil {
+		t.Fatalf("unable to execute request with expired key: %v", err)
+	}
+
+	if numExecuted != 5 || expiredResp.Invoice == resp.Invoice {
+		t.Fatalf("request with expired key wasn't executed: %v, %v",
+			numExecuted, expiredResp.Invoice)
+	}
+
+	// Too long key should be rejected.
+	key := make([]byte, maxIdempotencyKeyLength+1)
+	for j := range key {
+		key[j] = 'a'
+	}
+
+	_, err = execute(&CreateInvoiceRequest{
+		IdempotencyKey: string(key),
+	}, ctx)
+	checkReason(t, "long key", err, ErrorReason_REASON_INVALID_ARGUMENT)
+}
+
+func TestIdempotencyInProgress(t *testing.T) {
+	i := newIdempotency(newMockIdempotencyStorage(), time.Hour)
+	req := &CreateInvoiceRequest{IdempotencyKey: "key"}
+	ctx := withMacaroon("first")
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := i.execute(ctx, "CreateInvoice", req.IdempotencyKey, req,
+			&CreateInvoiceResponse{}, func() (proto.Message, error) {
+				close(started)
+				<-release
+				return &CreateInvoiceResponse{Invoice: "a"}, nil
+			})
+		done <- err
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("request wasn't started")
+	}
+
+	// Concurrent retry should be rejected while original request is in
+	// progress.
+	_, err := i.execute(ctx, "CreateInvoice", req.IdempotencyKey, req,
+		&CreateInvoiceResponse{}, func() (proto.Message, error) {
+			t.Fatalf("concurrent request shouldn't be executed")
+			return nil, nil
+		})
+	checkReason(t, "in progress", err, ErrorReason_REASON_REQUEST_IN_PROGRESS)
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("unable to execute request: %v", err)
+	}
+
+	// After completion retry receives the original result.
+	resp, err := i.execute(ctx, "CreateInvoice", req.IdempotencyKey, req,
+		&CreateInvoiceResponse{}, func() (proto.Message, error) {
+			t.Fatalf("completed request shouldn't be executed")
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatalf("unable to retry request: %v", err)
+	}
+
+	if resp.(*CreateInvoiceResponse).Invoice != "a" {
+		t.Fatalf("wrong result of retry: %v", resp)
+	}
+}
+
+func TestSendPaymentIdempotency(t *testing.T) {
+	hub := NewHub(&Config{
+		MetricsBackend:     &rpc.EmptyBackend{},
+		IdempotencyStorage: newMockIdempotencyStorage(),
+		IdempotencyTTL:     time.Hour,
+	})
+	ctx := withMacaroon("first")
+
+	key := make([]byte, maxIdempotencyKeyLength+1)
+	for j := range key {
+		key[j] = 'a'
+	}
+
+	tests := []struct {
+		name   string
+		req    *SendPaymentRequest
+		reason ErrorReason
+	}{
+		{
+			name: "invalid amount",
+			req: &SendPaymentRequest{
+				Amount:         "-",
+				IdempotencyKey: "key",
+			},
+			reason: ErrorReason_REASON_INVALID_ARGUMENT,
+		},
+		{
+			name: "long key",
+			req: &SendPaymentRequest{
+				IdempotencyKey: string(key),
+			},
+			reason: ErrorReason_REASON_INVALID_ARGUMENT,
+		},
+		{
+			name: "valid key",
+			req: &SendPaymentRequest{
+				IdempotencyKey: "key",
+			},
+			reason: ErrorReason_REASON_NOT_IMPLEMENTED,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := hub.SendPayment(ctx, test.req)
+		checkReason(t, test.name, err, test.reason)
+	}
+}
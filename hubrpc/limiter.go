@@ -0,0 +1,225 @@
+package hubrpc
+
+import (
+	"fmt"
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/metrics/rpc"
+	"github.com/go-errors/errors"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"net"
+	"path"
+	"sync"
+)
+
+// expensiveMethods is the set of methods which fetch the whole history of
+// payments, forwards, channels or channels snapshots, and therefore have
+// additional per method limit and limit of concurrent requests.
+var expensiveMethods = map[string]struct{}{
+	"/hubrpc.Hub/CheckNodeStats":      {},
+	"/hubrpc.Hub/NetworkGraph":        {},
+	"/hubrpc.Hub/PaymentTimeSeries":   {},
+	"/hubrpc.Hub/ProfitabilityReport": {},
+	"/hubrpc.Hub/ExportLedger":        {},
+	"/hubrpc.Hub/NodeCapacityHistory": {},
+	"/hubrpc.Hub/NetworkStats":        {},
+	"/hubrpc.Hub/ListPayments":        {},
+	"/hubrpc.Hub/Budget":              {},
+	"/hubrpc.Hub/ChannelsChange":      {},
+}
+
+const (
+	// limitCredential is the name of the limit of all requests of the
+	// client credential.
+	limitCredential = "credential"
+
+	// limitMethod is the name of the limit of expensive method requests of
+	// the client credential.
+	limitMethod = "method"
+
+	// limitConcurrency is the name of the limit of concurrent expensive
+	// requests.
+	limitConcurrency = "concurrency"
+)
+
+// LimiterConfig is the config of the hub rpc requests limiter.
+type LimiterConfig struct {
+	// CredentialRate is the number of requests per second which are
+	// allowed for the single client credential, with the burst of
+	// CredentialBurst requests.
+	CredentialRate  float64
+	CredentialBurst int
+
+	// MethodRate is the number of requests per second of every expensive
+	// method which are allowed for the single client credential, with the
+	// burst of MethodBurst requests.
+	MethodRate  float64
+	MethodBurst int
+
+	// MaxConcurrentExpensive is the maximum number of expensive requests
+	// of all clients which are processed concurrently.
+	MaxConcurrentExpensive int
+
+	// MetricsBackend is used to report rejected requests, and number of
+	// concurrent expensive requests.
+	MetricsBackend rpc.MetricsBackend
+}
+
+func (c *LimiterConfig) validate() error {
+	if c.CredentialRate <= 0 || c.CredentialBurst <= 0 {
+		return errors.New("credential rate and burst should be positive")
+	}
+
+	if c.MethodRate <= 0 || c.MethodBurst <= 0 {
+		return errors.New("method rate and burst should be positive")
+	}
+
+	if c.MaxConcurrentExpensive <= 0 {
+		return errors.New("max concurrent expensive requests should be " +
+			"positive")
+	}
+
+	if c.MetricsBackend == nil {
+		return errors.New("metric backend should be specified")
+	}
+
+	return nil
+}
+
+// Limiter rejects requests which exceed the rate limits of the client
+// credential, and expensive requests if too many of them are processed
+// concurrently, so that single client couldn't overload the hub and lnd.
+type Limiter struct {
+	cfg *LimiterConfig
+
+	credentials *common.RateLimiter
+	methods     *common.RateLimiter
+
+	mutex        sync.Mutex
+	numExpensive int
+}
+
+// NewLimiter creates new hub rpc requests limiter.
+func NewLimiter(cfg *LimiterConfig) (*Limiter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Errorf("config is invalid: %v", err)
+	}
+
+	return &Limiter{
+		cfg:         cfg,
+		credentials: common.NewRateLimiter(cfg.CredentialRate, cfg.CredentialBurst),
+		methods:     common.NewRateLimiter(cfg.MethodRate, cfg.MethodBurst),
+	}, nil
+}
+
+// credentialKey returns the key of the client credential, which is the
+// hash of the request macaroon, or the client ip address if macaroons are
+// disabled.
+func credentialKey(ctx context.Context) string {
+	if hash, ok := macaroonHash(ctx); ok {
+		return "macaroon:" + hash
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "ip:" + host
+	}
+
+	return ""
+}
+
+// acquireExpensive takes the slot of the concurrent expensive request,
+// returns false if all slots are taken.
+func (l *Limiter) acquireExpensive() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.numExpensive >= l.cfg.MaxConcurrentExpensive {
+		return false
+	}
+
+	l.numExpensive++
+	l.cfg.MetricsBackend.SetExpensiveRequests(l.numExpensive)
+	return true
+}
+
+// releaseExpensive releases the slot of the concurrent expensive request.
+func (l *Limiter) releaseExpensive() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.numExpensive--
+	l.cfg.MetricsBackend.SetExpensiveRequests(l.numExpensive)
+}
+
+// reject reports the rejected request, and returns resource exhausted
+// error.
+func (l *Limiter) reject(method, limit, desc string) error {
+	request := path.Base(method)
+	l.cfg.MetricsBackend.AddLimitedRequest(request, limit)
+
+	err := newErrRateLimited(desc)
+	log.Errorf("command(%v), rejected by %v limit: %v", request, limit,
+		err)
+
+	return err
+}
+
+// UnaryServerInterceptor rejects requests which exceed the rate limits, or
+// limit of concurrent expensive requests, with resource exhausted status.
+func (l *Limiter) UnaryServerInterceptor(ctx context.Context,
+	req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+
+	key := credentialKey(ctx)
+
+	if !l.credentials.Allow(key) {
+		return nil, l.reject(info.FullMethod, limitCredential,
+			fmt.Sprintf("too many requests, only %v requests per "+
+				"second are allowed", l.cfg.CredentialRate))
+	}
+
+	if _, ok := expensiveMethods[info.FullMethod]; !ok {
+		return handler(ctx, req)
+	}
+
+	if !l.methods.Allow(key + info.FullMethod) {
+		return nil, l.reject(info.FullMethod, limitMethod,
+			fmt.Sprintf("too many requests of method, only %v requests "+
+				"per second are allowed", l.cfg.MethodRate))
+	}
+
+	if !l.acquireExpensive() {
+		return nil, l.reject(info.FullMethod, limitConcurrency,
+			"too many concurrent expensive requests")
+	}
+	defer l.releaseExpensive()
+
+	return handler(ctx, req)
+}
+
+// ChainUnaryServerInterceptors returns interceptor which calls the given
+// interceptors in order, because gRPC server accepts only one of them.
+func ChainUnaryServerInterceptors(
+	interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+
+	return func(ctx context.Context, req interface{},
+		info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{},
+		error) {
+
+		chain := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], chain
+			chain = func(ctx context.Context, req interface{}) (interface{},
+				error) {
+				return interceptor(ctx, req, info, next)
+			}
+		}
+
+		return chain(ctx, req)
+	}
+}
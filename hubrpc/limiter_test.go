@@ -0,0 +1,267 @@
+package hubrpc
+
+import (
+	"github.com/bitlum/hub/metrics/rpc"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockMetricsBackend is the metrics backend which counts rejected requests
+// per limit.
+type mockMetricsBackend struct {
+	rpc.EmptyBackend
+
+	mutex        sync.Mutex
+	limited      map[string]int
+	numExpensive int
+}
+
+func (b *mockMetricsBackend) AddLimitedRequest(request string, limit string) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.limited[limit]++
+}
+
+func (b *mockMetricsBackend) SetExpensiveRequests(num int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.numExpensive = num
+}
+
+func newTestLimiter(t *testing.T, maxConcurrent int) (*Limiter,
+	*mockMetricsBackend) {
+
+	backend := &mockMetricsBackend{
+		limited: make(map[string]int),
+	}
+
+	// Rates are small enough for tokens not to be refilled during the
+	// test, so only burst is taken into account.
+	limiter, err := NewLimiter(&LimiterConfig{
+		CredentialRate:         0.001,
+		CredentialBurst:        3,
+		MethodRate:             0.001,
+		MethodBurst:            1,
+		MaxConcurrentExpensive: maxConcurrent,
+		MetricsBackend:         backend,
+	})
+	if err != nil {
+		t.Fatalf("unable to create limiter: %v", err)
+	}
+
+	return limiter, backend
+}
+
+// call calls the method through the limiter with the given handler.
+func call(l *Limiter, ctx context.Context, method string,
+	handler grpc.UnaryHandler) error {
+
+	_, err := l.UnaryServerInterceptor(ctx, nil,
+		&grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return err
+}
+
+func emptyHandler(ctx context.Context, req interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func TestLimiterRate(t *testing.T) {
+	l, backend := newTestLimiter(t, 10)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		method  string
+		limit   string
+		limited bool
+	}{
+		{
+			name:   "expensive method",
+			ctx:    withMacaroon("first"),
+			method: "/hubrpc.Hub/CheckNodeStats",
+		},
+		{
+			name:    "expensive method over method burst",
+			ctx:     withMacaroon("first"),
+			method:  "/hubrpc.Hub/CheckNodeStats",
+			limit:   limitMethod,
+			limited: true,
+		},
+		{
+			name:   "cheap method isn't limited per method",
+			ctx:    withMacaroon("first"),
+			method: "/hubrpc.Hub/Balance",
+		},
+		{
+			name:    "over credential burst",
+			ctx:     withMacaroon("first"),
+			method:  "/hubrpc.Hub/Balance",
+			limit:   limitCredential,
+			limited: true,
+		},
+		{
+			name:   "other credential has own limits",
+			ctx:    withMacaroon("second"),
+			method: "/hubrpc.Hub/CheckNodeStats",
+		},
+	}
+
+	for _, test := range tests {
+		err := call(l, test.ctx, test.method, emptyHandler)
+
+		if !test.limited {
+			if err != nil {
+				t.Fatalf("(%v) request shouldn't be limited: %v",
+					test.name, err)
+			}
+			continue
+		}
+
+		checkReason(t, test.name, err, ErrorReason_REASON_RATE_LIMITED)
+		if backend.limited[test.limit] != 1 {
+			t.Fatalf("(%v) rejected request wasn't reported by %v "+
+				"limit", test.name, test.limit)
+		}
+	}
+}
+
+func TestLimiterConcurrency(t *testing.T) {
+	l, backend := newTestLimiter(t, 1)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+
+	go func() {
+		done <- call(l, withMacaroon("first"), "/hubrpc.Hub/NetworkStats",
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				close(started)
+				<-release
+				return nil, nil
+			})
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(time.Second * 5):
+		t.Fatalf("request wasn't started")
+	}
+
+	// Expensive request of other credential should be rejected while the
+	// slot is taken, but cheap request should be processed.
+	err := call(l, withMacaroon("second"), "/hubrpc.Hub/NodeCapacityHistory",
+		emptyHandler)
+	checkReason(t, "concurrent", err, ErrorReason_REASON_RATE_LIMITED)
+
+	if backend.limited[limitConcurrency] != 1 {
+		t.Fatalf("rejected request wasn't reported by concurrency limit")
+	}
+
+	err = call(l, withMacaroon("second"), "/hubrpc.Hub/Balance", emptyHandler)
+	if err != nil {
+		t.Fatalf("cheap request shouldn't be limited: %v", err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("unable to process request: %v", err)
+	}
+
+	if backend.numExpensive != 0 {
+		t.Fatalf("slot of expensive request wasn't released: %v",
+			backend.numExpensive)
+	}
+
+	err = call(l, withMacaroon("third"), "/hubrpc.Hub/NodeCapacityHistory",
+		emptyHandler)
+	if err != nil {
+		t.Fatalf("request should be processed after release: %v", err)
+	}
+}
+
+func TestChainUnaryServerInterceptors(t *testing.T) {
+	var calls []string
+
+	newInterceptor := func(name string,
+		reject bool) grpc.UnaryServerInterceptor {
+
+		return func(ctx context.Context, req interface{},
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler) (interface{}, error) {
+
+			calls = append(calls, name)
+			if reject {
+				return nil, newErrRateLimited(name)
+			}
+
+			return handler(ctx, req)
+		}
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{},
+		error) {
+
+		calls = append(calls, "handler")
+		return req, nil
+	}
+
+	tests := []struct {
+		name         string
+		interceptors []grpc.UnaryServerInterceptor
+		calls        []string
+		rejected     bool
+	}{
+		{
+			name:  "no interceptors",
+			calls: []string{"handler"},
+		},
+		{
+			name: "interceptors are called in order",
+			interceptors: []grpc.UnaryServerInterceptor{
+				newInterceptor("a", false),
+				newInterceptor("b", false),
+			},
+			calls: []string{"a", "b", "handler"},
+		},
+		{
+			name: "rejection stops the chain",
+			interceptors: []grpc.UnaryServerInterceptor{
+				newInterceptor("a", true),
+				newInterceptor("b", false),
+			},
+			calls:    []string{"a"},
+			rejected: true,
+		},
+	}
+
+	for _, test := range tests {
+		calls = nil
+
+		chain := ChainUnaryServerInterceptors(test.interceptors...)
+		resp, err := chain(context.Background(), "req",
+			&grpc.UnaryServerInfo{}, handler)
+
+		if (err != nil) != test.rejected {
+			t.Fatalf("(%v) wrong rejection: %v", test.name, err)
+		}
+
+		if !test.rejected && resp != "req" {
+			t.Fatalf("(%v) wrong response: %v", test.name, resp)
+		}
+
+		if len(calls) != len(test.calls) {
+			t.Fatalf("(%v) wrong calls: %v", test.name, calls)
+		}
+
+		for i, call := range calls {
+			if call != test.calls[i] {
+				t.Fatalf("(%v) wrong calls: %v", test.name, calls)
+			}
+		}
+	}
+}
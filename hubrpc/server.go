@@ -1,21 +1,31 @@
 package hubrpc
 
 import (
+	"bytes"
 	"encoding/hex"
 	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/fees"
 	"github.com/bitlum/hub/lightning"
 	"github.com/bitlum/hub/manager"
 	"github.com/bitlum/hub/manager/stats"
 	"github.com/bitlum/hub/metrics"
 	"github.com/bitlum/hub/metrics/rpc"
+	"github.com/bitlum/hub/router"
+	"github.com/bitlum/hub/topology"
 	"github.com/btcsuite/btcutil"
-	"github.com/go-errors/errors"
+	"github.com/golang/protobuf/proto"
 	"github.com/shopspring/decimal"
 	"golang.org/x/net/context"
 	"math/rand"
 	"sort"
+	"time"
 )
 
+// maxLedgerEntries is the maximum number of ledger entries returned in the
+// single page, every entry is written as a few csv rows, and page should fit
+// in the maximum size of grpc message.
+const maxLedgerEntries = 2000
+
 type Config struct {
 	// Client...
 	Client lightning.Client
@@ -25,6 +35,25 @@ type Config struct {
 
 	// ...
 	NodeManager *manager.NodeManager
+
+	// FeeManager is used to fetch the history of channel fee policy
+	// updates.
+	FeeManager *fees.PolicyManager
+
+	// Topology is used to fetch the history of lightning network graph
+	// changes.
+	Topology *topology.Topology
+
+	// Router is used to calculate profitability of our channels and peers.
+	Router *router.Router
+
+	// IdempotencyStorage is used to persist results of the requests with
+	// idempotency key, if it isn't specified the key is ignored.
+	IdempotencyStorage IdempotencyStorage
+
+	// IdempotencyTTL is the period of time during which result of the
+	// request with idempotency key is returned on retries.
+	IdempotencyTTL time.Duration
 }
 
 // Hub is an implementation of gRPC server which receive the message from
@@ -32,19 +61,21 @@ type Config struct {
 // lightning node accordingly with initialised re-balancing strategy.
 type Hub struct {
 	cfg *Config
+
+	idempotency *idempotency
 }
 
 // NewHub creates new instance of the Hub.
 func NewHub(cfg *Config) *Hub {
 	return &Hub{
-		cfg: cfg,
+		cfg:         cfg,
+		idempotency: newIdempotency(cfg.IdempotencyStorage, cfg.IdempotencyTTL),
 	}
 }
 
 // Runtime check that Hub implements the hubrpc.ManagerServer interface.
 var _ HubServer = (*Hub)(nil)
 
-//
 // CreateInvoice is used to create lightning network invoice in which
 // will be used to receive money from external lightning network entity.
 func (h *Hub) CreateInvoice(ctx context.Context,
@@ -68,27 +99,36 @@ func (h *Hub) CreateInvoice(ctx context.Context,
 
 	amountSat, err := common.BtcStrToSatoshi(req.Amount)
 	if err != nil {
+		err := newErrInvalidArgument("amount", "%v", err)
 		log.Errorf("command(%v), id(%v), error: %v",
 			common.GetFunctionName(), requestID, err)
 		m.AddError(metrics.LowSeverity)
 		return nil, err
 	}
 
-	paymentRequest, invoice, err := h.cfg.Client.CreateInvoice("bitlum",
-		btcutil.Amount(amountSat), req.Description)
+	result, err := h.idempotency.execute(ctx, "CreateInvoice",
+		req.IdempotencyKey, req, &CreateInvoiceResponse{},
+		func() (proto.Message, error) {
+			paymentRequest, invoice, err := h.cfg.Client.CreateInvoice(
+				"bitlum", btcutil.Amount(amountSat), req.Description)
+			if err != nil {
+				return nil, newErrInternal(err.Error())
+			}
+
+			return &CreateInvoiceResponse{
+				CreationDate: common.ConvertTimeToMilliSeconds(invoice.Timestamp),
+				Expiry:       common.ConvertDurationToMilliSeconds(invoice.Expiry()),
+				Invoice:      paymentRequest,
+			}, nil
+		})
 	if err != nil {
-		err := newErrInternal(err.Error())
 		log.Errorf("command(%v), id(%v), error: %v",
 			common.GetFunctionName(), requestID, err)
 		m.AddError(metrics.LowSeverity)
 		return nil, err
 	}
 
-	resp = &CreateInvoiceResponse{
-		CreationDate: common.ConvertTimeToMilliSeconds(invoice.Timestamp),
-		Expiry:       common.ConvertDurationToMilliSeconds(invoice.Expiry()),
-		Invoice:      paymentRequest,
-	}
+	resp = result.(*CreateInvoiceResponse)
 
 	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
 		requestID, convertProtoMessage(resp))
@@ -96,7 +136,6 @@ func (h *Hub) CreateInvoice(ctx context.Context,
 	return resp, nil
 }
 
-//
 // ValidateInvoice is used to validate lightning invoice on belonging to
 // proper network as well as amount inside invoice.
 func (h *Hub) ValidateInvoice(ctx context.Context,
@@ -116,6 +155,7 @@ func (h *Hub) ValidateInvoice(ctx context.Context,
 
 	amountSat, err := common.BtcStrToSatoshi(req.Amount)
 	if err != nil {
+		err := newErrInvalidArgument("amount", "%v", err)
 		log.Errorf("command(%v), id(%v), error: %v",
 			common.GetFunctionName(), requestID, err)
 		m.AddError(metrics.LowSeverity)
@@ -124,6 +164,7 @@ func (h *Hub) ValidateInvoice(ctx context.Context,
 
 	invoice, err := h.cfg.Client.ValidateInvoice(req.Invoice, btcutil.Amount(amountSat))
 	if err != nil {
+		err := newErrInvalidArgument("invoice", "%v", err)
 		log.Errorf("command(%v), id(%v), error: %v",
 			common.GetFunctionName(), requestID, err)
 		m.AddError(metrics.LowSeverity)
@@ -168,7 +209,6 @@ func (h *Hub) ValidateInvoice(ctx context.Context,
 	return resp, nil
 }
 
-//
 // Balance is used to determine balance.
 func (h *Hub) Balance(ctx context.Context, req *BalanceRequest) (*BalanceResponse,
 	error) {
@@ -228,6 +268,7 @@ func (h *Hub) EstimateFee(ctx context.Context,
 
 	amountSat, err := common.BtcStrToSatoshi(req.Amount)
 	if err != nil {
+		err := newErrInvalidArgument("amount", "%v", err)
 		log.Errorf("command(%v), id(%v), error: %v",
 			common.GetFunctionName(), requestID, err)
 		m.AddError(metrics.LowSeverity)
@@ -258,63 +299,55 @@ func (h *Hub) EstimateFee(ctx context.Context,
 func (h *Hub) SendPayment(ctx context.Context,
 	req *SendPaymentRequest) (*Payment, error) {
 
-	return nil, errors.Errorf("not implemented")
-	//m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
-	//defer m.Finish()
-	//
-	//requestID := rand.Int()
-	//
-	//log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
-	//	requestID, convertProtoMessage(req))
-	//
-	//var (
-	//	resp    *Payment
-	//	payment *lightning.Payment
-	//	err     error
-	//)
-	//
-	//if req.Amount == "" {
-	//	req.Amount = "0"
-	//}
-	//
-	//amountSat, err := common.BtcStrToSatoshi(req.Amount)
-	//if err != nil {
-	//	log.Errorf("command(%v), id(%v), error: %v",
-	//		common.GetFunctionName(), requestID, err)
-	//	m.AddError(metrics.LowSeverity)
-	//	return nil, err
-	//}
-	//
-	//payment, err = h.cfg.Client.SendPaymentToRoute(req.Invoice,
-	//	btcutil.Amount(amountSat))
-	//if err != nil {
-	//	err := newErrInternal(err.Error())
-	//	log.Errorf("command(%v), id(%v), error: %v", common.GetFunctionName(),
-	//		requestID, err)
-	//	m.AddError(metrics.LowSeverity)
-	//	return nil, err
-	//}
-	//
-	//resp, err = convertPaymentToProto(payment)
-	//if err != nil {
-	//	err := newErrInternal(err.Error())
-	//	log.Errorf("command(%v), id(%v), error: %v",
-	//		common.GetFunctionName(), requestID, err)
-	//	m.AddError(metrics.LowSeverity)
-	//	return nil, err
-	//}
-	//
-	//log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
-	//	requestID, convertProtoMessage(resp))
-	//
-	//return resp, nil
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	if req.Amount == "" {
+		req.Amount = "0"
+	}
+
+	if _, err := common.BtcStrToSatoshi(req.Amount); err != nil {
+		err := newErrInvalidArgument("amount", "%v", err)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	// Payment is sent only once for the idempotency key, retries receive
+	// the result of the original payment. Sending itself isn't implemented
+	// yet, but the key is already validated, so that clients could rely on
+	// the idempotency contract.
+	result, err := h.idempotency.execute(ctx, "SendPayment",
+		req.IdempotencyKey, req, &Payment{},
+		func() (proto.Message, error) {
+			return nil, newErrNotImplemented("SendPayment")
+		})
+	if err != nil {
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	resp := result.(*Payment)
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
 }
 
 // PaymentByID is used to fetch the information about payment, by the
 // given system payment id.
 func (h *Hub) PaymentByID(ctx context.Context, req *PaymentByIDRequest) (*Payment,
 	error) {
-	return nil, errors.Errorf("endpoint is not implemented")
+	return nil, newErrNotImplemented("PaymentByID")
 
 	///requestID := rand.Int()
 	//
@@ -345,7 +378,6 @@ func (h *Hub) PaymentByID(ctx context.Context, req *PaymentByIDRequest) (*Paymen
 	//return resp, err
 }
 
-//
 // PaymentByReceipt is used to fetch the information about payment, by the
 // given receipt.
 func (h *Hub) PaymentByInvoice(ctx context.Context,
@@ -385,7 +417,6 @@ func (h *Hub) PaymentByInvoice(ctx context.Context,
 	return resp, nil
 }
 
-//
 // ListPayments returns list of payment which were registered by the
 // system.
 func (h *Hub) ListPayments(ctx context.Context,
@@ -410,7 +441,7 @@ func (h *Hub) ListPayments(ctx context.Context,
 	if req.Direction != PaymentDirection_DIRECTION_NONE {
 		direction, err = ConvertPaymentDirectionFromProto(req.Direction)
 		if err != nil {
-			err := newErrInternal(err.Error())
+			err := newErrInvalidArgument("direction", "%v", err)
 			log.Errorf("command(%v), id(%v), error: %v",
 				common.GetFunctionName(), requestID, err)
 			m.AddError(metrics.LowSeverity)
@@ -421,7 +452,7 @@ func (h *Hub) ListPayments(ctx context.Context,
 	if req.System != PaymentSystem_SYSTEM_NONE {
 		system, err = ConvertPaymentSystemFromProto(req.System)
 		if err != nil {
-			err := newErrInternal(err.Error())
+			err := newErrInvalidArgument("system", "%v", err)
 			log.Errorf("command(%v), id(%v), error: %v",
 				common.GetFunctionName(), requestID, err)
 			m.AddError(metrics.LowSeverity)
@@ -432,7 +463,7 @@ func (h *Hub) ListPayments(ctx context.Context,
 	if req.Status != PaymentStatus_STATUS_NONE {
 		status, err = ConvertPaymentStatusFromProto(req.Status)
 		if err != nil {
-			err := newErrInternal(err.Error())
+			err := newErrInvalidArgument("status", "%v", err)
 			log.Errorf("command(%v), id(%v), error: %v",
 				common.GetFunctionName(), requestID, err)
 			m.AddError(metrics.LowSeverity)
@@ -485,21 +516,115 @@ func (h *Hub) CheckNodeStats(ctx context.Context,
 	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
 		requestID, convertProtoMessage(req))
 
-	var period string
+	var period stats.Period
 	switch req.Period {
 	case Period_DAY:
-		period = "day"
+		period = stats.PeriodDay
 	case Period_WEEK, Period_PERIOD_NONE:
-		period = "week"
+		period = stats.PeriodWeek
 	case Period_MONTH:
-		period = "month"
+		period = stats.PeriodMonth
 	case Period_THREE_MONTH:
-		period = "three month"
+		period = stats.PeriodThreeMonth
 	default:
-		return nil, errors.Errorf("unknown period(%v)", req.Period)
+		return nil, newErrInvalidArgument("period", "unknown period(%v)",
+			req.Period)
+	}
+
+	if req.Offset < 0 {
+		err := newErrInvalidArgument("offset", "offset(%v) shouldn't be "+
+			"negative", req.Offset)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	if req.Limit < 0 {
+		err := newErrInvalidArgument("limit", "limit(%v) shouldn't be "+
+			"negative", req.Limit)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	if req.MinVolume < 0 {
+		err := newErrInvalidArgument("min_volume", "min volume(%v) "+
+			"shouldn't be negative", req.MinVolume)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	start, end, err := stats.PeriodRange(period, time.Now().Unix())
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	// Custom range of time takes precedence over the period.
+	if req.Start != 0 {
+		start, end = req.Start, req.End
+		if end == 0 {
+			end = time.Now().Unix()
+		}
+
+		if start < 0 || start >= end {
+			err := newErrInvalidArgument("start", "start(%v) should be "+
+				"positive and less than end(%v)", start, end)
+			log.Errorf("command(%v), id(%v), error: %v",
+				common.GetFunctionName(), requestID, err)
+			m.AddError(metrics.LowSeverity)
+			return nil, err
+		}
+	}
+
+	// Time series is returned only if bucket size is specified.
+	var series map[lightning.NodeID][]*stats.PaymentBucket
+	if req.BucketSize != BucketSize_BUCKET_NONE {
+		bucketSize, err := convertBucketSize(req.BucketSize)
+		if err != nil {
+			log.Errorf("command(%v), id(%v), error: %v",
+				common.GetFunctionName(), requestID, err)
+			m.AddError(metrics.LowSeverity)
+			return nil, err
+		}
+
+		if err := stats.ValidateSeriesRange(start, end,
+			bucketSize); err != nil {
+			err := newErrInvalidArgument("bucket_size", err.Error())
+			log.Errorf("command(%v), id(%v), error: %v",
+				common.GetFunctionName(), requestID, err)
+			m.AddError(metrics.LowSeverity)
+			return nil, err
+		}
+
+		series, err = h.cfg.NodeManager.PaymentTimeSeries(start, end,
+			bucketSize)
+		if err != nil {
+			err := newErrInternal(err.Error())
+			log.Errorf("command(%v), id(%v), error: %v",
+				common.GetFunctionName(), requestID, err)
+			m.AddError(metrics.LowSeverity)
+			return nil, err
+		}
+	}
+
+	nodeStats, err := h.cfg.NodeManager.GetNodeStats(start, end)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
 	}
 
-	nodeStats, err := h.cfg.NodeManager.GetNodeStats(period)
+	nodeAnomalies, err := h.cfg.NodeManager.NodeAnomalies(nodeStats)
 	if err != nil {
 		err := newErrInternal(err.Error())
 		log.Errorf("command(%v), id(%v), error: %v",
@@ -510,10 +635,16 @@ func (h *Hub) CheckNodeStats(ctx context.Context,
 
 	resp := &CheckNodeStatsResponse{}
 
-	checkAvailable := func(stats stats.NodeStats) bool {
-		// TODO check connection availability
-		return stats.LockedLocallyActive > (stats.AverageSentSat + stats.
-			AverageReceivedForwardSat - stats.AverageReceivedForwardSat)
+	// Node is available if we could send the typical payment directly to
+	// it, without AMP payment should fit in the single channel.
+	checkAvailable := func(stats stats.NodeStats,
+		health *topology.NodeHealth) bool {
+		if !health.IsConnected || stats.NumActiveChannels == 0 {
+			return false
+		}
+
+		return stats.MaxLockedLocallyActive > 0 &&
+			stats.MaxLockedLocallyActive >= stats.PercentileSentSat
 	}
 
 	btcUSDPrice, err := common.GetBitcoinUSDPRice()
@@ -543,18 +674,72 @@ func (h *Hub) CheckNodeStats(ctx context.Context,
 	rankedByPaymentSentNum := stats.RankByPaymentSentNum(nodeStats)
 	rankedByPaymentVolume := stats.RankByPaymentVolume(nodeStats)
 	rankedByIdleFunds := stats.RankByIdleFunds(nodeStats)
+	rankedByReceivedVolume := stats.RankByPaymentReceivedVolume(nodeStats)
+
+	importantNodes := make(map[lightning.NodeID]struct{})
+	for _, nodeID := range h.cfg.NodeManager.ImportantNodes() {
+		importantNodes[nodeID] = struct{}{}
+	}
+
+	// Requested node is looked up before the filters are applied, so that
+	// known node which doesn't pass the filters isn't reported as unknown.
+	candidates := nodeStats
+	if req.Node != "" {
+		candidates = nil
+		for nodeID, nodeStat := range nodeStats {
+			if req.Node == string(nodeID) ||
+				req.Node == h.cfg.NodeManager.GetDomain(nodeID) {
+				candidates = map[lightning.NodeID]stats.NodeStats{
+					nodeID: nodeStat,
+				}
+				break
+			}
+		}
+
+		if candidates == nil {
+			err := newErrNotFound("node", "node(%v) not found", req.Node)
+			log.Errorf("command(%v), id(%v), error: %v",
+				common.GetFunctionName(), requestID, err)
+			m.AddError(metrics.LowSeverity)
+			return nil, err
+		}
+	}
 
 	var statuses []*CheckNodeStatsResponse_NodeStatus
-	for nodeID, nodeStat := range nodeStats {
+	for nodeID, nodeStat := range candidates {
+		health := h.cfg.Topology.NodeHealth(nodeID)
+		available := checkAvailable(nodeStat, health)
+		_, important := importantNodes[nodeID]
+
+		if req.ImportantOnly && !important {
+			continue
+		}
+
+		if req.AvailableOnly && !available {
+			continue
+		}
+
+		volume := convertUSD(nodeStat.AverageSentSat +
+			nodeStat.AverageReceivedSat + nodeStat.AverageSentForwardSat +
+			nodeStat.AverageReceivedForwardSat)
+		if volume < req.MinVolume {
+			continue
+		}
+
 		statuses = append(statuses, &CheckNodeStatsResponse_NodeStatus{
 			Domain:    h.cfg.NodeManager.GetDomain(nodeID),
 			PubKey:    string(nodeID),
-			Available: checkAvailable(nodeStat),
-			Anomalies: []string{},
+			Available: available,
+			Important: important,
+			Anomalies: convertNodeAnomalies(nodeAnomalies[nodeID]),
+			Series:    convertPaymentBuckets(series[nodeID]),
 			RankStats: &CheckNodeStatsResponse_NodeStatus_RankStats{
 				RankPaymentsSentNum:    searchPosition(nodeID, rankedByPaymentSentNum),
 				RankPaymentsSentVolume: searchPosition(nodeID, rankedByPaymentVolume),
 				RankIdle:               searchPosition(nodeID, rankedByIdleFunds),
+
+				RankPaymentsReceivedVolume: searchPosition(nodeID,
+					rankedByReceivedVolume),
 			},
 			PaymentStats: &CheckNodeStatsResponse_NodeStatus_PaymentsStats{
 				AverageSentForward:     convertUSD(nodeStat.AverageSentForwardSat),
@@ -566,6 +751,9 @@ func (h *Hub) CheckNodeStats(ctx context.Context,
 				NumSent:                nodeStat.NumSentPayments,
 				NumReceivedForward:     nodeStat.NumForwardReceivedPayments,
 				NumSentForward:         nodeStat.NumForwardSentPayments,
+				AverageReceived:        convertUSD(nodeStat.AverageReceivedSat),
+				OverallReceived:        convertUSD(nodeStat.OverallReceivedSat),
+				NumReceived:            nodeStat.NumReceivedPayments,
 			},
 			ChannelStats: &CheckNodeStatsResponse_NodeStatus_ChannelStats{
 				LockedLocallyActive:   convertUSD(nodeStat.LockedLocallyActive),
@@ -573,6 +761,14 @@ func (h *Hub) CheckNodeStats(ctx context.Context,
 				LockedLocallyOverall:  convertUSD(nodeStat.LockedLocallyOverall),
 				LockedRemotelyOverall: convertUSD(nodeStat.LockedRemotelyOverall),
 			},
+			HealthStats: &CheckNodeStatsResponse_NodeStatus_HealthStats{
+				Connected:           health.IsConnected,
+				Uptime:              health.Uptime,
+				LastSeen:            health.LastSeen,
+				Disconnects:         int32(health.Disconnects),
+				LastAnnouncement:    health.LastAnnouncement,
+				AnnouncementsPerDay: health.AnnouncementsPerDay,
+			},
 		})
 	}
 
@@ -589,6 +785,12 @@ func (h *Hub) CheckNodeStats(ctx context.Context,
 				statuses[j].RankStats.RankIdle
 		})
 
+	case SortType_BY_RECEIVED:
+		sort.Slice(statuses, func(i, j int) bool {
+			return statuses[i].RankStats.RankPaymentsReceivedVolume <
+				statuses[j].RankStats.RankPaymentsReceivedVolume
+		})
+
 	case SortType_BY_VOLUME, SortType_SORT_NONE:
 		sort.Slice(statuses, func(i, j int) bool {
 			return statuses[i].RankStats.RankPaymentsSentVolume <
@@ -596,30 +798,114 @@ func (h *Hub) CheckNodeStats(ctx context.Context,
 		})
 
 	default:
-		return nil, errors.Errorf("unknown sort type(%v)", req.SortType)
+		return nil, newErrInvalidArgument("sort_type", "unknown sort "+
+			"type(%v)", req.SortType)
 	}
 
-	if req.Node != "" {
-		var s *CheckNodeStatsResponse_NodeStatus
-		for _, status := range statuses {
-			if status.Domain == req.Node || status.PubKey == req.Node {
-				s = status
-				break
-			}
-		}
+	resp.Total = int32(len(statuses))
 
-		if s != nil {
-			resp.Statuses = []*CheckNodeStatsResponse_NodeStatus{s}
-		} else {
-			err := errors.Errorf("node(%v) not found", req.Node)
-			log.Errorf("command(%v), id(%v), error: %v",
-				common.GetFunctionName(), requestID, err)
-			m.AddError(metrics.LowSeverity)
-			return nil, err
-		}
+	offset := int(req.Offset)
+	if offset > len(statuses) {
+		offset = len(statuses)
+	}
+	statuses = statuses[offset:]
+
+	if req.Limit != 0 && int(req.Limit) < len(statuses) {
+		statuses = statuses[:req.Limit]
+	}
+
+	resp.Statuses = statuses
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// Budget returns the state of the daily budget of channel operations,
+// fee of which is paid by us.
+func (h *Hub) Budget(ctx context.Context, req *BudgetRequest) (*BudgetResponse,
+	error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
 
-	} else {
-		resp.Statuses = statuses[:req.Limit]
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	reports, err := h.cfg.NodeManager.BudgetReport()
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	resp := &BudgetResponse{}
+	for _, report := range reports {
+		resp.Budgets = append(resp.Budgets, &BudgetResponse_OperationBudget{
+			Operation: string(report.Operation),
+			Limit:     report.LimitUSD,
+			Spent:     report.SpentUSD,
+			Pending:   report.PendingUSD,
+			Remaining: report.RemainingUSD,
+			Postponed: int32(report.Postponed),
+		})
+	}
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// FeePolicyUpdates returns the history of routing fee policy updates
+// of our channels.
+func (h *Hub) FeePolicyUpdates(ctx context.Context,
+	req *FeePolicyUpdatesRequest) (*FeePolicyUpdatesResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	if req.Limit < 0 {
+		err := newErrInvalidArgument("limit", "limit(%v) shouldn't be "+
+			"negative", req.Limit)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	history, err := h.cfg.FeeManager.History(int(req.Limit))
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.HighSeverity)
+		return nil, err
+	}
+
+	resp := &FeePolicyUpdatesResponse{}
+	for _, update := range history {
+		resp.Updates = append(resp.Updates,
+			&FeePolicyUpdatesResponse_PolicyUpdate{
+				ChannelId:   string(update.ChannelID),
+				NodeId:      string(update.NodeID),
+				OldPolicy:   convertFeePolicy(update.Old),
+				NewPolicy:   convertFeePolicy(update.New),
+				LocalRatio:  update.LocalRatio,
+				VolumeRatio: update.VolumeRatio,
+				Time:        update.Time,
+				DryRun:      update.DryRun,
+			})
 	}
 
 	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
@@ -627,3 +913,539 @@ func (h *Hub) CheckNodeStats(ctx context.Context,
 
 	return resp, nil
 }
+
+// ChannelsChange returns the history of channels count and capacity
+// changes, with the channels which were closed between checks.
+func (h *Hub) ChannelsChange(ctx context.Context,
+	req *ChannelsChangeRequest) (*ChannelsChangeResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	if req.Limit < 0 {
+		err := newErrInvalidArgument("limit", "limit(%v) shouldn't be "+
+			"negative", req.Limit)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	reports, err := h.cfg.NodeManager.ChannelsChangeReports(int(req.Limit))
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.HighSeverity)
+		return nil, err
+	}
+
+	resp := &ChannelsChangeResponse{}
+	for _, report := range reports {
+		protoReport := &ChannelsChangeResponse_Report{
+			Previous:     convertChannelsSnapshot(report.Previous),
+			Current:      convertChannelsSnapshot(report.Current),
+			CountDrop:    report.CountDrop,
+			CapacityDrop: report.CapacityDrop,
+			Alert:        report.Alert,
+		}
+
+		for _, closed := range report.ClosedChannels {
+			protoReport.ClosedChannels = append(protoReport.ClosedChannels,
+				&ChannelsChangeResponse_ClosedChannel{
+					ChannelId: string(closed.ChannelID),
+					NodeId:    string(closed.NodeID),
+					Alias:     h.cfg.NodeManager.GetDomain(closed.NodeID),
+					Capacity:  closed.Capacity.String(),
+					CloseType: string(closed.CloseType),
+					Initiator: string(closed.Initiator),
+				})
+		}
+
+		resp.Reports = append(resp.Reports, protoReport)
+	}
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// NetworkGraph returns the state of the lightning network graph at the
+// given moment of time.
+func (h *Hub) NetworkGraph(ctx context.Context,
+	req *NetworkGraphRequest) (*NetworkGraphResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	if req.Limit < 0 {
+		err := newErrInvalidArgument("limit", "limit(%v) shouldn't be "+
+			"negative", req.Limit)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	moment := req.Time
+	if moment == 0 {
+		moment = time.Now().Unix()
+	}
+
+	graph, err := h.cfg.Topology.GraphAt(moment)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	resp := &NetworkGraphResponse{
+		Time:        graph.Time,
+		NumNodes:    int32(len(graph.Nodes)),
+		NumChannels: int32(len(graph.Channels)),
+		Capacity:    graph.Capacity().String(),
+	}
+
+	nodes := make(map[lightning.NodeID]*NetworkGraphResponse_Node)
+	getNode := func(nodeID lightning.NodeID) *NetworkGraphResponse_Node {
+		node, ok := nodes[nodeID]
+		if !ok {
+			node = &NetworkGraphResponse_Node{
+				NodeId: string(nodeID),
+			}
+
+			if state, ok := graph.Nodes[nodeID]; ok {
+				node.Alias = state.Alias
+			}
+
+			nodes[nodeID] = node
+		}
+
+		return node
+	}
+
+	capacities := make(map[lightning.NodeID]btcutil.Amount)
+	for _, channel := range graph.Channels {
+		for _, nodeID := range []lightning.NodeID{channel.Node1,
+			channel.Node2} {
+			getNode(nodeID).NumChannels++
+			capacities[nodeID] += channel.Capacity
+		}
+	}
+
+	for nodeID, node := range nodes {
+		node.Capacity = capacities[nodeID].String()
+		resp.Nodes = append(resp.Nodes, node)
+	}
+
+	// Nodes with the biggest capacity go first.
+	sort.Slice(resp.Nodes, func(i, j int) bool {
+		a := capacities[lightning.NodeID(resp.Nodes[i].NodeId)]
+		b := capacities[lightning.NodeID(resp.Nodes[j].NodeId)]
+		return a > b
+	})
+
+	if req.Limit != 0 && int(req.Limit) < len(resp.Nodes) {
+		resp.Nodes = resp.Nodes[:req.Limit]
+	}
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// NodeCapacityHistory returns the history of changes of the node channels
+// capacity within the given period.
+func (h *Hub) NodeCapacityHistory(ctx context.Context,
+	req *NodeCapacityHistoryRequest) (*NodeCapacityHistoryResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	if req.NodeId == "" {
+		err := newErrInvalidArgument("node_id", "node id should be "+
+			"specified")
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	end := req.End
+	if end == 0 {
+		end = time.Now().Unix()
+	}
+
+	if req.Start > end {
+		err := newErrInvalidArgument("start", "start(%v) should be less "+
+			"than end(%v)", req.Start, end)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	points, err := h.cfg.Topology.NodeCapacityHistory(
+		lightning.NodeID(req.NodeId), req.Start, end)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	resp := &NodeCapacityHistoryResponse{}
+	for _, point := range points {
+		resp.Points = append(resp.Points, &NodeCapacityHistoryResponse_Point{
+			Time:        point.Time,
+			NumChannels: int32(point.NumChannels),
+			Capacity:    point.Capacity.String(),
+		})
+	}
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// NetworkAnomalies returns the history of detected anomalies of the
+// lightning network.
+func (h *Hub) NetworkAnomalies(ctx context.Context,
+	req *NetworkAnomaliesRequest) (*NetworkAnomaliesResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	if req.Limit < 0 {
+		err := newErrInvalidArgument("limit", "limit(%v) shouldn't be "+
+			"negative", req.Limit)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	events := h.cfg.Topology.Anomalies()
+	if req.Limit != 0 && int(req.Limit) < len(events) {
+		events = events[len(events)-int(req.Limit):]
+	}
+
+	resp := &NetworkAnomaliesResponse{}
+	for _, event := range events {
+		anomaly := &NetworkAnomaliesResponse_Anomaly{
+			Type:        string(event.Type),
+			NodeId:      string(event.NodeID),
+			Time:        event.Time,
+			Previous:    event.Previous,
+			Current:     event.Current,
+			Change:      event.Change,
+			Description: event.Description,
+		}
+
+		if event.NodeID != "" {
+			anomaly.Domain = h.cfg.NodeManager.GetDomain(event.NodeID)
+		}
+
+		resp.Anomalies = append(resp.Anomalies, anomaly)
+	}
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// NetworkStats returns the economics statistics of the lightning network
+// channels, such as average channel lifetime, open / close fees and routing
+// fees, alongside with the same statistics of our channels.
+func (h *Hub) NetworkStats(ctx context.Context,
+	req *NetworkStatsRequest) (*NetworkStatsResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	stats := h.cfg.Topology.NetworkStats()
+	if stats == nil {
+		err := newErrUnavailable("network stats haven't been calculated yet")
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	resp := &NetworkStatsResponse{
+		Time:     stats.Time,
+		NumNodes: int32(stats.NumNodes),
+		Capacity: stats.Capacity.String(),
+		Network:  convertEconomicStats(stats.Network),
+		Our:      convertEconomicStats(stats.Our),
+	}
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// PaymentTimeSeries returns payments activity with nodes, aggregated in
+// buckets of the given size within the given range of time.
+func (h *Hub) PaymentTimeSeries(ctx context.Context,
+	req *PaymentTimeSeriesRequest) (*PaymentTimeSeriesResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	bucketSize := stats.Day
+	if req.BucketSize != BucketSize_BUCKET_NONE {
+		var err error
+		bucketSize, err = convertBucketSize(req.BucketSize)
+		if err != nil {
+			log.Errorf("command(%v), id(%v), error: %v",
+				common.GetFunctionName(), requestID, err)
+			m.AddError(metrics.LowSeverity)
+			return nil, err
+		}
+	}
+
+	end := req.End
+	if end == 0 {
+		end = time.Now().Unix()
+	}
+
+	start := req.Start
+	if start == 0 {
+		start = end - stats.DefaultNumBuckets*int64(bucketSize)
+	}
+
+	if start < 0 || start >= end {
+		err := newErrInvalidArgument("start", "start(%v) should be "+
+			"positive and less than end(%v)", start, end)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	if err := stats.ValidateSeriesRange(start, end, bucketSize); err != nil {
+		err := newErrInvalidArgument("bucket_size", err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	series, err := h.cfg.NodeManager.PaymentTimeSeries(start, end,
+		bucketSize)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	resp := &PaymentTimeSeriesResponse{}
+	for nodeID, buckets := range series {
+		domain := h.cfg.NodeManager.GetDomain(nodeID)
+		if req.Node != "" && req.Node != string(nodeID) &&
+			req.Node != domain {
+			continue
+		}
+
+		resp.Series = append(resp.Series, &PaymentTimeSeriesResponse_Series{
+			NodeId:  string(nodeID),
+			Domain:  domain,
+			Buckets: convertPaymentBuckets(buckets),
+		})
+	}
+
+	sort.Slice(resp.Series, func(i, j int) bool {
+		return resp.Series[i].NodeId < resp.Series[j].NodeId
+	})
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// ProfitabilityReport returns the economy of our channels and peers within
+// the given range of time: forward fees earned, routing fees paid, on-chain
+// channel management fees, ROI and quality ratio.
+func (h *Hub) ProfitabilityReport(ctx context.Context,
+	req *ProfitabilityReportRequest) (*ProfitabilityReportResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	end := req.End
+	if end == 0 {
+		end = time.Now().Unix()
+	}
+
+	if req.Start < 0 || req.Start >= end {
+		err := newErrInvalidArgument("start", "start(%v) should be "+
+			"positive and less than end(%v)", req.Start, end)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	report, err := h.cfg.Router.ProfitabilityReport(req.Start, end)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	resp := &ProfitabilityReportResponse{
+		Start: report.StartTime,
+		End:   report.EndTime,
+		Total: convertProfitability(report.Total, ""),
+	}
+
+	for _, p := range report.Channels {
+		domain := h.cfg.NodeManager.GetDomain(p.NodeID)
+		resp.Channels = append(resp.Channels, convertProfitability(p, domain))
+	}
+
+	for _, p := range report.Peers {
+		domain := h.cfg.NodeManager.GetDomain(p.NodeID)
+		resp.Peers = append(resp.Peers, convertProfitability(p, domain))
+	}
+
+	log.Tracef("command(%v), id(%v), response(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(resp))
+
+	return resp, nil
+}
+
+// ExportLedger returns chronological double-entry ledger of incoming,
+// outgoing and forwarded payments, and on-chain channel management fees in
+// csv format.
+func (h *Hub) ExportLedger(ctx context.Context,
+	req *ExportLedgerRequest) (*ExportLedgerResponse, error) {
+
+	m := rpc.NewMetric(common.GetFunctionName(), h.cfg.MetricsBackend)
+	defer m.Finish()
+
+	requestID := rand.Int()
+
+	log.Tracef("command(%v), id(%v), request(%v)", common.GetFunctionName(),
+		requestID, convertProtoMessage(req))
+
+	end := req.End
+	if end == 0 {
+		end = time.Now().Unix()
+	}
+
+	if req.Start < 0 || req.Start >= end {
+		err := newErrInvalidArgument("start", "start(%v) should be "+
+			"positive and less than end(%v)", req.Start, end)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	if req.Offset < 0 {
+		err := newErrInvalidArgument("offset", "offset(%v) shouldn't be "+
+			"negative", req.Offset)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	if req.Limit < 0 {
+		err := newErrInvalidArgument("limit", "limit(%v) shouldn't be "+
+			"negative", req.Limit)
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	// Ledger is returned by pages, so that response doesn't exceed the
+	// maximum size of grpc message.
+	limit := int(req.Limit)
+	if limit == 0 || limit > maxLedgerEntries {
+		limit = maxLedgerEntries
+	}
+
+	entries, total, err := h.cfg.Router.Ledger(req.Start, end,
+		int(req.Offset), limit, req.Fiat)
+	if err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := router.WriteLedgerCSV(&buf, entries,
+		int(req.Offset)); err != nil {
+		err := newErrInternal(err.Error())
+		log.Errorf("command(%v), id(%v), error: %v",
+			common.GetFunctionName(), requestID, err)
+		m.AddError(metrics.LowSeverity)
+		return nil, err
+	}
+
+	resp := &ExportLedgerResponse{
+		Csv:        buf.String(),
+		NumEntries: int32(len(entries)),
+		Total:      int32(total),
+	}
+
+	// Ledger might be huge, so only number of entries is logged.
+	log.Tracef("command(%v), id(%v), response(num_entries: %v)",
+		common.GetFunctionName(), requestID, resp.NumEntries)
+
+	return resp, nil
+}
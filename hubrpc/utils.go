@@ -2,7 +2,11 @@ package hubrpc
 
 import (
 	"fmt"
+	"github.com/bitlum/hub/db"
 	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/manager/stats"
+	"github.com/bitlum/hub/router"
+	"github.com/bitlum/hub/topology"
 	"github.com/go-errors/errors"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
@@ -99,6 +103,112 @@ func convertPaymentToProto(payment *lightning.Payment) (*Payment, error) {
 	}, nil
 }
 
+func convertFeePolicy(policy *lightning.FeePolicy) *FeePolicyUpdatesResponse_FeePolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &FeePolicyUpdatesResponse_FeePolicy{
+		BaseFeeMsat: policy.BaseFeeMsat,
+		FeeRate:     policy.FeeRatePPM,
+	}
+}
+
+func convertChannelsSnapshot(snapshot *db.ChannelsSnapshot) *ChannelsChangeResponse_Snapshot {
+	if snapshot == nil {
+		return nil
+	}
+
+	return &ChannelsChangeResponse_Snapshot{
+		Time:        snapshot.Time,
+		NumChannels: int32(snapshot.NumChannels),
+		Capacity:    snapshot.Capacity.String(),
+	}
+}
+
+func convertNodeAnomalies(anomalies []*stats.Anomaly) []*CheckNodeStatsResponse_NodeStatus_Anomaly {
+	protoAnomalies := make([]*CheckNodeStatsResponse_NodeStatus_Anomaly, len(anomalies))
+	for i, anomaly := range anomalies {
+		protoAnomalies[i] = &CheckNodeStatsResponse_NodeStatus_Anomaly{
+			Code:        string(anomaly.Code),
+			Severity:    string(anomaly.Severity),
+			Description: anomaly.Description,
+		}
+	}
+
+	return protoAnomalies
+}
+
+// convertBucketSize converts proto bucket size in the size of time series
+// bucket.
+func convertBucketSize(size BucketSize) (stats.BucketSize, error) {
+	switch size {
+	case BucketSize_BUCKET_HOUR:
+		return stats.Hour, nil
+	case BucketSize_BUCKET_DAY:
+		return stats.Day, nil
+	case BucketSize_BUCKET_WEEK:
+		return stats.Week, nil
+	default:
+		return 0, newErrInvalidArgument("bucket_size", "unknown bucket "+
+			"size(%v)", size)
+	}
+}
+
+func convertPaymentBuckets(buckets []*stats.PaymentBucket) []*PaymentTimeSeriesResponse_Bucket {
+	protoBuckets := make([]*PaymentTimeSeriesResponse_Bucket, len(buckets))
+	for i, bucket := range buckets {
+		protoBuckets[i] = &PaymentTimeSeriesResponse_Bucket{
+			Start:              bucket.Start,
+			SentSat:            int64(bucket.SentSat),
+			SentForwardSat:     int64(bucket.SentForwardSat),
+			ReceivedForwardSat: int64(bucket.ReceivedForwardSat),
+			NumSent:            bucket.NumSentPayments,
+			NumSentForward:     bucket.NumForwardSentPayments,
+			NumReceivedForward: bucket.NumForwardReceivedPayments,
+			ReceivedSat:        int64(bucket.ReceivedSat),
+			NumReceived:        bucket.NumReceivedPayments,
+		}
+	}
+
+	return protoBuckets
+}
+
+func convertEconomicStats(stats *topology.EconomicStats) *EconomicStats {
+	return &EconomicStats{
+		NumChannels:           int32(stats.NumChannels),
+		NumClosedChannels:     int32(stats.NumClosedChannels),
+		AverageCapacity:       stats.AverageCapacity.String(),
+		AverageChannelAge:     stats.AverageChannelAge,
+		AverageClosedLifetime: stats.AverageClosedLifetime,
+		AverageOpenFee:        stats.AverageOpenFee.String(),
+		AverageCloseFee:       stats.AverageCloseFee.String(),
+		AverageBaseFeeMsat:    stats.AverageBaseFeeMsat,
+		AverageFeeRatePpm:     stats.AverageFeeRatePPM,
+	}
+}
+
+func convertProfitability(p *router.Profitability,
+	domain string) *Profitability {
+	return &Profitability{
+		ChannelId:           string(p.ChannelID),
+		NodeId:              string(p.NodeID),
+		Domain:              domain,
+		CapacitySat:         int64(p.Capacity),
+		EarnedForwardFeeSat: int64(p.EarnedForwardFee),
+		PaymentFeeSat:       int64(p.PaymentFee),
+		OpenFeeSat:          int64(p.OpenFee),
+		CloseFeeSat:         int64(p.CloseFee),
+		SwipeFeeSat:         int64(p.SwipeFee),
+		SentSat:             int64(p.SentFunds),
+		ReceivedSat:         int64(p.ReceivedFunds),
+		ForwardedSat:        int64(p.ForwardedFunds),
+		ProfitSat:           int64(p.Profit),
+		Roi:                 p.ROI,
+		QualityRatio:        p.QualityRatio,
+	}
+}
+
 func ConvertPaymentStatusFromProto(protoStatus PaymentStatus) (
 	lightning.PaymentStatus, error) {
 	var status lightning.PaymentStatus
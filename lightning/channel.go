@@ -96,6 +96,17 @@ func (c *Channel) OpenFee() (btcutil.Amount, error) {
 	return state.(*ChannelStateOpening).OpenFee, nil
 }
 
+// Initiator returns the side which initiated open of the channel, it is
+// the side which paid the open fee and will pay the close fee.
+func (c *Channel) Initiator() (ChannelInitiator, error) {
+	state, ok := c.States[ChannelOpening]
+	if !ok {
+		return "", errors.Errorf("channel state not found")
+	}
+
+	return state.(*ChannelStateOpening).Initiator, nil
+}
+
 // ...
 func (c *Channel) CommitFee() (btcutil.Amount, error) {
 	switch c.State {
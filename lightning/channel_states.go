@@ -27,6 +27,34 @@ const (
 	ChannelClosed ChannelStateName = "closed"
 )
 
+// ChannelCloseType is the way in which channel has been closed.
+type ChannelCloseType string
+
+const (
+	// CooperativeClose denotes that channel was closed by mutual agreement
+	// of both sides.
+	CooperativeClose ChannelCloseType = "cooperative"
+
+	// LocalForceClose denotes that channel was force closed by our side,
+	// by broadcasting our commitment transaction.
+	LocalForceClose ChannelCloseType = "local_force"
+
+	// RemoteForceClose denotes that channel was force closed by remote
+	// side, by broadcasting its commitment transaction.
+	RemoteForceClose ChannelCloseType = "remote_force"
+
+	// BreachClose denotes that remote side has broadcasted revoked
+	// commitment transaction, and funds were taken by justice transaction.
+	BreachClose ChannelCloseType = "breach"
+
+	// FundingCanceled denotes that channel funding transaction has never
+	// been confirmed.
+	FundingCanceled ChannelCloseType = "funding_canceled"
+
+	// UnknownClose is used if the way of channel close is unknown.
+	UnknownClose ChannelCloseType = "unknown"
+)
+
 // ChannelStateOpening denotes that channel open request has been sent in
 // blockchain network, and that we wait for its approval.
 type ChannelStateOpening struct {
@@ -105,6 +133,9 @@ type ChannelStateClosing struct {
 	// LockedBalance funds which are stuck in the network, until lightning
 	// network client will retrieve them, they couldn't be used.
 	LockedBalance btcutil.Amount
+
+	// CloseType is the way in which channel has been closed.
+	CloseType ChannelCloseType
 }
 
 // ChannelStateClosed denotes that channel close request was approved in blockchain,
@@ -0,0 +1,13 @@
+package lightning
+
+// FeePolicy is the routing fee policy of our side of the channel, which
+// determines the fee we take for forwarding payments through the channel.
+type FeePolicy struct {
+	// BaseFeeMsat is the fixed fee in millisatoshis which is taken for
+	// every forwarded payment.
+	BaseFeeMsat int64
+
+	// FeeRatePPM is the proportional fee in parts per million of the
+	// forwarded amount.
+	FeeRatePPM int64
+}
@@ -0,0 +1,46 @@
+package lightning
+
+import "github.com/btcsuite/btcutil"
+
+// GraphNode is the node announced in the lightning network.
+type GraphNode struct {
+	NodeID NodeID
+	Alias  string
+
+	// Addresses is the network addresses on which node is reachable.
+	Addresses []string
+
+	// LastUpdate is the time of the last node announcement.
+	LastUpdate int64
+}
+
+// GraphChannel is the channel announced in the lightning network.
+type GraphChannel struct {
+	// ShortChannelID is the compact representation of the channel
+	// funding output location in the blockchain.
+	ShortChannelID uint64
+
+	ChannelID ChannelID
+
+	Node1 NodeID
+	Node2 NodeID
+
+	Capacity btcutil.Amount
+
+	// Node1Policy is the routing fee policy of the first node, nil if
+	// node hasn't announced it yet.
+	Node1Policy *FeePolicy
+
+	// Node2Policy is the routing fee policy of the second node, nil if
+	// node hasn't announced it yet.
+	Node2Policy *FeePolicy
+
+	// LastUpdate is the time of the last channel update.
+	LastUpdate int64
+}
+
+// Graph is the lightning network channel graph as it seen by our node.
+type Graph struct {
+	Nodes    []*GraphNode
+	Channels []*GraphChannel
+}
@@ -2,10 +2,15 @@ package lightning
 
 import (
 	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/shopspring/decimal"
 )
 
+// ErrTimeout is returned if request to the lightning client has timed out,
+// in this case requested operation might still be in progress.
+var ErrTimeout = errors.New("request has timed out")
+
 // Client aka payment provider, aka hub, aka lightning network node.
 // This interface gives as unified way of managing different implementations of
 // lightning network daemons.
@@ -31,14 +36,30 @@ type TopologyClient interface {
 	// Channels returns all lightning network channels which belongs to us.
 	Channels() ([]*Channel, error)
 
-	// OpenChannel opens the lightning network channel with the given node.
+	// OpenChannel opens the lightning network channel with the given node,
+	// ErrTimeout is returned if channel open might still be in progress.
 	OpenChannel(nodeID NodeID, funds btcutil.Amount) error
 
-	// CloseChannel closes the specified lightning network channel.
+	// CloseChannel closes the specified lightning network channel,
+	// ErrTimeout is returned if channel close might still be in progress.
 	CloseChannel(channelID ChannelID) error
 
 	// ConnectToNode connects to node with tcp / ip connection.
 	ConnectToNode(nodeID NodeID) error
+
+	// ConnectedPeers returns nodes which are connected to us with
+	// tcp / ip connection.
+	ConnectedPeers() ([]NodeID, error)
+
+	// FeePolicies returns the current routing fee policies of our channels.
+	FeePolicies() (map[ChannelID]*FeePolicy, error)
+
+	// UpdateFeePolicy updates the routing fee policy of the given channel.
+	UpdateFeePolicy(channelID ChannelID, policy *FeePolicy) error
+
+	// DescribeGraph returns the lightning network channel graph as it
+	// seen by our node.
+	DescribeGraph() (*Graph, error)
 }
 
 type PaymentClient interface {
@@ -54,6 +75,13 @@ type PaymentClient interface {
 	// SendPayment makes the payment on behalf of lightning node.
 	SendPaymentToRoute(route *Route, paymentHash PaymentHash) (*Payment, error)
 
+	// SendCircularPayment sends payment from our node back to our node,
+	// which leaves through the outgoing channel and returns through the
+	// incoming one, and in this way moves local balance between channels.
+	// Payment is not sent if routing fee is greater than the given max fee.
+	SendCircularPayment(outgoing, incoming ChannelID, amount,
+		maxFee btcutil.Amount) (*Payment, error)
+
 	// CreateInvoice is used to create lightning network invoice.
 	CreateInvoice(receipt string, amount btcutil.Amount,
 		description string) (string, *zpay32.Invoice, error)
@@ -70,6 +98,14 @@ type PaymentClient interface {
 	// thorough lightning node.
 	ListForwardPayments() ([]*ForwardPayment, error)
 
+	// FilterPayments returns list of incoming and outgoing payments, which
+	// satisfy the given filter.
+	FilterPayments(filter PaymentsFilter) ([]*Payment, error)
+
+	// FilterForwardPayments returns list of forward payments which were
+	// routed thorough lightning node, and which satisfy the given filter.
+	FilterForwardPayments(filter PaymentsFilter) ([]*ForwardPayment, error)
+
 	// PaymentByInvoice returns payment by given lightning network invoice.
 	PaymentByInvoice(invoice string) (*Payment, error)
 }
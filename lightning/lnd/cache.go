@@ -11,6 +11,10 @@ import (
 	"time"
 )
 
+// channelNotSynced is the state of the channel, which info haven't been
+// saved yet.
+const channelNotSynced lightning.ChannelStateName = "not synced yet"
+
 // updateChannelStates fetches all lightning network node channels,
 // and populate internal channel storage with additional information.
 //
@@ -70,7 +74,7 @@ func (c *Client) syncChannels() error {
 				chanID, err)
 		}
 
-		prevState := lightning.ChannelStateName("not synced yet")
+		prevState := channelNotSynced
 		if prevInfo != nil {
 			prevState = prevInfo.State
 		}
@@ -96,7 +100,7 @@ func (c *Client) syncChannels() error {
 			// until this bug change channel state.
 
 			prevInfo.State = lightning.ChannelOpening
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -107,7 +111,7 @@ func (c *Client) syncChannels() error {
 			// Transition from opening => opening
 			// Nothing to do
 
-		case "not synced yet":
+		case channelNotSynced:
 			// Transition from non-existed => opening
 
 			openTxID, _, err := splitChannelPoint(openingChannel.Channel.ChannelPoint)
@@ -167,7 +171,7 @@ func (c *Client) syncChannels() error {
 				State: lightning.ChannelOpening,
 			}
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(newInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, newInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -195,7 +199,7 @@ func (c *Client) syncChannels() error {
 			continue
 		}
 
-		prevState := lightning.ChannelStateName("not synced yet")
+		prevState := channelNotSynced
 		if prevInfo != nil {
 			prevState = prevInfo.State
 		}
@@ -216,7 +220,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.OpenStuckBalance = getStuckBalance(newChannel.PendingHtlcs)
 			prevInfo.State = lightning.ChannelOpened
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -238,7 +242,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.OpenLocalBalance = btcutil.Amount(newChannel.LocalBalance)
 			prevInfo.OpenStuckBalance = getStuckBalance(newChannel.PendingHtlcs)
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -259,7 +263,7 @@ func (c *Client) syncChannels() error {
 			m.AddError(metrics.HighSeverity)
 			continue
 
-		case "not synced yet":
+		case channelNotSynced:
 			// Transition from non-existed => opened
 			// skipped opening
 
@@ -321,7 +325,7 @@ func (c *Client) syncChannels() error {
 				State: lightning.ChannelOpened,
 			}
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(newInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, newInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -351,7 +355,7 @@ func (c *Client) syncChannels() error {
 			continue
 		}
 
-		prevState := lightning.ChannelStateName("not synced yet")
+		prevState := channelNotSynced
 		if prevInfo != nil {
 			prevState = prevInfo.State
 		}
@@ -384,7 +388,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.OpenStuckBalance = 0 // unknown, lost data
 			prevInfo.State = lightning.ChannelOpened
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -412,7 +416,7 @@ func (c *Client) syncChannels() error {
 			m.AddError(metrics.HighSeverity)
 			continue
 
-		case "not synced yet":
+		case channelNotSynced:
 			// Transition from non-existed => wait for closing
 			// skipped opening
 			// skipped opened
@@ -477,7 +481,7 @@ func (c *Client) syncChannels() error {
 				State: lightning.ChannelOpened,
 			}
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(newInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, newInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -507,7 +511,7 @@ func (c *Client) syncChannels() error {
 			continue
 		}
 
-		prevState := lightning.ChannelStateName("not synced yet")
+		prevState := channelNotSynced
 		if prevInfo != nil {
 			prevState = prevInfo.State
 		}
@@ -561,7 +565,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.SwipeFees = 0 // cooperative close
 			prevInfo.State = lightning.ChannelClosing
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -598,7 +602,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.SwipeFees = 0 // cooperate close
 			prevInfo.State = lightning.ChannelClosing
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -619,7 +623,7 @@ func (c *Client) syncChannels() error {
 			m.AddError(metrics.HighSeverity)
 			continue
 
-		case "not synced yet":
+		case channelNotSynced:
 			// Transition from not existed => closing
 			// skipped opening transition
 			// skipped opened transition
@@ -699,7 +703,7 @@ func (c *Client) syncChannels() error {
 				State: lightning.ChannelClosing,
 			}
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(newInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, newInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -730,7 +734,7 @@ func (c *Client) syncChannels() error {
 			continue
 		}
 
-		prevState := lightning.ChannelStateName("not synced yet")
+		prevState := channelNotSynced
 		if prevInfo != nil {
 			prevState = prevInfo.State
 		}
@@ -804,7 +808,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.SwipeFees = swipeFees
 			prevInfo.State = lightning.ChannelClosing
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -865,7 +869,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.SwipeFees = swipeFees
 			prevInfo.State = lightning.ChannelClosing
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -886,7 +890,7 @@ func (c *Client) syncChannels() error {
 			m.AddError(metrics.HighSeverity)
 			continue
 
-		case "not synced yet":
+		case channelNotSynced:
 			openTxID, _, err := splitChannelPoint(closingChannel.Channel.ChannelPoint)
 			if err != nil {
 				log.Errorf("unable to get txid from channel "+
@@ -989,7 +993,7 @@ func (c *Client) syncChannels() error {
 				State: lightning.ChannelClosing,
 			}
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(newInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, newInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -1024,7 +1028,7 @@ func (c *Client) syncChannels() error {
 			continue
 		}
 
-		prevState := lightning.ChannelStateName("not synced yet")
+		prevState := channelNotSynced
 		if prevInfo != nil {
 			prevState = prevInfo.State
 		}
@@ -1079,7 +1083,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.CloseTime = time.Now().Unix()
 			prevInfo.State = lightning.ChannelClosed
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -1118,7 +1122,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.CloseTime = time.Now().Unix()
 			prevInfo.State = lightning.ChannelClosed
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -1135,7 +1139,7 @@ func (c *Client) syncChannels() error {
 			prevInfo.CloseTime = time.Now().Unix()
 			prevInfo.State = lightning.ChannelClosed
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(prevInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, prevInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -1148,7 +1152,7 @@ func (c *Client) syncChannels() error {
 		case lightning.ChannelClosed:
 			// Transition from not closed => closed
 
-		case "not synced yet":
+		case channelNotSynced:
 			// Transition from not existed => closed
 			// skipped opening
 			// skipped opened
@@ -1230,7 +1234,7 @@ func (c *Client) syncChannels() error {
 				State:     lightning.ChannelClosed,
 			}
 
-			if err := c.cfg.Storage.UpdateChannelAdditionalInfo(newInfo); err != nil {
+			if err := c.saveChannelInfo(prevState, newInfo); err != nil {
 				log.Errorf("unable to save channel("+
 					"%v) additional info: %v", chanID, err)
 				m.AddError(metrics.HighSeverity)
@@ -243,6 +243,18 @@ func (c *Client) Start() error {
 	c.wg.Add(1)
 	go c.updateChannelStates()
 
+	c.wg.Add(1)
+	go c.listenGraphUpdates()
+
+	c.wg.Add(1)
+	go c.listenInvoiceUpdates()
+
+	c.wg.Add(1)
+	go c.listenForwardUpdates()
+
+	c.wg.Add(1)
+	go c.listenPeerUpdates()
+
 	log.Info("lnd client started")
 	close(c.startedTrigger)
 	return nil
@@ -261,6 +273,7 @@ func (c *Client) Stop(reason string) error {
 	}
 
 	c.wg.Wait()
+	c.broadcaster.Stop()
 
 	log.Infof("lnd client shutdown, reason(%v)", reason)
 	return nil
@@ -147,3 +147,35 @@ func (e *Explorer) FetchTxTime(txID string) (int64, error) {
 
 	return txVerbose.Time, nil
 }
+
+// FetchSpendTxID fetch id of the transaction, which is included in the
+// block with the given height, and which spends the given output.
+func (e *Explorer) FetchSpendTxID(txID string, index uint32,
+	height uint32) (string, error) {
+
+	txHash, err := chainhash.NewHashFromStr(txID)
+	if err != nil {
+		return "", err
+	}
+
+	blockHash, err := e.rpc.GetBlockHash(int64(height))
+	if err != nil {
+		return "", err
+	}
+
+	block, err := e.rpc.GetBlock(blockHash)
+	if err != nil {
+		return "", err
+	}
+
+	for _, tx := range block.Transactions {
+		for _, input := range tx.TxIn {
+			outpoint := input.PreviousOutPoint
+			if outpoint.Hash.IsEqual(txHash) && outpoint.Index == index {
+				return tx.TxHash().String(), nil
+			}
+		}
+	}
+
+	return "", errors.New("unable to find spend transaction in block")
+}
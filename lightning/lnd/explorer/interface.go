@@ -16,4 +16,8 @@ type Explorer interface {
 	// FetchTxTime fetch transaction receive time, when transaction first
 	// appeared in blockchain.
 	FetchTxTime(txID string) (int64, error)
+
+	// FetchSpendTxID fetch id of the transaction, which is included in the
+	// block with the given height, and which spends the given output.
+	FetchSpendTxID(txID string, index uint32, height uint32) (string, error)
 }
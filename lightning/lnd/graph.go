@@ -0,0 +1,186 @@
+package lnd
+
+import (
+	"context"
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/common/broadcast"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"time"
+)
+
+// graphResubscribeDelay is the delay after which we try to subscribe on
+// the graph updates again, after subscription has failed.
+const graphResubscribeDelay = time.Second * 10
+
+// Runtime check to ensure that Client implements lightning.UpdatesStreamer
+// interface.
+var _ lightning.UpdatesStreamer = (*Client)(nil)
+
+// DescribeGraph returns the lightning network channel graph as it seen by
+// our node.
+//
+// NOTE: Part of the lightning.TopologyClient interface.
+func (c *Client) DescribeGraph() (*lightning.Graph, error) {
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	req := &lnrpc.ChannelGraphRequest{}
+	resp, err := c.rpc.DescribeGraph(timeout(60), req)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to describe graph: %v", err)
+	}
+
+	graph := &lightning.Graph{}
+	for _, node := range resp.Nodes {
+		var addresses []string
+		for _, address := range node.Addresses {
+			addresses = append(addresses, address.Addr)
+		}
+
+		graph.Nodes = append(graph.Nodes, &lightning.GraphNode{
+			NodeID:     lightning.NodeID(node.PubKey),
+			Alias:      node.Alias,
+			Addresses:  addresses,
+			LastUpdate: int64(node.LastUpdate),
+		})
+	}
+
+	for _, edge := range resp.Edges {
+		graph.Channels = append(graph.Channels, &lightning.GraphChannel{
+			ShortChannelID: edge.ChannelId,
+			ChannelID:      lightning.ChannelID(edge.ChanPoint),
+			Node1:          lightning.NodeID(edge.Node1Pub),
+			Node2:          lightning.NodeID(edge.Node2Pub),
+			Capacity:       btcutil.Amount(edge.Capacity),
+			Node1Policy:    convertRoutingPolicy(edge.Node1Policy),
+			Node2Policy:    convertRoutingPolicy(edge.Node2Policy),
+			LastUpdate:     int64(edge.LastUpdate),
+		})
+	}
+
+	return graph, nil
+}
+
+// RegisterOnUpdates returns receiver which returns updates about lightning
+// network graph changes, our channels state changes, and payments.
+//
+// NOTE: Part of the lightning.UpdatesStreamer interface.
+func (c *Client) RegisterOnUpdates() *broadcast.Receiver {
+	return c.broadcaster.Subscribe()
+}
+
+// listenGraphUpdates subscribes on lnd channel graph updates, and
+// broadcasts them to the update receivers. If subscription fails, than
+// we try to subscribe again after some delay.
+//
+// NOTE: Should run as goroutine.
+func (c *Client) listenGraphUpdates() {
+	defer func() {
+		log.Info("Stopped graph updates goroutine")
+		c.wg.Done()
+	}()
+
+	log.Info("Started graph updates goroutine")
+
+	for {
+		if err := c.receiveGraphUpdates(); err != nil {
+			log.Errorf("(graph updates) unable receive graph updates: %v",
+				err)
+		}
+
+		select {
+		case <-time.After(graphResubscribeDelay):
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// receiveGraphUpdates subscribes on lnd channel graph updates, and
+// broadcasts them until subscription fails or client is stopped.
+func (c *Client) receiveGraphUpdates() error {
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel subscription on client stop, otherwise receive would block
+	// forever.
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-c.quit:
+			cancel()
+		}
+	}()
+
+	req := &lnrpc.GraphTopologySubscription{}
+	client, err := c.rpc.SubscribeChannelGraph(ctx, req)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to subscribe on graph updates: %v", err)
+	}
+
+	for {
+		update, err := client.Recv()
+		if err != nil {
+			select {
+			case <-c.quit:
+				return nil
+			default:
+			}
+
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable to receive graph update: %v", err)
+		}
+
+		now := time.Now().Unix()
+
+		for _, node := range update.NodeUpdates {
+			c.broadcaster.Write(&lightning.UpdateGraphNode{
+				GraphNode: &lightning.GraphNode{
+					NodeID:     lightning.NodeID(node.IdentityKey),
+					Alias:      node.Alias,
+					Addresses:  node.Addresses,
+					LastUpdate: now,
+				},
+			})
+		}
+
+		for _, channel := range update.ChannelUpdates {
+			channelID, err := convertChannelPoint(channel.ChanPoint)
+			if err != nil {
+				log.Errorf("(graph updates) unable convert channel(%v) "+
+					"point: %v", channel.ChanId, err)
+			}
+
+			c.broadcaster.Write(&lightning.UpdateGraphChannel{
+				ShortChannelID:  channel.ChanId,
+				ChannelID:       channelID,
+				Capacity:        btcutil.Amount(channel.Capacity),
+				AdvertisingNode: lightning.NodeID(channel.AdvertisingNode),
+				ConnectingNode:  lightning.NodeID(channel.ConnectingNode),
+				Policy:          convertRoutingPolicy(channel.RoutingPolicy),
+				Time:            now,
+			})
+		}
+
+		for _, channel := range update.ClosedChans {
+			c.broadcaster.Write(&lightning.UpdateGraphChannelClosed{
+				ShortChannelID: channel.ChanId,
+				Capacity:       btcutil.Amount(channel.Capacity),
+				ClosedHeight:   channel.ClosedHeight,
+				Time:           now,
+			})
+		}
+	}
+}
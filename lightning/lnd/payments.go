@@ -2,6 +2,7 @@ package lnd
 
 import (
 	"encoding/hex"
+	"fmt"
 	"github.com/bitlum/graphql-go/errors"
 	"github.com/bitlum/hub/common"
 	"github.com/bitlum/hub/lightning"
@@ -11,9 +12,25 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc"
 	"github.com/lightningnetwork/lnd/zpay32"
 	"github.com/shopspring/decimal"
+	"sort"
 	"time"
 )
 
+const (
+	// internalReceipt is the receipt of invoices which were created for
+	// internal payments, i.e. circular payments used for channel
+	// re-balancing.
+	internalReceipt = "internal"
+
+	// circularRoutesNum is the number of routes which are requested in
+	// order to find the one which starts with the required channel.
+	circularRoutesNum = 20
+
+	// circularFinalCltvDelta is the time lock delta of the final hop of
+	// the circular payment.
+	circularFinalCltvDelta = 144
+)
+
 // Runtime check to ensure that Client implements
 // lightning. PaymentClient interface.
 var _ lightning.PaymentClient = (*Client)(nil)
@@ -166,6 +183,214 @@ func (c *Client) SendPaymentToRoute(route *lightning.Route,
 		common.GetFunctionName())
 }
 
+// SendCircularPayment sends payment from our node back to our node,
+// which leaves through the outgoing channel and returns through the
+// incoming one, and in this way moves local balance between channels.
+// Payment is not sent if routing fee is greater than the given max fee.
+//
+// NOTE: Part of the lightning.PaymentClient interface.
+func (c *Client) SendCircularPayment(outgoing, incoming lightning.ChannelID,
+	amount, maxFee btcutil.Amount) (*lightning.Payment, error) {
+
+	select {
+	case <-c.startedTrigger:
+	}
+
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	outgoingInfo, err := c.cfg.Storage.GetChannelAdditionalInfoByID(outgoing)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to get outgoing channel(%v) "+
+			"info: %v", outgoing, err)
+	}
+
+	incomingInfo, err := c.cfg.Storage.GetChannelAdditionalInfoByID(incoming)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to get incoming channel(%v) "+
+			"info: %v", incoming, err)
+	}
+
+	// Payment will return to us through the incoming channel, for that
+	// reason we should take into account the fee and time lock which remote
+	// node of the channel requires for forwarding the payment to us.
+	edge, err := c.rpc.GetChanInfo(timeout(30), &lnrpc.ChanInfoRequest{
+		ChanId: incomingInfo.ShortChannelID,
+	})
+	if err != nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("unable to get incoming channel(%v) "+
+			"edge: %v", incoming, err)
+	}
+
+	policy := edge.Node1Policy
+	if edge.Node2Pub == string(incomingInfo.NodeID) {
+		policy = edge.Node2Policy
+	}
+
+	if policy == nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("routing policy of incoming channel(%v)"+
+			" is unknown", incoming)
+	}
+
+	lastHopFee := hopFee(policy, amount)
+	if lastHopFee >= maxFee {
+		return nil, errors.Errorf("fee(%v) of incoming channel(%v) is "+
+			"greater than max fee(%v)", lastHopFee, incoming, maxFee)
+	}
+
+	info, err := c.rpc.GetInfo(timeout(30), &lnrpc.GetInfoRequest{})
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable get lnd node info: %v", err)
+	}
+
+	// Query routes to the remote node of the incoming channel, and choose
+	// the one which starts with the outgoing channel.
+	resp, err := c.rpc.QueryRoutes(timeout(30), &lnrpc.QueryRoutesRequest{
+		PubKey:         string(incomingInfo.NodeID),
+		Amt:            int64(amount + lastHopFee),
+		NumRoutes:      circularRoutesNum,
+		FinalCltvDelta: int32(circularFinalCltvDelta + policy.TimeLockDelta),
+		FeeLimit: &lnrpc.FeeLimit{
+			Limit: &lnrpc.FeeLimit_Fixed{
+				Fixed: int64(maxFee - lastHopFee),
+			},
+		},
+	})
+	if err != nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("unable to query routes: %v", err)
+	}
+
+	var route *lnrpc.Route
+	for _, r := range resp.Routes {
+		if isCircularRouteCandidate(r, outgoingInfo.ShortChannelID,
+			incomingInfo.ShortChannelID, c.lightningNodeUserID) {
+			route = r
+			break
+		}
+	}
+
+	if route == nil {
+		return nil, errors.Errorf("unable to find route from channel(%v) "+
+			"to channel(%v)", outgoing, incoming)
+	}
+
+	// Remote node of the incoming channel becomes intermediate hop,
+	// and forwards the payment to us, taking its fee.
+	finalExpiry := info.BlockHeight + circularFinalCltvDelta
+
+	lastHop := route.Hops[len(route.Hops)-1]
+	lastHop.AmtToForward = int64(amount)
+	lastHop.AmtToForwardMsat = int64(amount) * 1000
+	lastHop.Fee = int64(lastHopFee)
+	lastHop.FeeMsat = int64(lastHopFee) * 1000
+	lastHop.Expiry = finalExpiry
+
+	route.Hops = append(route.Hops, &lnrpc.Hop{
+		ChanId:           incomingInfo.ShortChannelID,
+		ChanCapacity:     edge.Capacity,
+		AmtToForward:     int64(amount),
+		AmtToForwardMsat: int64(amount) * 1000,
+		Expiry:           finalExpiry,
+		PubKey:           string(c.lightningNodeUserID),
+	})
+
+	route.TotalFees = route.TotalAmt - int64(amount)
+	route.TotalFeesMsat = route.TotalFees * 1000
+
+	if btcutil.Amount(route.TotalFees) > maxFee {
+		return nil, errors.Errorf("route fee(%v) is greater than max "+
+			"fee(%v)", btcutil.Amount(route.TotalFees), maxFee)
+	}
+
+	// Create the invoice which we will pay to ourselves, it is marked
+	// as internal, so that incoming payment would not be confused with
+	// the external one.
+	invoice, err := c.rpc.AddInvoice(timeout(30), &lnrpc.Invoice{
+		Receipt: []byte(internalReceipt),
+		Value:   int64(amount),
+		Memo:    fmt.Sprintf("rebalance %v -> %v", outgoing, incoming),
+	})
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to create invoice: %v", err)
+	}
+
+	sendResp, err := c.rpc.SendToRouteSync(timeout(60),
+		&lnrpc.SendToRouteRequest{
+			PaymentHash: invoice.RHash,
+			Routes:      []*lnrpc.Route{route},
+		})
+	if err != nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("unable to send payment: %v", err)
+	}
+
+	if sendResp.PaymentError != "" {
+		m.AddError(metrics.LowSeverity)
+		return nil, errors.Errorf("unable to send payment: %v",
+			sendResp.PaymentError)
+	}
+
+	payment := &lightning.Payment{
+		PaymentID:   "",
+		Receiver:    c.lightningNodeUserID,
+		FirstHop:    lightning.NodeID(route.Hops[0].PubKey),
+		UpdatedAt:   time.Now().Unix(),
+		Status:      lightning.Completed,
+		Direction:   lightning.Outgoing,
+		System:      lightning.Internal,
+		Amount:      amount,
+		MediaFee:    btcutil.Amount(route.TotalFees),
+		PaymentHash: lightning.PaymentHash(hex.EncodeToString(invoice.RHash)),
+	}
+
+	// lnd doesn't expose the stream of outgoing payments, for that reason
+	// payments are broadcast when they are sent by the hub, payments sent
+	// directly through lnd aren't broadcast.
+	c.broadcaster.Write(&lightning.UpdatePayment{
+		Payment: payment,
+	})
+
+	return payment, nil
+}
+
+// isCircularRouteCandidate checks that route starts with the outgoing
+// channel, and doesn't pass through our node or incoming channel,
+// so that it could be extended to return back to us.
+func isCircularRouteCandidate(route *lnrpc.Route, outgoing,
+	incoming uint64, ourNodeID lightning.NodeID) bool {
+
+	if len(route.Hops) == 0 || route.Hops[0].ChanId != outgoing {
+		return false
+	}
+
+	for _, hop := range route.Hops {
+		if hop.ChanId == incoming || hop.PubKey == string(ourNodeID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hopFee returns fee which node requires for forwarding of the given
+// amount according to its routing policy. Fee rate of the policy is in
+// millionths of the amount, fee is rounded up to satoshi, so that hop is
+// not underpaid.
+func hopFee(policy *lnrpc.RoutingPolicy, amount btcutil.Amount) btcutil.Amount {
+	feeMsat := policy.FeeBaseMsat +
+		int64(amount)*1000*policy.FeeRateMilliMsat/1000000
+
+	return btcutil.Amount((feeMsat + 999) / 1000)
+}
+
 // CreateInvoice is used to create lightning network invoice.
 //
 // NOTE: Part of the lightning.PaymentClient interface.
@@ -253,6 +478,22 @@ func (c *Client) ListPayments(asset string, status lightning.PaymentStatus,
 	direction lightning.PaymentDirection, system lightning.PaymentSystem) (
 	[]*lightning.Payment, error) {
 
+	return c.FilterPayments(lightning.PaymentsFilter{
+		Status:    status,
+		Direction: direction,
+		System:    system,
+	})
+}
+
+// FilterPayments returns list of incoming and outgoing payments, which
+// satisfy the given filter. Lnd doesn't support filtering of payments, but
+// payments are filtered before the storage lookups, which are needed to
+// find the sender of incoming payment.
+//
+// NOTE: Part of the lightning.PaymentClient interface.
+func (c *Client) FilterPayments(filter lightning.PaymentsFilter) (
+	[]*lightning.Payment, error) {
+
 	select {
 	case <-c.startedTrigger:
 	}
@@ -261,73 +502,213 @@ func (c *Client) ListPayments(asset string, status lightning.PaymentStatus,
 		c.cfg.MetricsBackend)
 	defer m.Finish()
 
-	incomingPayments, err := fetchInvoicePayments(c.rpc)
-	if err != nil {
-		m.AddError(metrics.HighSeverity)
-		err := errors.Errorf("unable to fetch incoming payments: %v", err)
-		log.Error(err)
-		return nil, err
+	// Lnd payments are always completed.
+	if filter.Status != lightning.AllStatuses &&
+		filter.Status != lightning.Completed {
+		return nil, nil
 	}
 
-	outgoingPayments, err := fetchOutgoingPayments(c.rpc)
+	var payments []*lightning.Payment
+
+	if filter.Direction == lightning.AllDirections ||
+		filter.Direction == lightning.Incoming {
+		incomingPayments, err := c.filterIncomingPayments(filter, m)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			err := errors.Errorf("unable to fetch incoming payments: %v", err)
+			log.Error(err)
+			return nil, err
+		}
+		payments = append(payments, incomingPayments...)
+	}
+
+	if filter.Direction == lightning.AllDirections ||
+		filter.Direction == lightning.Outgoing {
+		outgoingPayments, err := c.filterOutgoingPayments(filter)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			err := errors.Errorf("unable to fetch outgoing payments: %v", err)
+			log.Error(err)
+			return nil, err
+		}
+		payments = append(payments, outgoingPayments...)
+	}
+
+	// Incoming and outgoing payments are limited separately, for that
+	// reason their union should be limited once again.
+	return limitPayments(filter, payments), nil
+}
+
+// filterIncomingPayments returns settled invoices which satisfy the given
+// filter, converted to incoming payments, newest first if limit is
+// specified. Lnd lists invoices
+// in order of creation rather than settlement, for that reason all of them
+// are fetched, but the storage lookups, which are needed to find the sender,
+// are done only for the limited number of the newest matching invoices.
+func (c *Client) filterIncomingPayments(filter lightning.PaymentsFilter,
+	m crypto.Metric) ([]*lightning.Payment, error) {
+
+	invoices, err := fetchInvoicePayments(c.rpc,
+		func(invoice *lnrpc.Invoice) bool {
+			if !invoice.Settled {
+				return false
+			}
+
+			// Invoices which were created for the circular payments are
+			// the part of the node re-balancing.
+			internal := string(invoice.Receipt) == internalReceipt
+			if (filter.System == lightning.Internal && !internal) ||
+				(filter.System == lightning.External && internal) {
+				return false
+			}
+
+			return filter.MatchTime(invoice.SettleDate) &&
+				filter.MatchAmount(btcutil.Amount(invoice.AmtPaidSat))
+		})
 	if err != nil {
-		m.AddError(metrics.HighSeverity)
-		err := errors.Errorf("unable to fetch outgoing payments: %v", err)
-		log.Error(err)
 		return nil, err
 	}
 
+	if filter.Limit != 0 {
+		sort.SliceStable(invoices, func(i, j int) bool {
+			return invoices[i].SettleDate > invoices[j].SettleDate
+		})
+	}
+
 	var payments []*lightning.Payment
-	for _, incomingPayment := range incomingPayments {
-		if !incomingPayment.Settled {
+	for _, invoice := range invoices {
+		if len(payments) > 0 && filter.LimitReached(len(payments),
+			payments[len(payments)-1].UpdatedAt, invoice.SettleDate) {
+			break
+		}
+
+		payment := c.convertInvoice(invoice, m)
+		if !filter.MatchNodes(payment.Sender) {
 			continue
 		}
 
-		payments = append(payments, &lightning.Payment{
-			PaymentID:   "",
-			Receiver:    c.lightningNodeUserID,
-			UpdatedAt:   incomingPayment.SettleDate,
-			Status:      lightning.Completed,
-			Direction:   lightning.Incoming,
-			System:      lightning.External,
-			Amount:      btcutil.Amount(incomingPayment.AmtPaidSat),
-			MediaFee:    0,
-			PaymentHash: lightning.PaymentHash(hex.EncodeToString(incomingPayment.RHash)),
-		})
+		payments = append(payments, payment)
+	}
+
+	return payments, nil
+}
+
+// convertInvoice converts settled invoice to the incoming payment.
+func (c *Client) convertInvoice(invoice *lnrpc.Invoice,
+	m crypto.Metric) *lightning.Payment {
+
+	// Invoices which were created for the circular payments are
+	// the part of the node re-balancing.
+	paymentSystem := lightning.External
+	if string(invoice.Receipt) == internalReceipt {
+		paymentSystem = lightning.Internal
+	}
+
+	payment := &lightning.Payment{
+		PaymentID:   "",
+		Receiver:    c.lightningNodeUserID,
+		UpdatedAt:   invoice.SettleDate,
+		Status:      lightning.Completed,
+		Direction:   lightning.Incoming,
+		System:      paymentSystem,
+		Amount:      btcutil.Amount(invoice.AmtPaidSat),
+		MediaFee:    0,
+		PaymentHash: lightning.PaymentHash(hex.EncodeToString(invoice.RHash)),
+	}
+
+	// Attribute payment to the channel and peer from which the
+	// biggest part of it has been received.
+	if htlc := getBiggestSettledHTLC(invoice.Htlcs); htlc != nil {
+		info, err := c.cfg.Storage.GetChannelAdditionalInfoByShortID(
+			htlc.ChanId)
+		if err != nil {
+			// TODO(andrew.shvv) cache might no be in sync
+			m.AddError(metrics.LowSeverity)
+			log.Errorf("unable to get sender id by short"+
+				" chan id(%v): %v", htlc.ChanId, err)
+		} else {
+			payment.Sender = info.NodeID
+			payment.FromChannel = info.ChannelID
+		}
+	}
+
+	return payment
+}
+
+// filterOutgoingPayments returns outgoing payments which satisfy the given
+// filter, newest first if limit is specified. Lnd doesn't support pagination of payments, for that
+// reason all of them are fetched.
+func (c *Client) filterOutgoingPayments(filter lightning.PaymentsFilter) (
+	[]*lightning.Payment, error) {
+
+	outgoingPayments, err := fetchOutgoingPayments(c.rpc)
+	if err != nil {
+		return nil, err
 	}
 
+	var payments []*lightning.Payment
 	for _, outgoingPayment := range outgoingPayments {
-		payments = append(payments, &lightning.Payment{
+		receiver := lightning.NodeID(outgoingPayment.Path[len(outgoingPayment.Path)-1])
+
+		// Payments to ourselves are circular payments which are used
+		// for the channel re-balancing.
+		paymentSystem := lightning.External
+		if receiver == c.lightningNodeUserID {
+			paymentSystem = lightning.Internal
+		}
+
+		payment := &lightning.Payment{
 			PaymentID:   "",
-			Receiver:    lightning.NodeID(outgoingPayment.Path[len(outgoingPayment.Path)-1]),
+			Receiver:    receiver,
+			FirstHop:    lightning.NodeID(outgoingPayment.Path[0]),
 			UpdatedAt:   outgoingPayment.CreationDate,
 			Status:      lightning.Completed,
 			Direction:   lightning.Outgoing,
-			System:      lightning.External,
+			System:      paymentSystem,
 			Amount:      btcutil.Amount(outgoingPayment.Value),
 			MediaFee:    btcutil.Amount(outgoingPayment.Fee),
 			PaymentHash: lightning.PaymentHash(outgoingPayment.PaymentHash),
-		})
-	}
-
-	var filteredPayment []*lightning.Payment
-	for _, payment := range payments {
-		if payment.Status != status && status != lightning.AllStatuses {
-			continue
 		}
 
-		if payment.Direction != direction && direction != lightning.AllDirections {
+		if filter.System != lightning.AllSystems &&
+			filter.System != payment.System {
 			continue
 		}
 
-		if payment.System != system && system != lightning.AllSystems {
+		if !filter.MatchTime(payment.UpdatedAt) ||
+			!filter.MatchAmount(payment.Amount) ||
+			!filter.MatchNodes(payment.Receiver) {
 			continue
 		}
 
-		filteredPayment = append(filteredPayment, payment)
+		payments = append(payments, payment)
+	}
+
+	return limitPayments(filter, payments), nil
+}
+
+// limitPayments sorts payments newest first, and leaves the number of them
+// which is restricted by the limit of the filter, payments are left intact
+// if limit isn't specified.
+func limitPayments(filter lightning.PaymentsFilter,
+	payments []*lightning.Payment) []*lightning.Payment {
+
+	if filter.Limit == 0 {
+		return payments
 	}
 
-	return filteredPayment, nil
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].UpdatedAt > payments[j].UpdatedAt
+	})
+
+	for i := 1; i < len(payments); i++ {
+		if filter.LimitReached(i, payments[i-1].UpdatedAt,
+			payments[i].UpdatedAt) {
+			return payments[:i]
+		}
+	}
+
+	return payments
 }
 
 // ListForwardPayments returns list of forward payments which were routed
@@ -335,6 +716,17 @@ func (c *Client) ListPayments(asset string, status lightning.PaymentStatus,
 //
 // NOTE: Part of the lightning.PaymentClient interface.
 func (c *Client) ListForwardPayments() ([]*lightning.ForwardPayment, error) {
+	return c.FilterForwardPayments(lightning.PaymentsFilter{})
+}
+
+// FilterForwardPayments returns list of forward payments which were routed
+// thorough lightning node, and which satisfy the given filter. Range of time
+// is passed to lnd, amount and limit are checked before the storage lookups.
+//
+// NOTE: Part of the lightning.PaymentClient interface.
+func (c *Client) FilterForwardPayments(filter lightning.PaymentsFilter) (
+	[]*lightning.ForwardPayment, error) {
+
 	select {
 	case <-c.startedTrigger:
 	}
@@ -343,7 +735,23 @@ func (c *Client) ListForwardPayments() ([]*lightning.ForwardPayment, error) {
 		c.cfg.MetricsBackend)
 	defer m.Finish()
 
-	events, err := fetchForwardingPayments(c.rpc, 0)
+	// Lnd excludes the end time from the range, and treats zero start
+	// time as the absence of the range.
+	start := uint64(1)
+	if filter.Start > 0 {
+		start = uint64(filter.Start)
+	}
+
+	end := uint64(time.Now().Unix() + 1)
+	if filter.End > 0 {
+		end = uint64(filter.End)
+	}
+
+	if start >= end {
+		return nil, nil
+	}
+
+	events, err := fetchForwardingPaymentsInRange(c.rpc, 0, start, end)
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
 		err := errors.Errorf("unable to get forwarding events: %v", err)
@@ -351,6 +759,48 @@ func (c *Client) ListForwardPayments() ([]*lightning.ForwardPayment, error) {
 		return nil, err
 	}
 
+	// Events are converted starting from the newest one, so that storage
+	// lookups are done only for the events within the limit.
+	var forwardPayments []*lightning.ForwardPayment
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		if !filter.MatchAmount(btcutil.Amount(event.AmtOut)) {
+			continue
+		}
+
+		if len(forwardPayments) > 0 && filter.LimitReached(
+			len(forwardPayments), forwardPayments[len(forwardPayments)-1].Time,
+			int64(event.Timestamp)) {
+			break
+		}
+
+		converted := c.convertForwardingEvents(
+			[]*lnrpc.ForwardingEvent{event}, m)
+		if len(converted) == 0 {
+			continue
+		}
+
+		payment := converted[0]
+		if !filter.MatchNodes(payment.FromNode, payment.ToNode) {
+			continue
+		}
+		forwardPayments = append(forwardPayments, payment)
+	}
+
+	// Forward payments are returned in order of time.
+	for i, j := 0, len(forwardPayments)-1; i < j; i, j = i+1, j-1 {
+		forwardPayments[i], forwardPayments[j] =
+			forwardPayments[j], forwardPayments[i]
+	}
+
+	return forwardPayments, nil
+}
+
+// convertForwardingEvents converts lnd forwarding events in forward
+// payments, events which channels are unknown are skipped.
+func (c *Client) convertForwardingEvents(events []*lnrpc.ForwardingEvent,
+	m crypto.Metric) []*lightning.ForwardPayment {
+
 	var forwardPayments []*lightning.ForwardPayment
 	for _, event := range events {
 
@@ -384,7 +834,7 @@ func (c *Client) ListForwardPayments() ([]*lightning.ForwardPayment, error) {
 		})
 	}
 
-	return forwardPayments, nil
+	return forwardPayments
 }
 
 // PaymentByInvoice returns payment by given lightning network invoice.
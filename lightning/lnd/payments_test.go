@@ -0,0 +1,117 @@
+package lnd
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"testing"
+)
+
+func TestHopFee(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *lnrpc.RoutingPolicy
+		amount btcutil.Amount
+		fee    btcutil.Amount
+	}{
+		{
+			name: "base fee only",
+			policy: &lnrpc.RoutingPolicy{
+				FeeBaseMsat: 1000,
+			},
+			amount: 100000,
+			fee:    1,
+		},
+		{
+			name: "fee rate only",
+			policy: &lnrpc.RoutingPolicy{
+				FeeRateMilliMsat: 1000,
+			},
+			amount: 100000,
+			fee:    100,
+		},
+		{
+			name: "base fee and fee rate",
+			policy: &lnrpc.RoutingPolicy{
+				FeeBaseMsat:      1000,
+				FeeRateMilliMsat: 1,
+			},
+			amount: 1000000,
+			fee:    2,
+		},
+		{
+			name: "fee is rounded up",
+			policy: &lnrpc.RoutingPolicy{
+				FeeBaseMsat:      1,
+				FeeRateMilliMsat: 1,
+			},
+			amount: 10000,
+			fee:    1,
+		},
+	}
+
+	for _, test := range tests {
+		if fee := hopFee(test.policy, test.amount); fee != test.fee {
+			t.Fatalf("(%v) wrong fee: expected(%v), got(%v)", test.name,
+				test.fee, fee)
+		}
+	}
+}
+
+func TestLimitPayments(t *testing.T) {
+	newPayments := func(times ...int64) []*lightning.Payment {
+		var payments []*lightning.Payment
+		for _, time := range times {
+			payments = append(payments, &lightning.Payment{UpdatedAt: time})
+		}
+		return payments
+	}
+
+	tests := []struct {
+		name     string
+		limit    int
+		times    []int64
+		expected []int64
+	}{
+		{
+			name:     "no limit",
+			times:    []int64{1, 3, 2},
+			expected: []int64{1, 3, 2},
+		},
+		{
+			name:     "newest payments",
+			limit:    2,
+			times:    []int64{1, 3, 2},
+			expected: []int64{3, 2},
+		},
+		{
+			name:     "payments with the same time as the last one",
+			limit:    2,
+			times:    []int64{1, 2, 3, 2, 2},
+			expected: []int64{3, 2, 2, 2},
+		},
+		{
+			name:     "less payments than limit",
+			limit:    5,
+			times:    []int64{1, 2},
+			expected: []int64{2, 1},
+		},
+	}
+
+	for _, test := range tests {
+		filter := lightning.PaymentsFilter{Limit: test.limit}
+		payments := limitPayments(filter, newPayments(test.times...))
+
+		if len(payments) != len(test.expected) {
+			t.Fatalf("(%v) wrong number of payments: %v", test.name,
+				len(payments))
+		}
+
+		for i, payment := range payments {
+			if payment.UpdatedAt != test.expected[i] {
+				t.Fatalf("(%v) wrong payment(%v) time: %v", test.name, i,
+					payment.UpdatedAt)
+			}
+		}
+	}
+}
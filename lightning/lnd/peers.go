@@ -0,0 +1,93 @@
+package lnd
+
+import (
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/go-errors/errors"
+	"time"
+)
+
+// peersCheckDelay is the delay between checks of the connected peers.
+// Used version of lnd doesn't provide subscription on peer events, that is
+// why connections and disconnections are detected by the frequent checks
+// of the peers list.
+const peersCheckDelay = time.Second * 10
+
+// listenPeerUpdates keeps track of the connected peers, and broadcasts
+// updates about peers connections and disconnections.
+//
+// NOTE: Should run as goroutine.
+func (c *Client) listenPeerUpdates() {
+	defer func() {
+		log.Info("Stopped peer updates goroutine")
+		c.wg.Done()
+	}()
+
+	log.Info("Started peer updates goroutine")
+
+	var connected map[lightning.NodeID]struct{}
+	for {
+		peers, err := c.checkPeers(connected)
+		if err != nil {
+			log.Errorf("(peer updates) unable check peers: %v", err)
+		} else {
+			connected = peers
+		}
+
+		select {
+		case <-time.After(peersCheckDelay):
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// checkPeers fetches the list of connected peers, compares it with the
+// previously connected ones, and broadcasts the changes. If previous list
+// is nil, than nothing is broadcasted, because there is nothing to compare
+// with.
+func (c *Client) checkPeers(previous map[lightning.NodeID]struct{}) (
+	map[lightning.NodeID]struct{}, error) {
+
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	peers, err := fetchUsers(c.rpc)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to list peers: %v", err)
+	}
+
+	now := time.Now().Unix()
+
+	connected := make(map[lightning.NodeID]struct{}, len(peers))
+	for _, peer := range peers {
+		nodeID := lightning.NodeID(peer.PubKey)
+		connected[nodeID] = struct{}{}
+
+		if _, ok := previous[nodeID]; ok || previous == nil {
+			continue
+		}
+
+		c.broadcaster.Write(&lightning.UpdatePeerConnected{
+			NodeID: nodeID,
+			Time:   now,
+		})
+	}
+
+	for nodeID := range previous {
+		if _, ok := connected[nodeID]; ok {
+			continue
+		}
+
+		c.broadcaster.Write(&lightning.UpdatePeerDisconnected{
+			NodeID: nodeID,
+			Time:   now,
+		})
+	}
+
+	return connected, nil
+}
@@ -3,7 +3,6 @@ package lnd
 import (
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/lnrpc"
-	"math"
 	"time"
 )
 
@@ -71,20 +70,52 @@ func fetchChannels(c lnrpc.LightningClient) (
 		respClosedChannels.Channels, nil
 }
 
-// fetchInvoicePayments fetches the information about invoices which were
-// created by lightning network node, and its state.
-func fetchInvoicePayments(c lnrpc.LightningClient) (
-	[]*lnrpc.Invoice, error) {
+// fetchInvoicePayments gradually fetches the invoices which were created by
+// lightning network node, and returns the ones which satisfy the given match
+// function, in order of creation.
+func fetchInvoicePayments(c lnrpc.LightningClient,
+	match func(invoice *lnrpc.Invoice) bool) ([]*lnrpc.Invoice, error) {
 
-	reqInfo := &lnrpc.ListInvoiceRequest{
-		NumMaxInvoices: math.MaxInt16,
+	var invoices []*lnrpc.Invoice
+	var limit uint64 = 1000
+
+	// Fetch invoices by chunks, in order to avoid message overflow errors,
+	// in reversed order offset is the exclusive upper bound of the index,
+	// and zero offset means the last invoice.
+	var offset uint64
+	for {
+		req := &lnrpc.ListInvoiceRequest{
+			IndexOffset:    offset,
+			NumMaxInvoices: limit,
+			Reversed:       true,
+		}
+
+		resp, err := c.ListInvoices(timeout(30), req)
+		if err != nil {
+			return nil, err
+		}
+
+		// Invoices are returned in ascending order of the index within
+		// the chunk, chunks go in descending order.
+		for i := len(resp.Invoices) - 1; i >= 0; i-- {
+			if match(resp.Invoices[i]) {
+				invoices = append(invoices, resp.Invoices[i])
+			}
+		}
+
+		// If daemon returned less than a limit it means that we reached the
+		// beginning of the invoices list.
+		if uint64(len(resp.Invoices)) < limit || resp.FirstIndexOffset <= 1 {
+			break
+		}
+		offset = resp.FirstIndexOffset
 	}
-	resp, err := c.ListInvoices(timeout(30), reqInfo)
-	if err != nil {
-		return nil, err
+
+	for i, j := 0, len(invoices)-1; i < j; i, j = i+1, j-1 {
+		invoices[i], invoices[j] = invoices[j], invoices[i]
 	}
 
-	return resp.Invoices, nil
+	return invoices, nil
 }
 
 // fetchNodeInfo fetched information about hub node.
@@ -113,6 +144,15 @@ func fetchOutgoingPayments(c lnrpc.LightningClient) ([]*lnrpc.Payment, error) {
 func fetchForwardingPayments(c lnrpc.LightningClient, index uint32) (
 	[]*lnrpc.ForwardingEvent, error) {
 
+	return fetchForwardingPaymentsInRange(c, index, 1,
+		uint64(time.Now().Unix()))
+}
+
+// fetchForwardingPaymentsInRange gradually fetches the forwarding events
+// from lightning daemon, which happened within [start, end) range of time.
+func fetchForwardingPaymentsInRange(c lnrpc.LightningClient, index uint32,
+	start, end uint64) ([]*lnrpc.ForwardingEvent, error) {
+
 	var events []*lnrpc.ForwardingEvent
 	var limit uint32 = 1000
 
@@ -120,8 +160,8 @@ func fetchForwardingPayments(c lnrpc.LightningClient, index uint32) (
 	// overflow errors, lnd error response is restricted to ~50k updates.
 	for {
 		req := &lnrpc.ForwardingHistoryRequest{
-			StartTime:    1,
-			EndTime:      uint64(time.Now().Unix()),
+			StartTime:    start,
+			EndTime:      end,
 			IndexOffset:  index,
 			NumMaxEvents: limit,
 		}
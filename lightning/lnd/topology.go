@@ -8,10 +8,16 @@ import (
 	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/lnrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"strconv"
 	"strings"
 )
 
+// defaultTimeLockDelta is the time lock delta which is used on channel
+// policy update, if the current one is unknown.
+const defaultTimeLockDelta = 144
+
 // Runtime check to ensure that Client implements lightning.
 // TopologyClient interface.
 var _ lightning.TopologyClient = (*Client)(nil)
@@ -213,6 +219,7 @@ func (c *Client) Channels() ([]*lightning.Channel, error) {
 			RemoteBalance: btcutil.Amount(pendingClosingInfo.Channel.RemoteBalance),
 			LocalBalance:  btcutil.Amount(pendingClosingInfo.Channel.LocalBalance),
 			LockedBalance: 0,
+			CloseType:     lightning.CooperativeClose,
 		}
 
 		channels = append(channels, &lightning.Channel{
@@ -264,6 +271,7 @@ func (c *Client) Channels() ([]*lightning.Channel, error) {
 			RemoteBalance: btcutil.Amount(pendingClosingInfo.Channel.RemoteBalance),
 			LocalBalance:  btcutil.Amount(pendingClosingInfo.Channel.LocalBalance),
 			LockedBalance: btcutil.Amount(pendingClosingInfo.LimboBalance),
+			CloseType:     lightning.LocalForceClose,
 		}
 
 		channels = append(channels, &lightning.Channel{
@@ -322,6 +330,7 @@ func (c *Client) Channels() ([]*lightning.Channel, error) {
 			RemoteBalance: infoFromDB.ClosingRemoteBalance,
 			LocalBalance:  infoFromDB.ClosingLocalBalance,
 			LockedBalance: 0,
+			CloseType:     getCloseType(closeSummary.CloseType),
 		}
 
 		stateMap[lightning.ChannelClosed] = &lightning.ChannelStateClosed{
@@ -361,6 +370,11 @@ func (c *Client) OpenChannel(nodeID lightning.NodeID, funds btcutil.Amount) erro
 
 	if _, err := c.rpc.OpenChannelSync(timeout(200), req); err != nil {
 		m.AddError(metrics.HighSeverity)
+		if status.Code(err) == codes.DeadlineExceeded {
+			log.Errorf("open channel request has timed out: %v", err)
+			return lightning.ErrTimeout
+		}
+
 		err := errors.Errorf("unable to send open channel request: %v", err)
 		log.Error(err)
 		return err
@@ -408,6 +422,11 @@ func (c *Client) CloseChannel(id lightning.ChannelID) error {
 
 	if _, err = c.rpc.CloseChannel(timeout(30), req); err != nil {
 		m.AddError(metrics.HighSeverity)
+		if status.Code(err) == codes.DeadlineExceeded {
+			log.Errorf("close channel request has timed out: %v", err)
+			return lightning.ErrTimeout
+		}
+
 		err := errors.Errorf("unable close the channel: %v", err)
 		log.Error(err)
 		return err
@@ -481,6 +500,134 @@ func (c *Client) ConnectToNode(nodeID lightning.NodeID) error {
 	}
 }
 
+// ConnectedPeers returns nodes which are connected to us with tcp / ip
+// connection.
+//
+// NOTE: Part of the lightning.TopologyClient interface.
+func (c *Client) ConnectedPeers() ([]lightning.NodeID, error) {
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	peers, err := fetchUsers(c.rpc)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable to list peers: %v", err)
+	}
+
+	nodes := make([]lightning.NodeID, len(peers))
+	for i, peer := range peers {
+		nodes[i] = lightning.NodeID(peer.PubKey)
+	}
+
+	return nodes, nil
+}
+
+// FeePolicies returns the current routing fee policies of our channels.
+//
+// NOTE: Part of the lightning.TopologyClient interface.
+func (c *Client) FeePolicies() (map[lightning.ChannelID]*lightning.FeePolicy,
+	error) {
+
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	resp, err := c.rpc.FeeReport(timeout(30), &lnrpc.FeeReportRequest{})
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		err := errors.Errorf("unable to get fee report: %v", err)
+		log.Error(err)
+		return nil, err
+	}
+
+	policies := make(map[lightning.ChannelID]*lightning.FeePolicy,
+		len(resp.ChannelFees))
+	for _, channelFee := range resp.ChannelFees {
+		policies[lightning.ChannelID(channelFee.ChanPoint)] = &lightning.FeePolicy{
+			BaseFeeMsat: channelFee.BaseFeeMsat,
+			FeeRatePPM:  channelFee.FeePerMil,
+		}
+	}
+
+	return policies, nil
+}
+
+// UpdateFeePolicy updates the routing fee policy of the given channel.
+//
+// NOTE: Part of the lightning.TopologyClient interface.
+func (c *Client) UpdateFeePolicy(channelID lightning.ChannelID,
+	policy *lightning.FeePolicy) error {
+
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	txID, txIndex, err := splitChannelPoint(string(channelID))
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return err
+	}
+
+	index, err := strconv.ParseUint(txIndex, 10, 32)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable decode tx index: %v", err)
+	}
+
+	// Policy update requires time lock delta to be specified, for that
+	// reason we should fetch the current one to keep it unchanged.
+	info, err := c.cfg.Storage.GetChannelAdditionalInfoByID(channelID)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to get channel(%v) info: %v",
+			channelID, err)
+	}
+
+	edge, err := c.rpc.GetChanInfo(timeout(30), &lnrpc.ChanInfoRequest{
+		ChanId: info.ShortChannelID,
+	})
+	if err != nil {
+		m.AddError(metrics.LowSeverity)
+		return errors.Errorf("unable to get channel(%v) edge: %v",
+			channelID, err)
+	}
+
+	ourPolicy := edge.Node1Policy
+	if edge.Node2Pub == string(c.lightningNodeUserID) {
+		ourPolicy = edge.Node2Policy
+	}
+
+	timeLockDelta := uint32(defaultTimeLockDelta)
+	if ourPolicy != nil && ourPolicy.TimeLockDelta != 0 {
+		timeLockDelta = ourPolicy.TimeLockDelta
+	}
+
+	req := &lnrpc.PolicyUpdateRequest{
+		Scope: &lnrpc.PolicyUpdateRequest_ChanPoint{
+			ChanPoint: &lnrpc.ChannelPoint{
+				FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+					FundingTxidStr: txID,
+				},
+				OutputIndex: uint32(index),
+			},
+		},
+		BaseFeeMsat:   policy.BaseFeeMsat,
+		FeeRate:       float64(policy.FeeRatePPM) / 1000000,
+		TimeLockDelta: timeLockDelta,
+	}
+
+	if _, err := c.rpc.UpdateChannelPolicy(timeout(30), req); err != nil {
+		m.AddError(metrics.HighSeverity)
+		err := errors.Errorf("unable to update channel(%v) policy: %v",
+			channelID, err)
+		log.Error(err)
+		return err
+	}
+
+	return nil
+}
+
 // checkNodeConn check that we actually were connected to node, because
 // sometimes even if response was without error, remote peer might close
 // connect with us for some reason.
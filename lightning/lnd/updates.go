@@ -0,0 +1,236 @@
+package lnd
+
+import (
+	"context"
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/go-errors/errors"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"time"
+)
+
+const (
+	// invoicesResubscribeDelay is the delay before the subscription on
+	// invoice updates is restored after failure.
+	invoicesResubscribeDelay = time.Second * 5
+
+	// forwardsSyncDelay is the delay between checks for the new forwarding
+	// payments, because lnd doesn't expose the stream of forwarding events.
+	forwardsSyncDelay = time.Second * 5
+)
+
+// listenInvoiceUpdates subscribes on lnd invoice updates, and broadcasts
+// settled invoices as incoming payments to the update receivers. Invoices
+// settled before the client start aren't broadcast.
+//
+// NOTE: Should run as goroutine.
+func (c *Client) listenInvoiceUpdates() {
+	defer func() {
+		log.Info("Stopped invoice updates goroutine")
+		c.wg.Done()
+	}()
+
+	log.Info("Started invoice updates goroutine")
+
+	// Zero settle index means that only invoices settled after the
+	// subscription will be received. After the subscription failure
+	// it is restored from the last received invoice, so that invoices
+	// settled in between aren't lost.
+	var settleIndex uint64
+
+	for {
+		index, err := c.receiveInvoiceUpdates(settleIndex)
+		if err != nil {
+			log.Errorf("(invoice updates) unable receive invoice "+
+				"updates: %v", err)
+		}
+		settleIndex = index
+
+		select {
+		case <-time.After(invoicesResubscribeDelay):
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// receiveInvoiceUpdates subscribes on lnd invoice updates starting from the
+// given settle index, and broadcasts settled invoices until subscription
+// fails or client is stopped. Settle index of the last broadcast invoice is
+// returned.
+func (c *Client) receiveInvoiceUpdates(settleIndex uint64) (uint64, error) {
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel subscription on client stop, otherwise receive would block
+	// forever.
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-c.quit:
+			cancel()
+		}
+	}()
+
+	req := &lnrpc.InvoiceSubscription{SettleIndex: settleIndex}
+	client, err := c.rpc.SubscribeInvoices(ctx, req)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return settleIndex, errors.Errorf("unable to subscribe on invoice "+
+			"updates: %v", err)
+	}
+
+	for {
+		invoice, err := client.Recv()
+		if err != nil {
+			select {
+			case <-c.quit:
+				return settleIndex, nil
+			default:
+			}
+
+			m.AddError(metrics.HighSeverity)
+			return settleIndex, errors.Errorf("unable to receive invoice "+
+				"update: %v", err)
+		}
+
+		// Subscription also notifies about added invoices, and might
+		// repeat already received ones after re-subscription.
+		if !invoice.Settled || invoice.SettleIndex <= settleIndex {
+			continue
+		}
+		settleIndex = invoice.SettleIndex
+
+		c.broadcaster.Write(&lightning.UpdatePayment{
+			Payment: c.convertInvoice(invoice, m),
+		})
+	}
+}
+
+// listenForwardUpdates checks for the new forwarding payments, and
+// broadcasts them to the update receivers. Forwarding payments which
+// happened before the client start aren't broadcast.
+//
+// NOTE: Should run as goroutine.
+func (c *Client) listenForwardUpdates() {
+	defer func() {
+		log.Info("Stopped forward updates goroutine")
+		c.wg.Done()
+	}()
+
+	log.Info("Started forward updates goroutine")
+
+	cursor := &forwardsCursor{
+		start: uint64(time.Now().Unix()),
+	}
+
+	synced := false
+	for {
+		// Forwarding events which happened in the same second with the
+		// client start should be skipped, for that reason the first
+		// fetch is done without broadcasting.
+		if err := c.broadcastNewForwardPayments(cursor, synced); err != nil {
+			log.Errorf("(forward updates) unable to broadcast forward "+
+				"payments: %v", err)
+		} else {
+			synced = true
+		}
+
+		select {
+		case <-time.After(forwardsSyncDelay):
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// forwardsCursor is the position of the next forwarding event, lnd forwarding
+// history could be only fetched by time range, and time of event has
+// precision of seconds, for that reason position is the time of the last
+// received event, and number of received events with the same time.
+type forwardsCursor struct {
+	start  uint64
+	offset uint32
+}
+
+// advance moves cursor forward on the given received events.
+func (c *forwardsCursor) advance(events []*lnrpc.ForwardingEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	last := events[len(events)-1].Timestamp
+	if last != c.start {
+		c.start = last
+		c.offset = 0
+	}
+
+	for _, event := range events {
+		if event.Timestamp == last {
+			c.offset++
+		}
+	}
+}
+
+// broadcastNewForwardPayments fetches forwarding events starting from the
+// given cursor, broadcasts them if needed, and advances the cursor.
+func (c *Client) broadcastNewForwardPayments(cursor *forwardsCursor,
+	broadcast bool) error {
+
+	m := crypto.NewMetric(c.cfg.Asset, common.GetFunctionName(),
+		c.cfg.MetricsBackend)
+	defer m.Finish()
+
+	events, err := fetchForwardingPaymentsInRange(c.rpc, cursor.offset,
+		cursor.start, uint64(time.Now().Unix())+1)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to get forwarding events: %v", err)
+	}
+
+	if broadcast {
+		for _, payment := range c.convertForwardingEvents(events, m) {
+			c.broadcaster.Write(&lightning.UpdateForwardPayment{
+				ForwardPayment: payment,
+			})
+		}
+	}
+
+	cursor.advance(events)
+	return nil
+}
+
+// saveChannelInfo saves channel additional info, and broadcasts the update
+// if channel state has been changed.
+func (c *Client) saveChannelInfo(prevState lightning.ChannelStateName,
+	info *ChannelAdditionalInfo) error {
+
+	if err := c.cfg.Storage.UpdateChannelAdditionalInfo(info); err != nil {
+		return err
+	}
+
+	if prevState == info.State {
+		return nil
+	}
+
+	// Channel which haven't been synced yet is new for us.
+	if prevState == channelNotSynced {
+		prevState = ""
+	}
+
+	c.broadcaster.Write(&lightning.UpdateChannelState{
+		ChannelID: info.ChannelID,
+		NodeID:    info.NodeID,
+		PrevState: prevState,
+		State:     info.State,
+		Time:      time.Now().Unix(),
+	})
+
+	return nil
+}
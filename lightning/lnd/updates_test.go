@@ -0,0 +1,56 @@
+package lnd
+
+import (
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"testing"
+)
+
+func TestForwardsCursorAdvance(t *testing.T) {
+	cursor := &forwardsCursor{start: 10}
+
+	steps := []struct {
+		name       string
+		timestamps []uint64
+		start      uint64
+		offset     uint32
+	}{
+		{
+			name:   "no events",
+			start:  10,
+			offset: 0,
+		},
+		{
+			name:       "events in the start second",
+			timestamps: []uint64{10, 10},
+			start:      10,
+			offset:     2,
+		},
+		{
+			name:       "more events in the start second",
+			timestamps: []uint64{10},
+			start:      10,
+			offset:     3,
+		},
+		{
+			name:       "events in the next seconds",
+			timestamps: []uint64{11, 12, 12},
+			start:      12,
+			offset:     2,
+		},
+	}
+
+	for _, step := range steps {
+		var events []*lnrpc.ForwardingEvent
+		for _, timestamp := range step.timestamps {
+			events = append(events, &lnrpc.ForwardingEvent{
+				Timestamp: timestamp,
+			})
+		}
+
+		cursor.advance(events)
+		if cursor.start != step.start || cursor.offset != step.offset {
+			t.Fatalf("(%v) wrong cursor: %v, %v", step.name, cursor.start,
+				cursor.offset)
+		}
+	}
+}
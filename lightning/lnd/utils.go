@@ -2,9 +2,11 @@ package lnd
 
 import (
 	"context"
+	"fmt"
 	"github.com/bitlum/hub/lightning"
 	"github.com/bitlum/hub/lightning/lnd/explorer"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
 	"github.com/lightningnetwork/lnd/lnrpc"
@@ -54,6 +56,61 @@ func getOpenInitiator(localBalance int64) lightning.ChannelInitiator {
 	}
 }
 
+// getCloseType converts lnd channel closure type to the close type of the
+// channel.
+func getCloseType(closeType lnrpc.ChannelCloseSummary_ClosureType) lightning.ChannelCloseType {
+	switch closeType {
+	case lnrpc.ChannelCloseSummary_COOPERATIVE_CLOSE:
+		return lightning.CooperativeClose
+	case lnrpc.ChannelCloseSummary_LOCAL_FORCE_CLOSE:
+		return lightning.LocalForceClose
+	case lnrpc.ChannelCloseSummary_REMOTE_FORCE_CLOSE:
+		return lightning.RemoteForceClose
+	case lnrpc.ChannelCloseSummary_BREACH_CLOSE:
+		return lightning.BreachClose
+	case lnrpc.ChannelCloseSummary_FUNDING_CANCELED:
+		return lightning.FundingCanceled
+	default:
+		return lightning.UnknownClose
+	}
+}
+
+// convertRoutingPolicy converts lnd routing policy to the fee policy of
+// the channel.
+func convertRoutingPolicy(policy *lnrpc.RoutingPolicy) *lightning.FeePolicy {
+	if policy == nil {
+		return nil
+	}
+
+	return &lightning.FeePolicy{
+		BaseFeeMsat: policy.FeeBaseMsat,
+		FeeRatePPM:  policy.FeeRateMilliMsat,
+	}
+}
+
+// convertChannelPoint converts channel point to the channel id, which is
+// the funding transaction id and output index separated by colon.
+func convertChannelPoint(point *lnrpc.ChannelPoint) (lightning.ChannelID,
+	error) {
+
+	if point == nil {
+		return "", errors.New("channel point is empty")
+	}
+
+	txID := point.GetFundingTxidStr()
+	if txID == "" {
+		hash, err := chainhash.NewHash(point.GetFundingTxidBytes())
+		if err != nil {
+			return "", errors.Errorf("unable decode funding tx id: %v", err)
+		}
+
+		txID = hash.String()
+	}
+
+	return lightning.ChannelID(fmt.Sprintf("%v:%v", txID,
+		point.OutputIndex)), nil
+}
+
 func splitChannelPoint(channelPoint string) (string, string, error) {
 	parts := strings.Split(channelPoint, ":")
 	if len(parts) != 2 {
@@ -106,3 +163,20 @@ func getShortChannelIDByChannelPoint(e explorer.Explorer, channelPoint string) (
 
 	return chanID.ToUint64(), nil
 }
+
+// getBiggestSettledHTLC returns the settled invoice htlc with the biggest
+// amount, nil is returned if there is no settled htlc.
+func getBiggestSettledHTLC(htlcs []*lnrpc.InvoiceHTLC) *lnrpc.InvoiceHTLC {
+	var biggest *lnrpc.InvoiceHTLC
+	for _, htlc := range htlcs {
+		if htlc.State != lnrpc.InvoiceHTLCState_SETTLED {
+			continue
+		}
+
+		if biggest == nil || htlc.AmtMsat > biggest.AmtMsat {
+			biggest = htlc
+		}
+	}
+
+	return biggest
+}
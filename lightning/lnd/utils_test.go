@@ -0,0 +1,67 @@
+package lnd
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"testing"
+)
+
+func TestConvertChannelPoint(t *testing.T) {
+	txID := "d1a2b0e4b3b5b0e1a9d6c7f8e9d0c1b2a3f4e5d6c7b8a9f0e1d2c3b4a5f6e7d8"
+	hash, err := chainhash.NewHashFromStr(txID)
+	if err != nil {
+		t.Fatalf("unable to decode tx id: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		point    *lnrpc.ChannelPoint
+		expected lightning.ChannelID
+		valid    bool
+	}{
+		{
+			name: "tx id string",
+			point: &lnrpc.ChannelPoint{
+				FundingTxid: &lnrpc.ChannelPoint_FundingTxidStr{
+					FundingTxidStr: txID,
+				},
+				OutputIndex: 1,
+			},
+			expected: lightning.ChannelID(txID + ":1"),
+			valid:    true,
+		},
+		{
+			name: "tx id bytes",
+			point: &lnrpc.ChannelPoint{
+				FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+					FundingTxidBytes: hash[:],
+				},
+			},
+			expected: lightning.ChannelID(txID + ":0"),
+			valid:    true,
+		},
+		{
+			name: "wrong tx id bytes",
+			point: &lnrpc.ChannelPoint{
+				FundingTxid: &lnrpc.ChannelPoint_FundingTxidBytes{
+					FundingTxidBytes: []byte{1, 2, 3},
+				},
+			},
+		},
+		{
+			name: "empty point",
+		},
+	}
+
+	for _, test := range tests {
+		channelID, err := convertChannelPoint(test.point)
+		if (err == nil) != test.valid {
+			t.Fatalf("(%v) wrong validity: %v", test.name, err)
+		}
+
+		if channelID != test.expected {
+			t.Fatalf("(%v) wrong channel id: %v", test.name, channelID)
+		}
+	}
+}
@@ -106,6 +106,18 @@ type Payment struct {
 	// Receiver is an identificator of node which received the payment.
 	Receiver NodeID
 
+	// Sender is an identificator of adjacent node from which incoming
+	// payment has been received, empty if it is unknown.
+	Sender NodeID
+
+	// FromChannel is the channel through which incoming payment has been
+	// received, empty if it is unknown.
+	FromChannel ChannelID
+
+	// FirstHop is an identificator of adjacent node through which outgoing
+	// payment has been sent.
+	FirstHop NodeID
+
 	// UpdatedAt denotes the time when payment object has been last updated.
 	UpdatedAt int64
 
@@ -131,3 +143,92 @@ type Payment struct {
 	// network.
 	PaymentHash PaymentHash
 }
+
+// PaymentsFilter is used to select payments, zero value of the field means
+// that payments aren't restricted by it.
+type PaymentsFilter struct {
+	// Status restricts incoming and outgoing payments by the stage of
+	// processing, forward payments are always completed.
+	Status PaymentStatus
+
+	// Direction restricts incoming and outgoing payments by the direction,
+	// forward payments don't have it.
+	Direction PaymentDirection
+
+	// System restricts incoming and outgoing payments by the system,
+	// forward payments don't have it.
+	System PaymentSystem
+
+	// Start and End restricts payments by [Start, End) range of time.
+	Start int64
+	End   int64
+
+	// MinAmount and MaxAmount restricts payments by [MinAmount, MaxAmount]
+	// range of amount, for forward payments the outgoing amount is used.
+	MinAmount btcutil.Amount
+	MaxAmount btcutil.Amount
+
+	// Nodes restricts payments by the nodes which sent or received them,
+	// for incoming payments it is the sender, for outgoing it is the
+	// receiver, for forward payments it is either of the adjacent nodes.
+	// Nil list doesn't restrict payments, but empty one rejects all of them.
+	Nodes []NodeID
+
+	// Limit restricts the number of the newest payments which are
+	// returned, payments with the same time as the last one are returned
+	// as well, so that payments could be paged by time. Zero value doesn't
+	// restrict payments.
+	Limit int
+}
+
+// MatchTime checks that the given time is within the range of the filter.
+func (f PaymentsFilter) MatchTime(time int64) bool {
+	if f.Start != 0 && time < f.Start {
+		return false
+	}
+
+	if f.End != 0 && time >= f.End {
+		return false
+	}
+
+	return true
+}
+
+// MatchAmount checks that the given amount is within the range of the
+// filter.
+func (f PaymentsFilter) MatchAmount(amount btcutil.Amount) bool {
+	if f.MinAmount != 0 && amount < f.MinAmount {
+		return false
+	}
+
+	if f.MaxAmount != 0 && amount > f.MaxAmount {
+		return false
+	}
+
+	return true
+}
+
+// LimitReached checks that payment with the given time shouldn't be
+// returned, because the given number of the newer payments, the last of
+// which has the given last time, already satisfy the limit of the filter.
+func (f PaymentsFilter) LimitReached(selected int, lastTime, time int64) bool {
+	return f.Limit != 0 && selected >= f.Limit && time != lastTime
+}
+
+// MatchNodes checks that either of the given nodes is in the list of nodes
+// of the filter.
+func (f PaymentsFilter) MatchNodes(nodes ...NodeID) bool {
+	if f.Nodes == nil {
+		return true
+	}
+
+	for _, filterNode := range f.Nodes {
+		for _, node := range nodes {
+			if node == filterNode {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,9 @@
 package lightning
 
-import "github.com/bitlum/hub/common/broadcast"
+import (
+	"github.com/bitlum/hub/common/broadcast"
+	"github.com/btcsuite/btcutil"
+)
 
 // UpdatesStreamer is an entity which send updates about lightning network node
 // updates.
@@ -66,3 +69,97 @@ type UpdateForwardPayment struct {
 func (u *UpdatePayment) String() string {
 	return "payment"
 }
+
+func (u *UpdateForwardPayment) String() string {
+	return "forward_payment"
+}
+
+// UpdateChannelState is sent whenever our channel changes its state.
+type UpdateChannelState struct {
+	ChannelID ChannelID
+	NodeID    NodeID
+
+	// PrevState is the previous state of the channel, empty if channel
+	// is new for us.
+	PrevState ChannelStateName
+	State     ChannelStateName
+
+	Time int64
+}
+
+func (u *UpdateChannelState) String() string {
+	return "channel_state"
+}
+
+// UpdateGraphNode is sent when node announcement has been received from the
+// lightning network.
+type UpdateGraphNode struct {
+	*GraphNode
+}
+
+func (u *UpdateGraphNode) String() string {
+	return "graph_node"
+}
+
+// UpdateGraphChannel is sent when channel update has been received from the
+// lightning network. Update contains routing policy of the advertising node
+// only.
+type UpdateGraphChannel struct {
+	ShortChannelID uint64
+
+	// ChannelID is the channel funding output, empty if it is unknown.
+	ChannelID ChannelID
+
+	Capacity btcutil.Amount
+
+	AdvertisingNode NodeID
+	ConnectingNode  NodeID
+
+	// Policy is the routing fee policy of the advertising node.
+	Policy *FeePolicy
+
+	Time int64
+}
+
+func (u *UpdateGraphChannel) String() string {
+	return "graph_channel"
+}
+
+// UpdateGraphChannelClosed is sent when channel has been removed from the
+// lightning network graph.
+type UpdateGraphChannelClosed struct {
+	ShortChannelID uint64
+	Capacity       btcutil.Amount
+
+	// ClosedHeight is the height of the block in which channel has been
+	// closed.
+	ClosedHeight uint32
+
+	Time int64
+}
+
+func (u *UpdateGraphChannelClosed) String() string {
+	return "graph_channel_closed"
+}
+
+// UpdatePeerConnected is sent when node has established tcp / ip connection
+// with us.
+type UpdatePeerConnected struct {
+	NodeID NodeID
+	Time   int64
+}
+
+func (u *UpdatePeerConnected) String() string {
+	return "peer_connected"
+}
+
+// UpdatePeerDisconnected is sent when node has lost tcp / ip connection
+// with us.
+type UpdatePeerDisconnected struct {
+	NodeID NodeID
+	Time   int64
+}
+
+func (u *UpdatePeerDisconnected) String() string {
+	return "peer_disconnected"
+}
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"github.com/bitlum/hub/fees"
 	"github.com/bitlum/hub/manager"
+	"github.com/bitlum/hub/topology"
 	"os"
 
 	"io"
@@ -50,12 +52,16 @@ var (
 	mainLog    = backendLog.Logger("MAIN")
 	lndLog     = backendLog.Logger("LND")
 	managerLog = backendLog.Logger("MNGR")
+	feesLog    = backendLog.Logger("FEES")
+	topoLog    = backendLog.Logger("TOPO")
 )
 
 // Initialize package-global logger variables.
 func init() {
 	lnd.UseLogger(lndLog)
 	manager.UseLogger(managerLog)
+	fees.UseLogger(feesLog)
+	topology.UseLogger(topoLog)
 }
 
 // subsystemLoggers maps each subsystem identifier to its associated logger.
@@ -63,6 +69,8 @@ var subsystemLoggers = map[string]btclog.Logger{
 	"BACKEND": mainLog,
 	"LND":     lndLog,
 	"MNGR":    managerLog,
+	"FEES":    feesLog,
+	"TOPO":    topoLog,
 }
 
 // initLogRotator initializes the logging rotator to write logs to logFile and
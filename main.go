@@ -4,24 +4,32 @@ import (
 	"fmt"
 	"github.com/bitlum/hub/common"
 	"github.com/bitlum/hub/db/inmemory"
+	"github.com/bitlum/hub/db/sqlite"
 	"github.com/bitlum/hub/lightning/lnd/explorer/bitcoind"
 	"github.com/bitlum/hub/manager"
 	"github.com/bitlum/hub/metrics/rpc"
+	"github.com/bitlum/hub/router"
 	"math"
 	"os"
 	"runtime"
 
 	"context"
+	"github.com/bitlum/hub/fees"
 	"github.com/bitlum/hub/graphql"
 	"github.com/bitlum/hub/hubrpc"
 	"github.com/bitlum/hub/lightning"
 	"github.com/bitlum/hub/lightning/lnd"
 	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/budget"
 	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/bitlum/hub/metrics/network"
+	"github.com/bitlum/hub/topology"
+	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
 	"github.com/jessevdk/go-flags"
 	"google.golang.org/grpc"
 	"net"
+	"net/http"
 	"path/filepath"
 )
 
@@ -86,6 +94,30 @@ func backendMain() error {
 			"", err)
 	}
 
+	budgetMetricsBackend, err := budget.InitMetricsBackend(config.LND.Network)
+	if err != nil {
+		return errors.Errorf("unable to init budget metrics backend: %v",
+			err)
+	}
+
+	networkMetricsBackend, err := network.InitMetricsBackend(config.LND.Network)
+	if err != nil {
+		return errors.Errorf("unable to init network metrics backend: %v",
+			err)
+	}
+
+	// Create or open database file to persist channels snapshots, which are
+	// used by node manager to detect drop of channels across restarts.
+	hubDBName := "hub.sqlite"
+	mainLog.Infof("Opening sqlite database, path: '%v'",
+		filepath.Join(config.LND.DataDir, hubDBName))
+
+	hubDB, err := sqlite.Open(config.LND.DataDir, hubDBName)
+	if err != nil {
+		return errors.Errorf("unable to open database: %v", err)
+	}
+	defer hubDB.Close()
+
 	explorer, err := bitcoind.NewExplorer(&bitcoind.Config{
 		RPCHost:  config.Bitcoind.Host,
 		RPCPort:  config.Bitcoind.Port,
@@ -142,12 +174,16 @@ func backendMain() error {
 	// have channels and connection to the important nodes.
 
 	managerConfig := &manager.Config{
-		Client:             client,
-		MetricsBackend:     metricsBackend,
-		GetBitcoinPriceUSD: common.GetBitcoinUSDPRice,
-		Asset:              "BTC",
-		OurName:            "bitlum.io",
-		OurNodeID:          lightning.NodeID(info.NodeInfo.IdentityPubKey),
+		Client:                client,
+		MetricsBackend:        metricsBackend,
+		BudgetMetricsBackend:  budgetMetricsBackend,
+		NetworkMetricsBackend: networkMetricsBackend,
+		SnapshotStorage:       hubDB,
+		GetBitcoinPriceUSD:    common.GetBitcoinUSDPRice,
+		CloseIdleChannels:     config.Manager.CloseIdleChannels,
+		Asset:                 "BTC",
+		OurName:               "bitlum.io",
+		OurNodeID:             lightning.NodeID(info.NodeInfo.IdentityPubKey),
 	}
 
 	switch config.LND.Network {
@@ -159,6 +195,7 @@ func backendMain() error {
 		managerConfig.MaxCommitFeeUSD = math.MaxInt32
 		managerConfig.MaxLimboUSD = math.MaxInt32
 		managerConfig.MaxStuckBalanceUSD = math.MaxInt32
+		managerConfig.MaxRebalanceFeePPM = 10000
 	case "mainnet":
 		managerConfig.MaxChannelSizeUSD = 400
 		managerConfig.MinChannelSizeUSD = 50
@@ -167,6 +204,7 @@ func backendMain() error {
 		managerConfig.MaxCommitFeeUSD = 50
 		managerConfig.MaxLimboUSD = 300
 		managerConfig.MaxStuckBalanceUSD = 300
+		managerConfig.MaxRebalanceFeePPM = 1000
 	}
 
 	nodeManager, err := manager.NewNodeManager(managerConfig)
@@ -177,14 +215,87 @@ func backendMain() error {
 	nodeManager.Start()
 	defer nodeManager.Stop("stop")
 
+	// Initialise and start topology subsystem, which would keep the history
+	// of lightning network graph changes.
+	graphTopology, err := topology.NewTopology(&topology.Config{
+		Client:                client,
+		Explorer:              explorer,
+		Streamer:              client,
+		Storage:               hubDB,
+		MetricsBackend:        metricsBackend,
+		NetworkMetricsBackend: networkMetricsBackend,
+		ImportantNodes:        nodeManager.ImportantNodes,
+		Anomaly: &topology.AnomalyConfig{
+			NetworkCapacityThreshold: config.Topology.NetworkCapacityThreshold,
+			NodeCapacityThreshold:    config.Topology.NodeCapacityThreshold,
+			NodeMinCapacity:          btcutil.Amount(config.Topology.NodeMinCapacity),
+			CapacityWindow:           config.Topology.CapacityWindow,
+			UptimeDropThreshold:      config.Topology.UptimeDropThreshold,
+			UptimeWindow:             config.Topology.UptimeWindow,
+		},
+		Asset: "BTC",
+	})
+	if err != nil {
+		return errors.Errorf("unable create topology: %v", err)
+	}
+
+	if err := graphTopology.Start(); err != nil {
+		return errors.Errorf("unable start topology: %v", err)
+	}
+	defer graphTopology.Stop("stop")
+
+	// Initialise and start fee policy manager, which would update routing
+	// fee policies of our channels accordingly with their balance and
+	// forwarding activity.
+	nodePolicies, err := parseNodePolicies(config.Fees.NodePolicies)
+	if err != nil {
+		return errors.Errorf("unable parse node fee policies: %v", err)
+	}
+
+	feeManager, err := fees.NewPolicyManager(&fees.Config{
+		Client:            client,
+		MetricsBackend:    metricsBackend,
+		Storage:           hubDB,
+		Asset:             "BTC",
+		DryRun:            config.Fees.DryRun,
+		UpdatePeriod:      config.Fees.UpdatePeriod,
+		MinUpdateInterval: config.Fees.MinUpdateInterval,
+		DefaultPolicy: &fees.NodePolicy{
+			BaseFeeMsat:   config.Fees.BaseFeeMsat,
+			MinFeeRatePPM: config.Fees.MinFeeRatePPM,
+			MaxFeeRatePPM: config.Fees.MaxFeeRatePPM,
+		},
+		NodePolicies: nodePolicies,
+	})
+	if err != nil {
+		return errors.Errorf("unable create fee policy manager: %v", err)
+	}
+
+	feeManager.Start()
+	defer feeManager.Stop("stop")
+
 	mainLog.Infof("Start GraphQL server serving on: %v",
 		net.JoinHostPort(config.GraphQL.ListenHost, config.GraphQL.ListenPort))
 	graphQLServer, err := graphql.NewServer(graphql.Config{
 		ListenIP:         config.GraphQL.ListenHost,
 		ListenPort:       config.GraphQL.ListenPort,
 		SecureListenPort: config.GraphQL.SecureListenPort,
+		TLSCertPath:      config.GraphQL.TLSCertPath,
+		TLSKeyPath:       config.GraphQL.TLSKeyPath,
+		TLSSelfSigned:    config.GraphQL.TLSSelfSigned,
+		TLSExtraIPs:      config.GraphQL.TLSExtraIPs,
+		TLSExtraDomains:  config.GraphQL.TLSExtraDomains,
+		RedirectHTTP:     config.GraphQL.RedirectHTTP,
 		Client:           client,
 		GetAlias:         nodeManager.GetAlias,
+		Streamer:         client,
+		FindNodesByAlias: nodeManager.FindNodesByAlias,
+		GetNodeStats:     nodeManager.GetNodeStats,
+		GetNetworkStats:  graphTopology.NetworkStats,
+
+		APIToken:           config.GraphQL.APIToken,
+		MutationsPerMinute: config.GraphQL.MutationsPerMinute,
+		QueriesPerMinute:   config.GraphQL.QueriesPerMinute,
 	})
 	if err != nil {
 		return errors.New("unable to create GraphQL server: " +
@@ -202,25 +313,53 @@ func backendMain() error {
 		}
 	}()
 
+	addReloadHandler(func() {
+		if err := graphQLServer.ReloadCertificate(); err != nil {
+			mainLog.Errorf("unable to reload GraphQL certificate: %v", err)
+		}
+	})
+
 	for nodeName, nodePubKey := range config.LND.KnownPeers {
 		nodeID := lightning.NodeID(nodePubKey)
 		nodeManager.AddImportantNode(nodeID, nodeName)
 	}
 
-	//paymentRouter, err := router.NewRouter(router.Config{Client: client})
-	//if err != nil {
-	//	return errors.Errorf("unable to create payment router: %v", err)
-	//}
+	paymentRouter, err := router.NewRouter(router.Config{
+		Client:  client,
+		Metrics: metricsBackend,
+		Net:     config.LND.Network,
+
+		GetBitcoinPriceUSDHistory: common.GetBitcoinUSDPriceHistory,
+	})
+	if err != nil {
+		return errors.Errorf("unable to create payment router: %v", err)
+	}
 
 	// Setup gRPC endpoint to receive the management commands, and initialise
 	// optimisation strategy which will dictate us how to convert from one
 	// lightning network node state to another.
-	grpcServer := grpc.NewServer([]grpc.ServerOption{}...)
+	certLoader, err := getCertLoader(config.Hub)
+	if err != nil {
+		return errors.Errorf("unable to init gRPC certificate: %v", err)
+	}
+
+	grpcOpts, err := getGRPCServerOptions(config.Hub, certLoader,
+		rpcMetricsBackend)
+	if err != nil {
+		return errors.Errorf("unable to init gRPC security: %v", err)
+	}
+	grpcServer := grpc.NewServer(grpcOpts...)
 
 	hub := hubrpc.NewHub(&hubrpc.Config{
 		Client:         client,
 		MetricsBackend: rpcMetricsBackend,
 		NodeManager:    nodeManager,
+		FeeManager:     feeManager,
+		Topology:       graphTopology,
+		Router:         paymentRouter,
+
+		IdempotencyStorage: hubDB,
+		IdempotencyTTL:     config.Hub.IdempotencyTTL,
 	})
 	hubrpc.RegisterHubServer(grpcServer, hub)
 
@@ -247,8 +386,43 @@ func backendMain() error {
 		mainLog.Infof("Stopped gRPC server serving on: %v", addr)
 	}()
 
+	// Setup REST gateway which proxies JSON requests to the gRPC endpoint.
+	var restServer *http.Server
+	if !config.Hub.NoREST {
+		restServer, err = newRESTServer(context.Background(), config.Hub,
+			certLoader)
+		if err != nil {
+			return errors.Errorf("unable to create REST gateway: %v", err)
+		}
+
+		go func() {
+			mainLog.Infof("Start REST gateway serving on: %v",
+				restServer.Addr)
+
+			var err error
+			if restServer.TLSConfig != nil {
+				err = restServer.ListenAndServeTLS("", "")
+			} else {
+				err = restServer.ListenAndServe()
+			}
+			if err != nil && err != http.ErrServerClosed {
+				fail(errChan, "REST gateway unable to serve on %s: %v",
+					restServer.Addr, err)
+				return
+			}
+
+			mainLog.Infof("Stopped REST gateway serving on: %v",
+				restServer.Addr)
+		}()
+	}
+
 	addInterruptHandler(shutdownChannel, func() {
 		close(errChan)
+		if restServer != nil {
+			if err := restServer.Shutdown(context.Background()); err != nil {
+				mainLog.Errorf("unable to shutdown REST gateway: %v", err)
+			}
+		}
 		grpcServer.Stop()
 		if err := server.Shutdown(context.Background()); err != nil {
 			mainLog.Errorf("unable to shutdown metric server: %v", addr)
@@ -0,0 +1,511 @@
+package manager
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ChannelOperation is the on-chain channel operation, fee of which is paid
+// from our wallet, and which is restricted by the daily spending budget.
+type ChannelOperation string
+
+const (
+	// OpenOperation is the channel open, fee of which is paid by us if
+	// channel open was initiated by our node.
+	OpenOperation ChannelOperation = "open"
+
+	// CloseOperation is the channel close, fee of which is paid by the
+	// initiator of channel open, together with the fee of swiping pending
+	// htlc.
+	CloseOperation ChannelOperation = "close"
+)
+
+// budgetPeriod is the rolling window within which spending on channel
+// operations is restricted by the daily budget.
+const budgetPeriod = time.Hour * 24
+
+// fallbackOpenFee is the estimation of channel open fee which is used if
+// there is no history of our channel opens. It is the fee of funding
+// transaction of ~250 vbytes with the fee rate of 40 sat/vbyte, which is
+// intentionally pessimistic.
+const fallbackOpenFee = btcutil.Amount(10000)
+
+// ErrBudgetExceeded is returned if channel operation has been postponed,
+// because its fee would exceed the daily budget.
+var ErrBudgetExceeded = errors.New("daily budget exceeded")
+
+// spending is the record about funds which were spent, or committed to be
+// spent, on the channel operation.
+type spending struct {
+	operation ChannelOperation
+	nodeID    lightning.NodeID
+	channelID lightning.ChannelID
+
+	// time is the time when operation has been made.
+	time int64
+
+	// fee is the number of funds which were spent on the operation. For
+	// pending spendings it is an estimation, because actual fee is known
+	// only after lightning client synced the transaction.
+	fee btcutil.Amount
+}
+
+// BudgetReport is the report about state of the daily budget of the
+// particular channel operation.
+type BudgetReport struct {
+	Operation ChannelOperation
+
+	// Limit is the maximum number of funds we allow to spend on the
+	// operation during the day.
+	LimitUSD float64
+
+	// SpentUSD is the number of funds spent on the operation during the last
+	// day, including pending operations.
+	SpentUSD float64
+
+	// PendingUSD is the estimated number of funds of the operations
+	// which were made by us, but fee of which is not known yet.
+	PendingUSD float64
+
+	// RemainingUSD is the number of funds we still could spend on the
+	// operation.
+	RemainingUSD float64
+
+	// Postponed is number of operations which were postponed during the last
+	// day because of the budget exhaustion.
+	Postponed int
+}
+
+// budgetLedger keeps track of funds spent on the channel operations
+// during the budget period. Fee of operations which were committed by node
+// manager, but which are not yet reflected in channel states, is kept in
+// pending spendings, until lightning client syncs the actual one.
+type budgetLedger struct {
+	sync.Mutex
+
+	// spent are the spendings derived from the current channel states.
+	spent []*spending
+
+	// pending are the spendings committed by us, but which are not yet
+	// reflected in channel states.
+	pending []*spending
+
+	// postponed is the times of operations which were postponed because
+	// of the budget exhaustion.
+	postponed map[ChannelOperation][]int64
+}
+
+// newBudgetLedger creates new instance of budget ledger.
+func newBudgetLedger() *budgetLedger {
+	return &budgetLedger{
+		postponed: make(map[ChannelOperation][]int64),
+	}
+}
+
+// sync replaces the spendings with the ones derived from the given channel
+// states, and removes pending spendings which are either already reflected
+// in channel states or out of the budget period.
+func (l *budgetLedger) sync(channels []*lightning.Channel, now int64) {
+	l.Lock()
+	defer l.Unlock()
+
+	start := now - int64(budgetPeriod.Seconds())
+
+	var spent []*spending
+	for _, channel := range channels {
+		// Opening state might be absent for channels which were synced
+		// too late, in this case neither fee nor the side which paid it
+		// are known, so channel is skipped.
+		initiator, err := channel.Initiator()
+		if err != nil {
+			log.Debugf("Channel(%v) is skipped in budget ledger: %v",
+				channel.ChannelID, err)
+			continue
+		}
+
+		// Fee is paid by the initiator of channel open. Initiator might
+		// be empty if it wasn't reported by lightning client, in this case
+		// channel is counted as ours, to be on the safe side.
+		if initiator == lightning.RemoteInitiator {
+			continue
+		}
+
+		openTime, _ := channel.OpeningTime()
+		if openTime > start {
+			openFee, _ := channel.OpenFee()
+			spent = append(spent, &spending{
+				operation: OpenOperation,
+				nodeID:    channel.NodeID,
+				channelID: channel.ChannelID,
+				time:      openTime,
+				fee:       openFee,
+			})
+		}
+
+		closeTime, err := channel.ClosingTime()
+		if err != nil {
+			continue
+		}
+
+		if closeTime > start {
+			closeFee, _ := channel.CloseFee()
+			swipeFee, _ := channel.SwipeFee()
+			spent = append(spent, &spending{
+				operation: CloseOperation,
+				nodeID:    channel.NodeID,
+				channelID: channel.ChannelID,
+				time:      closeTime,
+				fee:       closeFee + swipeFee,
+			})
+		}
+	}
+
+	var pending []*spending
+	for _, p := range l.pending {
+		if p.time <= start || isReflected(p, spent) {
+			continue
+		}
+
+		pending = append(pending, p)
+	}
+
+	for operation, times := range l.postponed {
+		var postponed []int64
+		for _, t := range times {
+			if t > start {
+				postponed = append(postponed, t)
+			}
+		}
+		l.postponed[operation] = postponed
+	}
+
+	l.spent = spent
+	l.pending = pending
+}
+
+// isReflected checks whether pending spending has already been reflected in
+// the channel states. Channel open is identified by the node, because
+// channel id is unknown before channel is synced, channel close is
+// identified by the channel id.
+func isReflected(p *spending, spent []*spending) bool {
+	for _, s := range spent {
+		if s.operation != p.operation {
+			continue
+		}
+
+		switch p.operation {
+		case OpenOperation:
+			if s.nodeID == p.nodeID && s.time >= p.time {
+				return true
+			}
+		case CloseOperation:
+			if s.channelID == p.channelID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// reserve checks that operation with the given estimated fee fits in the
+// budget limit, and if so commits it as pending spending. If limit is
+// exceeded operation is counted as postponed and ErrBudgetExceeded is
+// returned.
+func (l *budgetLedger) reserve(operation ChannelOperation,
+	nodeID lightning.NodeID, channelID lightning.ChannelID,
+	fee, limit btcutil.Amount, now int64) (*spending, error) {
+
+	l.Lock()
+	defer l.Unlock()
+
+	spent, pending := l.total(operation)
+	if spent+pending+fee > limit {
+		l.postponed[operation] = append(l.postponed[operation], now)
+		return nil, ErrBudgetExceeded
+	}
+
+	p := &spending{
+		operation: operation,
+		nodeID:    nodeID,
+		channelID: channelID,
+		time:      now,
+		fee:       fee,
+	}
+
+	l.pending = append(l.pending, p)
+	return p, nil
+}
+
+// release removes pending spending, is used if operation has failed and
+// funds were not spent.
+func (l *budgetLedger) release(p *spending) {
+	l.Lock()
+	defer l.Unlock()
+
+	for i, s := range l.pending {
+		if s == p {
+			l.pending = append(l.pending[:i], l.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// report returns number of funds spent on the operation, number of funds
+// committed to pending operations, and number of postponed operations.
+func (l *budgetLedger) report(operation ChannelOperation) (btcutil.Amount,
+	btcutil.Amount, int) {
+
+	l.Lock()
+	defer l.Unlock()
+
+	spent, pending := l.total(operation)
+	return spent, pending, len(l.postponed[operation])
+}
+
+// total returns sum of spent and pending fees of the given operation.
+//
+// NOTE: Should be called under the ledger lock.
+func (l *budgetLedger) total(operation ChannelOperation) (btcutil.Amount,
+	btcutil.Amount) {
+
+	var spent, pending btcutil.Amount
+	for _, s := range l.spent {
+		if s.operation == operation {
+			spent += s.fee
+		}
+	}
+
+	for _, p := range l.pending {
+		if p.operation == operation {
+			pending += p.fee
+		}
+	}
+
+	return spent, pending
+}
+
+// estimateOpenFee returns average fee of channel opens initiated by us,
+// which is used as estimation of the fee of the next channel open. If there
+// is no history of our channel opens, fallbackOpenFee is returned, so that
+// budget is not bypassed.
+func estimateOpenFee(channels []*lightning.Channel) btcutil.Amount {
+	var overall btcutil.Amount
+	var num int64
+
+	for _, channel := range channels {
+		initiator, err := channel.Initiator()
+		if err != nil || initiator != lightning.LocalInitiator {
+			continue
+		}
+
+		openFee, _ := channel.OpenFee()
+		if openFee == 0 {
+			continue
+		}
+
+		overall += openFee
+		num++
+	}
+
+	if num == 0 {
+		return fallbackOpenFee
+	}
+
+	return overall / btcutil.Amount(num)
+}
+
+// openChannel opens channel with the given node if estimated fee of the
+// channel open fits in the daily budget, otherwise channel open is postponed
+// and ErrBudgetExceeded is returned.
+func (nm *NodeManager) openChannel(nodeID lightning.NodeID,
+	amount btcutil.Amount) error {
+
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		return errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	now := time.Now().Unix()
+	nm.ledger.sync(channels, now)
+
+	limit, err := nm.budgetLimit(OpenOperation)
+	if err != nil {
+		return err
+	}
+
+	p, err := nm.ledger.reserve(OpenOperation, nodeID, "",
+		estimateOpenFee(channels), limit, now)
+	if err != nil {
+		nm.cfg.BudgetMetricsBackend.AddPostponedOperation(nm.cfg.Asset,
+			string(OpenOperation))
+		return err
+	}
+
+	if err := nm.cfg.Client.OpenChannel(nodeID, amount); err != nil {
+		// If request has timed out, channel open might still be in
+		// progress, for that reason we keep fee reserved until channel
+		// is synced or budget period ends.
+		if err != lightning.ErrTimeout {
+			nm.ledger.release(p)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// closeChannel closes the given channel if its estimated close fee fits in
+// the daily budget, otherwise channel close is postponed and
+// ErrBudgetExceeded is returned.
+//
+// NOTE: All channel closes initiated by node manager should go through
+// this method.
+func (nm *NodeManager) closeChannel(channel *lightning.Channel) error {
+	// Commit fee of the channel opened by remote side is paid by remote
+	// side, so such close isn't limited by our budget.
+	if initiator, err := channel.Initiator(); err == nil &&
+		initiator == lightning.RemoteInitiator {
+		return nm.cfg.Client.CloseChannel(channel.ChannelID)
+	}
+
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		return errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	now := time.Now().Unix()
+	nm.ledger.sync(channels, now)
+
+	// Commit fee is the number of funds which has to be paid in order to
+	// cooperatively close the channel.
+	fee, err := channel.CommitFee()
+	if err != nil {
+		return errors.Errorf("unable get channel(%v) commit fee: %v",
+			channel.ChannelID, err)
+	}
+
+	limit, err := nm.budgetLimit(CloseOperation)
+	if err != nil {
+		return err
+	}
+
+	p, err := nm.ledger.reserve(CloseOperation, channel.NodeID,
+		channel.ChannelID, fee, limit, now)
+	if err != nil {
+		nm.cfg.BudgetMetricsBackend.AddPostponedOperation(nm.cfg.Asset,
+			string(CloseOperation))
+		return err
+	}
+
+	if err := nm.cfg.Client.CloseChannel(channel.ChannelID); err != nil {
+		if err != lightning.ErrTimeout {
+			nm.ledger.release(p)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// budgetLimit returns daily budget of the given operation in satoshis.
+func (nm *NodeManager) budgetLimit(operation ChannelOperation) (
+	btcutil.Amount, error) {
+
+	bitcoinPriceUSD, err := nm.cfg.GetBitcoinPriceUSD()
+	if err != nil {
+		return 0, errors.Errorf("unable get bitcoin price: %v", err)
+	}
+
+	limitUSD, err := nm.budgetLimitUSD(operation)
+	if err != nil {
+		return 0, err
+	}
+
+	return btcutil.Amount(limitUSD / bitcoinPriceUSD *
+		btcutil.SatoshiPerBitcoin), nil
+}
+
+// budgetLimitUSD returns daily budget of the given operation in USD.
+func (nm *NodeManager) budgetLimitUSD(operation ChannelOperation) (
+	float64, error) {
+
+	switch operation {
+	case OpenOperation:
+		return nm.cfg.MaxOpenSpendingPerDayUSD, nil
+	case CloseOperation:
+		return nm.cfg.MaxCloseSpendingPerDayUSD, nil
+	default:
+		return 0, errors.Errorf("unknown channel operation(%v)", operation)
+	}
+}
+
+// reportBudget syncs budget ledger with the given channels, and sends the
+// state of the daily budget in the monitoring subsystem.
+func (nm *NodeManager) reportBudget(channels []*lightning.Channel) error {
+	nm.ledger.sync(channels, time.Now().Unix())
+
+	reports, err := nm.budgetReports()
+	if err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		nm.cfg.BudgetMetricsBackend.SpentBudget(nm.cfg.Asset,
+			string(report.Operation), report.SpentUSD)
+		nm.cfg.BudgetMetricsBackend.RemainingBudget(nm.cfg.Asset,
+			string(report.Operation), report.RemainingUSD)
+	}
+
+	return nil
+}
+
+// BudgetReport returns the state of the daily budget of channel operations.
+func (nm *NodeManager) BudgetReport() ([]*BudgetReport, error) {
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		return nil, errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	nm.ledger.sync(channels, time.Now().Unix())
+
+	return nm.budgetReports()
+}
+
+// budgetReports creates budget report for every channel operation.
+func (nm *NodeManager) budgetReports() ([]*BudgetReport, error) {
+	bitcoinPriceUSD, err := nm.cfg.GetBitcoinPriceUSD()
+	if err != nil {
+		return nil, errors.Errorf("unable get bitcoin price: %v", err)
+	}
+
+	var reports []*BudgetReport
+	for _, operation := range []ChannelOperation{OpenOperation,
+		CloseOperation} {
+
+		limitUSD, err := nm.budgetLimitUSD(operation)
+		if err != nil {
+			return nil, err
+		}
+
+		spent, pending, postponed := nm.ledger.report(operation)
+		spentUSD := (spent + pending).ToBTC() * bitcoinPriceUSD
+
+		reports = append(reports, &BudgetReport{
+			Operation:    operation,
+			LimitUSD:     limitUSD,
+			SpentUSD:     spentUSD,
+			PendingUSD:   pending.ToBTC() * bitcoinPriceUSD,
+			RemainingUSD: math.Max(limitUSD-spentUSD, 0),
+			Postponed:    postponed,
+		})
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,259 @@
+package manager
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBudgetLedgerSync(t *testing.T) {
+	now := time.Now().Unix()
+	old := now - int64(budgetPeriod.Seconds()) - 1
+
+	closing := newOpenedChannel("3:0", "c", lightning.LocalInitiator, old,
+		300, 0, 0)
+	closing.State = lightning.ChannelClosing
+	closing.States[lightning.ChannelClosing] = &lightning.ChannelStateClosing{
+		ChannelID:    "3:0",
+		CreationTime: now,
+		CloseFee:     400,
+		SwipeFee:     50,
+	}
+
+	channels := []*lightning.Channel{
+		// Channel without opening state should be skipped.
+		{
+			ChannelID: "0:0",
+			NodeID:    "x",
+			State:     lightning.ChannelOpened,
+			States: map[lightning.ChannelStateName]interface{}{
+				lightning.ChannelOpened: &lightning.ChannelStateOpened{},
+			},
+		},
+		newOpenedChannel("1:0", "a", lightning.LocalInitiator, now, 100,
+			0, 0),
+		newOpenedChannel("2:0", "b", lightning.RemoteInitiator, now, 200,
+			0, 0),
+		closing,
+	}
+
+	ledger := newBudgetLedger()
+	ledger.sync(channels, now)
+
+	spent, pending, _ := ledger.report(OpenOperation)
+	if spent != 100 || pending != 0 {
+		t.Fatalf("wrong open spending: spent(%v), pending(%v)", spent,
+			pending)
+	}
+
+	spent, pending, _ = ledger.report(CloseOperation)
+	if spent != 450 || pending != 0 {
+		t.Fatalf("wrong close spending: spent(%v), pending(%v)", spent,
+			pending)
+	}
+}
+
+func TestBudgetLedgerReserve(t *testing.T) {
+	now := time.Now().Unix()
+
+	ledger := newBudgetLedger()
+	ledger.sync([]*lightning.Channel{
+		newOpenedChannel("1:0", "a", lightning.LocalInitiator, now, 600,
+			0, 0),
+	}, now)
+
+	p, err := ledger.reserve(OpenOperation, "b", "", 300, 1000, now)
+	if err != nil {
+		t.Fatalf("unable to reserve: %v", err)
+	}
+
+	if _, err := ledger.reserve(OpenOperation, "c", "", 300, 1000,
+		now); err != ErrBudgetExceeded {
+		t.Fatalf("budget should be exceeded, got: %v", err)
+	}
+
+	spent, pending, postponed := ledger.report(OpenOperation)
+	if spent != 600 || pending != 300 || postponed != 1 {
+		t.Fatalf("wrong report: spent(%v), pending(%v), postponed(%v)",
+			spent, pending, postponed)
+	}
+
+	// Released pending spending should free the budget.
+	ledger.release(p)
+	if _, err := ledger.reserve(OpenOperation, "c", "", 300, 1000,
+		now); err != nil {
+		t.Fatalf("unable to reserve: %v", err)
+	}
+
+	// Pending spending should be removed once it is reflected in channel
+	// states.
+	ledger.sync([]*lightning.Channel{
+		newOpenedChannel("1:0", "a", lightning.LocalInitiator, now, 600,
+			0, 0),
+		newOpenedChannel("2:0", "c", lightning.LocalInitiator, now, 250,
+			0, 0),
+	}, now)
+
+	spent, pending, _ = ledger.report(OpenOperation)
+	if spent != 850 || pending != 0 {
+		t.Fatalf("wrong report: spent(%v), pending(%v)", spent, pending)
+	}
+}
+
+func TestEstimateOpenFee(t *testing.T) {
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name     string
+		channels []*lightning.Channel
+		fee      btcutil.Amount
+	}{
+		{
+			name: "no history",
+			channels: []*lightning.Channel{
+				newOpenedChannel("1:0", "a", lightning.RemoteInitiator,
+					now, 500, 0, 0),
+			},
+			fee: fallbackOpenFee,
+		},
+		{
+			name: "average of local opens",
+			channels: []*lightning.Channel{
+				newOpenedChannel("1:0", "a", lightning.LocalInitiator,
+					now, 100, 0, 0),
+				newOpenedChannel("2:0", "b", lightning.LocalInitiator,
+					now, 300, 0, 0),
+				newOpenedChannel("3:0", "c", lightning.RemoteInitiator,
+					now, 1000, 0, 0),
+			},
+			fee: 200,
+		},
+	}
+
+	for _, test := range tests {
+		if fee := estimateOpenFee(test.channels); fee != test.fee {
+			t.Fatalf("(%v) wrong fee: expected(%v), got(%v)", test.name,
+				test.fee, fee)
+		}
+	}
+}
+
+func TestCloseChannelBudget(t *testing.T) {
+	now := time.Now().Unix()
+
+	// Commit fee of every channel is 1000 sat, with the bitcoin price of
+	// 1e8 USD every satoshi costs 1 USD, so only two closes fit in budget.
+	client := &mockClient{
+		channels: []*lightning.Channel{
+			newOpenedChannel("1:0", "a", lightning.LocalInitiator, now, 0,
+				100, 0),
+			newOpenedChannel("2:0", "a", lightning.LocalInitiator, now, 0,
+				100, 0),
+			newOpenedChannel("3:0", "a", lightning.LocalInitiator, now, 0,
+				100, 0),
+			newOpenedChannel("4:0", "b", lightning.LocalInitiator, now, 0,
+				100, 0),
+		},
+	}
+
+	budgetBackend := &mockBudgetBackend{}
+	nm := &NodeManager{
+		cfg: &Config{
+			Client:               client,
+			BudgetMetricsBackend: budgetBackend,
+			GetBitcoinPriceUSD: func() (float64, error) {
+				return btcutil.SatoshiPerBitcoin, nil
+			},
+			MaxCloseSpendingPerDayUSD: 2500,
+			Asset:                     "BTC",
+		},
+		ledger: newBudgetLedger(),
+	}
+
+	err := nm.closeNodeChannels("a", client.channels)
+	if err != ErrBudgetExceeded {
+		t.Fatalf("budget should be exceeded, got: %v", err)
+	}
+
+	expected := []lightning.ChannelID{"1:0", "2:0"}
+	if !reflect.DeepEqual(client.closed, expected) {
+		t.Fatalf("wrong closed channels: expected(%v), got(%v)", expected,
+			client.closed)
+	}
+
+	if budgetBackend.postponed[string(CloseOperation)] != 1 {
+		t.Fatalf("close should be reported as postponed")
+	}
+
+	// Close of channel opened by remote side isn't limited by budget, and
+	// doesn't reserve it.
+	remote := newOpenedChannel("5:0", "c", lightning.RemoteInitiator, now,
+		0, 100, 0)
+	if err := nm.closeChannel(remote); err != nil {
+		t.Fatalf("remote channel close shouldn't be postponed: %v", err)
+	}
+
+	if _, pending, _ := nm.ledger.report(CloseOperation); pending != 2000 {
+		t.Fatalf("remote channel close shouldn't be reserved: %v", pending)
+	}
+}
+
+func TestCloseChannelTimeout(t *testing.T) {
+	now := time.Now().Unix()
+
+	client := &mockClient{
+		channels: []*lightning.Channel{
+			newOpenedChannel("1:0", "a", lightning.LocalInitiator, now, 0,
+				100, 0),
+		},
+	}
+
+	nm := &NodeManager{
+		cfg: &Config{
+			Client:               client,
+			BudgetMetricsBackend: &mockBudgetBackend{},
+			GetBitcoinPriceUSD: func() (float64, error) {
+				return btcutil.SatoshiPerBitcoin, nil
+			},
+			MaxCloseSpendingPerDayUSD: 2500,
+			Asset:                     "BTC",
+		},
+		ledger: newBudgetLedger(),
+	}
+
+	tests := []struct {
+		name     string
+		closeErr error
+		pending  btcutil.Amount
+	}{
+		{
+			// Failed close hasn't spent the funds, so reservation is
+			// released.
+			name:     "failed close",
+			closeErr: errors.New("failed"),
+			pending:  0,
+		},
+		{
+			// Close might still be in progress after timeout, so
+			// reservation is kept until channel is synced.
+			name:     "timed out close",
+			closeErr: lightning.ErrTimeout,
+			pending:  1000,
+		},
+	}
+
+	for _, test := range tests {
+		client.closeErr = test.closeErr
+		if err := nm.closeChannel(client.channels[0]); err == nil {
+			t.Fatalf("(%v) close error should be returned", test.name)
+		}
+
+		_, pending, _ := nm.ledger.report(CloseOperation)
+		if pending != test.pending {
+			t.Fatalf("(%v) wrong pending fee: %v", test.name, pending)
+		}
+	}
+}
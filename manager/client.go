@@ -5,12 +5,12 @@ import (
 	"github.com/bitlum/hub/lightning"
 	"github.com/bitlum/hub/manager/stats"
 	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/budget"
 	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/bitlum/hub/metrics/network"
 	"github.com/btcsuite/btcutil"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/go-errors/errors"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"math/rand"
 	"strings"
 	"sync"
@@ -18,6 +18,11 @@ import (
 	"time"
 )
 
+// maxFlowChannelMultiplier is the ratio of channel size to the percentile
+// payment amount, which is used on channel open when biggest channel with
+// node is unable to fit the payment.
+const maxFlowChannelMultiplier = 2
+
 type Config struct {
 	// Client is the entity which gives us glimpse of information about
 	// lightning network.
@@ -27,6 +32,18 @@ type Config struct {
 	// monitoring subsystem.
 	MetricsBackend crypto.MetricsBackend
 
+	// BudgetMetricsBackend is used to report the state of daily channel
+	// operations budget in the monitoring subsystem.
+	BudgetMetricsBackend budget.MetricsBackend
+
+	// NetworkMetricsBackend is used to report the state of our channels
+	// in the monitoring subsystem.
+	NetworkMetricsBackend network.MetricsBackend
+
+	// SnapshotStorage is used to persist channels snapshots, which are
+	// used to detect drop of channels count and capacity.
+	SnapshotStorage SnapshotStorage
+
 	// BitconPriceUSD is current bitcoin price which is used for calculation of
 	// minimum and maximum channel size in bitcoin.
 	GetBitcoinPriceUSD func() (float64, error)
@@ -63,6 +80,17 @@ type Config struct {
 	// on being stuck as pending htlcs in channels.
 	MaxStuckBalanceUSD float64
 
+	// MaxRebalanceFeePPM is the maximum fee in parts per million of the
+	// amount, which we allow to pay for circular payment on channel
+	// re-balancing.
+	MaxRebalanceFeePPM int64
+
+	// CloseIdleChannels enables closing of channels with the nodes with
+	// the most idle funds, if there is not enough funds to open channel
+	// with important node. Channel closes are restricted by the daily
+	// close budget.
+	CloseIdleChannels bool
+
 	OurNodeID lightning.NodeID
 	OurName   string
 
@@ -79,6 +107,18 @@ func (c *Config) validate() error {
 		return errors.New("metric backend should be specified")
 	}
 
+	if c.BudgetMetricsBackend == nil {
+		return errors.New("budget metric backend should be specified")
+	}
+
+	if c.NetworkMetricsBackend == nil {
+		return errors.New("network metric backend should be specified")
+	}
+
+	if c.SnapshotStorage == nil {
+		return errors.New("snapshot storage should be specified")
+	}
+
 	if c.GetBitcoinPriceUSD == nil {
 		return errors.New("bitcoin usd price func should be specified")
 	}
@@ -127,6 +167,10 @@ func (c *Config) validate() error {
 		return errors.New("max stuck balance be specified")
 	}
 
+	if c.MaxRebalanceFeePPM == 0 {
+		return errors.New("max rebalance fee should be specified")
+	}
+
 	return nil
 }
 
@@ -162,6 +206,10 @@ type NodeManager struct {
 	// validity of cooperation between us and this node.
 	importantNodes      map[lightning.NodeID]string
 	importantNodesMutex sync.Mutex
+
+	// ledger keeps track of fees spent on channel operations initiated by
+	// us, and is used to restrict them by the daily budget.
+	ledger *budgetLedger
 }
 
 // NewNodeManager creates new instance.
@@ -174,6 +222,7 @@ func NewNodeManager(cfg *Config) (*NodeManager, error) {
 		quit:           make(chan struct{}),
 		cfg:            cfg,
 		importantNodes: make(map[lightning.NodeID]string),
+		ledger:         newBudgetLedger(),
 	}, nil
 }
 
@@ -187,6 +236,9 @@ func (nm *NodeManager) Start() {
 	go func() {
 		checkNodesTicker := time.NewTicker(time.Second * 25)
 		reportDailyStatsTicker := time.NewTicker(time.Second * 25)
+		rebalanceTicker := time.NewTicker(time.Minute * 10)
+		checkMaxFlowTicker := time.NewTicker(time.Minute * 5)
+		checkChannelsTicker := time.NewTicker(time.Hour * 3)
 
 		defer func() {
 			log.Info("Stopped checking connection with important nodes goroutine")
@@ -194,6 +246,9 @@ func (nm *NodeManager) Start() {
 
 			checkNodesTicker.Stop()
 			reportDailyStatsTicker.Stop()
+			rebalanceTicker.Stop()
+			checkMaxFlowTicker.Stop()
+			checkChannelsTicker.Stop()
 		}()
 
 		log.Info("Started checking connection with important nodes goroutine")
@@ -210,6 +265,22 @@ func (nm *NodeManager) Start() {
 					log.Errorf("unable to report daily stats: %v", err)
 					continue
 				}
+			case <-rebalanceTicker.C:
+				if err := nm.rebalanceChannels(); err != nil {
+					log.Errorf("unable to rebalance channels: %v", err)
+					continue
+				}
+			case <-checkMaxFlowTicker.C:
+				if err := nm.checkMaxFlowAmount(); err != nil {
+					log.Errorf("unable to check max flow amount: %v", err)
+					continue
+				}
+			case <-checkChannelsTicker.C:
+				if err := nm.checkChannelsCountChange(); err != nil {
+					log.Errorf("unable to check channels count change: %v",
+						err)
+					continue
+				}
 			case <-nm.quit:
 				return
 			}
@@ -230,26 +301,148 @@ func (nm *NodeManager) Stop(reason string) {
 	log.Infof("Node manager shutdown, reason(%v)", reason)
 }
 
-func checkChannelsCountChange() {
-	// Algorithm count of channels drop check:
-	// Per 3 hours:
-	// 1. Check count of channels.
-	// 2. Check previous count of active channels.
-	// 3. Send metric in prometheus
-	// 4. If drop is 20% than alert.
-	// 5. Who closed channels and why?
-	// 6. Save current count of channel, and prev check time.
-	// TODO(andrew.svv) implement
+// checkChannelsCountChange compares count and overall capacity of opened
+// channels with the previous snapshot, and alerts if drop is bigger than
+// threshold, reporting which channels were closed and why. Snapshot is
+// persisted, so that drop is detected across restarts.
+func (nm *NodeManager) checkChannelsCountChange() error {
+	m := crypto.NewMetric(nm.cfg.Asset, common.GetFunctionName(),
+		nm.cfg.MetricsBackend)
+	defer m.Finish()
 
-	// Algorithm capacity drop check:
-	// Per 3 hours:
-	// 1. Check overall capacity.
-	// 2. Check prev overall capacity.
-	// 3. Send metric in prometheus
-	// 4. If drop 20% than alert.
-	// 5. Who closed channels and why?
-	// 6. Save current overall capacity, and prev check time.
-	// TODO(andrew.svv) implement
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	current := takeChannelsSnapshot(channels, time.Now().Unix())
+	nm.reportChannelsMetrics(channels)
+
+	previous, err := nm.cfg.SnapshotStorage.LastChannelsSnapshot()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable get last channels snapshot: %v", err)
+	}
+
+	if err := nm.cfg.SnapshotStorage.AddChannelsSnapshot(current); err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable save channels snapshot: %v", err)
+	}
+
+	// On the first check there is nothing to compare with.
+	if previous == nil {
+		log.Infof("Saved first channels snapshot, channels(%v), "+
+			"capacity(%v)", current.NumChannels, current.Capacity)
+		return nil
+	}
+
+	report := compareSnapshots(previous, current, channels)
+
+	nm.cfg.NetworkMetricsBackend.ChannelsDrop(nm.cfg.Asset, "count",
+		report.CountDrop)
+	nm.cfg.NetworkMetricsBackend.ChannelsDrop(nm.cfg.Asset, "capacity",
+		report.CapacityDrop)
+
+	if !report.Alert {
+		log.Infof("Channels count changed from %v to %v, capacity "+
+			"changed from %v to %v", previous.NumChannels,
+			current.NumChannels, previous.Capacity, current.Capacity)
+		return nil
+	}
+
+	m.AddError(metrics.HighSeverity)
+	log.Errorf("Channels drop detected, count changed from %v to %v "+
+		"(drop %.2f), capacity changed from %v to %v (drop %.2f), "+
+		"since %v", previous.NumChannels, current.NumChannels,
+		report.CountDrop, previous.Capacity, current.Capacity,
+		report.CapacityDrop, time.Unix(previous.Time, 0))
+
+	for _, closed := range report.ClosedChannels {
+		initiator := string(closed.Initiator)
+		if initiator == "" {
+			initiator = "unknown"
+		}
+
+		log.Errorf("Channel(%v) with node(%v), capacity(%v) was closed, "+
+			"close type(%v), initiator(%v)", closed.ChannelID,
+			closed.NodeID, closed.Capacity, closed.CloseType,
+			initiator)
+	}
+
+	return nil
+}
+
+// reportChannelsMetrics sends the current number of channels, users and
+// locked funds in the monitoring subsystem.
+func (nm *NodeManager) reportChannelsMetrics(channels []*lightning.Channel) {
+	var (
+		numActive   int
+		numInactive int
+		local       btcutil.Amount
+		remote      btcutil.Amount
+	)
+
+	users := make(map[lightning.NodeID]struct{})
+	for _, channel := range channels {
+		if channel.CurrentState() != lightning.ChannelOpened {
+			continue
+		}
+
+		if channel.IsActive() {
+			numActive++
+		} else {
+			numInactive++
+		}
+
+		state := channel.States[lightning.ChannelOpened].(*lightning.ChannelStateOpened)
+		local += state.LocalBalance
+		remote += state.RemoteBalance
+		users[channel.NodeID] = struct{}{}
+	}
+
+	backend := nm.cfg.NetworkMetricsBackend
+	backend.TotalChannels(nm.cfg.Asset, "opened", "active", numActive)
+	backend.TotalChannels(nm.cfg.Asset, "opened", "inactive", numInactive)
+	backend.TotalFundsLockedLocally(nm.cfg.Asset, uint64(local))
+	backend.TotalFundsLockedRemotely(nm.cfg.Asset, uint64(remote))
+	backend.TotalUsers(nm.cfg.Asset, len(users))
+}
+
+// ChannelsChangeReports returns the given number of the last channels change
+// reports, sorted from the oldest to the newest one. Reports are derived
+// from the persisted channels snapshots, so that they are available after
+// the restart.
+func (nm *NodeManager) ChannelsChangeReports(limit int) (
+	[]*ChannelsChangeReport, error) {
+
+	if limit <= 0 || limit > maxChannelsChangeReports {
+		limit = maxChannelsChangeReports
+	}
+
+	// Every report is the comparison of the snapshot with the previous
+	// one, so one more snapshot is needed.
+	snapshots, err := nm.cfg.SnapshotStorage.LastChannelsSnapshots(limit + 1)
+	if err != nil {
+		return nil, errors.Errorf("unable get channels snapshots: %v", err)
+	}
+
+	if len(snapshots) < 2 {
+		return nil, nil
+	}
+
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		return nil, errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	reports := make([]*ChannelsChangeReport, 0, len(snapshots)-1)
+	for i := 1; i < len(snapshots); i++ {
+		reports = append(reports, compareSnapshots(snapshots[i-1],
+			snapshots[i], channels))
+	}
+
+	return reports, nil
 }
 
 // reportSuccessMetric reports ration of how many payment to important nodes
@@ -285,6 +478,12 @@ func (nm *NodeManager) reportDailyStats() error {
 		return err
 	}
 
+	if err := nm.reportBudget(channels); err != nil {
+		err := errors.Errorf("unable report budget: %v", err)
+		m.AddError(metrics.HighSeverity)
+		return err
+	}
+
 	overallStats, err := stats.GetChannelOverallStats(channels)
 	if err != nil {
 		err := errors.Errorf("unable calculate channels overall stats: %v", err)
@@ -390,9 +589,161 @@ func (nm *NodeManager) reportDailyStats() error {
 // checkMaxFlowAmount ensures that we don't have situations where we have
 // bunch of small channels, but all payment to important node are big,
 // which would result in fails, as far as lightning don't AMP yet.
-func (nm *NodeManager) checkMaxFlowAmount() {
-	// Check max flow to the node, as far we don't have AMP.
-	// TODO(andrew.svv) implement
+func (nm *NodeManager) checkMaxFlowAmount() error {
+	m := crypto.NewMetric(nm.cfg.Asset, common.GetFunctionName(),
+		nm.cfg.MetricsBackend)
+	defer m.Finish()
+
+	now := time.Now().Unix()
+	nodeStats, err := nm.GetNodeStats(now-int64(stats.Week), now)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable to calculate nodes statistics: %v", err)
+	}
+
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	// If channel with node is already opening, we should wait for it to
+	// be opened, before making another attempt.
+	openingNodes := make(map[lightning.NodeID]struct{})
+	for _, channel := range channels {
+		if channel.CurrentState() == lightning.ChannelOpening {
+			openingNodes[channel.NodeID] = struct{}{}
+		}
+	}
+
+	bitcoinPriceUSD, err := nm.cfg.GetBitcoinPriceUSD()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable get bitcoin price: %v", err)
+	}
+
+	minChannelSizeSat := btcutil.Amount(nm.cfg.MinChannelSizeUSD /
+		bitcoinPriceUSD * btcutil.SatoshiPerBitcoin)
+
+	maxChannelSizeSat := btcutil.Amount(nm.cfg.MaxChannelSizeUSD /
+		bitcoinPriceUSD * btcutil.SatoshiPerBitcoin)
+
+	nm.importantNodesMutex.Lock()
+	importantNodes := make(map[lightning.NodeID]string, len(nm.importantNodes))
+	for nodeID, name := range nm.importantNodes {
+		importantNodes[nodeID] = name
+	}
+	nm.importantNodesMutex.Unlock()
+
+	for nodeID, nodeName := range importantNodes {
+		stat, ok := nodeStats[nodeID]
+		if !ok || stat.MaxLockedLocallyActive >= stat.PercentileSentSat {
+			continue
+		}
+
+		m.AddError(metrics.HighSeverity)
+		log.Warnf("Node(%v), id(%v): biggest channel local balance(%v) is "+
+			"less than 95th percentile of payment amount(%v)", nodeName,
+			nodeID, stat.MaxLockedLocallyActive, stat.PercentileSentSat)
+
+		if _, ok := openingNodes[nodeID]; ok {
+			continue
+		}
+
+		// Part of local balance is locked by channel reserve and commit
+		// fee, for that reason channel should be bigger than percentile
+		// payment amount.
+		channelSizeSat := stat.PercentileSentSat * maxFlowChannelMultiplier
+		if channelSizeSat < minChannelSizeSat {
+			channelSizeSat = minChannelSizeSat
+		}
+
+		if channelSizeSat > maxChannelSizeSat {
+			if stat.PercentileSentSat >= maxChannelSizeSat {
+				log.Warnf("Node(%v), id(%v): percentile payment amount(%v)"+
+					" is greater than max channel size(%v), unable to fit "+
+					"it in the single channel", nodeName, nodeID,
+					stat.PercentileSentSat, maxChannelSizeSat)
+				continue
+			}
+
+			channelSizeSat = maxChannelSizeSat
+		}
+
+		log.Infof("Node(%v), id(%v): create channel with size(%v), "+
+			"to fit percentile payment amount(%v)", nodeName, nodeID,
+			channelSizeSat, stat.PercentileSentSat)
+
+		if err := nm.openChannel(nodeID, channelSizeSat); err != nil {
+			if err == ErrBudgetExceeded {
+				return errors.Errorf("open of channel with node(%v) id("+
+					"%v), amount(%v) is postponed: %v", nodeName, nodeID,
+					channelSizeSat, err)
+			}
+
+			m.AddError(metrics.HighSeverity)
+			return errors.Errorf("unable open channel with node(%v) id("+
+				"%v), amount(%v): %v", nodeName, nodeID, channelSizeSat, err)
+		}
+	}
+
+	return nil
+}
+
+// NodeAnomalies evaluates the state of the given nodes with anomaly rules,
+// and returns detected anomalies by node.
+func (nm *NodeManager) NodeAnomalies(
+	nodeStats map[lightning.NodeID]stats.NodeStats) (
+	map[lightning.NodeID][]*stats.Anomaly, error) {
+
+	m := crypto.NewMetric(nm.cfg.Asset, common.GetFunctionName(),
+		nm.cfg.MetricsBackend)
+	defer m.Finish()
+
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	peers, err := nm.cfg.Client.ConnectedPeers()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable fetch connected peers: %v", err)
+	}
+
+	connected := make(map[lightning.NodeID]struct{}, len(peers))
+	for _, nodeID := range peers {
+		connected[nodeID] = struct{}{}
+	}
+
+	nodeChannels := make(map[lightning.NodeID][]*lightning.Channel)
+	for _, channel := range channels {
+		nodeChannels[channel.NodeID] = append(nodeChannels[channel.NodeID],
+			channel)
+	}
+
+	nm.importantNodesMutex.Lock()
+	important := make(map[lightning.NodeID]struct{}, len(nm.importantNodes))
+	for nodeID := range nm.importantNodes {
+		important[nodeID] = struct{}{}
+	}
+	nm.importantNodesMutex.Unlock()
+
+	anomalies := make(map[lightning.NodeID][]*stats.Anomaly, len(nodeStats))
+	for nodeID, stat := range nodeStats {
+		_, isImportant := important[nodeID]
+		_, isConnected := connected[nodeID]
+
+		anomalies[nodeID] = stats.DetectAnomalies(&stats.NodeAnomalyState{
+			Stats:       stat,
+			Channels:    nodeChannels[nodeID],
+			IsImportant: isImportant,
+			IsConnected: isConnected,
+		}, stats.DefaultAnomalyRules)
+	}
+
+	return anomalies, nil
 }
 
 // checkNodesAvailability ensures that we always connected with lightning
@@ -417,7 +768,8 @@ func (nm *NodeManager) checkNodesAvailability() error {
 
 	// Aggregate statistic over the last week, and calculate average payment
 	// flow for one day.
-	nodeStats, err := nm.GetNodeStats("week")
+	now := time.Now().Unix()
+	nodeStats, err := nm.GetNodeStats(now-int64(stats.Week), now)
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
 		return errors.Errorf("unable to calculate nodes statistics: %v", err)
@@ -541,11 +893,16 @@ func (nm *NodeManager) checkNodesAvailability() error {
 				averageReceivedForwardInUSD, channelSizeSat, spew.Sdump(stat))
 		}
 
-		if err := nm.cfg.Client.OpenChannel(stat.NodeID, channelSizeSat); err != nil {
-			if status.Code(err) != codes.DeadlineExceeded {
-				if err := nm.suggestIdleNodes(channelSizeSat); err != nil {
-					log.Warnf("unable to give suggestion which nodes are idle: %v"+
-						"", err)
+		if err := nm.openChannel(stat.NodeID, channelSizeSat); err != nil {
+			if err == ErrBudgetExceeded {
+				return errors.Errorf("open of channel with node(%v) id("+
+					"%v), amount(%v) is postponed: %v", nodeName,
+					stat.NodeID, channelSizeSat, err)
+			}
+
+			if err != lightning.ErrTimeout {
+				if err := nm.releaseIdleFunds(channelSizeSat); err != nil {
+					log.Warnf("unable to release idle funds: %v", err)
 				}
 			}
 
@@ -560,19 +917,28 @@ func (nm *NodeManager) checkNodesAvailability() error {
 	return nil
 }
 
-// suggestIdleNodes is used as suggestion function for which funds and from which
-// node we have to remove to release idle funds and most unused funds.
-func (nm *NodeManager) suggestIdleNodes(amount btcutil.Amount) error {
+// releaseIdleFunds is used to release the given number of funds from the
+// nodes with the most idle funds. If closing of idle channels is enabled,
+// channels of this nodes are closed within the daily budget, otherwise
+// nodes are only suggested in the log.
+func (nm *NodeManager) releaseIdleFunds(amount btcutil.Amount) error {
 	m := crypto.NewMetric(nm.cfg.Asset, common.GetFunctionName(),
 		nm.cfg.MetricsBackend)
 	defer m.Finish()
 
-	nodeStats, err := nm.GetNodeStats("month")
+	now := time.Now().Unix()
+	nodeStats, err := nm.GetNodeStats(now-30*int64(stats.Day), now)
 	if err != nil {
 		m.AddError(metrics.HighSeverity)
 		return errors.Errorf("unable to calculate nodes statistics: %v", err)
 	}
 
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch channels: %v", err)
+	}
+
 	for _, node := range stats.RankByIdleFunds(nodeStats) {
 		// Skip removing nodes which are important.
 		if _, ok := nm.importantNodes[node.NodeID]; ok {
@@ -586,12 +952,22 @@ func (nm *NodeManager) suggestIdleNodes(amount btcutil.Amount) error {
 		// because expected flow in the direction of node is less that locked
 		// funds.
 		fundsToRelease := node.LockedLocallyOverall - sentFlow
-		if fundsToRelease < 0 {
-			fundsToRelease = 0
+		if fundsToRelease <= 0 {
+			continue
 		}
 
-		log.Debugf("Suggest to remove funds(%v) from node(%v)",
-			fundsToRelease, node.NodeID)
+		if !nm.cfg.CloseIdleChannels {
+			log.Debugf("Suggest to remove funds(%v) from node(%v)",
+				fundsToRelease, node.NodeID)
+		} else {
+			log.Infof("Remove funds(%v) from node(%v)", fundsToRelease,
+				node.NodeID)
+
+			if err := nm.closeNodeChannels(node.NodeID, channels); err != nil {
+				return errors.Errorf("unable to close channels with "+
+					"node(%v): %v", node.NodeID, err)
+			}
+		}
 
 		amount -= fundsToRelease
 		if amount < 0 {
@@ -602,6 +978,27 @@ func (nm *NodeManager) suggestIdleNodes(amount btcutil.Amount) error {
 	return nil
 }
 
+// closeNodeChannels closes all opened channels with the given node.
+func (nm *NodeManager) closeNodeChannels(nodeID lightning.NodeID,
+	channels []*lightning.Channel) error {
+
+	for _, channel := range channels {
+		if channel.NodeID != nodeID ||
+			channel.CurrentState() != lightning.ChannelOpened {
+			continue
+		}
+
+		if err := nm.closeChannel(channel); err != nil {
+			return err
+		}
+
+		log.Infof("Channel(%v) with node(%v) is closing", channel.ChannelID,
+			nodeID)
+	}
+
+	return nil
+}
+
 // AddImportantNode is used to notify node manager, which nodes are important
 // and has to be monitored for channel existence, and availability. As well as
 // report is something is wrong.
@@ -613,6 +1010,19 @@ func (nm *NodeManager) AddImportantNode(nodeID lightning.NodeID, nodeName string
 	nm.importantNodes[nodeID] = nodeName
 }
 
+// ImportantNodes returns the list of nodes which are important for us.
+func (nm *NodeManager) ImportantNodes() []lightning.NodeID {
+	nm.importantNodesMutex.Lock()
+	defer nm.importantNodesMutex.Unlock()
+
+	nodes := make([]lightning.NodeID, 0, len(nm.importantNodes))
+	for nodeID := range nm.importantNodes {
+		nodes = append(nodes, nodeID)
+	}
+
+	return nodes
+}
+
 // getRandomPseudonym returns random pseudonym to obscure the real
 // identification of receiver/sender.
 func getRandomPseudonym() string {
@@ -654,37 +1064,94 @@ func (nm *NodeManager) GetAlias(nodeID lightning.NodeID) string {
 	return strings.ToLower(name)
 }
 
+// FindNodesByAlias returns public keys of the nodes which alias contains
+// the given string, matching is case insensitive. Nodes which aren't in the
+// public list have obscured alias, and can't be found.
+func (nm *NodeManager) FindNodesByAlias(alias string) []lightning.NodeID {
+	nm.importantNodesMutex.Lock()
+	defer nm.importantNodesMutex.Unlock()
+
+	alias = strings.ToLower(alias)
+	nodes := make([]lightning.NodeID, 0)
+
+	if strings.Contains(strings.ToLower(nm.cfg.OurName), alias) {
+		nodes = append(nodes, nm.cfg.OurNodeID)
+	}
+
+	for nodeID, name := range nm.importantNodes {
+		if strings.Contains(strings.ToLower(name), alias) {
+			nodes = append(nodes, nodeID)
+		}
+	}
+
+	return nodes
+}
+
 // GetNodeStats returns statistics which node managers is using to make
-// decision about funds management.
-func (nm *NodeManager) GetNodeStats(period string) (
+// decision about funds management, payments statistics is calculated over
+// [start, end) range of time.
+func (nm *NodeManager) GetNodeStats(start, end int64) (
 	map[lightning.NodeID]stats.NodeStats, error) {
 
 	m := crypto.NewMetric(nm.cfg.Asset, common.GetFunctionName(),
 		nm.cfg.MetricsBackend)
 	defer m.Finish()
 
-	payments, err := nm.cfg.Client.ListPayments("", lightning.AllStatuses,
-		lightning.AllDirections, lightning.AllSystems)
+	payments, forwardPayments, err := nm.listExternalPayments()
 	if err != nil {
-		err := errors.Errorf("unable list payments: %v", err)
 		m.AddError(metrics.HighSeverity)
 		return nil, err
 	}
 
-	forwardPayments, err := nm.cfg.Client.ListForwardPayments()
+	channels, err := nm.cfg.Client.Channels()
 	if err != nil {
-		err := errors.Errorf("unable list forward payments: %v", err)
+		err := errors.Errorf("unable fetch channels: %v", err)
 		m.AddError(metrics.HighSeverity)
 		return nil, err
 	}
 
-	channels, err := nm.cfg.Client.Channels()
+	return stats.GetNodeStats(start, end, payments,
+		forwardPayments, channels)
+}
+
+// PaymentTimeSeries returns payments activity with every node, aggregated
+// in buckets of the given size within [start, end) range of time.
+func (nm *NodeManager) PaymentTimeSeries(start, end int64,
+	bucketSize stats.BucketSize) (map[lightning.NodeID][]*stats.PaymentBucket,
+	error) {
+
+	m := crypto.NewMetric(nm.cfg.Asset, common.GetFunctionName(),
+		nm.cfg.MetricsBackend)
+	defer m.Finish()
+
+	payments, forwardPayments, err := nm.listExternalPayments()
 	if err != nil {
-		err := errors.Errorf("unable fetch channels: %v", err)
 		m.AddError(metrics.HighSeverity)
 		return nil, err
 	}
 
-	return stats.GetNodeStats(period, payments,
-		forwardPayments, channels)
+	return stats.GetPaymentTimeSeries(start, end, bucketSize, payments,
+		forwardPayments)
+}
+
+// listExternalPayments returns payments and forward payments which are
+// used to calculate statistics of interaction with other nodes.
+func (nm *NodeManager) listExternalPayments() ([]*lightning.Payment,
+	[]*lightning.ForwardPayment, error) {
+
+	// Internal payments are sent by us to ourselves, and shouldn't affect
+	// statistics of interaction with other nodes.
+	payments, err := nm.cfg.Client.ListPayments("", lightning.AllStatuses,
+		lightning.AllDirections, lightning.External)
+	if err != nil {
+		return nil, nil, errors.Errorf("unable list payments: %v", err)
+	}
+
+	forwardPayments, err := nm.cfg.Client.ListForwardPayments()
+	if err != nil {
+		return nil, nil, errors.Errorf("unable list forward payments: %v",
+			err)
+	}
+
+	return payments, forwardPayments, nil
 }
@@ -0,0 +1,169 @@
+package manager
+
+import (
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"sort"
+)
+
+const (
+	// starvedBalanceRatio is the ratio of local balance to the channel
+	// balance, below which channel with important node is considered as
+	// starved, and requires additional local balance.
+	starvedBalanceRatio = 0.2
+
+	// excessBalanceRatio is the ratio of local balance to the channel
+	// balance, above which we could move local balance to other channels.
+	excessBalanceRatio = 0.5
+
+	// minRebalanceAmount is the minimal amount of circular payment,
+	// this is needed to avoid paying base fee for small re-balancing.
+	minRebalanceAmount = btcutil.Amount(10000)
+)
+
+// rebalanceChannel is the channel which takes part in the re-balancing,
+// with local and overall balance being tracked during the re-balancing.
+type rebalanceChannel struct {
+	channel *lightning.Channel
+	local   btcutil.Amount
+	overall btcutil.Amount
+}
+
+// ratio returns the ratio of local balance to the overall channel balance.
+func (c *rebalanceChannel) ratio() float64 {
+	if c.overall == 0 {
+		return 0
+	}
+
+	return float64(c.local) / float64(c.overall)
+}
+
+// excess returns number of local funds which could be moved to other
+// channels, without channel being starved after that.
+func (c *rebalanceChannel) excess() btcutil.Amount {
+	excess := c.local - btcutil.Amount(float64(c.overall)*excessBalanceRatio)
+	if excess < 0 {
+		return 0
+	}
+
+	return excess
+}
+
+// needed returns number of local funds which is needed to make channel
+// balanced.
+func (c *rebalanceChannel) needed() btcutil.Amount {
+	needed := btcutil.Amount(float64(c.overall)*excessBalanceRatio) - c.local
+	if needed < 0 {
+		return 0
+	}
+
+	return needed
+}
+
+// rebalanceChannels moves local balance from channels with excess local
+// balance to the channels with important nodes which are starved,
+// by sending circular payments to ourselves. Re-balancing is cheaper than
+// channel re-opening, but still fee of every circular payment is capped
+// by max fee ppm.
+func (nm *NodeManager) rebalanceChannels() error {
+	m := crypto.NewMetric(nm.cfg.Asset, common.GetFunctionName(),
+		nm.cfg.MetricsBackend)
+	defer m.Finish()
+
+	channels, err := nm.cfg.Client.Channels()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	nm.importantNodesMutex.Lock()
+	importantNodes := make(map[lightning.NodeID]string, len(nm.importantNodes))
+	for nodeID, name := range nm.importantNodes {
+		importantNodes[nodeID] = name
+	}
+	nm.importantNodesMutex.Unlock()
+
+	var starved, excess []*rebalanceChannel
+	for _, channel := range channels {
+		if !channel.IsActive() {
+			continue
+		}
+
+		state := channel.States[lightning.ChannelOpened].(*lightning.ChannelStateOpened)
+		c := &rebalanceChannel{
+			channel: channel,
+			local:   state.LocalBalance,
+			overall: state.LocalBalance + state.RemoteBalance,
+		}
+
+		_, isImportant := importantNodes[channel.NodeID]
+		if isImportant && c.ratio() < starvedBalanceRatio {
+			starved = append(starved, c)
+		} else if c.excess() >= minRebalanceAmount {
+			excess = append(excess, c)
+		}
+	}
+
+	// Start with the most starved channels, and take funds from channels
+	// with the biggest excess first.
+	sort.Slice(starved, func(i, j int) bool {
+		return starved[i].ratio() < starved[j].ratio()
+	})
+
+	sort.Slice(excess, func(i, j int) bool {
+		return excess[i].excess() > excess[j].excess()
+	})
+
+	for _, to := range starved {
+		for _, from := range excess {
+			if to.needed() < minRebalanceAmount {
+				break
+			}
+
+			// Channels with the same node couldn't be re-balanced by
+			// circular payment, because payment has to leave through one
+			// channel and return back through another.
+			if from.channel.NodeID == to.channel.NodeID {
+				continue
+			}
+
+			amount := to.needed()
+			if amount > from.excess() {
+				amount = from.excess()
+			}
+
+			if amount < minRebalanceAmount {
+				continue
+			}
+
+			maxFee := amount * btcutil.Amount(nm.cfg.MaxRebalanceFeePPM) /
+				1000000
+
+			payment, err := nm.cfg.Client.SendCircularPayment(
+				from.channel.ChannelID, to.channel.ChannelID, amount,
+				maxFee)
+			if err != nil {
+				log.Warnf("Unable to re-balance amount(%v) from channel(%v) "+
+					"to channel(%v) with important node(%v), "+
+					"max fee(%v): %v", amount, from.channel.ChannelID,
+					to.channel.ChannelID, importantNodes[to.channel.NodeID],
+					maxFee, err)
+				continue
+			}
+
+			log.Infof("Re-balanced amount(%v) from channel(%v) to "+
+				"channel(%v) with important node(%v), fee(%v)", amount,
+				from.channel.ChannelID, to.channel.ChannelID,
+				importantNodes[to.channel.NodeID], payment.MediaFee)
+
+			from.local -= amount + payment.MediaFee
+			to.local += amount
+		}
+	}
+
+	return nil
+}
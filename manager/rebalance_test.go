@@ -0,0 +1,57 @@
+package manager
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"testing"
+	"time"
+)
+
+func TestRebalanceChannels(t *testing.T) {
+	now := time.Now().Unix()
+
+	client := &mockClient{
+		channels: []*lightning.Channel{
+			// Starved channel with important node.
+			newOpenedChannel("1:0", "important", lightning.LocalInitiator,
+				now, 0, 10000, 990000),
+			// Channel with excess local balance.
+			newOpenedChannel("2:0", "a", lightning.LocalInitiator, now, 0,
+				900000, 100000),
+			// Channel with excess local balance with the same node
+			// couldn't be used.
+			newOpenedChannel("3:0", "important", lightning.LocalInitiator,
+				now, 0, 1000000, 0),
+		},
+	}
+
+	nm := &NodeManager{
+		cfg: &Config{
+			Client:             client,
+			MetricsBackend:     &mockMetricsBackend{},
+			MaxRebalanceFeePPM: 1000,
+			Asset:              "BTC",
+		},
+		importantNodes: map[lightning.NodeID]string{
+			"important": "important",
+		},
+	}
+
+	if err := nm.rebalanceChannels(); err != nil {
+		t.Fatalf("unable to rebalance channels: %v", err)
+	}
+
+	if len(client.circularPayments) != 1 {
+		t.Fatalf("wrong number of circular payments: %v",
+			len(client.circularPayments))
+	}
+
+	// Amount should be capped by the excess of the outgoing channel, fee
+	// should be capped by max fee rate.
+	payment := client.circularPayments[0]
+	if payment.outgoing != "2:0" || payment.incoming != "1:0" ||
+		payment.amount != 400000 || payment.maxFee != 400 {
+		t.Fatalf("wrong circular payment: %v -> %v, amount(%v), "+
+			"max fee(%v)", payment.outgoing, payment.incoming,
+			payment.amount, payment.maxFee)
+	}
+}
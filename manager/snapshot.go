@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+)
+
+const (
+	// channelsDropThreshold is the relative drop of channels count or
+	// capacity, starting from which we should alert.
+	channelsDropThreshold = 0.2
+
+	// maxChannelsChangeReports is the maximum number of channels change
+	// reports which are returned at once.
+	maxChannelsChangeReports = 100
+)
+
+// SnapshotStorage is used to persist channels snapshots, so that drop of
+// channels could be detected across restarts.
+type SnapshotStorage interface {
+	// AddChannelsSnapshot saves channels snapshot.
+	AddChannelsSnapshot(snapshot *db.ChannelsSnapshot) error
+
+	// LastChannelsSnapshot returns the latest saved channels snapshot,
+	// nil is returned if there is no snapshots.
+	LastChannelsSnapshot() (*db.ChannelsSnapshot, error)
+
+	// LastChannelsSnapshots returns the given number of the latest saved
+	// channels snapshots, sorted from the oldest to the newest one.
+	LastChannelsSnapshots(limit int) ([]*db.ChannelsSnapshot, error)
+}
+
+// ClosedChannel is the information about channel which was closed since
+// the previous snapshot.
+type ClosedChannel struct {
+	ChannelID lightning.ChannelID
+	NodeID    lightning.NodeID
+
+	// Capacity is the balance which was locked in the channel before close.
+	Capacity btcutil.Amount
+
+	// CloseType is the way in which channel has been closed.
+	CloseType lightning.ChannelCloseType
+
+	// Initiator is the side which closed the channel, empty if unknown.
+	Initiator lightning.ChannelInitiator
+}
+
+// ChannelsChangeReport is the result of comparison of the current channels
+// snapshot with the previous one.
+type ChannelsChangeReport struct {
+	Previous *db.ChannelsSnapshot
+	Current  *db.ChannelsSnapshot
+
+	// CountDrop is the relative drop of channels count, negative if
+	// number of channels has increased.
+	CountDrop float64
+
+	// CapacityDrop is the relative drop of channels capacity, negative if
+	// capacity has increased.
+	CapacityDrop float64
+
+	// Alert is true if drop of count or capacity exceeds threshold.
+	Alert bool
+
+	// ClosedChannels is the channels which were closed since the previous
+	// snapshot.
+	ClosedChannels []*ClosedChannel
+}
+
+// takeChannelsSnapshot creates snapshot of the opened channels.
+func takeChannelsSnapshot(channels []*lightning.Channel,
+	now int64) *db.ChannelsSnapshot {
+
+	snapshot := &db.ChannelsSnapshot{
+		Time: now,
+	}
+
+	for _, channel := range channels {
+		if channel.CurrentState() != lightning.ChannelOpened {
+			continue
+		}
+
+		state := channel.States[lightning.ChannelOpened].(*lightning.ChannelStateOpened)
+
+		snapshot.NumChannels++
+		snapshot.Capacity += state.LocalBalance + state.RemoteBalance
+		snapshot.ChannelIDs = append(snapshot.ChannelIDs, channel.ChannelID)
+	}
+
+	return snapshot
+}
+
+// compareSnapshots compares current snapshot with the previous one, and finds
+// out which channels were closed since the previous snapshot.
+func compareSnapshots(previous, current *db.ChannelsSnapshot,
+	channels []*lightning.Channel) *ChannelsChangeReport {
+
+	report := &ChannelsChangeReport{
+		Previous: previous,
+		Current:  current,
+	}
+
+	if previous.NumChannels != 0 {
+		report.CountDrop = float64(previous.NumChannels-current.NumChannels) /
+			float64(previous.NumChannels)
+	}
+
+	if previous.Capacity != 0 {
+		report.CapacityDrop = float64(previous.Capacity-current.Capacity) /
+			float64(previous.Capacity)
+	}
+
+	report.Alert = report.CountDrop >= channelsDropThreshold ||
+		report.CapacityDrop >= channelsDropThreshold
+
+	channelsByID := make(map[lightning.ChannelID]*lightning.Channel)
+	for _, channel := range channels {
+		channelsByID[channel.ChannelID] = channel
+	}
+
+	for _, channelID := range previous.ChannelIDs {
+		channel, ok := channelsByID[channelID]
+		if !ok || channel.CurrentState() == lightning.ChannelOpened {
+			continue
+		}
+
+		closedChannel := &ClosedChannel{
+			ChannelID: channel.ChannelID,
+			NodeID:    channel.NodeID,
+			CloseType: lightning.UnknownClose,
+		}
+
+		if state, ok := channel.States[lightning.ChannelOpened]; ok {
+			opened := state.(*lightning.ChannelStateOpened)
+			closedChannel.Capacity = opened.LocalBalance + opened.RemoteBalance
+		}
+
+		if state, ok := channel.States[lightning.ChannelClosing]; ok {
+			closing := state.(*lightning.ChannelStateClosing)
+			closedChannel.CloseType = closing.CloseType
+		}
+
+		switch closedChannel.CloseType {
+		case lightning.LocalForceClose:
+			closedChannel.Initiator = lightning.LocalInitiator
+		case lightning.RemoteForceClose, lightning.BreachClose:
+			closedChannel.Initiator = lightning.RemoteInitiator
+		}
+
+		report.ClosedChannels = append(report.ClosedChannels, closedChannel)
+	}
+
+	return report
+}
@@ -0,0 +1,125 @@
+package manager
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"testing"
+)
+
+// mockSnapshotStorage is the in-memory storage of channels snapshots.
+type mockSnapshotStorage struct {
+	snapshots []*db.ChannelsSnapshot
+}
+
+func (s *mockSnapshotStorage) AddChannelsSnapshot(
+	snapshot *db.ChannelsSnapshot) error {
+
+	s.snapshots = append(s.snapshots, snapshot)
+	return nil
+}
+
+func (s *mockSnapshotStorage) LastChannelsSnapshot() (*db.ChannelsSnapshot,
+	error) {
+
+	if len(s.snapshots) == 0 {
+		return nil, nil
+	}
+
+	return s.snapshots[len(s.snapshots)-1], nil
+}
+
+func (s *mockSnapshotStorage) LastChannelsSnapshots(
+	limit int) ([]*db.ChannelsSnapshot, error) {
+
+	if limit > len(s.snapshots) {
+		limit = len(s.snapshots)
+	}
+
+	return s.snapshots[len(s.snapshots)-limit:], nil
+}
+
+func TestChannelsChangeReports(t *testing.T) {
+	closed := newOpenedChannel("2:0", "b", lightning.LocalInitiator, 0, 0,
+		100, 100)
+	closed.State = lightning.ChannelClosed
+
+	storage := &mockSnapshotStorage{
+		snapshots: []*db.ChannelsSnapshot{
+			{
+				Time:        1,
+				NumChannels: 1,
+				Capacity:    200,
+				ChannelIDs:  []lightning.ChannelID{"1:0"},
+			},
+			{
+				Time:        2,
+				NumChannels: 2,
+				Capacity:    400,
+				ChannelIDs:  []lightning.ChannelID{"1:0", "2:0"},
+			},
+			{
+				Time:        3,
+				NumChannels: 1,
+				Capacity:    200,
+				ChannelIDs:  []lightning.ChannelID{"1:0"},
+			},
+		},
+	}
+
+	nm := &NodeManager{
+		cfg: &Config{
+			Client: &mockClient{
+				channels: []*lightning.Channel{
+					newOpenedChannel("1:0", "a", lightning.LocalInitiator,
+						0, 0, 100, 100),
+					closed,
+				},
+			},
+			SnapshotStorage: storage,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		limit      int
+		numReports int
+	}{
+		{
+			name:       "all reports",
+			limit:      0,
+			numReports: 2,
+		},
+		{
+			name:       "last report",
+			limit:      1,
+			numReports: 1,
+		},
+	}
+
+	for _, test := range tests {
+		reports, err := nm.ChannelsChangeReports(test.limit)
+		if err != nil {
+			t.Fatalf("(%v) unable to get reports: %v", test.name, err)
+		}
+
+		if len(reports) != test.numReports {
+			t.Fatalf("(%v) wrong number of reports: %v", test.name,
+				len(reports))
+		}
+
+		// The last report should be the drop of the closed channel.
+		last := reports[len(reports)-1]
+		if last.Current.Time != 3 || !last.Alert ||
+			len(last.ClosedChannels) != 1 ||
+			last.ClosedChannels[0].ChannelID != "2:0" {
+			t.Fatalf("(%v) wrong last report: %+v", test.name, last)
+		}
+	}
+
+	storage.snapshots = storage.snapshots[:1]
+	reports, err := nm.ChannelsChangeReports(0)
+	if err != nil || len(reports) != 0 {
+		t.Fatalf("single snapshot shouldn't give reports: %v, %v",
+			len(reports), err)
+	}
+}
@@ -0,0 +1,269 @@
+package stats
+
+import (
+	"fmt"
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+)
+
+// AnomalyCode is the identifier of anomaly type.
+type AnomalyCode string
+
+const (
+	// InactiveChannelsAnomaly is used when some of opened channels with
+	// node are inactive, and couldn't be used to send payments.
+	InactiveChannelsAnomaly AnomalyCode = "inactive_channels"
+
+	// StuckBalanceAnomaly is used when part of funds is stuck in pending
+	// htlc.
+	StuckBalanceAnomaly AnomalyCode = "stuck_balance"
+
+	// RemoteOnlyLiquidityAnomaly is used when all funds in channels with
+	// node are on remote side, and we couldn't send payments to it.
+	RemoteOnlyLiquidityAnomaly AnomalyCode = "remote_only_liquidity"
+
+	// PendingCloseAnomaly is used when channels with node are in the
+	// process of closing, and funds are locked until they will be closed.
+	PendingCloseAnomaly AnomalyCode = "pending_close"
+
+	// InsufficientCapacityAnomaly is used when active local balance is
+	// less than average daily flow of payments to node.
+	InsufficientCapacityAnomaly AnomalyCode = "insufficient_capacity"
+
+	// MaxFlowAnomaly is used when biggest channel local balance is less
+	// than typical payment to important node, which couldn't be split
+	// between channels without AMP.
+	MaxFlowAnomaly AnomalyCode = "max_flow"
+
+	// ImportantNodeDisconnectedAnomaly is used when important node is not
+	// connected to us.
+	ImportantNodeDisconnectedAnomaly AnomalyCode = "important_node_disconnected"
+)
+
+// AnomalySeverity is the level of anomaly importance.
+type AnomalySeverity string
+
+const (
+	// InfoSeverity is used for anomalies which don't require actions,
+	// but should be taken into account.
+	InfoSeverity AnomalySeverity = "info"
+
+	// WarningSeverity is used for anomalies which degrade interaction
+	// with node.
+	WarningSeverity AnomalySeverity = "warning"
+
+	// CriticalSeverity is used for anomalies which make payments to node
+	// impossible.
+	CriticalSeverity AnomalySeverity = "critical"
+)
+
+// Anomaly is the problem in our interaction with node.
+type Anomaly struct {
+	Code        AnomalyCode
+	Severity    AnomalySeverity
+	Description string
+}
+
+// NodeAnomalyState is the state of the node, which is evaluated by anomaly
+// rules.
+type NodeAnomalyState struct {
+	Stats NodeStats
+
+	// Channels is our channels with the node.
+	Channels []*lightning.Channel
+
+	// IsImportant is true if node is important for us.
+	IsImportant bool
+
+	// IsConnected is true if node is connected to us with tcp / ip
+	// connection.
+	IsConnected bool
+}
+
+// AnomalyRule evaluates the state of the node, and returns anomaly, nil is
+// returned if state is normal.
+type AnomalyRule func(state *NodeAnomalyState) *Anomaly
+
+// DefaultAnomalyRules is the set of rules which are used to evaluate state
+// of nodes.
+var DefaultAnomalyRules = []AnomalyRule{
+	checkImportantNodeDisconnected,
+	checkInactiveChannels,
+	checkStuckBalance,
+	checkRemoteOnlyLiquidity,
+	checkPendingClose,
+	checkInsufficientCapacity,
+	checkMaxFlow,
+}
+
+// DetectAnomalies evaluates the state of the node with the given rules, and
+// returns detected anomalies.
+func DetectAnomalies(state *NodeAnomalyState,
+	rules []AnomalyRule) []*Anomaly {
+
+	var anomalies []*Anomaly
+	for _, rule := range rules {
+		if anomaly := rule(state); anomaly != nil {
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+
+	return anomalies
+}
+
+// checkImportantNodeDisconnected checks that important node is connected
+// to us.
+func checkImportantNodeDisconnected(state *NodeAnomalyState) *Anomaly {
+	if !state.IsImportant || state.IsConnected {
+		return nil
+	}
+
+	return &Anomaly{
+		Code:        ImportantNodeDisconnectedAnomaly,
+		Severity:    CriticalSeverity,
+		Description: "important node is not connected",
+	}
+}
+
+// checkInactiveChannels checks that all opened channels with node are
+// active.
+func checkInactiveChannels(state *NodeAnomalyState) *Anomaly {
+	var numOpened, numInactive int
+	for _, channel := range state.Channels {
+		if channel.CurrentState() != lightning.ChannelOpened {
+			continue
+		}
+
+		numOpened++
+		if !channel.IsActive() {
+			numInactive++
+		}
+	}
+
+	if numInactive == 0 {
+		return nil
+	}
+
+	severity := WarningSeverity
+	if numInactive == numOpened {
+		severity = CriticalSeverity
+	}
+
+	return &Anomaly{
+		Code:     InactiveChannelsAnomaly,
+		Severity: severity,
+		Description: fmt.Sprintf("%v of %v opened channels are inactive, "+
+			"inactive local balance(%v)", numInactive, numOpened,
+			state.Stats.LockedLocallyOverall-state.Stats.LockedLocallyActive),
+	}
+}
+
+// checkStuckBalance checks that there is no funds stuck in pending htlc.
+func checkStuckBalance(state *NodeAnomalyState) *Anomaly {
+	var stuckBalance btcutil.Amount
+	for _, channel := range state.Channels {
+		if channel.CurrentState() != lightning.ChannelOpened {
+			continue
+		}
+
+		balance, err := channel.StuckBalance()
+		if err != nil {
+			continue
+		}
+
+		stuckBalance += balance
+	}
+
+	if stuckBalance == 0 {
+		return nil
+	}
+
+	return &Anomaly{
+		Code:     StuckBalanceAnomaly,
+		Severity: WarningSeverity,
+		Description: fmt.Sprintf("funds(%v) are stuck in pending htlc",
+			stuckBalance),
+	}
+}
+
+// checkRemoteOnlyLiquidity checks that we have local balance with node, if
+// we have channels with it.
+func checkRemoteOnlyLiquidity(state *NodeAnomalyState) *Anomaly {
+	if state.Stats.LockedRemotelyOverall == 0 ||
+		state.Stats.LockedLocallyOverall != 0 {
+		return nil
+	}
+
+	return &Anomaly{
+		Code:     RemoteOnlyLiquidityAnomaly,
+		Severity: WarningSeverity,
+		Description: fmt.Sprintf("all funds(%v) are locked on remote side, "+
+			"payments couldn't be sent to node",
+			state.Stats.LockedRemotelyOverall),
+	}
+}
+
+// checkPendingClose checks that there is no channels with node in the
+// process of closing.
+func checkPendingClose(state *NodeAnomalyState) *Anomaly {
+	var numClosing int
+	var limboBalance btcutil.Amount
+	for _, channel := range state.Channels {
+		if channel.CurrentState() != lightning.ChannelClosing {
+			continue
+		}
+
+		numClosing++
+
+		balance, err := channel.LimboBalance()
+		if err != nil {
+			continue
+		}
+
+		limboBalance += balance
+	}
+
+	if numClosing == 0 {
+		return nil
+	}
+
+	return &Anomaly{
+		Code:     PendingCloseAnomaly,
+		Severity: InfoSeverity,
+		Description: fmt.Sprintf("%v channels are pending close, limbo "+
+			"balance(%v)", numClosing, limboBalance),
+	}
+}
+
+// checkInsufficientCapacity checks that active local balance is enough to
+// serve the average daily flow of payments to node.
+func checkInsufficientCapacity(state *NodeAnomalyState) *Anomaly {
+	flow := state.Stats.AverageSentSat + state.Stats.AverageSentForwardSat
+	if flow == 0 || state.Stats.LockedLocallyActive >= flow {
+		return nil
+	}
+
+	return &Anomaly{
+		Code:     InsufficientCapacityAnomaly,
+		Severity: WarningSeverity,
+		Description: fmt.Sprintf("active local balance(%v) is less than "+
+			"average daily flow(%v)", state.Stats.LockedLocallyActive, flow),
+	}
+}
+
+// checkMaxFlow checks that typical payment to important node could be
+// fitted in the single channel.
+func checkMaxFlow(state *NodeAnomalyState) *Anomaly {
+	if !state.IsImportant ||
+		state.Stats.MaxLockedLocallyActive >= state.Stats.PercentileSentSat {
+		return nil
+	}
+
+	return &Anomaly{
+		Code:     MaxFlowAnomaly,
+		Severity: WarningSeverity,
+		Description: fmt.Sprintf("biggest channel local balance(%v) is "+
+			"less than 95th percentile of payment amount(%v)",
+			state.Stats.MaxLockedLocallyActive, state.Stats.PercentileSentSat),
+	}
+}
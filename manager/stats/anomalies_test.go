@@ -0,0 +1,304 @@
+package stats
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"testing"
+)
+
+func newOpenedChannel(isActive bool, stuck btcutil.Amount) *lightning.Channel {
+	return &lightning.Channel{
+		State: lightning.ChannelOpened,
+		States: map[lightning.ChannelStateName]interface{}{
+			lightning.ChannelOpened: &lightning.ChannelStateOpened{
+				IsActive:     isActive,
+				StuckBalance: stuck,
+			},
+		},
+	}
+}
+
+func newClosingChannel(locked btcutil.Amount) *lightning.Channel {
+	return &lightning.Channel{
+		State: lightning.ChannelClosing,
+		States: map[lightning.ChannelStateName]interface{}{
+			lightning.ChannelClosing: &lightning.ChannelStateClosing{
+				LockedBalance: locked,
+			},
+		},
+	}
+}
+
+func TestAnomalyRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     AnomalyRule
+		state    *NodeAnomalyState
+		code     AnomalyCode
+		severity AnomalySeverity
+	}{
+		{
+			name: "important node connected",
+			rule: checkImportantNodeDisconnected,
+			state: &NodeAnomalyState{
+				IsImportant: true,
+				IsConnected: true,
+			},
+		},
+		{
+			name:  "not important node disconnected",
+			rule:  checkImportantNodeDisconnected,
+			state: &NodeAnomalyState{},
+		},
+		{
+			name: "important node disconnected",
+			rule: checkImportantNodeDisconnected,
+			state: &NodeAnomalyState{
+				IsImportant: true,
+			},
+			code:     ImportantNodeDisconnectedAnomaly,
+			severity: CriticalSeverity,
+		},
+		{
+			name: "all channels active",
+			rule: checkInactiveChannels,
+			state: &NodeAnomalyState{
+				Channels: []*lightning.Channel{
+					newOpenedChannel(true, 0),
+					newClosingChannel(100),
+				},
+			},
+		},
+		{
+			name: "some channels inactive",
+			rule: checkInactiveChannels,
+			state: &NodeAnomalyState{
+				Channels: []*lightning.Channel{
+					newOpenedChannel(true, 0),
+					newOpenedChannel(false, 0),
+				},
+			},
+			code:     InactiveChannelsAnomaly,
+			severity: WarningSeverity,
+		},
+		{
+			name: "all channels inactive",
+			rule: checkInactiveChannels,
+			state: &NodeAnomalyState{
+				Channels: []*lightning.Channel{
+					newOpenedChannel(false, 0),
+				},
+			},
+			code:     InactiveChannelsAnomaly,
+			severity: CriticalSeverity,
+		},
+		{
+			name: "no stuck balance",
+			rule: checkStuckBalance,
+			state: &NodeAnomalyState{
+				Channels: []*lightning.Channel{
+					newOpenedChannel(true, 0),
+				},
+			},
+		},
+		{
+			name: "stuck balance",
+			rule: checkStuckBalance,
+			state: &NodeAnomalyState{
+				Channels: []*lightning.Channel{
+					newOpenedChannel(true, 0),
+					newOpenedChannel(true, 100),
+				},
+			},
+			code:     StuckBalanceAnomaly,
+			severity: WarningSeverity,
+		},
+		{
+			name:  "no channels",
+			rule:  checkRemoteOnlyLiquidity,
+			state: &NodeAnomalyState{},
+		},
+		{
+			name: "local liquidity",
+			rule: checkRemoteOnlyLiquidity,
+			state: &NodeAnomalyState{
+				Stats: NodeStats{
+					ChannelNodeStats: ChannelNodeStats{
+						LockedLocallyOverall:  10,
+						LockedRemotelyOverall: 100,
+					},
+				},
+			},
+		},
+		{
+			name: "remote only liquidity",
+			rule: checkRemoteOnlyLiquidity,
+			state: &NodeAnomalyState{
+				Stats: NodeStats{
+					ChannelNodeStats: ChannelNodeStats{
+						LockedRemotelyOverall: 100,
+					},
+				},
+			},
+			code:     RemoteOnlyLiquidityAnomaly,
+			severity: WarningSeverity,
+		},
+		{
+			name: "no closing channels",
+			rule: checkPendingClose,
+			state: &NodeAnomalyState{
+				Channels: []*lightning.Channel{
+					newOpenedChannel(true, 0),
+				},
+			},
+		},
+		{
+			name: "closing channels",
+			rule: checkPendingClose,
+			state: &NodeAnomalyState{
+				Channels: []*lightning.Channel{
+					newClosingChannel(100),
+				},
+			},
+			code:     PendingCloseAnomaly,
+			severity: InfoSeverity,
+		},
+		{
+			name:  "no payments flow",
+			rule:  checkInsufficientCapacity,
+			state: &NodeAnomalyState{},
+		},
+		{
+			name: "sufficient capacity",
+			rule: checkInsufficientCapacity,
+			state: &NodeAnomalyState{
+				Stats: NodeStats{
+					PaymentNodeStats: PaymentNodeStats{
+						AverageSentSat:        50,
+						AverageSentForwardSat: 50,
+					},
+					ChannelNodeStats: ChannelNodeStats{
+						LockedLocallyActive: 100,
+					},
+				},
+			},
+		},
+		{
+			name: "insufficient capacity",
+			rule: checkInsufficientCapacity,
+			state: &NodeAnomalyState{
+				Stats: NodeStats{
+					PaymentNodeStats: PaymentNodeStats{
+						AverageSentSat:        50,
+						AverageSentForwardSat: 51,
+					},
+					ChannelNodeStats: ChannelNodeStats{
+						LockedLocallyActive: 100,
+					},
+				},
+			},
+			code:     InsufficientCapacityAnomaly,
+			severity: WarningSeverity,
+		},
+		{
+			name: "max flow of not important node",
+			rule: checkMaxFlow,
+			state: &NodeAnomalyState{
+				Stats: NodeStats{
+					PaymentNodeStats: PaymentNodeStats{
+						PercentileSentSat: 100,
+					},
+				},
+			},
+		},
+		{
+			name: "sufficient max flow",
+			rule: checkMaxFlow,
+			state: &NodeAnomalyState{
+				IsImportant: true,
+				Stats: NodeStats{
+					PaymentNodeStats: PaymentNodeStats{
+						PercentileSentSat: 100,
+					},
+					ChannelNodeStats: ChannelNodeStats{
+						MaxLockedLocallyActive: 100,
+					},
+				},
+			},
+		},
+		{
+			name: "insufficient max flow",
+			rule: checkMaxFlow,
+			state: &NodeAnomalyState{
+				IsImportant: true,
+				Stats: NodeStats{
+					PaymentNodeStats: PaymentNodeStats{
+						PercentileSentSat: 100,
+					},
+					ChannelNodeStats: ChannelNodeStats{
+						MaxLockedLocallyActive: 99,
+					},
+				},
+			},
+			code:     MaxFlowAnomaly,
+			severity: WarningSeverity,
+		},
+	}
+
+	for _, test := range tests {
+		anomaly := test.rule(test.state)
+
+		if test.code == "" {
+			if anomaly != nil {
+				t.Fatalf("(%v) anomaly shouldn't be detected, got: %v",
+					test.name, anomaly.Code)
+			}
+			continue
+		}
+
+		if anomaly == nil {
+			t.Fatalf("(%v) anomaly should be detected", test.name)
+		}
+
+		if anomaly.Code != test.code || anomaly.Severity != test.severity {
+			t.Fatalf("(%v) wrong anomaly: %v(%v)", test.name, anomaly.Code,
+				anomaly.Severity)
+		}
+
+		if anomaly.Description == "" {
+			t.Fatalf("(%v) anomaly description is empty", test.name)
+		}
+	}
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	state := &NodeAnomalyState{
+		IsImportant: true,
+		Channels: []*lightning.Channel{
+			newOpenedChannel(false, 0),
+			newClosingChannel(100),
+		},
+	}
+
+	anomalies := DetectAnomalies(state, DefaultAnomalyRules)
+
+	expected := []AnomalyCode{
+		ImportantNodeDisconnectedAnomaly,
+		InactiveChannelsAnomaly,
+		PendingCloseAnomaly,
+	}
+
+	if len(anomalies) != len(expected) {
+		t.Fatalf("wrong number of anomalies: %v", len(anomalies))
+	}
+
+	for i, anomaly := range anomalies {
+		if anomaly.Code != expected[i] {
+			t.Fatalf("wrong anomaly(%v): %v", i, anomaly.Code)
+		}
+	}
+
+	if anomalies := DetectAnomalies(state, nil); len(anomalies) != 0 {
+		t.Fatalf("anomalies shouldn't be detected without rules")
+	}
+}
@@ -25,6 +25,15 @@ type ChannelNodeStats struct {
 	// which are locked on remote side. This number include pending, as well
 	// as active funds.
 	LockedRemotelyOverall btcutil.Amount
+
+	// MaxLockedLocallyActive is the biggest local balance of the single
+	// active channel, which is the maximum amount of payment we could send
+	// to the node without AMP.
+	MaxLockedLocallyActive btcutil.Amount
+
+	// NumActiveChannels is number of opened channels which could be used
+	// for sending / forwarding payments.
+	NumActiveChannels int
 }
 
 func calculateChannelNodeStats(channels []*lightning.Channel) (
@@ -44,8 +53,13 @@ func calculateChannelNodeStats(channels []*lightning.Channel) (
 		switch s := currentState.(type) {
 		case *lightning.ChannelStateOpened:
 			if channel.IsActive() {
+				stat.NumActiveChannels++
 				stat.LockedLocallyActive += s.LocalBalance
 				stat.LockedRemotelyActive += s.RemoteBalance
+
+				if s.LocalBalance > stat.MaxLockedLocallyActive {
+					stat.MaxLockedLocallyActive = s.LocalBalance
+				}
 			}
 
 			stat.LockedLocallyOverall += s.LocalBalance
@@ -5,7 +5,6 @@ import (
 	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
 	"github.com/montanaflynn/stats"
-	"time"
 )
 
 type PaymentNodeStats struct {
@@ -26,9 +25,15 @@ type PaymentNodeStats struct {
 	// to this node generated by forwarding activity in the network.
 	AverageSentForwardSat btcutil.Amount
 
+	// AverageReceivedSat is the number of funds which were received by us
+	// from this node, i.e. payments to our invoices which arrived through
+	// channels with this node.
+	AverageReceivedSat btcutil.Amount
+
 	OverallSentSat            btcutil.Amount
 	OverallReceivedForwardSat btcutil.Amount
 	OverallSentForwardSat     btcutil.Amount
+	OverallReceivedSat        btcutil.Amount
 
 	// NumForwardSentPayments number of times we have sent forward payment to this node.
 	NumForwardSentPayments int32
@@ -39,43 +44,49 @@ type PaymentNodeStats struct {
 
 	// NumSentPayments number of times we have sent payment to this node.
 	NumSentPayments int32
+
+	// NumReceivedPayments number of times we have received payment from
+	// this node.
+	NumReceivedPayments int32
+
+	// PercentileSentSat is the 95th percentile of amount of payments,
+	// which were sent to this node, including the forwarded ones.
+	// Without AMP the payment of this amount should be fitted in the
+	// single channel.
+	PercentileSentSat btcutil.Amount
 }
 
+// sentPercentile is the percentile of the sent payments amount, which is
+// used to calculate the amount of payment we should be able to send
+// through the single channel.
+const sentPercentile = 95
+
 // calculateNodeStats calculate average day payment statistics activity between
-// our node and other nodes calculated over the week, also aggregate stats
-// about funds locked and locally and remotely with node,
+// our node and other nodes calculated over the [start, end) range of time,
+// also aggregate stats about funds locked and locally and remotely with node,
 // as well 95% percentile payment amount.
-func calculatePaymentsStats(period string, payments []*lightning.Payment,
+func calculatePaymentsStats(start, end int64, payments []*lightning.Payment,
 	forwardPayments []*lightning.ForwardPayment) (map[lightning.
 NodeID]PaymentNodeStats, error) {
 
-	day := int64(60 * 60 * 24)
-	start := int64(0)
-	end := int64(0)
-
-	switch period {
-	case "day":
-		start = time.Now().Unix() - day
-		end = time.Now().Unix()
-	case "week":
-		start = time.Now().Unix() - 7*day
-		end = time.Now().Unix()
-	case "month":
-		start = time.Now().Unix() - 30*day
-		end = time.Now().Unix()
-	case "three month":
-		start = time.Now().Unix() - 3*30*day
-		end = time.Now().Unix()
-	default:
-		return nil, errors.Errorf("period(%v) not supported", period)
+	if start >= end {
+		return nil, errors.Errorf("start(%v) should be less than end(%v)",
+			start, end)
 	}
 
+	// If range is less than a day, than averages are calculated over the
+	// whole range.
+	day := int64(Day)
 	numDays := (end - start) / day
+	if numDays == 0 {
+		numDays = 1
+	}
 	nodeStats := make(map[lightning.NodeID]PaymentNodeStats)
+	sentAmounts := make(map[lightning.NodeID][]int64)
 
 	for _, payment := range payments {
 		// Skip payment which are lies bong inspected period of time.
-		if start > payment.UpdatedAt || end < payment.UpdatedAt {
+		if !InRange(payment.UpdatedAt, start, end) {
 			continue
 		}
 
@@ -84,14 +95,27 @@ NodeID]PaymentNodeStats, error) {
 			stat.OverallSentSat += payment.Amount
 			stat.NumSentPayments += 1
 			nodeStats[payment.Receiver] = stat
+
+			sentAmounts[payment.Receiver] = append(
+				sentAmounts[payment.Receiver], int64(payment.Amount))
 		} else if payment.Direction == lightning.Incoming {
-			// In this case we un
+			// Incoming payment is attributed to the adjacent node
+			// from which it has been received, if it is unknown we
+			// couldn't say who paid us.
+			if payment.Sender == "" {
+				continue
+			}
+
+			stat := nodeStats[payment.Sender]
+			stat.OverallReceivedSat += payment.Amount
+			stat.NumReceivedPayments += 1
+			nodeStats[payment.Sender] = stat
 		}
 	}
 
 	for _, payment := range forwardPayments {
 		// Skip payment which are lies bong inspected period of time.
-		if start > payment.Time || end < payment.Time {
+		if !InRange(payment.Time, start, end) {
 			continue
 		}
 
@@ -104,6 +128,9 @@ NodeID]PaymentNodeStats, error) {
 		stat.OverallSentForwardSat += payment.OutgoingAmount
 		stat.NumForwardSentPayments += 1
 		nodeStats[payment.ToNode] = stat
+
+		sentAmounts[payment.ToNode] = append(sentAmounts[payment.ToNode],
+			int64(payment.OutgoingAmount))
 	}
 
 	// Calculate average send flow for one day.
@@ -113,6 +140,19 @@ NodeID]PaymentNodeStats, error) {
 			OverallReceivedForwardSat) / numDays)
 		stat.AverageSentForwardSat = btcutil.Amount(int64(stat.
 			OverallSentForwardSat) / numDays)
+		stat.AverageReceivedSat = btcutil.Amount(int64(stat.
+			OverallReceivedSat) / numDays)
+
+		if amounts, ok := sentAmounts[nodeID]; ok {
+			percentileAmount, err := getPercentilePaymentAmount(
+				sentPercentile, amounts)
+			if err != nil {
+				return nil, errors.Errorf("unable calculate percentile "+
+					"payment amount for node(%v): %v", nodeID, err)
+			}
+			stat.PercentileSentSat = btcutil.Amount(percentileAmount)
+		}
+
 		nodeStats[nodeID] = stat
 	}
 
@@ -126,7 +166,7 @@ NodeID]PaymentNodeStats, error) {
 func getPercentilePaymentAmount(percent float64,
 	paymentsAmounts []int64) (int64, error) {
 
-	paymentsAmountsF := make([]float64, len(paymentsAmounts))
+	paymentsAmountsF := make([]float64, 0, len(paymentsAmounts))
 	for _, amount := range paymentsAmounts {
 		paymentsAmountsF = append(paymentsAmountsF, float64(amount))
 	}
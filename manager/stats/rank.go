@@ -71,6 +71,25 @@ func RankByPaymentVolume(nodeStats map[lightning.NodeID]NodeStats) []RankedStat
 	return rankedNodes
 }
 
+// RankByPaymentReceivedVolume rank nodes by the amount of funds received
+// from them in average, i.e. payments to our invoices. First node in the
+// list is the one which pays us the most.
+func RankByPaymentReceivedVolume(nodeStats map[lightning.NodeID]NodeStats) []RankedStat {
+	var rankedNodes []RankedStat
+	for _, stat := range nodeStats {
+		rankedNodes = append(rankedNodes, RankedStat{
+			Rank:      float64(stat.AverageReceivedSat),
+			NodeStats: stat,
+		})
+	}
+
+	sort.Slice(rankedNodes, func(i, j int) bool {
+		return rankedNodes[i].Rank > rankedNodes[j].Rank
+	})
+
+	return rankedNodes
+}
+
 // RankByIdleFunds sort nodes based on idleness of out funds locked with it,
 // if we don't have any activity, and we have local funds,
 // than we should release them first. First node in the list is most idle one.
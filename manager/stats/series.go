@@ -0,0 +1,143 @@
+package stats
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+)
+
+// BucketSize is the size of time series bucket in seconds.
+type BucketSize int64
+
+const (
+	Hour BucketSize = 60 * 60
+	Day  BucketSize = 24 * Hour
+	Week BucketSize = 7 * Day
+)
+
+// maxBuckets is the maximum number of buckets in the time series, it is
+// needed to prevent huge responses on the wide ranges with small buckets.
+const maxBuckets = 10000
+
+// DefaultNumBuckets is the number of buckets in the time series, which is
+// used if start of the series isn't specified.
+const DefaultNumBuckets = 30
+
+// PaymentBucket is the payments activity between our node and other node
+// within the bucket of time series.
+type PaymentBucket struct {
+	// Start is the beginning of the bucket, bucket ends where the next one
+	// starts.
+	Start int64
+
+	SentSat            btcutil.Amount
+	SentForwardSat     btcutil.Amount
+	ReceivedForwardSat btcutil.Amount
+	ReceivedSat        btcutil.Amount
+
+	NumSentPayments            int32
+	NumForwardSentPayments     int32
+	NumForwardReceivedPayments int32
+	NumReceivedPayments        int32
+}
+
+// ValidateSeriesRange checks that [start, end) range of time could be split
+// on the buckets of the given size, without exceeding the maximum number of
+// buckets.
+func ValidateSeriesRange(start, end int64, bucketSize BucketSize) error {
+	if start >= end {
+		return errors.Errorf("start(%v) should be less than end(%v)",
+			start, end)
+	}
+
+	if bucketSize <= 0 {
+		return errors.Errorf("bucket size(%v) should be positive",
+			bucketSize)
+	}
+
+	if numBuckets := bucketsNumber(start, end, bucketSize); numBuckets >
+		maxBuckets {
+		return errors.Errorf("number of buckets(%v) exceeds "+
+			"maximum(%v)", numBuckets, maxBuckets)
+	}
+
+	return nil
+}
+
+// bucketsNumber returns the number of buckets of the given size needed to
+// cover [start, end) range of time.
+func bucketsNumber(start, end int64, bucketSize BucketSize) int64 {
+	size := int64(bucketSize)
+	return (end - start + size - 1) / size
+}
+
+// GetPaymentTimeSeries splits [start, end) range of time on the buckets of
+// the given size, and aggregates payments activity of every node in them.
+// Every node series contains all buckets, even if there were no payments
+// within them, last bucket could be shorter than others.
+func GetPaymentTimeSeries(start, end int64, bucketSize BucketSize,
+	payments []*lightning.Payment,
+	forwardPayments []*lightning.ForwardPayment) (
+	map[lightning.NodeID][]*PaymentBucket, error) {
+
+	if err := ValidateSeriesRange(start, end, bucketSize); err != nil {
+		return nil, err
+	}
+
+	size := int64(bucketSize)
+	numBuckets := bucketsNumber(start, end, bucketSize)
+
+	series := make(map[lightning.NodeID][]*PaymentBucket)
+	getBucket := func(nodeID lightning.NodeID, moment int64) *PaymentBucket {
+		buckets, ok := series[nodeID]
+		if !ok {
+			buckets = make([]*PaymentBucket, numBuckets)
+			for i := range buckets {
+				buckets[i] = &PaymentBucket{
+					Start: start + int64(i)*size,
+				}
+			}
+			series[nodeID] = buckets
+		}
+
+		return buckets[(moment-start)/size]
+	}
+
+	for _, payment := range payments {
+		if !InRange(payment.UpdatedAt, start, end) {
+			continue
+		}
+
+		switch payment.Direction {
+		case lightning.Outgoing:
+			bucket := getBucket(payment.Receiver, payment.UpdatedAt)
+			bucket.SentSat += payment.Amount
+			bucket.NumSentPayments++
+
+		case lightning.Incoming:
+			if payment.Sender == "" {
+				continue
+			}
+
+			bucket := getBucket(payment.Sender, payment.UpdatedAt)
+			bucket.ReceivedSat += payment.Amount
+			bucket.NumReceivedPayments++
+		}
+	}
+
+	for _, payment := range forwardPayments {
+		if !InRange(payment.Time, start, end) {
+			continue
+		}
+
+		bucket := getBucket(payment.FromNode, payment.Time)
+		bucket.ReceivedForwardSat += payment.IncomingAmount
+		bucket.NumForwardReceivedPayments++
+
+		bucket = getBucket(payment.ToNode, payment.Time)
+		bucket.SentForwardSat += payment.OutgoingAmount
+		bucket.NumForwardSentPayments++
+	}
+
+	return series, nil
+}
@@ -0,0 +1,201 @@
+package stats
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"testing"
+)
+
+func TestValidateSeriesRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		start      int64
+		end        int64
+		bucketSize BucketSize
+		valid      bool
+	}{
+		{
+			name:       "single bucket",
+			start:      0,
+			end:        1,
+			bucketSize: Day,
+			valid:      true,
+		},
+		{
+			name:       "maximum buckets",
+			start:      0,
+			end:        maxBuckets * int64(Hour),
+			bucketSize: Hour,
+			valid:      true,
+		},
+		{
+			name:       "too many buckets",
+			start:      0,
+			end:        maxBuckets*int64(Hour) + 1,
+			bucketSize: Hour,
+		},
+		{
+			name:       "empty range",
+			start:      10,
+			end:        10,
+			bucketSize: Day,
+		},
+		{
+			name:       "zero bucket size",
+			start:      0,
+			end:        10,
+			bucketSize: 0,
+		},
+	}
+
+	for _, test := range tests {
+		err := ValidateSeriesRange(test.start, test.end, test.bucketSize)
+		if (err == nil) != test.valid {
+			t.Fatalf("(%v) wrong validity: %v", test.name, err)
+		}
+	}
+}
+
+func TestGetPaymentTimeSeries(t *testing.T) {
+	start := int64(1000)
+	end := start + 2*int64(Day) + 1
+
+	payments := []*lightning.Payment{
+		{
+			Direction: lightning.Outgoing,
+			Receiver:  "a",
+			Amount:    10,
+			UpdatedAt: start,
+		},
+		{
+			Direction: lightning.Outgoing,
+			Receiver:  "a",
+			Amount:    20,
+			UpdatedAt: start + int64(Day),
+		},
+		{
+			// Payment out of range shouldn't be counted.
+			Direction: lightning.Outgoing,
+			Receiver:  "a",
+			Amount:    40,
+			UpdatedAt: end,
+		},
+		{
+			Direction: lightning.Incoming,
+			Sender:    "b",
+			Amount:    30,
+			UpdatedAt: end - 1,
+		},
+		{
+			// Incoming payment with unknown sender shouldn't be counted.
+			Direction: lightning.Incoming,
+			Amount:    50,
+			UpdatedAt: start,
+		},
+	}
+
+	forwards := []*lightning.ForwardPayment{
+		{
+			FromNode:       "b",
+			ToNode:         "a",
+			IncomingAmount: 11,
+			OutgoingAmount: 10,
+			Time:           start + int64(Day) - 1,
+		},
+	}
+
+	series, err := GetPaymentTimeSeries(start, end, Day, payments, forwards)
+	if err != nil {
+		t.Fatalf("unable to get series: %v", err)
+	}
+
+	if len(series) != 2 {
+		t.Fatalf("wrong number of nodes: %v", len(series))
+	}
+
+	for nodeID, buckets := range series {
+		if len(buckets) != 3 {
+			t.Fatalf("(%v) wrong number of buckets: %v", nodeID,
+				len(buckets))
+		}
+
+		for i, bucket := range buckets {
+			if bucket.Start != start+int64(i)*int64(Day) {
+				t.Fatalf("(%v) wrong start of bucket(%v): %v", nodeID, i,
+					bucket.Start)
+			}
+		}
+	}
+
+	expected := map[lightning.NodeID][]PaymentBucket{
+		"a": {
+			{
+				SentSat:                10,
+				NumSentPayments:        1,
+				SentForwardSat:         10,
+				NumForwardSentPayments: 1,
+			},
+			{
+				SentSat:         20,
+				NumSentPayments: 1,
+			},
+			{},
+		},
+		"b": {
+			{
+				ReceivedForwardSat:         11,
+				NumForwardReceivedPayments: 1,
+			},
+			{},
+			{
+				ReceivedSat:         30,
+				NumReceivedPayments: 1,
+			},
+		},
+	}
+
+	for nodeID, buckets := range expected {
+		for i, bucket := range buckets {
+			bucket.Start = series[nodeID][i].Start
+			if *series[nodeID][i] != bucket {
+				t.Fatalf("(%v) wrong bucket(%v): %+v", nodeID, i,
+					*series[nodeID][i])
+			}
+		}
+	}
+
+	if _, err := GetPaymentTimeSeries(0, maxBuckets*int64(Hour)+1, Hour,
+		payments, forwards); err == nil {
+		t.Fatalf("series with too many buckets shouldn't be created")
+	}
+}
+
+func TestPeriodRange(t *testing.T) {
+	now := int64(100 * Day)
+
+	tests := []struct {
+		period Period
+		start  int64
+		valid  bool
+	}{
+		{period: PeriodDay, start: now - int64(Day), valid: true},
+		{period: PeriodWeek, start: now - int64(Week), valid: true},
+		{period: PeriodMonth, start: now - 30*int64(Day), valid: true},
+		{period: PeriodThreeMonth, start: now - 90*int64(Day), valid: true},
+		{period: "year"},
+	}
+
+	for _, test := range tests {
+		start, end, err := PeriodRange(test.period, now)
+		if (err == nil) != test.valid {
+			t.Fatalf("(%v) wrong validity: %v", test.period, err)
+		}
+
+		if !test.valid {
+			continue
+		}
+
+		if start != test.start || end != now {
+			t.Fatalf("(%v) wrong range: [%v, %v)", test.period, start, end)
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package stats
 
-import "github.com/bitlum/hub/lightning"
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/go-errors/errors"
+)
 
-func GetNodeStats(period string, payments []*lightning.Payment,
+// GetNodeStats returns payments and channels statistics of nodes, payments
+// statistics is calculated over [start, end) range of time.
+func GetNodeStats(start, end int64, payments []*lightning.Payment,
 	forwardPayments []*lightning.ForwardPayment,
 	channels []*lightning.Channel) (map[lightning.NodeID]NodeStats, error) {
 
@@ -11,7 +16,7 @@ func GetNodeStats(period string, payments []*lightning.Payment,
 		return nil, err
 	}
 
-	paymentChannelStats, err := calculatePaymentsStats(period, payments,
+	paymentChannelStats, err := calculatePaymentsStats(start, end, payments,
 		forwardPayments)
 	if err != nil {
 		return nil, err
@@ -35,3 +40,36 @@ func GetNodeStats(period string, payments []*lightning.Payment,
 
 	return nodeStats, nil
 }
+
+// Period is the name of the period of time over which statistic is
+// aggregated.
+type Period string
+
+const (
+	PeriodDay        Period = "day"
+	PeriodWeek       Period = "week"
+	PeriodMonth      Period = "month"
+	PeriodThreeMonth Period = "three month"
+)
+
+// PeriodRange converts the period in the range of time which ends at the
+// given moment.
+func PeriodRange(period Period, now int64) (int64, int64, error) {
+	switch period {
+	case PeriodDay:
+		return now - int64(Day), now, nil
+	case PeriodWeek:
+		return now - int64(Week), now, nil
+	case PeriodMonth:
+		return now - 30*int64(Day), now, nil
+	case PeriodThreeMonth:
+		return now - 3*30*int64(Day), now, nil
+	default:
+		return 0, 0, errors.Errorf("period(%v) not supported", period)
+	}
+}
+
+// InRange checks that time lies within [start, end) range.
+func InRange(moment, start, end int64) bool {
+	return moment >= start && moment < end
+}
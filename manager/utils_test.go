@@ -0,0 +1,140 @@
+package manager
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/metrics"
+	"github.com/btcsuite/btcutil"
+	"sync"
+	"time"
+)
+
+// mockClient is the lightning client which keeps the given channels, and
+// records channel operations and circular payments made through it.
+type mockClient struct {
+	lightning.Client
+
+	sync.Mutex
+	channels []*lightning.Channel
+
+	opened []lightning.NodeID
+	closed []lightning.ChannelID
+
+	// closeErr is returned on channel close, after channel is counted as
+	// closed.
+	closeErr error
+
+	circularPayments []*circularPayment
+}
+
+// circularPayment is the circular payment sent through mock client.
+type circularPayment struct {
+	outgoing, incoming lightning.ChannelID
+	amount, maxFee     btcutil.Amount
+}
+
+func (c *mockClient) Channels() ([]*lightning.Channel, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.channels, nil
+}
+
+func (c *mockClient) OpenChannel(nodeID lightning.NodeID,
+	funds btcutil.Amount) error {
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.opened = append(c.opened, nodeID)
+	return nil
+}
+
+func (c *mockClient) CloseChannel(channelID lightning.ChannelID) error {
+	c.Lock()
+	defer c.Unlock()
+
+	c.closed = append(c.closed, channelID)
+	return c.closeErr
+}
+
+func (c *mockClient) SendCircularPayment(outgoing,
+	incoming lightning.ChannelID, amount,
+	maxFee btcutil.Amount) (*lightning.Payment, error) {
+
+	c.Lock()
+	defer c.Unlock()
+
+	c.circularPayments = append(c.circularPayments, &circularPayment{
+		outgoing: outgoing,
+		incoming: incoming,
+		amount:   amount,
+		maxFee:   maxFee,
+	})
+
+	return &lightning.Payment{}, nil
+}
+
+// mockBudgetBackend is the budget metrics backend which counts postponed
+// operations.
+type mockBudgetBackend struct {
+	postponed map[string]int
+}
+
+func (b *mockBudgetBackend) SpentBudget(asset, operation string,
+	spentUSD float64) {
+}
+
+func (b *mockBudgetBackend) RemainingBudget(asset, operation string,
+	remainingUSD float64) {
+}
+
+func (b *mockBudgetBackend) AddPostponedOperation(asset, operation string) {
+	if b.postponed == nil {
+		b.postponed = make(map[string]int)
+	}
+	b.postponed[operation]++
+}
+
+// newOpenedChannel returns opened channel with the given node, opening
+// state of which has the given initiator and open fee.
+func newOpenedChannel(channelID lightning.ChannelID, nodeID lightning.NodeID,
+	initiator lightning.ChannelInitiator, openTime int64,
+	openFee, localBalance, remoteBalance btcutil.Amount) *lightning.Channel {
+
+	return &lightning.Channel{
+		ChannelID: channelID,
+		NodeID:    nodeID,
+		State:     lightning.ChannelOpened,
+		States: map[lightning.ChannelStateName]interface{}{
+			lightning.ChannelOpening: &lightning.ChannelStateOpening{
+				ChannelID:    channelID,
+				CreationTime: openTime,
+				OpenFee:      openFee,
+				Initiator:    initiator,
+			},
+			lightning.ChannelOpened: &lightning.ChannelStateOpened{
+				ChannelID:     channelID,
+				CreationTime:  openTime,
+				CommitFee:     1000,
+				LocalBalance:  localBalance,
+				RemoteBalance: remoteBalance,
+				IsActive:      true,
+			},
+		},
+	}
+}
+
+// mockMetricsBackend is the metrics backend which ignores all metrics.
+type mockMetricsBackend struct{}
+
+func (b *mockMetricsBackend) AddMethod(asset, method string) {}
+
+func (b *mockMetricsBackend) AddError(asset, method string,
+	severity metrics.Severity) {
+}
+
+func (b *mockMetricsBackend) AddPanic(asset, method string) {}
+
+func (b *mockMetricsBackend) AddMethodDuration(asset, method string,
+	dur time.Duration) {
+}
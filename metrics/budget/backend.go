@@ -0,0 +1,170 @@
+package budget
+
+import (
+	"github.com/bitlum/hub/metrics"
+	"github.com/go-errors/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// subsystem is used as the second part in the name of the metric,
+	// after the metrics namespace.
+	subsystem = "budget"
+
+	// assetLabel is used to distinguish different currency and daemon on the
+	// metrics server.
+	assetLabel = "asset"
+
+	// operationLabel is used to distinguish different channel operations,
+	// i.e. open, close.
+	operationLabel = "operation"
+)
+
+// MetricsBackend is a system which is responsible for receiving,
+// storing and possible representing the given metrics.
+type MetricsBackend interface {
+	// SpentBudget accept number of funds in USD which were spent or
+	// committed to be spent on the given channel operation during the
+	// budget period.
+	SpentBudget(asset, operation string, spentUSD float64)
+
+	// RemainingBudget accept number of funds in USD which could still be
+	// spent on the given channel operation during the budget period.
+	RemainingBudget(asset, operation string, remainingUSD float64)
+
+	// AddPostponedOperation increment number of channel operations which
+	// were postponed because of the budget exhaustion.
+	AddPostponedOperation(asset, operation string)
+}
+
+// PrometheusBackend is the main subsystem metrics implementation. Uses
+// prometheus metrics singletons defined above.
+//
+// WARN: Method name should be taken from limited set.
+// Don't use dynamic naming, it may cause dramatic increase of the amount of
+// data on the metric server.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+type PrometheusBackend struct {
+	spentCurrent     *prometheus.GaugeVec
+	remainingCurrent *prometheus.GaugeVec
+	postponedTotal   *prometheus.CounterVec
+}
+
+// SpentBudget accept number of funds in USD which were spent or
+// committed to be spent on the given channel operation during the
+// budget period.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) SpentBudget(asset, operation string,
+	spentUSD float64) {
+	m.spentCurrent.With(prometheus.Labels{
+		assetLabel:     asset,
+		operationLabel: operation,
+	}).Set(spentUSD)
+}
+
+// RemainingBudget accept number of funds in USD which could still be
+// spent on the given channel operation during the budget period.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) RemainingBudget(asset, operation string,
+	remainingUSD float64) {
+	m.remainingCurrent.With(prometheus.Labels{
+		assetLabel:     asset,
+		operationLabel: operation,
+	}).Set(remainingUSD)
+}
+
+// AddPostponedOperation increment number of channel operations which
+// were postponed because of the budget exhaustion.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) AddPostponedOperation(asset, operation string) {
+	m.postponedTotal.With(prometheus.Labels{
+		assetLabel:     asset,
+		operationLabel: operation,
+	}).Add(1)
+}
+
+// InitMetricsBackend creates subsystem metrics for specified
+// net. Creates and tries to register metrics singletons. If register was
+// already done, than return error.
+func InitMetricsBackend(net string) (MetricsBackend, error) {
+	backend := PrometheusBackend{}
+
+	backend.spentCurrent = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "spent_usd_current",
+			Help: "Funds in USD spent on channel operations during the" +
+				" last day",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			operationLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.spentCurrent); err != nil {
+		return nil, errors.Errorf(
+			"unable to register 'spentCurrent' metric: " +
+				err.Error())
+	}
+
+	backend.remainingCurrent = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "remaining_usd_current",
+			Help: "Funds in USD which could still be spent on channel" +
+				" operations during the last day",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			operationLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.remainingCurrent); err != nil {
+		return nil, errors.Errorf(
+			"unable to register 'remainingCurrent' metric: " +
+				err.Error())
+	}
+
+	backend.postponedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "postponed_total",
+			Help: "Total channel operations postponed because of the" +
+				" budget exhaustion",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			operationLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.postponedTotal); err != nil {
+		return nil, errors.Errorf(
+			"unable to register 'postponedTotal' metric: " +
+				err.Error())
+	}
+
+	return backend, nil
+}
@@ -26,6 +26,21 @@ const (
 	// sideLabel is used to distinguish different sides, i.e. local node,
 	// remote peer.
 	sideLabel = "side"
+
+	// dropTypeLabel is used to distinguish different types of channels
+	// drop, i.e. count, capacity.
+	dropTypeLabel = "drop_type"
+
+	// nodeLabel is used to distinguish different nodes of lightning
+	// network.
+	//
+	// NOTE: Should be used only for limited set of nodes, i.e. important
+	// ones.
+	nodeLabel = "node"
+
+	// anomalyTypeLabel is used to distinguish different types of network
+	// anomalies, i.e. network capacity, node uptime.
+	anomalyTypeLabel = "anomaly_type"
 )
 
 // MetricsBackend is a system which is responsible for receiving,
@@ -58,6 +73,22 @@ type MetricsBackend interface {
 	// AddEarnedFunds increment number of funds which we earned by forwarding
 	// payments.
 	AddEarnedFunds(asset string, earned uint64)
+
+	// ChannelsDrop accept relative drop of channels count or capacity in
+	// comparison with the previous check.
+	ChannelsDrop(asset, dropType string, drop float64)
+
+	// NodeUptime accept ratio of time during which node was connected to
+	// us, within the uptime window.
+	NodeUptime(asset, node string, uptime float64)
+
+	// NodeLastSeen accept number of seconds passed since node was seen
+	// connected to us last time.
+	NodeLastSeen(asset, node string, seconds float64)
+
+	// AddAnomaly increment number of detected network anomalies of the
+	// given type.
+	AddAnomaly(asset, anomalyType string)
 }
 
 // PrometheusBackend is the main subsystem metrics implementation. Uses
@@ -78,6 +109,13 @@ type PrometheusBackend struct {
 	earnedFundsTotal   *prometheus.CounterVec
 
 	forwardingPaymentTotal *prometheus.CounterVec
+
+	channelsDropCurrent *prometheus.GaugeVec
+
+	nodeUptimeCurrent   *prometheus.GaugeVec
+	nodeLastSeenCurrent *prometheus.GaugeVec
+
+	anomaliesTotal *prometheus.CounterVec
 }
 
 // TotalChannels accept total number of lightning network payment
@@ -162,6 +200,54 @@ func (m PrometheusBackend) AddEarnedFunds(asset string, earned uint64) {
 	}).Add(float64(earned))
 }
 
+// ChannelsDrop accept relative drop of channels count or capacity in
+// comparison with the previous check.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) ChannelsDrop(asset, dropType string, drop float64) {
+	m.channelsDropCurrent.With(prometheus.Labels{
+		assetLabel:    asset,
+		dropTypeLabel: dropType,
+	}).Set(drop)
+}
+
+// NodeUptime accept ratio of time during which node was connected to us,
+// within the uptime window.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) NodeUptime(asset, node string, uptime float64) {
+	m.nodeUptimeCurrent.With(prometheus.Labels{
+		assetLabel: asset,
+		nodeLabel:  node,
+	}).Set(uptime)
+}
+
+// NodeLastSeen accept number of seconds passed since node was seen
+// connected to us last time.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) NodeLastSeen(asset, node string, seconds float64) {
+	m.nodeLastSeenCurrent.With(prometheus.Labels{
+		assetLabel: asset,
+		nodeLabel:  node,
+	}).Set(seconds)
+}
+
+// AddAnomaly increment number of detected network anomalies of the given
+// type.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) AddAnomaly(asset, anomalyType string) {
+	m.anomaliesTotal.With(prometheus.Labels{
+		assetLabel:       asset,
+		anomalyTypeLabel: anomalyType,
+	}).Inc()
+}
+
 // InitMetricsBackend creates subsystem metrics for specified
 // net. Creates and tries to register metrics singletons. If register was
 // already done, than return error.
@@ -304,5 +390,96 @@ func InitMetricsBackend(net string) (MetricsBackend, error) {
 				err.Error())
 	}
 
+	backend.channelsDropCurrent = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "channels_drop_current",
+			Help: "Relative drop of channels count or capacity in" +
+				" comparison with the previous check",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			dropTypeLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.channelsDropCurrent); err != nil {
+		return nil, errors.Errorf(
+			"unable to register 'channelsDropCurrent' metric: " +
+				err.Error())
+	}
+
+	backend.nodeUptimeCurrent = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "node_uptime_current",
+			Help: "Ratio of time during which node was connected to our" +
+				" lightning network node",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			nodeLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.nodeUptimeCurrent); err != nil {
+		return nil, errors.Errorf(
+			"unable to register 'nodeUptimeCurrent' metric: " +
+				err.Error())
+	}
+
+	backend.nodeLastSeenCurrent = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "node_last_seen_seconds",
+			Help: "Seconds passed since node was connected to our" +
+				" lightning network node last time",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			nodeLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.nodeLastSeenCurrent); err != nil {
+		return nil, errors.Errorf(
+			"unable to register 'nodeLastSeenCurrent' metric: " +
+				err.Error())
+	}
+
+	backend.anomaliesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "anomalies_total",
+			Help:      "Total network anomalies detected by topology",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			assetLabel,
+			anomalyTypeLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.anomaliesTotal); err != nil {
+		return nil, errors.Errorf(
+			"unable to register 'anomaliesTotal' metric: " +
+				err.Error())
+	}
+
 	return backend, nil
 }
@@ -20,6 +20,10 @@ const (
 	// severityLabel is used to distinguish different error codes by its
 	// level of importance.
 	severityLabel = "severity"
+
+	// limitLabel is used to distinguish which limit has rejected the
+	// request.
+	limitLabel = "limit"
 )
 
 // MetricsBackend is a system which is responsible for receiving and storing
@@ -29,15 +33,19 @@ type MetricsBackend interface {
 	AddError(request string, severity metrics.Severity)
 	AddPanic(request string)
 	AddRequestDuration(request string, dur time.Duration)
+	AddLimitedRequest(request string, limit string)
+	SetExpensiveRequests(num int)
 }
 
 // EmptyBackend is used as an empty metricsBackend backend in order to avoid
 type EmptyBackend struct{}
 
 func (b *EmptyBackend) AddRequest(query string)                            {}
-func (b *EmptyBackend) AddError(query string, errCode string)              {}
+func (b *EmptyBackend) AddError(query string, severity metrics.Severity)   {}
 func (b *EmptyBackend) AddPanic(query string)                              {}
 func (b *EmptyBackend) AddRequestDuration(query string, dur time.Duration) {}
+func (b *EmptyBackend) AddLimitedRequest(query string, limit string)       {}
+func (b *EmptyBackend) SetExpensiveRequests(num int)                       {}
 
 // PrometheusBackend is the main subsystem metrics implementation. Uses
 // prometheus metrics singletons defined above.
@@ -53,6 +61,8 @@ type PrometheusBackend struct {
 	errorsTotal            *prometheus.CounterVec
 	panicsTotal            *prometheus.CounterVec
 	requestDurationSeconds *prometheus.HistogramVec
+	limitedRequestsTotal   *prometheus.CounterVec
+	expensiveRequests      prometheus.Gauge
 }
 
 // AddRequest increases request counter for the given request name.
@@ -111,6 +121,29 @@ func (m PrometheusBackend) AddRequestDuration(request string,
 	).Observe(dur.Seconds())
 }
 
+// AddLimitedRequest increases counter of requests which were rejected by
+// the given limit.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) AddLimitedRequest(request string, limit string) {
+	m.limitedRequestsTotal.With(
+		prometheus.Labels{
+			requestLabel: request,
+			limitLabel:   limit,
+		},
+	).Add(1)
+}
+
+// SetExpensiveRequests sets the number of expensive requests which are
+// processed concurrently.
+//
+// NOTE: Non-pointer receiver made by intent to avoid conflict in the system
+// with parallel metrics report.
+func (m PrometheusBackend) SetExpensiveRequests(num int) {
+	m.expensiveRequests.Set(float64(num))
+}
+
 // InitMetricsBackend creates subsystem metrics for specified
 // net. Creates and tries to register metrics singletons. If register was
 // already done, than function not returning error.
@@ -204,5 +237,45 @@ func InitMetricsBackend(net string) (MetricsBackend, error) {
 				err.Error())
 	}
 
+	backend.limitedRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "limited_requests_total",
+			Help:      "Total requests which were rejected by rate or concurrency limit",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+		[]string{
+			requestLabel,
+			limitLabel,
+		},
+	)
+
+	if err := prometheus.Register(backend.limitedRequestsTotal); err != nil {
+		return backend, errors.Errorf(
+			"unable to register 'limitedRequestsTotal' metric: " +
+				err.Error())
+	}
+
+	backend.expensiveRequests = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metrics.Namespace,
+			Subsystem: subsystem,
+			Name:      "expensive_requests",
+			Help:      "Number of expensive requests processed concurrently",
+			ConstLabels: prometheus.Labels{
+				metrics.NetLabel: net,
+			},
+		},
+	)
+
+	if err := prometheus.Register(backend.expensiveRequests); err != nil {
+		return backend, errors.Errorf(
+			"unable to register 'expensiveRequests' metric: " +
+				err.Error())
+	}
+
 	return backend, nil
 }
@@ -1,10 +1,13 @@
 package router
 
 import (
+	"github.com/bitlum/hub/common"
 	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/metrics"
 	"github.com/bitlum/hub/metrics/crypto"
 	"github.com/btcsuite/btcutil"
 	"github.com/go-errors/errors"
+	"time"
 )
 
 // Router responsibilities:
@@ -27,6 +30,12 @@ type Config struct {
 
 	// Net...
 	Net string
+
+	// GetBitcoinPriceUSDHistory is used to fetch daily bitcoin prices in
+	// USD, which are needed to calculate fiat value of ledger entries. If
+	// it isn't specified, fiat value couldn't be calculated.
+	GetBitcoinPriceUSDHistory func(start, end time.Time) (map[string]float64,
+		error)
 }
 
 func (c Config) validate() error {
@@ -59,6 +68,113 @@ func NewRouter(cfg Config) (*Router, error) {
 	}, nil
 }
 
+// ProfitabilityReport calculates economy of our channels and peers within
+// [start, end) period, i.e. earned forward fees, paid routing fees and
+// on-chain channel management fees.
+func (r *Router) ProfitabilityReport(start, end int64) (*ProfitabilityReport,
+	error) {
+
+	m := crypto.NewMetric(r.cfg.Client.Asset(), common.GetFunctionName(),
+		r.cfg.Metrics)
+	defer m.Finish()
+
+	channels, err := r.cfg.Client.Channels()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	payments, err := r.cfg.Client.ListPayments("", lightning.Completed,
+		lightning.AllDirections, lightning.AllSystems)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable list payments: %v", err)
+	}
+
+	forwardPayments, err := r.cfg.Client.ListForwardPayments()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, errors.Errorf("unable list forward payments: %v", err)
+	}
+
+	return calculateProfitability(start, end, channels, payments,
+		forwardPayments)
+}
+
+// Ledger returns the chronological list of ledger entries of payments,
+// forwarding payments and on-chain channel management fees, within
+// [start, end) period, alongside with the overall number of entries. Only
+// limit number of entries starting from the offset are returned, all entries
+// are returned if limit is zero. If fiat is true, bitcoin price of the event
+// day is attached to every returned entry.
+func (r *Router) Ledger(start, end int64, offset, limit int,
+	fiat bool) ([]*LedgerEntry, int, error) {
+
+	m := crypto.NewMetric(r.cfg.Client.Asset(), common.GetFunctionName(),
+		r.cfg.Metrics)
+	defer m.Finish()
+
+	if fiat && r.cfg.GetBitcoinPriceUSDHistory == nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, 0, errors.Errorf("bitcoin price source isn't " +
+			"specified")
+	}
+
+	channels, err := r.cfg.Client.Channels()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, 0, errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	payments, err := r.cfg.Client.ListPayments("", lightning.Completed,
+		lightning.AllDirections, lightning.AllSystems)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, 0, errors.Errorf("unable list payments: %v", err)
+	}
+
+	forwardPayments, err := r.cfg.Client.ListForwardPayments()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, 0, errors.Errorf("unable list forward payments: %v",
+			err)
+	}
+
+	entries, err := buildLedger(start, end, channels, payments,
+		forwardPayments)
+	if err != nil {
+		m.AddError(metrics.LowSeverity)
+		return nil, 0, err
+	}
+
+	total := len(entries)
+
+	if offset > len(entries) {
+		offset = len(entries)
+	}
+	entries = entries[offset:]
+
+	if limit != 0 && limit < len(entries) {
+		entries = entries[:limit]
+	}
+
+	if !fiat || len(entries) == 0 {
+		return entries, total, nil
+	}
+
+	prices, err := r.cfg.GetBitcoinPriceUSDHistory(
+		time.Unix(entries[0].Time, 0),
+		time.Unix(entries[len(entries)-1].Time, 0))
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return nil, 0, errors.Errorf("unable to fetch bitcoin prices: "+
+			"%v", err)
+	}
+
+	setLedgerPrices(entries, prices)
+	return entries, total, nil
+}
+
 func (r *Router) SendPayment(invoiceStr string, inputAmountSat btcutil.Amount) (
 	*lightning.Payment, error) {
 	// 1. Decode invoice and get public key.
@@ -1,7 +1,11 @@
 package router
 
 import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/manager/stats"
 	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"sort"
 )
 
 type nodeEconomy struct {
@@ -11,6 +15,7 @@ type nodeEconomy struct {
 	endTime   int64
 
 	paymentStats
+	channelStats
 
 	// qualityRatio shows how much fees we spent to move 1 sat in lightning
 	// network. In this case fee will include payment fee as well as channel
@@ -38,4 +43,305 @@ type paymentStats struct {
 	// receivedFunds is the number of funds which were received by us,
 	// during specified period of time.
 	receivedFunds btcutil.Amount
+
+	// forwardedFunds is the number of funds which were forwarded by our
+	// node, during specified period of time.
+	forwardedFunds btcutil.Amount
+}
+
+type channelStats struct {
+	// openFee, closeFee and swipeFee is the number of funds which were
+	// paid to miners for channel management, during specified period of
+	// time.
+	openFee  btcutil.Amount
+	closeFee btcutil.Amount
+	swipeFee btcutil.Amount
+
+	// capacity is the number of funds locked in the channels.
+	capacity btcutil.Amount
+}
+
+// add adds the other economy to this one.
+func (e *nodeEconomy) add(other *nodeEconomy) {
+	e.paymentFee += other.paymentFee
+	e.earnedForwardFee += other.earnedForwardFee
+	e.sentFunds += other.sentFunds
+	e.receivedFunds += other.receivedFunds
+	e.forwardedFunds += other.forwardedFunds
+	e.openFee += other.openFee
+	e.closeFee += other.closeFee
+	e.swipeFee += other.swipeFee
+	e.capacity += other.capacity
+}
+
+// spentFee returns the overall number of funds spent on payment and channel
+// management fees.
+func (e *nodeEconomy) spentFee() btcutil.Amount {
+	return e.paymentFee + e.openFee + e.closeFee + e.swipeFee
+}
+
+// profit returns the difference between earned and spent fees.
+func (e *nodeEconomy) profit() btcutil.Amount {
+	return e.earnedForwardFee - e.spentFee()
+}
+
+// calculateQualityRatio calculates the fee spent to move 1 sat.
+func (e *nodeEconomy) calculateQualityRatio() {
+	e.qualityRatio = 0
+
+	moved := e.sentFunds + e.receivedFunds + e.forwardedFunds
+	if moved != 0 {
+		e.qualityRatio = float64(e.spentFee()) / float64(moved)
+	}
+}
+
+// Profitability is the economy of the channel or peer over the period of
+// time.
+type Profitability struct {
+	// ChannelID is the channel which economy is described, empty if
+	// economy describes the peer.
+	ChannelID lightning.ChannelID
+
+	// NodeID is the peer which economy is described, empty if economy
+	// describes the whole node.
+	NodeID lightning.NodeID
+
+	// Capacity is the number of funds locked in channels.
+	Capacity btcutil.Amount
+
+	// EarnedForwardFee is the fee earned by forwarding payments.
+	EarnedForwardFee btcutil.Amount
+
+	// PaymentFee is the routing fee paid for sending payments, including
+	// re-balancing payments.
+	PaymentFee btcutil.Amount
+
+	// OpenFee, CloseFee and SwipeFee is the on-chain fees of channel
+	// management.
+	OpenFee  btcutil.Amount
+	CloseFee btcutil.Amount
+	SwipeFee btcutil.Amount
+
+	SentFunds      btcutil.Amount
+	ReceivedFunds  btcutil.Amount
+	ForwardedFunds btcutil.Amount
+
+	// Profit is the difference between earned and spent fees.
+	Profit btcutil.Amount
+
+	// ROI is the profit relative to the capacity.
+	ROI float64
+
+	// QualityRatio is the fee spent to move 1 sat.
+	QualityRatio float64
+}
+
+// ProfitabilityReport is the economy of our channels and peers over the
+// period of time, which is used to decide which channels are worth keeping.
+type ProfitabilityReport struct {
+	StartTime int64
+	EndTime   int64
+
+	// Channels and Peers is sorted by profit, the least profitable first.
+	Channels []*Profitability
+	Peers    []*Profitability
+
+	// Total is the economy of the whole node.
+	Total *Profitability
+}
+
+// newProfitability converts economy into profitability.
+func newProfitability(channelID lightning.ChannelID, nodeID lightning.NodeID,
+	economy *nodeEconomy) *Profitability {
+
+	economy.calculateQualityRatio()
+
+	p := &Profitability{
+		ChannelID:        channelID,
+		NodeID:           nodeID,
+		Capacity:         economy.capacity,
+		EarnedForwardFee: economy.earnedForwardFee,
+		PaymentFee:       economy.paymentFee,
+		OpenFee:          economy.openFee,
+		CloseFee:         economy.closeFee,
+		SwipeFee:         economy.swipeFee,
+		SentFunds:        economy.sentFunds,
+		ReceivedFunds:    economy.receivedFunds,
+		ForwardedFunds:   economy.forwardedFunds,
+		Profit:           economy.profit(),
+		QualityRatio:     economy.qualityRatio,
+	}
+
+	if economy.capacity != 0 {
+		p.ROI = float64(p.Profit) / float64(economy.capacity)
+	}
+
+	return p
+}
+
+// isLocalInitiator checks that channel was opened by us, i.e. we have paid
+// the open fee, and will pay the close fee.
+func isLocalInitiator(channel *lightning.Channel) bool {
+	initiator, err := channel.Initiator()
+	return err == nil && initiator == lightning.LocalInitiator
+}
+
+// channelCapacity returns the number of funds locked in the channel.
+func channelCapacity(channel *lightning.Channel) btcutil.Amount {
+	if state, ok := channel.States[lightning.ChannelOpened]; ok {
+		s := state.(*lightning.ChannelStateOpened)
+		return s.LocalBalance + s.RemoteBalance
+	}
+
+	if state, ok := channel.States[lightning.ChannelOpening]; ok {
+		s := state.(*lightning.ChannelStateOpening)
+		return s.LocalBalance + s.RemoteBalance
+	}
+
+	return 0
+}
+
+// calculateProfitability calculates economy of the channels and peers
+// within [start, end) period. Forward fee is attributed to the outgoing
+// channel, because its liquidity has been used, and its fee policy has
+// been applied. Payment fee is attributed to the peer of the first hop
+// only, because outgoing channel of the payment is unknown.
+func calculateProfitability(start, end int64, channels []*lightning.Channel,
+	payments []*lightning.Payment,
+	forwardPayments []*lightning.ForwardPayment) (*ProfitabilityReport,
+	error) {
+
+	if start >= end {
+		return nil, errors.Errorf("start(%v) should be less than end(%v)",
+			start, end)
+	}
+
+	channelEconomies := make(map[lightning.ChannelID]*nodeEconomy)
+	peerEconomies := make(map[lightning.NodeID]*nodeEconomy)
+	channelNodes := make(map[lightning.ChannelID]lightning.NodeID)
+
+	newEconomy := func() *nodeEconomy {
+		return &nodeEconomy{
+			startTime: start,
+			endTime:   end,
+		}
+	}
+
+	getChannel := func(channelID lightning.ChannelID) *nodeEconomy {
+		economy, ok := channelEconomies[channelID]
+		if !ok {
+			economy = newEconomy()
+			channelEconomies[channelID] = economy
+		}
+		return economy
+	}
+
+	getPeer := func(nodeID lightning.NodeID) *nodeEconomy {
+		economy, ok := peerEconomies[nodeID]
+		if !ok {
+			economy = newEconomy()
+			peerEconomies[nodeID] = economy
+		}
+		return economy
+	}
+
+	for _, channel := range channels {
+		channelNodes[channel.ChannelID] = channel.NodeID
+
+		economy := getChannel(channel.ChannelID)
+		economy.capacity = channelCapacity(channel)
+
+		// We track fee on the moment they were spent from our wallet. Open
+		// and close fees are paid by the initiator of the channel, swipe
+		// fee is always paid by us.
+		isLocal := isLocalInitiator(channel)
+
+		if openingTime, err := channel.OpeningTime(); err == nil && isLocal &&
+			stats.InRange(openingTime, start, end) {
+			economy.openFee, _ = channel.OpenFee()
+		}
+
+		if closingTime, err := channel.ClosingTime(); err == nil &&
+			stats.InRange(closingTime, start, end) {
+			if isLocal {
+				economy.closeFee, _ = channel.CloseFee()
+			}
+			economy.swipeFee, _ = channel.SwipeFee()
+		}
+	}
+
+	for _, payment := range forwardPayments {
+		if !stats.InRange(payment.Time, start, end) {
+			continue
+		}
+
+		economy := getChannel(payment.ToChannel)
+		economy.earnedForwardFee += payment.ForwardFee
+		economy.forwardedFunds += payment.OutgoingAmount
+		channelNodes[payment.ToChannel] = payment.ToNode
+	}
+
+	for _, payment := range payments {
+		if payment.Status != lightning.Completed ||
+			!stats.InRange(payment.UpdatedAt, start, end) {
+			continue
+		}
+
+		switch payment.Direction {
+		case lightning.Outgoing:
+			if payment.FirstHop == "" {
+				continue
+			}
+
+			// Fee of the internal re-balancing payments is the part of
+			// channel management cost, but funds are not actually sent.
+			economy := getPeer(payment.FirstHop)
+			economy.paymentFee += payment.MediaFee
+			if payment.System == lightning.External {
+				economy.sentFunds += payment.Amount
+			}
+
+		case lightning.Incoming:
+			if payment.FromChannel == "" || payment.System != lightning.External {
+				continue
+			}
+
+			economy := getChannel(payment.FromChannel)
+			economy.receivedFunds += payment.Amount
+			channelNodes[payment.FromChannel] = payment.Sender
+		}
+	}
+
+	report := &ProfitabilityReport{
+		StartTime: start,
+		EndTime:   end,
+	}
+
+	total := newEconomy()
+	for channelID, economy := range channelEconomies {
+		nodeID := channelNodes[channelID]
+		getPeer(nodeID).add(economy)
+
+		report.Channels = append(report.Channels,
+			newProfitability(channelID, nodeID, economy))
+	}
+
+	for nodeID, economy := range peerEconomies {
+		total.add(economy)
+
+		report.Peers = append(report.Peers,
+			newProfitability("", nodeID, economy))
+	}
+
+	report.Total = newProfitability("", "", total)
+
+	sort.Slice(report.Channels, func(i, j int) bool {
+		return report.Channels[i].Profit < report.Channels[j].Profit
+	})
+
+	sort.Slice(report.Peers, func(i, j int) bool {
+		return report.Peers[i].Profit < report.Peers[j].Profit
+	})
+
+	return report, nil
 }
@@ -0,0 +1,185 @@
+package router
+
+import (
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"testing"
+)
+
+// newClosedChannel returns channel with the given node, which was opened
+// and closed at the given moments, with the given fees.
+func newClosedChannel(channelID lightning.ChannelID, nodeID lightning.NodeID,
+	initiator lightning.ChannelInitiator, openTime, closeTime int64,
+	openFee, closeFee, swipeFee btcutil.Amount) *lightning.Channel {
+
+	return &lightning.Channel{
+		ChannelID: channelID,
+		NodeID:    nodeID,
+		State:     lightning.ChannelClosing,
+		States: map[lightning.ChannelStateName]interface{}{
+			lightning.ChannelOpening: &lightning.ChannelStateOpening{
+				ChannelID:    channelID,
+				CreationTime: openTime,
+				OpenFee:      openFee,
+				Initiator:    initiator,
+			},
+			lightning.ChannelClosing: &lightning.ChannelStateClosing{
+				ChannelID:    channelID,
+				CreationTime: closeTime,
+				CloseFee:     closeFee,
+				SwipeFee:     swipeFee,
+			},
+		},
+	}
+}
+
+func TestCalculateProfitability(t *testing.T) {
+	channels := []*lightning.Channel{
+		newClosedChannel("local", "a", lightning.LocalInitiator, 10, 20,
+			100, 50, 5),
+
+		// Open and close fees of channel opened by remote side are paid
+		// by remote side, only swipe fee is paid by us.
+		newClosedChannel("remote", "b", lightning.RemoteInitiator, 10, 20,
+			200, 60, 6),
+
+		// Fees of channel opened and closed out of the period shouldn't be
+		// counted.
+		newClosedChannel("old", "a", lightning.LocalInitiator, 0, 5,
+			300, 70, 7),
+	}
+
+	forwards := []*lightning.ForwardPayment{
+		{
+			FromNode:       "b",
+			ToNode:         "a",
+			FromChannel:    "remote",
+			ToChannel:      "local",
+			IncomingAmount: 1010,
+			OutgoingAmount: 1000,
+			ForwardFee:     10,
+			Time:           15,
+		},
+		{
+			FromNode:       "b",
+			ToNode:         "a",
+			FromChannel:    "remote",
+			ToChannel:      "local",
+			IncomingAmount: 1010,
+			OutgoingAmount: 1000,
+			ForwardFee:     10,
+			Time:           100,
+		},
+	}
+
+	payments := []*lightning.Payment{
+		{
+			FirstHop:  "b",
+			Status:    lightning.Completed,
+			Direction: lightning.Outgoing,
+			System:    lightning.External,
+			Amount:    500,
+			MediaFee:  3,
+			UpdatedAt: 15,
+		},
+		{
+			// Funds of the internal re-balancing payment are not sent,
+			// but its fee is spent.
+			FirstHop:  "a",
+			Status:    lightning.Completed,
+			Direction: lightning.Outgoing,
+			System:    lightning.Internal,
+			Amount:    500,
+			MediaFee:  2,
+			UpdatedAt: 15,
+		},
+		{
+			FromChannel: "remote",
+			Sender:      "b",
+			Status:      lightning.Completed,
+			Direction:   lightning.Incoming,
+			System:      lightning.External,
+			Amount:      400,
+			UpdatedAt:   15,
+		},
+		{
+			FirstHop:  "b",
+			Status:    lightning.Failed,
+			Direction: lightning.Outgoing,
+			System:    lightning.External,
+			Amount:    500,
+			MediaFee:  3,
+			UpdatedAt: 15,
+		},
+	}
+
+	if _, err := calculateProfitability(10, 10, channels, payments,
+		forwards); err == nil {
+		t.Fatalf("empty period should be rejected")
+	}
+
+	report, err := calculateProfitability(10, 30, channels, payments,
+		forwards)
+	if err != nil {
+		t.Fatalf("unable to calculate profitability: %v", err)
+	}
+
+	expectedChannels := map[lightning.ChannelID]Profitability{
+		"local": {
+			ChannelID:        "local",
+			NodeID:           "a",
+			EarnedForwardFee: 10,
+			OpenFee:          100,
+			CloseFee:         50,
+			SwipeFee:         5,
+			ForwardedFunds:   1000,
+			Profit:           -145,
+		},
+		"remote": {
+			ChannelID:     "remote",
+			NodeID:        "b",
+			SwipeFee:      6,
+			ReceivedFunds: 400,
+			Profit:        -6,
+		},
+		"old": {
+			ChannelID: "old",
+			NodeID:    "a",
+		},
+	}
+
+	if len(report.Channels) != len(expectedChannels) {
+		t.Fatalf("wrong number of channels: %v", len(report.Channels))
+	}
+
+	for _, channel := range report.Channels {
+		expected := expectedChannels[channel.ChannelID]
+		expected.QualityRatio = channel.QualityRatio
+		if *channel != expected {
+			t.Fatalf("(%v) wrong profitability: %+v", channel.ChannelID,
+				*channel)
+		}
+	}
+
+	for i := 1; i < len(report.Channels); i++ {
+		if report.Channels[i-1].Profit > report.Channels[i].Profit {
+			t.Fatalf("channels aren't sorted by profit")
+		}
+	}
+
+	total := report.Total
+	if total.PaymentFee != 5 || total.OpenFee != 100 ||
+		total.CloseFee != 50 || total.SwipeFee != 11 ||
+		total.SentFunds != 500 || total.EarnedForwardFee != 10 {
+		t.Fatalf("wrong total profitability: %+v", *total)
+	}
+
+	if total.Profit != 10-5-100-50-11 {
+		t.Fatalf("wrong total profit: %v", total.Profit)
+	}
+
+	moved := float64(500 + 400 + 1000)
+	if total.QualityRatio != float64(5+100+50+11)/moved {
+		t.Fatalf("wrong quality ratio: %v", total.QualityRatio)
+	}
+}
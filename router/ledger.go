@@ -0,0 +1,333 @@
+package router
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/manager/stats"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LedgerAccount is the account of the ledger, between which funds are
+// moved.
+type LedgerAccount string
+
+const (
+	// LightningAccount is our funds locked in the lightning network
+	// channels.
+	LightningAccount LedgerAccount = "assets:lightning"
+
+	// OnChainAccount is our funds in the on-chain wallet.
+	OnChainAccount LedgerAccount = "assets:onchain"
+
+	// ReceivedPaymentsAccount is the funds which were received by us in
+	// the lightning network.
+	ReceivedPaymentsAccount LedgerAccount = "income:payments"
+
+	// ForwardFeeAccount is the fee which were earned by us for forwarding
+	// payments.
+	ForwardFeeAccount LedgerAccount = "income:forward_fee"
+
+	// SentPaymentsAccount is the funds which were sent by us in the
+	// lightning network.
+	SentPaymentsAccount LedgerAccount = "expenses:payments"
+
+	// RoutingFeeAccount is the fee which were paid by us to route the
+	// payments.
+	RoutingFeeAccount LedgerAccount = "expenses:routing_fee"
+
+	// OnChainFeeAccount is the fee which were paid by us to miners for
+	// channel management.
+	OnChainFeeAccount LedgerAccount = "expenses:onchain_fee"
+)
+
+// LedgerEntryType is the type of the event which is recorded in the ledger.
+type LedgerEntryType string
+
+const (
+	IncomingPaymentEntry LedgerEntryType = "incoming_payment"
+	OutgoingPaymentEntry LedgerEntryType = "outgoing_payment"
+	ForwardPaymentEntry  LedgerEntryType = "forward_payment"
+	RebalanceFeeEntry    LedgerEntryType = "rebalance_fee"
+	OpenFeeEntry         LedgerEntryType = "open_fee"
+	CloseFeeEntry        LedgerEntryType = "close_fee"
+	SwipeFeeEntry        LedgerEntryType = "swipe_fee"
+)
+
+// LedgerPosting is the movement of funds on the single account, only one of
+// debit or credit is non-zero.
+type LedgerPosting struct {
+	Account LedgerAccount
+	Debit   btcutil.Amount
+	Credit  btcutil.Amount
+}
+
+// LedgerEntry is the event which moved funds between accounts, sum of debits
+// of the postings is always equal to the sum of credits.
+type LedgerEntry struct {
+	Time      int64
+	Type      LedgerEntryType
+	ChannelID lightning.ChannelID
+	NodeID    lightning.NodeID
+
+	// PaymentID is the identificator of the payment, empty if entry
+	// isn't associated with payment.
+	PaymentID string
+
+	Postings []*LedgerPosting
+
+	// PriceUSD is the bitcoin price at the time of the event, zero if it
+	// is unknown.
+	PriceUSD float64
+}
+
+// debit creates posting which increases the account.
+func debit(account LedgerAccount, amount btcutil.Amount) *LedgerPosting {
+	return &LedgerPosting{Account: account, Debit: amount}
+}
+
+// credit creates posting which decreases the account.
+func credit(account LedgerAccount, amount btcutil.Amount) *LedgerPosting {
+	return &LedgerPosting{Account: account, Credit: amount}
+}
+
+// buildLedger converts payments, forwarding payments and channel states
+// within [start, end) period in the chronological list of ledger entries.
+func buildLedger(start, end int64, channels []*lightning.Channel,
+	payments []*lightning.Payment,
+	forwardPayments []*lightning.ForwardPayment) ([]*LedgerEntry, error) {
+
+	if start >= end {
+		return nil, errors.Errorf("start(%v) should be less than end(%v)",
+			start, end)
+	}
+
+	var entries []*LedgerEntry
+
+	for _, payment := range payments {
+		if payment.Status != lightning.Completed ||
+			!stats.InRange(payment.UpdatedAt, start, end) {
+			continue
+		}
+
+		switch payment.Direction {
+		case lightning.Incoming:
+			// Funds of the internal circular payment are received
+			// from our own outgoing payment, so they are not income.
+			if payment.System == lightning.Internal {
+				continue
+			}
+
+			entries = append(entries, &LedgerEntry{
+				Time:      payment.UpdatedAt,
+				Type:      IncomingPaymentEntry,
+				ChannelID: payment.FromChannel,
+				NodeID:    payment.Sender,
+				PaymentID: payment.PaymentID,
+				Postings: []*LedgerPosting{
+					debit(LightningAccount, payment.Amount),
+					credit(ReceivedPaymentsAccount, payment.Amount),
+				},
+			})
+
+		case lightning.Outgoing:
+			// Internal circular payment only moves funds between our
+			// channels, and only fee is actually spent by us.
+			if payment.System == lightning.Internal {
+				if payment.MediaFee == 0 {
+					continue
+				}
+
+				entries = append(entries, &LedgerEntry{
+					Time:      payment.UpdatedAt,
+					Type:      RebalanceFeeEntry,
+					NodeID:    payment.FirstHop,
+					PaymentID: payment.PaymentID,
+					Postings: []*LedgerPosting{
+						debit(RoutingFeeAccount,
+							payment.MediaFee),
+						credit(LightningAccount,
+							payment.MediaFee),
+					},
+				})
+				continue
+			}
+
+			postings := []*LedgerPosting{
+				debit(SentPaymentsAccount, payment.Amount),
+			}
+
+			if payment.MediaFee != 0 {
+				postings = append(postings, debit(RoutingFeeAccount,
+					payment.MediaFee))
+			}
+
+			postings = append(postings, credit(LightningAccount,
+				payment.Amount+payment.MediaFee))
+
+			entries = append(entries, &LedgerEntry{
+				Time:      payment.UpdatedAt,
+				Type:      OutgoingPaymentEntry,
+				NodeID:    payment.Receiver,
+				PaymentID: payment.PaymentID,
+				Postings:  postings,
+			})
+		}
+	}
+
+	for _, payment := range forwardPayments {
+		if !stats.InRange(payment.Time, start, end) {
+			continue
+		}
+
+		// Forwarded funds are moved between our channels, and only fee is
+		// actually earned by us.
+		entries = append(entries, &LedgerEntry{
+			Time:      payment.Time,
+			Type:      ForwardPaymentEntry,
+			ChannelID: payment.ToChannel,
+			NodeID:    payment.ToNode,
+			Postings: []*LedgerPosting{
+				debit(LightningAccount, payment.IncomingAmount),
+				credit(LightningAccount, payment.OutgoingAmount),
+				credit(ForwardFeeAccount, payment.ForwardFee),
+			},
+		})
+	}
+
+	feeEntry := func(channel *lightning.Channel, moment int64,
+		entryType LedgerEntryType, fee btcutil.Amount) {
+
+		if fee == 0 || !stats.InRange(moment, start, end) {
+			return
+		}
+
+		entries = append(entries, &LedgerEntry{
+			Time:      moment,
+			Type:      entryType,
+			ChannelID: channel.ChannelID,
+			NodeID:    channel.NodeID,
+			Postings: []*LedgerPosting{
+				debit(OnChainFeeAccount, fee),
+				credit(OnChainAccount, fee),
+			},
+		})
+	}
+
+	for _, channel := range channels {
+		// Open and close fees are paid by the initiator of the channel,
+		// swipe fee is always paid by us.
+		isLocal := isLocalInitiator(channel)
+
+		openingTime, err := channel.OpeningTime()
+		if err == nil && isLocal {
+			fee, _ := channel.OpenFee()
+			feeEntry(channel, openingTime, OpenFeeEntry, fee)
+		}
+
+		if closingTime, err := channel.ClosingTime(); err == nil {
+			if isLocal {
+				fee, _ := channel.CloseFee()
+				feeEntry(channel, closingTime, CloseFeeEntry,
+					fee)
+			}
+
+			fee, _ := channel.SwipeFee()
+			feeEntry(channel, closingTime, SwipeFeeEntry, fee)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time < entries[j].Time
+	})
+
+	return entries, nil
+}
+
+// setLedgerPrices sets bitcoin price of the event day for every entry.
+func setLedgerPrices(entries []*LedgerEntry, prices map[string]float64) {
+	for _, entry := range entries {
+		date := time.Unix(entry.Time, 0).UTC().Format(common.DateLayout)
+		entry.PriceUSD = prices[date]
+	}
+}
+
+// ledgerHeader is the header of the ledger csv file.
+var ledgerHeader = []string{
+	"entry", "time", "type", "account", "debit_sat", "credit_sat",
+	"debit_btc", "credit_btc", "debit_usd", "credit_usd", "price_usd",
+	"channel_id", "node_id", "payment_id",
+}
+
+// WriteLedgerCSV writes ledger entries in the csv format, every posting of
+// the entry is written as the separate row, fiat columns are empty if price
+// is unknown. Offset is the number of entries which precede the given ones,
+// it is used to number the entries, and header is written only if offset is
+// zero, so that pages of the ledger could be concatenated in the single
+// file.
+func WriteLedgerCSV(w io.Writer, entries []*LedgerEntry, offset int) error {
+	formatBTC := func(amount btcutil.Amount) string {
+		return strconv.FormatFloat(amount.ToBTC(), 'f', 8, 64)
+	}
+
+	formatUSD := func(amount btcutil.Amount, price float64) string {
+		if price == 0 {
+			return ""
+		}
+
+		return strconv.FormatFloat(amount.ToBTC()*price, 'f', 2, 64)
+	}
+
+	writer := csv.NewWriter(w)
+	if offset == 0 {
+		if err := writer.Write(ledgerHeader); err != nil {
+			return errors.Errorf("unable to write header: %v", err)
+		}
+	}
+
+	for i, entry := range entries {
+		number := offset + i + 1
+
+		price := ""
+		if entry.PriceUSD != 0 {
+			price = strconv.FormatFloat(entry.PriceUSD, 'f', 2, 64)
+		}
+
+		for _, posting := range entry.Postings {
+			record := []string{
+				strconv.Itoa(number),
+				time.Unix(entry.Time, 0).UTC().Format(time.RFC3339),
+				string(entry.Type),
+				string(posting.Account),
+				fmt.Sprint(int64(posting.Debit)),
+				fmt.Sprint(int64(posting.Credit)),
+				formatBTC(posting.Debit),
+				formatBTC(posting.Credit),
+				formatUSD(posting.Debit, entry.PriceUSD),
+				formatUSD(posting.Credit, entry.PriceUSD),
+				price,
+				string(entry.ChannelID),
+				string(entry.NodeID),
+				entry.PaymentID,
+			}
+
+			if err := writer.Write(record); err != nil {
+				return errors.Errorf("unable to write entry(%v): %v",
+					number, err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return errors.Errorf("unable to flush ledger: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package router
+
+import (
+	"bytes"
+	"encoding/csv"
+	"github.com/bitlum/hub/lightning"
+	"testing"
+)
+
+func TestBuildLedger(t *testing.T) {
+	channels := []*lightning.Channel{
+		newClosedChannel("local", "a", lightning.LocalInitiator, 10, 20,
+			100, 50, 5),
+
+		// Open and close fees of channel opened by remote side are paid
+		// by remote side, only swipe fee is paid by us.
+		newClosedChannel("remote", "b", lightning.RemoteInitiator, 10, 20,
+			200, 60, 6),
+	}
+
+	forwards := []*lightning.ForwardPayment{
+		{
+			FromNode:       "b",
+			ToNode:         "a",
+			FromChannel:    "remote",
+			ToChannel:      "local",
+			IncomingAmount: 1010,
+			OutgoingAmount: 1000,
+			ForwardFee:     10,
+			Time:           12,
+		},
+	}
+
+	payments := []*lightning.Payment{
+		{
+			PaymentID: "outgoing",
+			Receiver:  "c",
+			FirstHop:  "b",
+			Status:    lightning.Completed,
+			Direction: lightning.Outgoing,
+			System:    lightning.External,
+			Amount:    500,
+			MediaFee:  3,
+			UpdatedAt: 13,
+		},
+		{
+			PaymentID:   "incoming",
+			Sender:      "b",
+			FromChannel: "remote",
+			Status:      lightning.Completed,
+			Direction:   lightning.Incoming,
+			System:      lightning.External,
+			Amount:      400,
+			UpdatedAt:   14,
+		},
+
+		// Circular re-balancing payment moves funds between our channels,
+		// only its fee should be recorded.
+		{
+			PaymentID: "rebalance",
+			FirstHop:  "a",
+			Status:    lightning.Completed,
+			Direction: lightning.Outgoing,
+			System:    lightning.Internal,
+			Amount:    700,
+			MediaFee:  2,
+			UpdatedAt: 15,
+		},
+		{
+			Status:    lightning.Completed,
+			Direction: lightning.Incoming,
+			System:    lightning.Internal,
+			Amount:    700,
+			UpdatedAt: 15,
+		},
+	}
+
+	if _, err := buildLedger(10, 10, channels, payments,
+		forwards); err == nil {
+		t.Fatalf("empty period should be rejected")
+	}
+
+	entries, err := buildLedger(10, 30, channels, payments, forwards)
+	if err != nil {
+		t.Fatalf("unable to build ledger: %v", err)
+	}
+
+	expected := []LedgerEntryType{
+		OpenFeeEntry,
+		ForwardPaymentEntry,
+		OutgoingPaymentEntry,
+		IncomingPaymentEntry,
+		RebalanceFeeEntry,
+		CloseFeeEntry,
+		SwipeFeeEntry,
+		SwipeFeeEntry,
+	}
+
+	if len(entries) != len(expected) {
+		t.Fatalf("wrong number of entries: %v", len(entries))
+	}
+
+	accounts := make(map[LedgerAccount]int64)
+	for i, entry := range entries {
+		if entry.Type != expected[i] {
+			t.Fatalf("wrong type of entry(%v): %v", i, entry.Type)
+		}
+
+		var debits, credits int64
+		for _, posting := range entry.Postings {
+			debits += int64(posting.Debit)
+			credits += int64(posting.Credit)
+			accounts[posting.Account] += int64(posting.Debit) -
+				int64(posting.Credit)
+		}
+
+		if debits != credits {
+			t.Fatalf("debits(%v) of entry(%v) aren't equal to "+
+				"credits(%v)", debits, entry.Type, credits)
+		}
+	}
+
+	expectedAccounts := map[LedgerAccount]int64{
+		LightningAccount:        1010 - 1000 - 503 + 400 - 2,
+		OnChainAccount:          -(100 + 50 + 5 + 6),
+		ReceivedPaymentsAccount: -400,
+		ForwardFeeAccount:       -10,
+		SentPaymentsAccount:     500,
+		RoutingFeeAccount:       3 + 2,
+		OnChainFeeAccount:       100 + 50 + 5 + 6,
+	}
+
+	for account, balance := range expectedAccounts {
+		if accounts[account] != balance {
+			t.Fatalf("wrong balance of account(%v): %v", account,
+				accounts[account])
+		}
+	}
+}
+
+func TestWriteLedgerCSV(t *testing.T) {
+	entries := []*LedgerEntry{
+		{
+			Time: 10,
+			Type: OpenFeeEntry,
+			Postings: []*LedgerPosting{
+				debit(OnChainFeeAccount, 100),
+				credit(OnChainAccount, 100),
+			},
+		},
+		{
+			Time: 20,
+			Type: SwipeFeeEntry,
+			Postings: []*LedgerPosting{
+				debit(OnChainFeeAccount, 5000),
+				credit(OnChainAccount, 5000),
+			},
+			PriceUSD: 10000,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		offset     int
+		numRecords int
+		firstEntry string
+	}{
+		{
+			name:       "first page",
+			offset:     0,
+			numRecords: 5,
+			firstEntry: "1",
+		},
+		{
+			name:       "next page",
+			offset:     10,
+			numRecords: 4,
+			firstEntry: "11",
+		},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := WriteLedgerCSV(&buf, entries, test.offset); err != nil {
+			t.Fatalf("(%v) unable to write ledger: %v", test.name, err)
+		}
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatalf("(%v) unable to read ledger: %v", test.name, err)
+		}
+
+		if len(records) != test.numRecords {
+			t.Fatalf("(%v) wrong number of records: %v", test.name,
+				len(records))
+		}
+
+		// Header is written only in the first page.
+		first := records[0]
+		if test.offset == 0 {
+			first = records[1]
+		}
+
+		if first[0] != test.firstEntry {
+			t.Fatalf("(%v) wrong number of the first entry: %v",
+				test.name, first[0])
+		}
+
+		last := records[len(records)-1]
+		if last[9] != "0.50" || last[10] != "10000.00" {
+			t.Fatalf("(%v) wrong fiat columns: %v", test.name, last)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/hubrpc"
+	"github.com/bitlum/hub/metrics/rpc"
+	"github.com/go-errors/errors"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"net"
+	"net/http"
+)
+
+// swaggerPath is the path on which REST gateway serves the OpenAPI
+// specification of the hub api.
+const swaggerPath = "/v1/swagger.json"
+
+// getCertLoader generates self-signed certificate of gRPC endpoint if it
+// doesn't exist, and returns the loader of it, which is reloaded on SIGHUP.
+// Returns nil if tls is disabled in config.
+func getCertLoader(cfg *hubConfig) (*common.CertLoader, error) {
+	if cfg.NoTLS {
+		return nil, nil
+	}
+
+	if !common.FileExists(cfg.TLSCertPath) ||
+		!common.FileExists(cfg.TLSKeyPath) {
+		mainLog.Infof("Generating self-signed TLS certificate(%v) "+
+			"and key(%v)", cfg.TLSCertPath, cfg.TLSKeyPath)
+
+		err := common.GenCertPair(cfg.TLSCertPath, cfg.TLSKeyPath,
+			cfg.TLSExtraIPs, cfg.TLSExtraDomains)
+		if err != nil {
+			return nil, errors.Errorf("unable to generate tls "+
+				"cert: %v", err)
+		}
+	}
+
+	certLoader, err := common.NewCertLoader(cfg.TLSCertPath,
+		cfg.TLSKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	addReloadHandler(func() {
+		if err := certLoader.Reload(); err != nil {
+			mainLog.Errorf("unable to reload gRPC certificate: %v",
+				err)
+			return
+		}
+		mainLog.Infof("gRPC TLS certificate(%v) has been reloaded",
+			cfg.TLSCertPath)
+	})
+
+	return certLoader, nil
+}
+
+// getGRPCServerOptions returns options of hub gRPC server, which enable tls
+// and macaroon authentication, unless they are disabled in config, and
+// limits of the requests rate.
+func getGRPCServerOptions(cfg *hubConfig, certLoader *common.CertLoader,
+	metricsBackend rpc.MetricsBackend) ([]grpc.ServerOption, error) {
+
+	var (
+		opts         []grpc.ServerOption
+		interceptors []grpc.UnaryServerInterceptor
+	)
+
+	if certLoader != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			GetCertificate: certLoader.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		})))
+	} else {
+		mainLog.Warn("TLS of gRPC endpoint is disabled")
+	}
+
+	if !cfg.NoMacaroons {
+		auth, err := hubrpc.NewAuth(cfg.MacaroonDir)
+		if err != nil {
+			return nil, errors.Errorf("unable to init macaroons: %v", err)
+		}
+
+		interceptors = append(interceptors, auth.UnaryServerInterceptor)
+	} else {
+		mainLog.Warn("Macaroon authentication of gRPC endpoint is disabled")
+	}
+
+	// Limiter goes after authentication, so that the rate of the client is
+	// accounted by its verified macaroon.
+	limiter, err := hubrpc.NewLimiter(&hubrpc.LimiterConfig{
+		CredentialRate:         cfg.RateLimit,
+		CredentialBurst:        cfg.RateBurst,
+		MethodRate:             cfg.ExpensiveRateLimit,
+		MethodBurst:            cfg.ExpensiveRateBurst,
+		MaxConcurrentExpensive: cfg.MaxConcurrentExpensive,
+		MetricsBackend:         metricsBackend,
+	})
+	if err != nil {
+		return nil, errors.Errorf("unable to create limiter: %v", err)
+	}
+	interceptors = append(interceptors, limiter.UnaryServerInterceptor)
+
+	opts = append(opts, grpc.UnaryInterceptor(
+		hubrpc.ChainUnaryServerInterceptors(interceptors...)))
+
+	return opts, nil
+}
+
+// newRESTServer creates http server of the REST gateway, which proxies
+// requests to the hub gRPC endpoint, and therefore is using the same
+// authentication. Macaroon should be passed in hex in the
+// "Grpc-Metadata-Macaroon" header. Server uses the same certificate as the
+// gRPC endpoint, if tls is enabled.
+func newRESTServer(ctx context.Context, cfg *hubConfig,
+	certLoader *common.CertLoader) (*http.Server, error) {
+
+	// Gateway is dialing gRPC endpoint locally, so wildcard address is
+	// replaced with the loopback one.
+	host := cfg.Host
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	endpoint := net.JoinHostPort(host, cfg.Port)
+
+	var opts []grpc.DialOption
+	if certLoader != nil {
+		creds, err := credentials.NewClientTLSFromFile(cfg.TLSCertPath, "")
+		if err != nil {
+			return nil, errors.Errorf("unable to read gRPC "+
+				"certificate: %v", err)
+		}
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	gatewayMux := runtime.NewServeMux(
+		runtime.WithProtoErrorHandler(hubrpc.GatewayErrorHandler))
+	err := hubrpc.RegisterHubHandlerFromEndpoint(ctx, gatewayMux, endpoint,
+		opts)
+	if err != nil {
+		return nil, errors.Errorf("unable to register REST gateway: %v",
+			err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", gatewayMux)
+	mux.HandleFunc(swaggerPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(hubrpc.SwaggerJSON))
+	})
+
+	server := &http.Server{
+		Addr:    net.JoinHostPort(cfg.RESTHost, cfg.RESTPort),
+		Handler: mux,
+	}
+
+	if certLoader != nil {
+		server.TLSConfig = &tls.Config{
+			GetCertificate: certLoader.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		}
+	} else {
+		mainLog.Warn("TLS of REST endpoint is disabled")
+	}
+
+	return server, nil
+}
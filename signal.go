@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"syscall"
 )
 
 // interruptChannel is used to receive SIGINT (Ctrl+C) signals.
@@ -94,3 +95,17 @@ func addInterruptHandler(shutdownChannel chan struct{}, handler func()) {
 
 	addHandlerChannel <- handler
 }
+
+// addReloadHandler adds a handler to call when a SIGHUP is received, which
+// is used to reload files without restart, e.g. TLS certificates.
+func addReloadHandler(handler func()) {
+	reloadChannel := make(chan os.Signal, 1)
+	signal.Notify(reloadChannel, syscall.SIGHUP)
+
+	go func() {
+		for range reloadChannel {
+			log.Println("Received SIGHUP.")
+			handler()
+		}
+	}()
+}
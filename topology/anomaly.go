@@ -0,0 +1,449 @@
+package topology
+
+import (
+	"fmt"
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// anomalyCheckPeriod is the period of running of anomaly detectors.
+	anomalyCheckPeriod = time.Minute * 10
+
+	// maxAnomalyEvents is the maximum number of the latest anomaly events
+	// which are kept in memory and restored from the storage on start.
+	maxAnomalyEvents = 1000
+)
+
+// AnomalyType is the type of the detected anomaly.
+type AnomalyType string
+
+const (
+	// NetworkCapacityAnomaly is the sudden change of overall network
+	// capacity.
+	NetworkCapacityAnomaly AnomalyType = "network_capacity"
+
+	// NodeCapacityAnomaly is the sudden change of capacity of the
+	// particular node channels.
+	NodeCapacityAnomaly AnomalyType = "node_capacity"
+
+	// NodeUptimeAnomaly is the sudden drop of the online percentage of
+	// the known node.
+	NodeUptimeAnomaly AnomalyType = "node_uptime"
+)
+
+// AnomalyConfig is the thresholds and windows of anomaly detectors.
+type AnomalyConfig struct {
+	// NetworkCapacityThreshold is the relative change of overall network
+	// capacity within the capacity window, starting from which change is
+	// considered as anomaly.
+	NetworkCapacityThreshold float64
+
+	// NodeCapacityThreshold is the relative change of node capacity within
+	// the capacity window, starting from which change is considered as
+	// anomaly.
+	NodeCapacityThreshold float64
+
+	// NodeMinCapacity is the minimal capacity of the node, below which
+	// its capacity changes are ignored. This is needed to avoid noise from
+	// the small nodes.
+	NodeMinCapacity btcutil.Amount
+
+	// CapacityWindow is the period of time over which capacity change is
+	// calculated.
+	CapacityWindow time.Duration
+
+	// UptimeDropThreshold is the drop of node online percentage within
+	// the uptime window in comparison with its weekly online percentage,
+	// starting from which drop is considered as anomaly.
+	UptimeDropThreshold float64
+
+	// UptimeWindow is the period of time over which recent online
+	// percentage of the node is calculated.
+	UptimeWindow time.Duration
+}
+
+// validate check that anomaly config is valid.
+func (c *AnomalyConfig) validate() error {
+	if c.NetworkCapacityThreshold <= 0 {
+		return errors.New("network capacity threshold should be positive")
+	}
+
+	if c.NodeCapacityThreshold <= 0 {
+		return errors.New("node capacity threshold should be positive")
+	}
+
+	if c.NodeMinCapacity < 0 {
+		return errors.New("node min capacity shouldn't be negative")
+	}
+
+	if c.CapacityWindow <= 0 {
+		return errors.New("capacity window should be positive")
+	}
+
+	if c.UptimeDropThreshold <= 0 || c.UptimeDropThreshold > 1 {
+		return errors.New("uptime drop threshold should be in (0, 1]")
+	}
+
+	if c.UptimeWindow <= 0 || c.UptimeWindow >= healthWindow {
+		return errors.Errorf("uptime window should be positive and less "+
+			"than %v", healthWindow)
+	}
+
+	return nil
+}
+
+// AnomalyEvent is the record about detected anomaly.
+type AnomalyEvent struct {
+	Type AnomalyType
+
+	// NodeID is the node to which anomaly is related, empty for network
+	// wide anomalies.
+	NodeID lightning.NodeID
+
+	Time int64
+
+	// Previous is the value at the beginning of the window, i.e. capacity
+	// in satoshis or online percentage.
+	Previous float64
+
+	// Current is the current value.
+	Current float64
+
+	// Change is the relative change of capacity, or absolute change of
+	// online percentage. Capacity change of the node which appeared within
+	// the window is 1.
+	Change float64
+
+	Description string
+}
+
+// capacitySnapshot is the capacity of the network and its nodes at the
+// particular moment of time.
+type capacitySnapshot struct {
+	time     int64
+	capacity btcutil.Amount
+	nodes    map[lightning.NodeID]btcutil.Amount
+}
+
+// newCapacitySnapshot takes capacity snapshot of the graph.
+func newCapacitySnapshot(graph *Graph) *capacitySnapshot {
+	return &capacitySnapshot{
+		time:     graph.Time,
+		capacity: graph.Capacity(),
+		nodes:    nodeCapacities(graph),
+	}
+}
+
+// anomalyDetector runs detectors and keeps the history of detected
+// anomalies.
+type anomalyDetector struct {
+	cfg *AnomalyConfig
+
+	// snapshots is the capacity snapshots taken within the capacity
+	// window, preceded by the last snapshot taken before the window, which
+	// current capacity is compared with.
+	//
+	// NOTE: Should be used only by the main goroutine.
+	snapshots []*capacitySnapshot
+
+	// lastEvents is the time of the last event of the same type and node,
+	// is used to avoid reporting the same anomaly on every check.
+	lastEvents map[string]int64
+
+	events      []*AnomalyEvent
+	eventsMutex sync.Mutex
+}
+
+// newAnomalyDetector creates new anomaly detector.
+func newAnomalyDetector(cfg *AnomalyConfig) *anomalyDetector {
+	return &anomalyDetector{
+		cfg:        cfg,
+		lastEvents: make(map[string]int64),
+	}
+}
+
+// addSnapshot saves the capacity snapshot, and returns the last snapshot
+// taken at or before the beginning of the capacity window, nil is returned
+// if there is no such snapshot yet. Snapshots should be added in order of
+// their time.
+func (d *anomalyDetector) addSnapshot(
+	snapshot *capacitySnapshot) *capacitySnapshot {
+
+	d.snapshots = append(d.snapshots, snapshot)
+	windowStart := snapshot.time - int64(d.cfg.CapacityWindow.Seconds())
+
+	previous := -1
+	for i, s := range d.snapshots {
+		if s.time > windowStart {
+			break
+		}
+		previous = i
+	}
+
+	if previous == -1 {
+		return nil
+	}
+
+	// Older snapshots are not needed anymore, because the next
+	// comparisons will be made with the same or later snapshots.
+	d.snapshots = d.snapshots[previous:]
+	return d.snapshots[0]
+}
+
+// capacityAnomalies compares current network and nodes capacity with the
+// previous one, and returns detected anomalies.
+func (d *anomalyDetector) capacityAnomalies(previous,
+	current *capacitySnapshot) []*AnomalyEvent {
+
+	var events []*AnomalyEvent
+
+	if previous.capacity != 0 {
+		prevCapacity := float64(previous.capacity)
+		curCapacity := float64(current.capacity)
+		change := (curCapacity - prevCapacity) / prevCapacity
+
+		if math.Abs(change) >= d.cfg.NetworkCapacityThreshold {
+			events = append(events, &AnomalyEvent{
+				Type:     NetworkCapacityAnomaly,
+				Time:     current.time,
+				Previous: prevCapacity,
+				Current:  curCapacity,
+				Change:   change,
+				Description: fmt.Sprintf("network capacity changed "+
+					"from %v to %v within %v",
+					previous.capacity, current.capacity,
+					d.cfg.CapacityWindow),
+			})
+		}
+	}
+
+	// If there is no previous graph, than every node would look like the
+	// new one, so there is nothing to compare with.
+	if previous.capacity == 0 {
+		return events
+	}
+
+	// Nodes which appeared within the window are included with zero
+	// previous capacity.
+	nodes := make(map[lightning.NodeID]btcutil.Amount, len(previous.nodes))
+	for nodeID, capacity := range previous.nodes {
+		nodes[nodeID] = capacity
+	}
+	for nodeID := range current.nodes {
+		if _, ok := nodes[nodeID]; !ok {
+			nodes[nodeID] = 0
+		}
+	}
+
+	for nodeID, prevCapacity := range nodes {
+		curCapacity := current.nodes[nodeID]
+		if prevCapacity < d.cfg.NodeMinCapacity &&
+			curCapacity < d.cfg.NodeMinCapacity {
+			continue
+		}
+
+		// Relative change of the new node is unbounded, so it is reported
+		// as the full capacity increase, which is symmetrical to the
+		// closing of all node channels.
+		change := float64(1)
+		if prevCapacity != 0 {
+			change = float64(curCapacity-prevCapacity) /
+				float64(prevCapacity)
+		}
+
+		if math.Abs(change) < d.cfg.NodeCapacityThreshold {
+			continue
+		}
+
+		events = append(events, &AnomalyEvent{
+			Type:     NodeCapacityAnomaly,
+			NodeID:   nodeID,
+			Time:     current.time,
+			Previous: float64(prevCapacity),
+			Current:  float64(curCapacity),
+			Change:   change,
+			Description: fmt.Sprintf("node capacity changed "+
+				"from %v to %v within %v", prevCapacity,
+				curCapacity, d.cfg.CapacityWindow),
+		})
+	}
+
+	return events
+}
+
+// uptimeAnomalies compares recent online percentage of the given nodes
+// with their weekly online percentage, and returns detected anomalies.
+func (d *anomalyDetector) uptimeAnomalies(health *healthTracker,
+	nodes []lightning.NodeID, now int64) []*AnomalyEvent {
+
+	var events []*AnomalyEvent
+	for _, nodeID := range nodes {
+		weekly := health.health(nodeID, now).Uptime
+		recent := health.windowUptime(nodeID, d.cfg.UptimeWindow, now)
+
+		drop := weekly - recent
+		if drop < d.cfg.UptimeDropThreshold {
+			continue
+		}
+
+		events = append(events, &AnomalyEvent{
+			Type:     NodeUptimeAnomaly,
+			NodeID:   nodeID,
+			Time:     now,
+			Previous: weekly,
+			Current:  recent,
+			Change:   -drop,
+			Description: fmt.Sprintf("node online percentage dropped "+
+				"from %.2f%% to %.2f%% within %v", weekly*100,
+				recent*100, d.cfg.UptimeWindow),
+		})
+	}
+
+	return events
+}
+
+// addEvent saves anomaly event, if the same anomaly wasn't reported within
+// the given window. Returns true if event has been saved.
+func (d *anomalyDetector) addEvent(event *AnomalyEvent,
+	window time.Duration) bool {
+
+	key := string(event.Type) + ":" + string(event.NodeID)
+	if last, ok := d.lastEvents[key]; ok &&
+		event.Time-last < int64(window.Seconds()) {
+		return false
+	}
+	d.lastEvents[key] = event.Time
+
+	d.eventsMutex.Lock()
+	defer d.eventsMutex.Unlock()
+
+	d.events = append(d.events, event)
+	if len(d.events) > maxAnomalyEvents {
+		d.events = d.events[len(d.events)-maxAnomalyEvents:]
+	}
+
+	return true
+}
+
+// restore restores the history of anomaly events, so that the same
+// anomalies wouldn't be reported again after the restart. Events should be
+// sorted by time.
+func (d *anomalyDetector) restore(events []*AnomalyEvent) {
+	for _, event := range events {
+		key := string(event.Type) + ":" + string(event.NodeID)
+		d.lastEvents[key] = event.Time
+	}
+
+	d.eventsMutex.Lock()
+	defer d.eventsMutex.Unlock()
+
+	d.events = append(d.events, events...)
+	if len(d.events) > maxAnomalyEvents {
+		d.events = d.events[len(d.events)-maxAnomalyEvents:]
+	}
+}
+
+// Anomalies returns the list of the last detected anomalies, sorted from
+// the oldest to the newest one.
+func (t *Topology) Anomalies() []*AnomalyEvent {
+	t.anomalies.eventsMutex.Lock()
+	defer t.anomalies.eventsMutex.Unlock()
+
+	events := make([]*AnomalyEvent, len(t.anomalies.events))
+	copy(events, t.anomalies.events)
+	return events
+}
+
+// detectAnomalies runs anomaly detectors, and records detected anomalies.
+// Current graph is compared with the in-memory capacity snapshots, which
+// are taken on every check.
+//
+// NOTE: Should be used only by the main goroutine.
+func (t *Topology) detectAnomalies() {
+	now := time.Now().Unix()
+	cfg := t.anomalies.cfg
+
+	current := newCapacitySnapshot(t.graph)
+	current.time = now
+
+	var events []*AnomalyEvent
+
+	// If there is no history yet, than there is nothing to compare with.
+	if previous := t.anomalies.addSnapshot(current); previous != nil {
+		events = t.anomalies.capacityAnomalies(previous, current)
+	}
+
+	events = append(events, t.anomalies.uptimeAnomalies(t.health,
+		t.cfg.ImportantNodes(), now)...)
+
+	for _, event := range events {
+		window := cfg.CapacityWindow
+		if event.Type == NodeUptimeAnomaly {
+			window = cfg.UptimeWindow
+		}
+
+		if !t.anomalies.addEvent(event, window) {
+			continue
+		}
+
+		if err := t.cfg.Storage.AddAnomalyEvent(&db.AnomalyEvent{
+			Type:        string(event.Type),
+			NodeID:      event.NodeID,
+			Time:        event.Time,
+			Previous:    event.Previous,
+			Current:     event.Current,
+			Change:      event.Change,
+			Description: event.Description,
+		}); err != nil {
+			log.Errorf("unable to save anomaly event: %v", err)
+		}
+
+		t.cfg.NetworkMetricsBackend.AddAnomaly(t.cfg.Asset,
+			string(event.Type))
+
+		log.Warnf("Anomaly(%v) detected, node(%v): %v", event.Type,
+			event.NodeID, event.Description)
+	}
+}
+
+// restoreAnomalies restores the latest anomaly events from the storage.
+func (t *Topology) restoreAnomalies() error {
+	dbEvents, err := t.cfg.Storage.LastAnomalyEvents(maxAnomalyEvents)
+	if err != nil {
+		return errors.Errorf("unable fetch anomaly events: %v", err)
+	}
+
+	events := make([]*AnomalyEvent, len(dbEvents))
+	for i, event := range dbEvents {
+		events[i] = &AnomalyEvent{
+			Type:        AnomalyType(event.Type),
+			NodeID:      event.NodeID,
+			Time:        event.Time,
+			Previous:    event.Previous,
+			Current:     event.Current,
+			Change:      event.Change,
+			Description: event.Description,
+		}
+	}
+
+	t.anomalies.restore(events)
+	return nil
+}
+
+// nodeCapacities returns the overall capacity of channels of every node in
+// the graph.
+func nodeCapacities(graph *Graph) map[lightning.NodeID]btcutil.Amount {
+	capacities := make(map[lightning.NodeID]btcutil.Amount)
+	for _, channel := range graph.Channels {
+		capacities[channel.Node1] += channel.Capacity
+		capacities[channel.Node2] += channel.Capacity
+	}
+
+	return capacities
+}
@@ -0,0 +1,361 @@
+package topology
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"testing"
+	"time"
+)
+
+func newTestAnomalyConfig() *AnomalyConfig {
+	return &AnomalyConfig{
+		NetworkCapacityThreshold: 0.2,
+		NodeCapacityThreshold:    0.5,
+		NodeMinCapacity:          100,
+		CapacityWindow:           time.Second * 100,
+		UptimeDropThreshold:      0.3,
+		UptimeWindow:             time.Second * 100,
+	}
+}
+
+func TestAddSnapshot(t *testing.T) {
+	detector := newAnomalyDetector(newTestAnomalyConfig())
+
+	tests := []struct {
+		time     int64
+		previous int64
+	}{
+		{time: 0, previous: -1},
+		{time: 50, previous: -1},
+		{time: 100, previous: 0},
+		{time: 120, previous: 0},
+		{time: 160, previous: 50},
+	}
+
+	for _, test := range tests {
+		previous := detector.addSnapshot(&capacitySnapshot{time: test.time})
+
+		if test.previous == -1 {
+			if previous != nil {
+				t.Fatalf("(%v) previous snapshot shouldn't be found",
+					test.time)
+			}
+			continue
+		}
+
+		if previous == nil || previous.time != test.previous {
+			t.Fatalf("(%v) wrong previous snapshot, expected %v",
+				test.time, test.previous)
+		}
+	}
+
+	if len(detector.snapshots) != 4 {
+		t.Fatalf("old snapshots weren't removed, snapshots: %v",
+			len(detector.snapshots))
+	}
+}
+
+func TestNewCapacitySnapshot(t *testing.T) {
+	graph := newGraph(10)
+	graph.apply(nil, []*db.ChannelState{
+		{ShortChannelID: 1, Node1: "a", Node2: "b", Capacity: 100},
+		{ShortChannelID: 2, Node1: "b", Node2: "c", Capacity: 200},
+		{ShortChannelID: 3, Node1: "c", Node2: "d", Capacity: 300,
+			Closed: true},
+	})
+
+	snapshot := newCapacitySnapshot(graph)
+
+	if snapshot.time != 10 {
+		t.Fatalf("wrong time: %v", snapshot.time)
+	}
+
+	if snapshot.capacity != 300 {
+		t.Fatalf("wrong capacity: %v", snapshot.capacity)
+	}
+
+	expected := map[lightning.NodeID]btcutil.Amount{
+		"a": 100,
+		"b": 300,
+		"c": 200,
+	}
+
+	if len(snapshot.nodes) != len(expected) {
+		t.Fatalf("wrong number of nodes: %v", len(snapshot.nodes))
+	}
+
+	for nodeID, capacity := range expected {
+		if snapshot.nodes[nodeID] != capacity {
+			t.Fatalf("wrong capacity of node(%v): %v", nodeID,
+				snapshot.nodes[nodeID])
+		}
+	}
+}
+
+func TestCapacityAnomalies(t *testing.T) {
+	detector := newAnomalyDetector(newTestAnomalyConfig())
+
+	type anomaly struct {
+		anomalyType AnomalyType
+		nodeID      lightning.NodeID
+	}
+
+	tests := []struct {
+		name     string
+		previous *capacitySnapshot
+		current  *capacitySnapshot
+		expected []anomaly
+	}{
+		{
+			name: "no changes",
+			previous: &capacitySnapshot{
+				capacity: 1000,
+				nodes:    map[lightning.NodeID]btcutil.Amount{"a": 1000},
+			},
+			current: &capacitySnapshot{
+				capacity: 1000,
+				nodes:    map[lightning.NodeID]btcutil.Amount{"a": 1000},
+			},
+		},
+		{
+			name: "changes below thresholds",
+			previous: &capacitySnapshot{
+				capacity: 1000,
+				nodes:    map[lightning.NodeID]btcutil.Amount{"a": 1000},
+			},
+			current: &capacitySnapshot{
+				capacity: 1100,
+				nodes:    map[lightning.NodeID]btcutil.Amount{"a": 1400},
+			},
+		},
+		{
+			name: "network capacity drop",
+			previous: &capacitySnapshot{
+				capacity: 1000,
+			},
+			current: &capacitySnapshot{
+				capacity: 800,
+			},
+			expected: []anomaly{
+				{anomalyType: NetworkCapacityAnomaly},
+			},
+		},
+		{
+			name: "node channels closed",
+			previous: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"a": 1000,
+					"b": 9000,
+				},
+			},
+			current: &capacitySnapshot{
+				capacity: 9000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"b": 9000,
+				},
+			},
+			expected: []anomaly{
+				{anomalyType: NodeCapacityAnomaly, nodeID: "a"},
+			},
+		},
+		{
+			name: "small node is ignored",
+			previous: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"a": 50,
+					"b": 9950,
+				},
+			},
+			current: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"a": 10,
+					"b": 9990,
+				},
+			},
+		},
+		{
+			name: "small node became big",
+			previous: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"a": 50,
+					"b": 9950,
+				},
+			},
+			current: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"a": 1000,
+					"b": 9000,
+				},
+			},
+			expected: []anomaly{
+				{anomalyType: NodeCapacityAnomaly, nodeID: "a"},
+			},
+		},
+		{
+			name: "big node appeared",
+			previous: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"b": 10000,
+				},
+			},
+			current: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"a": 1000,
+					"b": 9000,
+				},
+			},
+			expected: []anomaly{
+				{anomalyType: NodeCapacityAnomaly, nodeID: "a"},
+			},
+		},
+		{
+			name: "small node appeared",
+			previous: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"b": 10000,
+				},
+			},
+			current: &capacitySnapshot{
+				capacity: 10000,
+				nodes: map[lightning.NodeID]btcutil.Amount{
+					"a": 50,
+					"b": 9950,
+				},
+			},
+		},
+		{
+			name: "empty previous graph",
+			previous: &capacitySnapshot{
+				capacity: 0,
+			},
+			current: &capacitySnapshot{
+				capacity: 1000,
+				nodes:    map[lightning.NodeID]btcutil.Amount{"a": 1000},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		events := detector.capacityAnomalies(test.previous, test.current)
+
+		if len(events) != len(test.expected) {
+			t.Fatalf("(%v) wrong number of anomalies: %v", test.name,
+				len(events))
+		}
+
+		for i, event := range events {
+			if event.Type != test.expected[i].anomalyType ||
+				event.NodeID != test.expected[i].nodeID {
+				t.Fatalf("(%v) wrong anomaly: %v(%v)", test.name,
+					event.Type, event.NodeID)
+			}
+		}
+	}
+}
+
+func TestUptimeAnomalies(t *testing.T) {
+	detector := newAnomalyDetector(newTestAnomalyConfig())
+	health := newHealthTracker(healthWindow)
+
+	// Node "a" is online all the time, node "b" is online during the
+	// first 900 seconds, and node "c" is online only in the recent window.
+	for now := int64(0); now <= 1000; now += 10 {
+		connected := []lightning.NodeID{"a"}
+		if now < 900 {
+			connected = append(connected, "b")
+		} else {
+			connected = append(connected, "c")
+		}
+
+		health.addSample(connected, now)
+	}
+
+	events := detector.uptimeAnomalies(health,
+		[]lightning.NodeID{"a", "b", "c", "d"}, 1000)
+
+	if len(events) != 1 {
+		t.Fatalf("wrong number of anomalies: %v", len(events))
+	}
+
+	if events[0].Type != NodeUptimeAnomaly || events[0].NodeID != "b" {
+		t.Fatalf("wrong anomaly: %v(%v)", events[0].Type,
+			events[0].NodeID)
+	}
+
+	if events[0].Current != 0 {
+		t.Fatalf("wrong recent uptime: %v", events[0].Current)
+	}
+}
+
+func TestAddEvent(t *testing.T) {
+	detector := newAnomalyDetector(newTestAnomalyConfig())
+
+	window := time.Second * 100
+	event := func(nodeID lightning.NodeID, moment int64) *AnomalyEvent {
+		return &AnomalyEvent{
+			Type:   NodeCapacityAnomaly,
+			NodeID: nodeID,
+			Time:   moment,
+		}
+	}
+
+	if !detector.addEvent(event("a", 0), window) {
+		t.Fatalf("first event should be added")
+	}
+
+	if detector.addEvent(event("a", 50), window) {
+		t.Fatalf("repeated event within window shouldn't be added")
+	}
+
+	if !detector.addEvent(event("b", 50), window) {
+		t.Fatalf("event of other node should be added")
+	}
+
+	if !detector.addEvent(event("a", 100), window) {
+		t.Fatalf("event after window should be added")
+	}
+
+	if len(detector.events) != 3 {
+		t.Fatalf("wrong number of events: %v", len(detector.events))
+	}
+}
+
+func TestRestoreAnomalies(t *testing.T) {
+	detector := newAnomalyDetector(newTestAnomalyConfig())
+
+	window := time.Second * 100
+	detector.restore([]*AnomalyEvent{
+		{Type: NodeCapacityAnomaly, NodeID: "a", Time: 0},
+		{Type: NodeUptimeAnomaly, NodeID: "b", Time: 10},
+	})
+
+	if len(detector.events) != 2 {
+		t.Fatalf("wrong number of events: %v", len(detector.events))
+	}
+
+	if detector.addEvent(&AnomalyEvent{
+		Type:   NodeCapacityAnomaly,
+		NodeID: "a",
+		Time:   50,
+	}, window) {
+		t.Fatalf("restored event should be reported only once " +
+			"within window")
+	}
+
+	if !detector.addEvent(&AnomalyEvent{
+		Type:   NodeCapacityAnomaly,
+		NodeID: "b",
+		Time:   50,
+	}, window) {
+		t.Fatalf("event of other type should be added")
+	}
+}
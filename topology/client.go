@@ -1,26 +1,781 @@
 package topology
 
+import (
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/lightning/lnd/explorer"
+	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/bitlum/hub/metrics/network"
+	"github.com/go-errors/errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// graphSyncPeriod is the period of synchronisation of our graph state with
+// the graph of lightning node, which is needed to catch up with the missed
+// updates.
+const graphSyncPeriod = time.Hour
+
 // Topology responsibilities:
 //
 // 0. Ask lightning client for querying routes and adding metrics to
 // channels and nodes, so that decision about better path could be made
 // wiser.
 //
-// 1. Store historical changes of lighting network topology:
-// 	1.1 Overall channel change over time.
+//  1. Store historical changes of lighting network topology:
+//     1.1 Overall channel change over time.
+//
+//  2. Derive additional metrics about nodes healthiness.
+//     2.1 Percentage of time node being online.
+//     2.2 Spam rate of node.
+//     2.3 Last time node being active.
 //
-// 2. Derive additional metrics about nodes healthiness.
-// 	2.1 Percentage of time node being online.
-// 	2.2 Spam rate of node.
-// 	2.3 Last time node being active.
+//  3. Derive anomalies:
+//     3.1 Drastic network capacity increase / decrease.
+//     3.2 Drastic particular nodes capacity increase / decrease.
+//     3.3 Drastic online percentage change for nodes known to us.
 //
-// 3. Derive anomalies:
-//	3.1 Drastic network capacity increase / decrease.
-//	3.2 Drastic particular nodes capacity increase / decrease.
-//	3.3 Drastic online percentage change for nodes known to us.
+//  4. Derive additional general metrics about network:
+//     4.1 Average channel time.
+//     4.2 Average channel open / close fee.
+//     4.3 Average channel routing fees.
+type Topology struct {
+	started  int32
+	shutdown int32
+	wg       sync.WaitGroup
+	quit     chan struct{}
+
+	cfg *Config
+
+	// graph is the current state of the lightning network graph, which is
+	// used to find out whether received update actually changes something.
+	//
+	// NOTE: Should be modified only by the main goroutine after start,
+	// under the graph mutex.
+	graphMtx sync.RWMutex
+	graph    *Graph
+
+	// closedChannels is the channels which were closed since the last
+	// statistics calculation.
+	//
+	// NOTE: Should be used under the graph mutex.
+	closedChannels []*db.ChannelState
+
+	// health keeps track of connection state and gossip activity of the
+	// nodes.
+	health *healthTracker
+
+	// anomalies detects anomalies of the network and keeps their history.
+	anomalies *anomalyDetector
+
+	// fees fetches and caches fees of channels funding and closing
+	// transactions.
+	//
+	// NOTE: Should be used only by the statistics goroutine.
+	fees *feeFetcher
+
+	// closedStats accumulates statistics of closed channels.
+	//
+	// NOTE: Should be used only by the statistics goroutine.
+	closedStats *closedAccumulator
+
+	// stats is the last calculated economics statistics of the network.
+	statsMtx sync.RWMutex
+	stats    *NetworkStats
+}
+
+// Config is the config of topology subsystem.
+type Config struct {
+	// Client is the entity which gives us glimpse of information about
+	// lightning network graph.
+	Client lightning.Client
+
+	// Explorer is used to fetch fees of channels funding and closing
+	// transactions.
+	Explorer explorer.Explorer
+
+	// Streamer is used to receive lightning network graph updates.
+	Streamer lightning.UpdatesStreamer
+
+	// Storage is used to persist the history of graph changes.
+	Storage Storage
+
+	// MetricsBackend is used to send metrics about state of hub in the
+	// monitoring subsystem.
+	MetricsBackend crypto.MetricsBackend
+
+	// NetworkMetricsBackend is used to report healthiness of important
+	// nodes in the monitoring subsystem.
+	NetworkMetricsBackend network.MetricsBackend
+
+	// ImportantNodes returns the list of nodes which are important for us,
+	// they are tracked even if they are not connected to us, and their
+	// healthiness is reported in the monitoring subsystem.
+	ImportantNodes func() []lightning.NodeID
+
+	// Anomaly is the thresholds and windows of anomaly detectors.
+	Anomaly *AnomalyConfig
+
+	Asset string
+}
+
+// validate check that config is valid.
+func (c *Config) validate() error {
+	if c.Client == nil {
+		return errors.New("lightning client should be specified")
+	}
+
+	if c.Explorer == nil {
+		return errors.New("explorer should be specified")
+	}
+
+	if c.Streamer == nil {
+		return errors.New("updates streamer should be specified")
+	}
+
+	if c.Storage == nil {
+		return errors.New("storage should be specified")
+	}
+
+	if c.MetricsBackend == nil {
+		return errors.New("metric backend should be specified")
+	}
+
+	if c.NetworkMetricsBackend == nil {
+		return errors.New("network metric backend should be specified")
+	}
+
+	if c.ImportantNodes == nil {
+		return errors.New("important nodes func should be specified")
+	}
+
+	if c.Anomaly == nil {
+		return errors.New("anomaly config should be specified")
+	}
+
+	if err := c.Anomaly.validate(); err != nil {
+		return errors.Errorf("anomaly config is invalid: %v", err)
+	}
+
+	if c.Asset == "" {
+		return errors.New("asset should be specified")
+	}
+
+	return nil
+}
+
+// NewTopology creates new instance of topology subsystem.
+func NewTopology(cfg *Config) (*Topology, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, errors.Errorf("config is invalid: %v", err)
+	}
+
+	return &Topology{
+		quit:        make(chan struct{}),
+		cfg:         cfg,
+		health:      newHealthTracker(healthWindow),
+		anomalies:   newAnomalyDetector(cfg.Anomaly),
+		fees:        newFeeFetcher(cfg.Explorer),
+		closedStats: newClosedAccumulator(),
+	}, nil
+}
+
+// Start restores the last known state of the graph, synchronises it with
+// the graph of lightning node, and launches goroutines which save graph
+// updates in the history and calculate network economics statistics.
+func (t *Topology) Start() error {
+	if !atomic.CompareAndSwapInt32(&t.started, 0, 1) {
+		log.Warn("Topology already started")
+		return nil
+	}
+
+	graph, closed, err := t.restoreGraph(time.Now().Unix())
+	if err != nil {
+		return errors.Errorf("unable restore graph: %v", err)
+	}
+	t.graph = graph
+
+	// Channels closed before the restart are accounted in the closed
+	// channels statistics during the first calculation.
+	t.closedChannels = closed
+
+	// Seed anomaly detector with the graph at the beginning of the
+	// capacity window, so that capacity anomalies could be detected right
+	// after the restart.
+	windowStart := time.Now().Unix() -
+		int64(t.cfg.Anomaly.CapacityWindow.Seconds())
+	previous, err := t.GraphAt(windowStart)
+	if err != nil {
+		return errors.Errorf("unable restore previous graph: %v", err)
+	}
+
+	if len(previous.Channels) != 0 {
+		t.anomalies.addSnapshot(newCapacitySnapshot(previous))
+	}
+
+	if err := t.restoreHealth(); err != nil {
+		return errors.Errorf("unable restore nodes health: %v", err)
+	}
+
+	if err := t.restoreAnomalies(); err != nil {
+		return errors.Errorf("unable restore anomalies: %v", err)
+	}
+
+	// Subscribe before the synchronisation, so that we wouldn't lose
+	// updates which happen in between.
+	receiver := t.cfg.Streamer.RegisterOnUpdates()
+
+	if err := t.syncGraph(); err != nil {
+		receiver.Stop()
+		return errors.Errorf("unable sync graph: %v", err)
+	}
+
+	if err := t.samplePeers(); err != nil {
+		receiver.Stop()
+		return errors.Errorf("unable sample peers: %v", err)
+	}
+
+	t.wg.Add(1)
+	go func() {
+		syncTicker := time.NewTicker(graphSyncPeriod)
+		sampleTicker := time.NewTicker(peersSamplePeriod)
+		anomalyTicker := time.NewTicker(anomalyCheckPeriod)
+		announcementsTicker := time.NewTicker(announcementsSavePeriod)
+
+		defer func() {
+			log.Info("Stopped graph history goroutine")
+			t.wg.Done()
+
+			syncTicker.Stop()
+			sampleTicker.Stop()
+			anomalyTicker.Stop()
+			announcementsTicker.Stop()
+			receiver.Stop()
+		}()
+
+		log.Info("Started graph history goroutine")
+
+		for {
+			select {
+			case update, ok := <-receiver.Read():
+				if !ok {
+					return
+				}
+
+				if err := t.applyUpdate(update); err != nil {
+					log.Errorf("unable to apply graph update: %v", err)
+					continue
+				}
+			case <-syncTicker.C:
+				if err := t.pruneHealth(); err != nil {
+					log.Errorf("unable to prune peer events: %v", err)
+				}
+
+				if err := t.syncGraph(); err != nil {
+					log.Errorf("unable to sync graph: %v", err)
+					continue
+				}
+			case <-sampleTicker.C:
+				if err := t.samplePeers(); err != nil {
+					log.Errorf("unable to sample peers: %v", err)
+					continue
+				}
+			case <-anomalyTicker.C:
+				t.detectAnomalies()
+			case <-announcementsTicker.C:
+				if err := t.saveAnnouncements(); err != nil {
+					log.Errorf("unable to save announcements: %v", err)
+				}
+			case <-t.quit:
+				if err := t.saveAnnouncements(); err != nil {
+					log.Errorf("unable to save announcements: %v", err)
+				}
+				return
+			}
+		}
+	}()
+
+	t.wg.Add(1)
+	go func() {
+		statsTicker := time.NewTicker(statsPeriod)
+
+		defer func() {
+			log.Info("Stopped network stats goroutine")
+			t.wg.Done()
+
+			statsTicker.Stop()
+		}()
+
+		log.Info("Started network stats goroutine")
+
+		for {
+			if err := t.calculateStats(); err != nil {
+				log.Errorf("unable to calculate network stats: %v", err)
+			}
+
+			select {
+			case <-statsTicker.C:
+			case <-t.quit:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully stops the topology subsystem.
+func (t *Topology) Stop(reason string) {
+	if !atomic.CompareAndSwapInt32(&t.shutdown, 0, 1) {
+		log.Warn("Topology already shutdown")
+		return
+	}
+
+	close(t.quit)
+	t.wg.Wait()
+
+	log.Infof("Topology shutdown, reason(%v)", reason)
+}
+
+// GraphAt returns the state of the lightning network graph at the given
+// moment of time.
+func (t *Topology) GraphAt(moment int64) (*Graph, error) {
+	graph, _, err := t.restoreGraph(moment)
+	return graph, err
+}
+
+// restoreGraph restores the state of the lightning network graph at the
+// given moment of time, and returns the last states of the channels which
+// were closed before it.
+func (t *Topology) restoreGraph(moment int64) (*Graph, []*db.ChannelState,
+	error) {
+
+	nodes, err := t.cfg.Storage.LatestNodeStates(moment)
+	if err != nil {
+		return nil, nil, errors.Errorf("unable fetch node states: %v", err)
+	}
+
+	channels, err := t.cfg.Storage.LatestChannelStates(moment)
+	if err != nil {
+		return nil, nil, errors.Errorf("unable fetch channel states: %v",
+			err)
+	}
+
+	var closed []*db.ChannelState
+	for _, channel := range channels {
+		if channel.Closed {
+			closed = append(closed, channel)
+		}
+	}
+
+	graph := newGraph(moment)
+	graph.apply(nodes, channels)
+	return graph, closed, nil
+}
+
+// statsGraph returns the copy of the current graph, and the channels which
+// were closed since the previous call.
+func (t *Topology) statsGraph(now int64) (*Graph, []*db.ChannelState) {
+	t.graphMtx.Lock()
+	defer t.graphMtx.Unlock()
+
+	graph := newGraph(now)
+	for nodeID, node := range t.graph.Nodes {
+		graph.Nodes[nodeID] = node
+	}
+
+	for shortChannelID, channel := range t.graph.Channels {
+		graph.Channels[shortChannelID] = channel
+	}
+
+	closed := t.closedChannels
+	t.closedChannels = nil
+
+	return graph, closed
+}
+
+// NodeCapacityHistory returns the history of changes of the given node
+// channels capacity within the given period.
+func (t *Topology) NodeCapacityHistory(nodeID lightning.NodeID, start,
+	end int64) ([]*CapacityPoint, error) {
+
+	channels, err := t.cfg.Storage.NodeChannelStates(nodeID, start, end)
+	if err != nil {
+		return nil, errors.Errorf("unable fetch channel states: %v", err)
+	}
+
+	return capacityHistory(nodeID, channels, start), nil
+}
+
+// NodeHealth returns healthiness metrics of the given node.
+func (t *Topology) NodeHealth(nodeID lightning.NodeID) *NodeHealth {
+	return t.health.health(nodeID, time.Now().Unix())
+}
+
+// NodesHealth returns healthiness metrics of all nodes which were connected
+// to us or sent gossip announcements within the health window.
+func (t *Topology) NodesHealth() []*NodeHealth {
+	now := time.Now().Unix()
+
+	var nodes []*NodeHealth
+	for _, nodeID := range t.health.nodes() {
+		nodes = append(nodes, t.health.health(nodeID, now))
+	}
+
+	return nodes
+}
+
+// samplePeers samples connection state of the peers, and reports the
+// healthiness of important nodes in the monitoring subsystem.
+func (t *Topology) samplePeers() error {
+	m := crypto.NewMetric(t.cfg.Asset, common.GetFunctionName(),
+		t.cfg.MetricsBackend)
+	defer m.Finish()
+
+	peers, err := t.cfg.Client.ConnectedPeers()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch connected peers: %v", err)
+	}
+
+	now := time.Now().Unix()
+	connects, disconnects := t.health.addSample(peers, now)
+
+	for _, nodeID := range connects {
+		if err := t.savePeerEvent(nodeID, db.PeerConnected, now); err != nil {
+			m.AddError(metrics.HighSeverity)
+			log.Errorf("unable to save peer event: %v", err)
+		}
+	}
+
+	for _, nodeID := range disconnects {
+		err := t.savePeerEvent(nodeID, db.PeerDisconnected, now)
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			log.Errorf("unable to save peer event: %v", err)
+		}
+	}
+
+	for _, nodeID := range t.cfg.ImportantNodes() {
+		health := t.health.health(nodeID, now)
+
+		// If node has never been seen, than it is offline since we
+		// started tracking.
+		lastSeen := health.LastSeen
+		if lastSeen == 0 {
+			lastSeen = t.health.trackingStart
+		}
+
+		t.cfg.NetworkMetricsBackend.NodeUptime(t.cfg.Asset, string(nodeID),
+			health.Uptime)
+		t.cfg.NetworkMetricsBackend.NodeLastSeen(t.cfg.Asset,
+			string(nodeID), float64(now-lastSeen))
+	}
+
+	return nil
+}
+
+// restoreHealth restores connection state and gossip activity of the
+// nodes from the saved peer events.
 //
-// 4. Derive additional general metrics about network:
-// 	4.1 Average channel time.
-// 	4.2 Average channel open / close fee.
-// 	4.3 Average channel routing fees.
-type Topology struct{}
+// NOTE: Connection sessions which were open at the moment of shutdown are
+// restored as open, and are closed by the first peers sample, so that
+// downtime of the hub is counted as uptime of such nodes.
+func (t *Topology) restoreHealth() error {
+	if err := t.pruneHealth(); err != nil {
+		return err
+	}
+
+	events, err := t.cfg.Storage.PeerEvents()
+	if err != nil {
+		return errors.Errorf("unable fetch peer events: %v", err)
+	}
+
+	announcements, err := t.cfg.Storage.NodeAnnouncements()
+	if err != nil {
+		return errors.Errorf("unable fetch node announcements: %v", err)
+	}
+
+	t.health.restore(events, announcements, time.Now().Unix())
+	return nil
+}
+
+// pruneHealth removes peer events and numbers of node announcements which
+// are out of the health window.
+func (t *Topology) pruneHealth() error {
+	windowStart := time.Now().Unix() - int64(healthWindow.Seconds())
+	if err := t.cfg.Storage.RemovePeerEvents(windowStart); err != nil {
+		return errors.Errorf("unable remove peer events: %v", err)
+	}
+
+	err := t.cfg.Storage.RemoveNodeAnnouncements(windowStart -
+		announcementsDay)
+	if err != nil {
+		return errors.Errorf("unable remove node announcements: %v", err)
+	}
+
+	return nil
+}
+
+// saveAnnouncements saves numbers of node announcements which were changed
+// since the previous save.
+func (t *Topology) saveAnnouncements() error {
+	unsaved := t.health.unsavedAnnouncements()
+	if len(unsaved) == 0 {
+		return nil
+	}
+
+	if err := t.cfg.Storage.SaveNodeAnnouncements(unsaved); err != nil {
+		t.health.markUnsaved(unsaved)
+		return errors.Errorf("unable save node announcements: %v", err)
+	}
+
+	return nil
+}
+
+// savePeerEvent saves the event, which is used to restore node health
+// after the restart.
+func (t *Topology) savePeerEvent(nodeID lightning.NodeID,
+	eventType db.PeerEventType, moment int64) error {
+
+	return t.cfg.Storage.AddPeerEvent(&db.PeerEvent{
+		NodeID: nodeID,
+		Type:   eventType,
+		Time:   moment,
+	})
+}
+
+// syncGraph fetches the graph of lightning node, and saves the difference
+// with our last known state in the history. This is needed to catch up
+// with the changes which happened while we were offline, or which were
+// missed by the update subscription.
+func (t *Topology) syncGraph() error {
+	m := crypto.NewMetric(t.cfg.Asset, common.GetFunctionName(),
+		t.cfg.MetricsBackend)
+	defer m.Finish()
+
+	graph, err := t.cfg.Client.DescribeGraph()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable describe graph: %v", err)
+	}
+
+	now := time.Now().Unix()
+
+	for _, node := range graph.Nodes {
+		err := t.updateNode(&db.NodeState{
+			NodeID:    node.NodeID,
+			Alias:     node.Alias,
+			Addresses: node.Addresses,
+			Time:      now,
+		})
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return err
+		}
+	}
+
+	known := make(map[uint64]struct{}, len(graph.Channels))
+	for _, channel := range graph.Channels {
+		known[channel.ShortChannelID] = struct{}{}
+
+		err := t.updateChannel(&db.ChannelState{
+			ShortChannelID: channel.ShortChannelID,
+			ChannelID:      channel.ChannelID,
+			Node1:          channel.Node1,
+			Node2:          channel.Node2,
+			Capacity:       channel.Capacity,
+			Node1Policy:    channel.Node1Policy,
+			Node2Policy:    channel.Node2Policy,
+			Time:           now,
+		})
+		if err != nil {
+			m.AddError(metrics.HighSeverity)
+			return err
+		}
+	}
+
+	// Channels which are not in the graph anymore were closed while we
+	// were not watching.
+	for shortChannelID, channel := range t.graph.Channels {
+		if _, ok := known[shortChannelID]; ok {
+			continue
+		}
+
+		closed := *channel
+		closed.Closed = true
+		closed.Time = now
+
+		if err := t.updateChannel(&closed); err != nil {
+			m.AddError(metrics.HighSeverity)
+			return err
+		}
+	}
+
+	log.Infof("Graph synced, nodes(%v), channels(%v), capacity(%v)",
+		len(t.graph.Nodes), len(t.graph.Channels), t.graph.Capacity())
+
+	return nil
+}
+
+// applyUpdate converts lightning network graph update to the node or
+// channel state, and saves it if it differs from the current one. Peer
+// connection updates and gossip announcements are registered in the
+// health tracker, connection updates are saved as peer events, and
+// announcements are saved periodically.
+func (t *Topology) applyUpdate(update interface{}) error {
+	switch u := update.(type) {
+	case *lightning.UpdatePeerConnected:
+		if !t.health.addConnect(u.NodeID, u.Time) {
+			return nil
+		}
+
+		return t.savePeerEvent(u.NodeID, db.PeerConnected, u.Time)
+
+	case *lightning.UpdatePeerDisconnected:
+		if !t.health.addDisconnect(u.NodeID, u.Time) {
+			return nil
+		}
+
+		return t.savePeerEvent(u.NodeID, db.PeerDisconnected, u.Time)
+
+	case *lightning.UpdateGraphNode:
+		t.health.addAnnouncement(u.NodeID, u.LastUpdate)
+
+		return t.updateNode(&db.NodeState{
+			NodeID:    u.NodeID,
+			Alias:     u.Alias,
+			Addresses: u.Addresses,
+			Time:      u.LastUpdate,
+		})
+
+	case *lightning.UpdateGraphChannel:
+		t.health.addAnnouncement(u.AdvertisingNode, u.Time)
+
+		var state db.ChannelState
+		if channel, ok := t.graph.Channels[u.ShortChannelID]; ok {
+			state = *channel
+		} else {
+			// Node order in the channel is determined by the order of
+			// their public keys.
+			state.ShortChannelID = u.ShortChannelID
+			state.Node1, state.Node2 = u.AdvertisingNode, u.ConnectingNode
+			if state.Node1 > state.Node2 {
+				state.Node1, state.Node2 = state.Node2, state.Node1
+			}
+		}
+
+		if u.ChannelID != "" {
+			state.ChannelID = u.ChannelID
+		}
+
+		state.Capacity = u.Capacity
+		state.Time = u.Time
+
+		switch u.AdvertisingNode {
+		case state.Node1:
+			state.Node1Policy = u.Policy
+		case state.Node2:
+			state.Node2Policy = u.Policy
+		default:
+			return errors.Errorf("advertising node(%v) doesn't belong to "+
+				"channel(%v)", u.AdvertisingNode, u.ShortChannelID)
+		}
+
+		return t.updateChannel(&state)
+
+	case *lightning.UpdateGraphChannelClosed:
+		channel, ok := t.graph.Channels[u.ShortChannelID]
+		if !ok {
+			return nil
+		}
+
+		closed := *channel
+		closed.Closed = true
+		closed.ClosedHeight = u.ClosedHeight
+		closed.Time = u.Time
+
+		return t.updateChannel(&closed)
+	}
+
+	return nil
+}
+
+// updateNode saves node state in the history, if it differs from the
+// current one.
+func (t *Topology) updateNode(state *db.NodeState) error {
+	current, ok := t.graph.Nodes[state.NodeID]
+	if ok && current.Alias == state.Alias &&
+		equalStrings(current.Addresses, state.Addresses) {
+		return nil
+	}
+
+	if err := t.cfg.Storage.AddNodeState(state); err != nil {
+		return errors.Errorf("unable save node(%v) state: %v",
+			state.NodeID, err)
+	}
+
+	t.graphMtx.Lock()
+	t.graph.apply([]*db.NodeState{state}, nil)
+	t.graphMtx.Unlock()
+
+	return nil
+}
+
+// updateChannel saves channel state in the history, if it differs from the
+// current one.
+func (t *Topology) updateChannel(state *db.ChannelState) error {
+	current, ok := t.graph.Channels[state.ShortChannelID]
+	if !ok && state.Closed {
+		return nil
+	}
+
+	if ok && !state.Closed && current.ChannelID == state.ChannelID &&
+		current.Capacity == state.Capacity &&
+		current.Node1 == state.Node1 && current.Node2 == state.Node2 &&
+		equalPolicies(current.Node1Policy, state.Node1Policy) &&
+		equalPolicies(current.Node2Policy, state.Node2Policy) {
+		return nil
+	}
+
+	if err := t.cfg.Storage.AddChannelState(state); err != nil {
+		return errors.Errorf("unable save channel(%v) state: %v",
+			state.ShortChannelID, err)
+	}
+
+	t.graphMtx.Lock()
+	t.graph.apply(nil, []*db.ChannelState{state})
+	if state.Closed {
+		t.closedChannels = append(t.closedChannels, state)
+	}
+	t.graphMtx.Unlock()
+
+	return nil
+}
+
+// equalPolicies checks that fee policies are the same.
+func equalPolicies(a, b *lightning.FeePolicy) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// equalStrings checks that string slices are the same.
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,140 @@
+package topology
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/btcsuite/btcutil"
+	"sort"
+)
+
+// Graph is the state of lightning network graph at the particular moment
+// of time.
+type Graph struct {
+	Time int64
+
+	Nodes    map[lightning.NodeID]*db.NodeState
+	Channels map[uint64]*db.ChannelState
+}
+
+// newGraph creates empty graph.
+func newGraph(time int64) *Graph {
+	return &Graph{
+		Time:     time,
+		Nodes:    make(map[lightning.NodeID]*db.NodeState),
+		Channels: make(map[uint64]*db.ChannelState),
+	}
+}
+
+// Capacity returns the overall capacity of the channels in the graph.
+func (g *Graph) Capacity() btcutil.Amount {
+	var capacity btcutil.Amount
+	for _, channel := range g.Channels {
+		capacity += channel.Capacity
+	}
+
+	return capacity
+}
+
+// NodeChannels returns the channels of the given node.
+func (g *Graph) NodeChannels(nodeID lightning.NodeID) []*db.ChannelState {
+	var channels []*db.ChannelState
+	for _, channel := range g.Channels {
+		if channel.Node1 == nodeID || channel.Node2 == nodeID {
+			channels = append(channels, channel)
+		}
+	}
+
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].ShortChannelID < channels[j].ShortChannelID
+	})
+
+	return channels
+}
+
+// apply applies the node and channel states to the graph. States should
+// be sorted by time.
+func (g *Graph) apply(nodes []*db.NodeState, channels []*db.ChannelState) {
+	for _, state := range nodes {
+		g.Nodes[state.NodeID] = state
+	}
+
+	for _, state := range channels {
+		if state.Closed {
+			delete(g.Channels, state.ShortChannelID)
+			continue
+		}
+
+		g.Channels[state.ShortChannelID] = state
+	}
+}
+
+// CapacityPoint is the capacity of the node channels at the particular
+// moment of time.
+type CapacityPoint struct {
+	Time        int64
+	NumChannels int
+	Capacity    btcutil.Amount
+}
+
+// capacityHistory returns the history of changes of the node channels
+// capacity within the given period. The first point is the state at the
+// beginning of the period. Channel states should be sorted by time.
+func capacityHistory(nodeID lightning.NodeID, channels []*db.ChannelState,
+	start int64) []*CapacityPoint {
+
+	var (
+		points   []*CapacityPoint
+		capacity btcutil.Amount
+	)
+
+	nodeChannels := make(map[uint64]btcutil.Amount)
+	addPoint := func(pointTime int64) {
+		point := &CapacityPoint{
+			Time:        pointTime,
+			NumChannels: len(nodeChannels),
+			Capacity:    capacity,
+		}
+
+		// Merge changes which happened at the same moment, and skip the
+		// changes which don't affect the capacity, i.e. policy updates.
+		if len(points) != 0 {
+			last := points[len(points)-1]
+			if last.Time == pointTime {
+				points[len(points)-1] = point
+				return
+			}
+
+			if last.NumChannels == point.NumChannels &&
+				last.Capacity == point.Capacity {
+				return
+			}
+		}
+
+		points = append(points, point)
+	}
+
+	addPoint(start)
+
+	for _, state := range channels {
+		if state.Node1 != nodeID && state.Node2 != nodeID {
+			continue
+		}
+
+		capacity -= nodeChannels[state.ShortChannelID]
+		delete(nodeChannels, state.ShortChannelID)
+
+		if !state.Closed {
+			capacity += state.Capacity
+			nodeChannels[state.ShortChannelID] = state.Capacity
+		}
+
+		pointTime := state.Time
+		if pointTime < start {
+			pointTime = start
+		}
+
+		addPoint(pointTime)
+	}
+
+	return points
+}
@@ -0,0 +1,452 @@
+package topology
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"sync"
+	"time"
+)
+
+const (
+	// healthWindow is the rolling window over which uptime, number of
+	// disconnects and announcements rate of the node are calculated.
+	healthWindow = time.Hour * 24 * 7
+
+	// peersSamplePeriod is the period of sampling of the connected peers,
+	// which is needed to catch up with the missed connection updates.
+	peersSamplePeriod = time.Minute
+
+	// announcementsSavePeriod is the period of saving of the numbers of
+	// gossip announcements, which are counted in memory, because gossip
+	// of the whole network is too intensive to save every announcement.
+	announcementsSavePeriod = time.Minute * 10
+
+	// announcementsDay is the size of the time bucket in which gossip
+	// announcements of the node are counted, in seconds.
+	announcementsDay = int64(24 * time.Hour / time.Second)
+)
+
+// NodeHealth is the set of metrics which describe healthiness of the node.
+type NodeHealth struct {
+	NodeID lightning.NodeID
+
+	// IsConnected is true if node was connected to us during the last
+	// sample.
+	IsConnected bool
+
+	// Uptime is the ratio of time during which node was connected to us,
+	// within the health window, or since we started tracking.
+	Uptime float64
+
+	// LastSeen is the last time when node was connected to us, zero if
+	// node has never been seen.
+	LastSeen int64
+
+	// Disconnects is number of node disconnects within the health window.
+	Disconnects int
+
+	// LastAnnouncement is the time of the last gossip announcement
+	// received from the node, zero if unknown.
+	LastAnnouncement int64
+
+	// AnnouncementsPerDay is the average number of gossip announcements
+	// received from the node per day within the health window, which is
+	// the spam rate of the node.
+	AnnouncementsPerDay float64
+}
+
+// session is the period of time during which node was connected to us.
+type session struct {
+	start int64
+
+	// end is zero while node is connected.
+	end int64
+}
+
+// healthTracker keeps track of connection state and gossip activity of the
+// nodes, within the rolling window.
+type healthTracker struct {
+	sync.Mutex
+
+	window int64
+
+	// trackingStart is the time of the first sample or connection event.
+	trackingStart int64
+
+	sessions    map[lightning.NodeID][]*session
+	disconnects map[lightning.NodeID][]int64
+	lastSeen    map[lightning.NodeID]int64
+
+	// announcements is the number of node announcements per day.
+	announcements map[lightning.NodeID]map[int64]*db.NodeAnnouncements
+
+	// unsaved is the set of announcement numbers which were changed since
+	// they were saved last time.
+	unsaved map[*db.NodeAnnouncements]struct{}
+}
+
+// newHealthTracker creates new health tracker with the given window.
+func newHealthTracker(window time.Duration) *healthTracker {
+	return &healthTracker{
+		window:        int64(window.Seconds()),
+		sessions:      make(map[lightning.NodeID][]*session),
+		disconnects:   make(map[lightning.NodeID][]int64),
+		lastSeen:      make(map[lightning.NodeID]int64),
+		announcements: make(map[lightning.NodeID]map[int64]*db.NodeAnnouncements),
+		unsaved:       make(map[*db.NodeAnnouncements]struct{}),
+	}
+}
+
+// addSample updates connection state of the nodes accordingly with the
+// list of the currently connected peers. Node which was connected during
+// the previous sample, but is not connected now, is considered as
+// disconnected. Returns the nodes which connection state has changed.
+func (h *healthTracker) addSample(connected []lightning.NodeID,
+	now int64) ([]lightning.NodeID, []lightning.NodeID) {
+
+	h.Lock()
+	defer h.Unlock()
+
+	var connects, disconnects []lightning.NodeID
+
+	isConnected := make(map[lightning.NodeID]struct{}, len(connected))
+	for _, nodeID := range connected {
+		isConnected[nodeID] = struct{}{}
+		h.lastSeen[nodeID] = now
+
+		if h.connect(nodeID, now) {
+			connects = append(connects, nodeID)
+		}
+	}
+
+	for nodeID := range h.sessions {
+		if _, ok := isConnected[nodeID]; ok {
+			continue
+		}
+
+		if h.disconnect(nodeID, now) {
+			disconnects = append(disconnects, nodeID)
+		}
+	}
+
+	h.prune(now)
+	return connects, disconnects
+}
+
+// addConnect registers connection of the node, returns false if node is
+// already known to be connected.
+func (h *healthTracker) addConnect(nodeID lightning.NodeID, now int64) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	h.lastSeen[nodeID] = now
+	return h.connect(nodeID, now)
+}
+
+// addDisconnect registers disconnection of the node, returns false if node
+// is not known to be connected.
+func (h *healthTracker) addDisconnect(nodeID lightning.NodeID,
+	now int64) bool {
+
+	h.Lock()
+	defer h.Unlock()
+
+	return h.disconnect(nodeID, now)
+}
+
+// connect starts new session of the node, if node isn't connected already.
+//
+// NOTE: Should be called under the lock.
+func (h *healthTracker) connect(nodeID lightning.NodeID, now int64) bool {
+	if h.trackingStart == 0 {
+		h.trackingStart = now
+	}
+
+	sessions := h.sessions[nodeID]
+	if len(sessions) != 0 && sessions[len(sessions)-1].end == 0 {
+		return false
+	}
+
+	h.sessions[nodeID] = append(sessions, &session{start: now})
+	return true
+}
+
+// disconnect ends the current session of the node, if node is connected.
+//
+// NOTE: Should be called under the lock.
+func (h *healthTracker) disconnect(nodeID lightning.NodeID, now int64) bool {
+	if h.trackingStart == 0 {
+		h.trackingStart = now
+	}
+
+	sessions := h.sessions[nodeID]
+	if len(sessions) == 0 || sessions[len(sessions)-1].end != 0 {
+		return false
+	}
+
+	sessions[len(sessions)-1].end = now
+	h.disconnects[nodeID] = append(h.disconnects[nodeID], now)
+	h.lastSeen[nodeID] = now
+	return true
+}
+
+// addAnnouncement registers gossip announcement received from the node.
+func (h *healthTracker) addAnnouncement(nodeID lightning.NodeID,
+	moment int64) {
+
+	h.Lock()
+	defer h.Unlock()
+
+	days, ok := h.announcements[nodeID]
+	if !ok {
+		days = make(map[int64]*db.NodeAnnouncements)
+		h.announcements[nodeID] = days
+	}
+
+	day := moment - moment%announcementsDay
+	announcements, ok := days[day]
+	if !ok {
+		announcements = &db.NodeAnnouncements{
+			NodeID: nodeID,
+			Day:    day,
+		}
+		days[day] = announcements
+	}
+
+	announcements.Count++
+	if moment > announcements.LastAnnouncement {
+		announcements.LastAnnouncement = moment
+	}
+
+	h.unsaved[announcements] = struct{}{}
+}
+
+// unsavedAnnouncements returns copies of the announcement numbers which
+// were changed since the previous call, so that they could be saved.
+func (h *healthTracker) unsavedAnnouncements() []*db.NodeAnnouncements {
+	h.Lock()
+	defer h.Unlock()
+
+	unsaved := make([]*db.NodeAnnouncements, 0, len(h.unsaved))
+	for announcements := range h.unsaved {
+		announcementsCopy := *announcements
+		unsaved = append(unsaved, &announcementsCopy)
+	}
+
+	h.unsaved = make(map[*db.NodeAnnouncements]struct{})
+	return unsaved
+}
+
+// markUnsaved marks announcement numbers of the given nodes and days as
+// unsaved, is used if saving has failed.
+func (h *healthTracker) markUnsaved(unsaved []*db.NodeAnnouncements) {
+	h.Lock()
+	defer h.Unlock()
+
+	for _, u := range unsaved {
+		if announcements, ok := h.announcements[u.NodeID][u.Day]; ok {
+			h.unsaved[announcements] = struct{}{}
+		}
+	}
+}
+
+// restore restores the state of the tracker from the saved peer events,
+// which should be sorted by time, and saved numbers of announcements.
+func (h *healthTracker) restore(events []*db.PeerEvent,
+	announcements []*db.NodeAnnouncements, now int64) {
+
+	h.Lock()
+	defer h.Unlock()
+
+	for _, event := range events {
+		switch event.Type {
+		case db.PeerConnected:
+			h.lastSeen[event.NodeID] = event.Time
+			h.connect(event.NodeID, event.Time)
+
+		case db.PeerDisconnected:
+			h.disconnect(event.NodeID, event.Time)
+		}
+	}
+
+	for _, a := range announcements {
+		days, ok := h.announcements[a.NodeID]
+		if !ok {
+			days = make(map[int64]*db.NodeAnnouncements)
+			h.announcements[a.NodeID] = days
+		}
+
+		restored := *a
+		days[a.Day] = &restored
+	}
+
+	h.prune(now)
+}
+
+// prune removes information which is out of the window.
+//
+// NOTE: Should be called under the lock.
+func (h *healthTracker) prune(now int64) {
+	windowStart := now - h.window
+
+	for nodeID, sessions := range h.sessions {
+		var i int
+		for i < len(sessions) && sessions[i].end != 0 &&
+			sessions[i].end < windowStart {
+			i++
+		}
+
+		if i == len(sessions) {
+			delete(h.sessions, nodeID)
+		} else {
+			h.sessions[nodeID] = sessions[i:]
+		}
+	}
+
+	pruneTimes := func(times map[lightning.NodeID][]int64) {
+		for nodeID, nodeTimes := range times {
+			var i int
+			for i < len(nodeTimes) && nodeTimes[i] < windowStart {
+				i++
+			}
+
+			if i == len(nodeTimes) {
+				delete(times, nodeID)
+			} else {
+				times[nodeID] = nodeTimes[i:]
+			}
+		}
+	}
+
+	pruneTimes(h.disconnects)
+
+	// Day of announcements is removed only when it is completely out of
+	// the window.
+	for nodeID, days := range h.announcements {
+		for day, announcements := range days {
+			if day+announcementsDay <= windowStart {
+				delete(days, day)
+				delete(h.unsaved, announcements)
+			}
+		}
+
+		if len(days) == 0 {
+			delete(h.announcements, nodeID)
+		}
+	}
+}
+
+// health returns health metrics of the given node.
+func (h *healthTracker) health(nodeID lightning.NodeID,
+	now int64) *NodeHealth {
+
+	h.Lock()
+	defer h.Unlock()
+
+	windowStart := now - h.window
+	if windowStart < h.trackingStart {
+		windowStart = h.trackingStart
+	}
+
+	health := &NodeHealth{
+		NodeID:      nodeID,
+		LastSeen:    h.lastSeen[nodeID],
+		Disconnects: len(h.disconnects[nodeID]),
+	}
+
+	health.Uptime, health.IsConnected = h.uptime(nodeID, windowStart, now)
+
+	// Announcements are counted per day, so the day which is partially
+	// out of the window is counted completely.
+	var numAnnouncements int
+	for day, announcements := range h.announcements[nodeID] {
+		if day+announcementsDay > now-h.window {
+			numAnnouncements += announcements.Count
+		}
+
+		if announcements.LastAnnouncement > health.LastAnnouncement {
+			health.LastAnnouncement = announcements.LastAnnouncement
+		}
+	}
+
+	windowDays := float64(h.window) / float64(24*time.Hour/time.Second)
+	health.AnnouncementsPerDay = float64(numAnnouncements) / windowDays
+
+	return health
+}
+
+// windowUptime returns the ratio of time during which node was connected to
+// us, within the given window, or since we started tracking.
+func (h *healthTracker) windowUptime(nodeID lightning.NodeID,
+	window time.Duration, now int64) float64 {
+
+	h.Lock()
+	defer h.Unlock()
+
+	windowStart := now - int64(window.Seconds())
+	if windowStart < h.trackingStart {
+		windowStart = h.trackingStart
+	}
+
+	uptime, _ := h.uptime(nodeID, windowStart, now)
+	return uptime
+}
+
+// uptime returns the ratio of time during which node was connected to us
+// since the given time, and whether node is connected now.
+//
+// NOTE: Should be called under the lock.
+func (h *healthTracker) uptime(nodeID lightning.NodeID, windowStart,
+	now int64) (float64, bool) {
+
+	var (
+		covered     int64
+		isConnected bool
+	)
+
+	for _, s := range h.sessions[nodeID] {
+		start, end := s.start, s.end
+		if end == 0 {
+			end = now
+			isConnected = true
+		}
+
+		if start < windowStart {
+			start = windowStart
+		}
+
+		if end > start {
+			covered += end - start
+		}
+	}
+
+	if period := now - windowStart; period > 0 {
+		return float64(covered) / float64(period), isConnected
+	} else if isConnected {
+		return 1, isConnected
+	}
+
+	return 0, isConnected
+}
+
+// nodes returns the list of nodes about which we have any information.
+func (h *healthTracker) nodes() []lightning.NodeID {
+	h.Lock()
+	defer h.Unlock()
+
+	known := make(map[lightning.NodeID]struct{})
+	for nodeID := range h.lastSeen {
+		known[nodeID] = struct{}{}
+	}
+
+	for nodeID := range h.announcements {
+		known[nodeID] = struct{}{}
+	}
+
+	nodes := make([]lightning.NodeID, 0, len(known))
+	for nodeID := range known {
+		nodes = append(nodes, nodeID)
+	}
+
+	return nodes
+}
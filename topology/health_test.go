@@ -0,0 +1,162 @@
+package topology
+
+import (
+	"fmt"
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHealthSample(t *testing.T) {
+	health := newHealthTracker(time.Second * 1000)
+
+	connects, disconnects := health.addSample([]lightning.NodeID{"a", "b"}, 1000)
+	if !reflect.DeepEqual(connects, []lightning.NodeID{"a", "b"}) ||
+		len(disconnects) != 0 {
+		t.Fatalf("wrong changes: %v, %v", connects, disconnects)
+	}
+
+	connects, disconnects = health.addSample([]lightning.NodeID{"a"}, 1100)
+	if len(connects) != 0 ||
+		!reflect.DeepEqual(disconnects, []lightning.NodeID{"b"}) {
+		t.Fatalf("wrong changes: %v, %v", connects, disconnects)
+	}
+
+	a := health.health("a", 1200)
+	if !a.IsConnected || a.Uptime != 1 || a.Disconnects != 0 {
+		t.Fatalf("wrong health of node a: %v", healthString(a))
+	}
+
+	b := health.health("b", 1200)
+	if b.IsConnected || b.Uptime != 0.5 || b.Disconnects != 1 ||
+		b.LastSeen != 1100 {
+		t.Fatalf("wrong health of node b: %v", healthString(b))
+	}
+}
+
+func TestHealthEvents(t *testing.T) {
+	health := newHealthTracker(time.Second * 1000)
+
+	if !health.addConnect("a", 1000) {
+		t.Fatalf("connection should be registered")
+	}
+
+	if health.addConnect("a", 1010) {
+		t.Fatalf("repeated connection shouldn't be registered")
+	}
+
+	if health.addDisconnect("b", 1010) {
+		t.Fatalf("disconnection of unknown node shouldn't be registered")
+	}
+
+	if !health.addDisconnect("a", 1050) {
+		t.Fatalf("disconnection should be registered")
+	}
+
+	if health.addDisconnect("a", 1060) {
+		t.Fatalf("repeated disconnection shouldn't be registered")
+	}
+
+	// Sample shouldn't report disconnection, which has been already
+	// received as event.
+	connects, disconnects := health.addSample(nil, 1100)
+	if len(connects) != 0 || len(disconnects) != 0 {
+		t.Fatalf("wrong changes: %v, %v", connects, disconnects)
+	}
+
+	a := health.health("a", 1100)
+	if a.IsConnected || a.Uptime != 0.5 || a.Disconnects != 1 ||
+		a.LastSeen != 1050 {
+		t.Fatalf("wrong health of node a: %v", healthString(a))
+	}
+}
+
+func TestHealthRestore(t *testing.T) {
+	events := []*db.PeerEvent{
+		{NodeID: "a", Type: db.PeerConnected, Time: 1000},
+		{NodeID: "b", Type: db.PeerConnected, Time: 1000},
+		{NodeID: "b", Type: db.PeerDisconnected, Time: 1050},
+		{NodeID: "c", Type: db.PeerDisconnected, Time: 1060},
+	}
+
+	announcements := []*db.NodeAnnouncements{
+		{NodeID: "a", Day: 0, Count: 2, LastAnnouncement: 1010},
+	}
+
+	health := newHealthTracker(time.Second * 1000)
+	health.restore(events, announcements, 1100)
+
+	a := health.health("a", 1100)
+	if !a.IsConnected || a.Uptime != 1 || a.LastAnnouncement != 1010 {
+		t.Fatalf("wrong health of node a: %v", healthString(a))
+	}
+
+	b := health.health("b", 1100)
+	if b.IsConnected || b.Uptime != 0.5 || b.Disconnects != 1 {
+		t.Fatalf("wrong health of node b: %v", healthString(b))
+	}
+
+	c := health.health("c", 1100)
+	if c.IsConnected || c.Uptime != 0 || c.Disconnects != 0 {
+		t.Fatalf("wrong health of node c: %v", healthString(c))
+	}
+
+	// Node which was connected before the shutdown, and isn't connected
+	// after the restart, should be disconnected by the first sample.
+	_, disconnects := health.addSample(nil, 1200)
+	if !reflect.DeepEqual(disconnects, []lightning.NodeID{"a"}) {
+		t.Fatalf("wrong disconnects: %v", disconnects)
+	}
+}
+
+func TestHealthAnnouncements(t *testing.T) {
+	day := announcementsDay
+	health := newHealthTracker(time.Duration(2*day) * time.Second)
+
+	health.addAnnouncement("a", 10)
+	health.addAnnouncement("a", day+20)
+	health.addAnnouncement("a", day+5)
+
+	unsaved := health.unsavedAnnouncements()
+	if len(unsaved) != 2 {
+		t.Fatalf("wrong number of unsaved announcements: %v", len(unsaved))
+	}
+
+	if len(health.unsavedAnnouncements()) != 0 {
+		t.Fatalf("announcements should be unsaved only once")
+	}
+
+	// Announcements which failed to be saved should be saved again.
+	health.markUnsaved(unsaved[:1])
+	if len(health.unsavedAnnouncements()) != 1 {
+		t.Fatalf("announcements should be unsaved after failure")
+	}
+
+	a := health.health("a", day+30)
+	if a.LastAnnouncement != day+20 || a.AnnouncementsPerDay != 1.5 {
+		t.Fatalf("wrong health of node a: %v", healthString(a))
+	}
+
+	// The first day is completely out of the window since the third day,
+	// its announcements shouldn't be counted.
+	health.addAnnouncement("a", 3*day)
+	health.addSample(nil, 3*day)
+
+	a = health.health("a", 3*day)
+	if a.LastAnnouncement != 3*day || a.AnnouncementsPerDay != 1.5 {
+		t.Fatalf("wrong health of node a after prune: %v",
+			healthString(a))
+	}
+
+	if len(health.announcements["a"]) != 2 {
+		t.Fatalf("first day of announcements wasn't pruned: %v",
+			len(health.announcements["a"]))
+	}
+}
+
+// healthString returns string representation of node health.
+func healthString(health *NodeHealth) string {
+	return fmt.Sprintf("%+v", *health)
+}
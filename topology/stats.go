@@ -0,0 +1,640 @@
+package topology
+
+import (
+	"github.com/bitlum/hub/common"
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/lightning/lnd/explorer"
+	"github.com/bitlum/hub/metrics"
+	"github.com/bitlum/hub/metrics/crypto"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// statsPeriod is the period of calculation of the network economics
+	// statistics.
+	statsPeriod = time.Hour
+
+	// maxFeeFetches is the maximum number of channels fees of which are
+	// fetched from blockchain during one statistics calculation. It is
+	// needed in order to not overload bitcoind with requests. Channels are
+	// picked randomly among the ones with unknown fees, so that known fees
+	// are the uniform sample of all channels, fees of the rest of channels
+	// are fetched during next calculations.
+	maxFeeFetches = 100
+
+	// feeRetryDelay is the delay after the first failed fetch of the fee,
+	// after which fetch is retried, every next failure doubles the delay.
+	feeRetryDelay = statsPeriod
+
+	// maxFeeAttempts is the number of failed fetches of the fee, after
+	// which fee is considered as unknown, i.e. transaction isn't known to
+	// bitcoind.
+	maxFeeAttempts = 5
+
+	// blockInterval is the average time between blocks, which is used to
+	// estimate time of channel funding by its short channel id.
+	blockInterval = int64(time.Minute * 10 / time.Second)
+)
+
+// EconomicStats is the average economic characteristics of the set of
+// channels.
+type EconomicStats struct {
+	// NumChannels is number of opened channels.
+	NumChannels int
+
+	// NumClosedChannels is number of channels which are known to be closed.
+	NumClosedChannels int
+
+	AverageCapacity btcutil.Amount
+
+	// AverageChannelAge is the average time in seconds since the open of
+	// currently opened channels.
+	AverageChannelAge int64
+
+	// AverageClosedLifetime is the average time in seconds between open
+	// and close of closed channels.
+	AverageClosedLifetime int64
+
+	// AverageOpenFee is the average miners fee of channel funding
+	// transaction.
+	AverageOpenFee btcutil.Amount
+
+	// AverageCloseFee is the average miners fee of channel closing
+	// transaction.
+	AverageCloseFee btcutil.Amount
+
+	// AverageBaseFeeMsat and AverageFeeRatePPM is the average routing fee
+	// policy of the channels.
+	AverageBaseFeeMsat int64
+	AverageFeeRatePPM  int64
+}
+
+// NetworkStats is the economics statistics of the lightning network, and
+// our node, which is used to benchmark our channels costs against the
+// network.
+type NetworkStats struct {
+	// Time is the time when statistics has been calculated.
+	Time int64
+
+	NumNodes int
+
+	// Capacity is the overall capacity of the network.
+	Capacity btcutil.Amount
+
+	// Network is the statistics of all channels in the network.
+	Network *EconomicStats
+
+	// Our is the statistics of our channels.
+	Our *EconomicStats
+}
+
+// average accumulates values to calculate their mean.
+type average struct {
+	sum   int64
+	count int64
+}
+
+func (a *average) add(value int64) {
+	a.sum += value
+	a.count++
+}
+
+// merge adds values accumulated by other average.
+func (a *average) merge(other average) {
+	a.sum += other.sum
+	a.count += other.count
+}
+
+func (a *average) value() int64 {
+	if a.count == 0 {
+		return 0
+	}
+
+	return a.sum / a.count
+}
+
+// economicsAccumulator accumulates characteristics of channels, and
+// converts them into the economic statistics.
+type economicsAccumulator struct {
+	numChannels       int
+	numClosedChannels int
+
+	capacity       average
+	age            average
+	closedLifetime average
+	openFee        average
+	closeFee       average
+	baseFee        average
+	feeRate        average
+}
+
+func (a *economicsAccumulator) addPolicy(policy *lightning.FeePolicy) {
+	if policy == nil {
+		return
+	}
+
+	a.baseFee.add(policy.BaseFeeMsat)
+	a.feeRate.add(policy.FeeRatePPM)
+}
+
+func (a *economicsAccumulator) stats() *EconomicStats {
+	return &EconomicStats{
+		NumChannels:           a.numChannels,
+		NumClosedChannels:     a.numClosedChannels,
+		AverageCapacity:       btcutil.Amount(a.capacity.value()),
+		AverageChannelAge:     a.age.value(),
+		AverageClosedLifetime: a.closedLifetime.value(),
+		AverageOpenFee:        btcutil.Amount(a.openFee.value()),
+		AverageCloseFee:       btcutil.Amount(a.closeFee.value()),
+		AverageBaseFeeMsat:    a.baseFee.value(),
+		AverageFeeRatePPM:     a.feeRate.value(),
+	}
+}
+
+// closedAccumulator accumulates statistics of closed channels. Closed
+// channels don't change, that is why they are accounted only once, and
+// are kept in memory only until their fees are fetched.
+type closedAccumulator struct {
+	numChannels int
+
+	lifetime average
+	openFee  average
+	closeFee average
+
+	// pending is the closed channels, fees of which are not fetched yet,
+	// by short channel id.
+	pending map[uint64]*pendingClosed
+}
+
+// pendingClosed is the closed channel, fees of which are not fetched yet.
+type pendingClosed struct {
+	channel *db.ChannelState
+
+	// openFeeDone and closeFeeDone are true if fee has been fetched and
+	// accounted, or it can't be fetched.
+	openFeeDone  bool
+	closeFeeDone bool
+}
+
+func newClosedAccumulator() *closedAccumulator {
+	return &closedAccumulator{
+		pending: make(map[uint64]*pendingClosed),
+	}
+}
+
+// add accounts closed channel, and remembers it as pending, so that its
+// fees would be fetched later.
+func (a *closedAccumulator) add(channel *db.ChannelState, height uint32,
+	now int64) {
+
+	a.numChannels++
+
+	openTime, ok := fundingTime(channel.ShortChannelID, height, now)
+	if ok && channel.Time > openTime {
+		a.lifetime.add(channel.Time - openTime)
+	}
+
+	// Fees couldn't be fetched without funding output, and close fee
+	// couldn't be fetched without block height of closing transaction.
+	if channel.ChannelID == "" {
+		return
+	}
+
+	a.pending[channel.ShortChannelID] = &pendingClosed{
+		channel:      channel,
+		closeFeeDone: channel.ClosedHeight == 0,
+	}
+}
+
+// fetchFees fetches unknown fees of the pending channel, and removes it
+// from pending once all its fees are accounted.
+func (a *closedAccumulator) fetchFees(p *pendingClosed, fees *feeFetcher,
+	now int64) {
+
+	channelID := p.channel.ChannelID
+
+	if !p.openFeeDone {
+		if fee, ok := fees.openFee(channelID, now); ok {
+			a.openFee.add(int64(fee))
+			p.openFeeDone = true
+		} else if fees.exhausted(fees.openFailures, channelID) {
+			p.openFeeDone = true
+		}
+	}
+
+	if !p.closeFeeDone {
+		fee, ok := fees.closeFee(channelID, p.channel.ClosedHeight, now)
+		if ok {
+			a.closeFee.add(int64(fee))
+			p.closeFeeDone = true
+		} else if fees.exhausted(fees.closeFailures, channelID) {
+			p.closeFeeDone = true
+		}
+	}
+
+	if p.openFeeDone && p.closeFeeDone {
+		delete(a.pending, p.channel.ShortChannelID)
+	}
+}
+
+// fetchable checks whether any of unknown fees of the pending channel could
+// be fetched now.
+func (p *pendingClosed) fetchable(fees *feeFetcher, now int64) bool {
+	return (!p.openFeeDone && fees.available(fees.openFailures,
+		p.channel.ChannelID, now)) ||
+		(!p.closeFeeDone && fees.available(fees.closeFailures,
+			p.channel.ChannelID, now))
+}
+
+// fetchFailure is the record about failed fetches of the fee.
+type fetchFailure struct {
+	attempts int
+
+	// retryTime is the time after which fetch could be retried.
+	retryTime int64
+}
+
+// feeFetcher fetches fees of channel funding and closing transactions,
+// failed fetches are retried with exponential backoff.
+//
+// NOTE: Should be used only by the statistics goroutine.
+type feeFetcher struct {
+	explorer explorer.Explorer
+
+	// openFees is the fees of funding transactions by channel id.
+	openFees map[lightning.ChannelID]btcutil.Amount
+
+	// openFailures and closeFailures is the failed fetches of funding and
+	// closing transactions fees by channel id.
+	openFailures  map[lightning.ChannelID]*fetchFailure
+	closeFailures map[lightning.ChannelID]*fetchFailure
+}
+
+func newFeeFetcher(explorer explorer.Explorer) *feeFetcher {
+	return &feeFetcher{
+		explorer:      explorer,
+		openFees:      make(map[lightning.ChannelID]btcutil.Amount),
+		openFailures:  make(map[lightning.ChannelID]*fetchFailure),
+		closeFailures: make(map[lightning.ChannelID]*fetchFailure),
+	}
+}
+
+// available checks that fee of the channel could be fetched now, i.e. it
+// hasn't failed or retry time has come.
+func (f *feeFetcher) available(failures map[lightning.ChannelID]*fetchFailure,
+	channelID lightning.ChannelID, now int64) bool {
+
+	failure, ok := failures[channelID]
+	if !ok {
+		return true
+	}
+
+	return failure.attempts < maxFeeAttempts && now >= failure.retryTime
+}
+
+// exhausted checks that fee of the channel has failed to be fetched too
+// many times, and shouldn't be fetched anymore.
+func (f *feeFetcher) exhausted(failures map[lightning.ChannelID]*fetchFailure,
+	channelID lightning.ChannelID) bool {
+
+	failure, ok := failures[channelID]
+	return ok && failure.attempts >= maxFeeAttempts
+}
+
+// addFailure registers failed fetch of the fee, and schedules the retry.
+func (f *feeFetcher) addFailure(failures map[lightning.ChannelID]*fetchFailure,
+	channelID lightning.ChannelID, now int64) {
+
+	failure, ok := failures[channelID]
+	if !ok {
+		failure = &fetchFailure{}
+		failures[channelID] = failure
+	}
+
+	delay := int64(feeRetryDelay.Seconds()) << uint(failure.attempts)
+	failure.attempts++
+	failure.retryTime = now + delay
+}
+
+// cachedOpenFee returns the fee of channel funding transaction, if it has
+// been already fetched.
+func (f *feeFetcher) cachedOpenFee(
+	channelID lightning.ChannelID) (btcutil.Amount, bool) {
+
+	fee, ok := f.openFees[channelID]
+	return fee, ok
+}
+
+// openFee returns the fee of channel funding transaction, false is
+// returned if fee is unknown and couldn't be fetched now.
+func (f *feeFetcher) openFee(channelID lightning.ChannelID,
+	now int64) (btcutil.Amount, bool) {
+
+	if fee, ok := f.openFees[channelID]; ok {
+		return fee, true
+	}
+
+	if !f.available(f.openFailures, channelID, now) {
+		return 0, false
+	}
+
+	txID, _, err := splitChannelID(channelID)
+	if err != nil {
+		f.addFailure(f.openFailures, channelID, now)
+		return 0, false
+	}
+
+	fee, err := f.explorer.FetchTxFee(txID)
+	if err != nil {
+		log.Debugf("Unable to fetch channel(%v) open fee: %v", channelID, err)
+		f.addFailure(f.openFailures, channelID, now)
+		return 0, false
+	}
+
+	delete(f.openFailures, channelID)
+	f.openFees[channelID] = fee
+	return fee, true
+}
+
+// closeFee returns the fee of channel closing transaction, which is
+// included in the block with the given height, false is returned if fee
+// couldn't be fetched now.
+func (f *feeFetcher) closeFee(channelID lightning.ChannelID, height uint32,
+	now int64) (btcutil.Amount, bool) {
+
+	if !f.available(f.closeFailures, channelID, now) {
+		return 0, false
+	}
+
+	txID, index, err := splitChannelID(channelID)
+	if err != nil {
+		f.addFailure(f.closeFailures, channelID, now)
+		return 0, false
+	}
+
+	closeTxID, err := f.explorer.FetchSpendTxID(txID, index, height)
+	if err != nil {
+		log.Debugf("Unable to fetch channel(%v) close tx: %v", channelID, err)
+		f.addFailure(f.closeFailures, channelID, now)
+		return 0, false
+	}
+
+	fee, err := f.explorer.FetchTxFee(closeTxID)
+	if err != nil {
+		log.Debugf("Unable to fetch channel(%v) close fee: %v", channelID,
+			err)
+		f.addFailure(f.closeFailures, channelID, now)
+		return 0, false
+	}
+
+	delete(f.closeFailures, channelID)
+	return fee, true
+}
+
+// prune removes cached fees and failures of the channels, which are not in
+// the given set.
+func (f *feeFetcher) prune(keep map[lightning.ChannelID]struct{}) {
+	for channelID := range f.openFees {
+		if _, ok := keep[channelID]; !ok {
+			delete(f.openFees, channelID)
+		}
+	}
+
+	for _, failures := range []map[lightning.ChannelID]*fetchFailure{
+		f.openFailures, f.closeFailures,
+	} {
+		for channelID := range failures {
+			if _, ok := keep[channelID]; !ok {
+				delete(failures, channelID)
+			}
+		}
+	}
+}
+
+// NetworkStats returns the last calculated economics statistics of the
+// network, nil is returned if statistics hasn't been calculated yet.
+func (t *Topology) NetworkStats() *NetworkStats {
+	t.statsMtx.RLock()
+	defer t.statsMtx.RUnlock()
+
+	return t.stats
+}
+
+// calculateStats calculates economics statistics of the network and our
+// node, using the current graph, blockchain and our channels.
+func (t *Topology) calculateStats() error {
+	m := crypto.NewMetric(t.cfg.Asset, common.GetFunctionName(),
+		t.cfg.MetricsBackend)
+	defer m.Finish()
+
+	now := time.Now().Unix()
+
+	info, err := t.cfg.Client.Info()
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return errors.Errorf("unable fetch info: %v", err)
+	}
+
+	networkStats, graph := t.calculateNetworkStats(now, info.BlockHeight)
+
+	ourStats, err := t.calculateOurStats(now)
+	if err != nil {
+		m.AddError(metrics.HighSeverity)
+		return err
+	}
+
+	stats := &NetworkStats{
+		Time:     now,
+		NumNodes: len(graph.Nodes),
+		Capacity: graph.Capacity(),
+		Network:  networkStats,
+		Our:      ourStats,
+	}
+
+	t.statsMtx.Lock()
+	t.stats = stats
+	t.statsMtx.Unlock()
+
+	log.Infof("Network stats calculated, channels(%v), average age(%v), "+
+		"average open fee(%v), average close fee(%v), our average open "+
+		"fee(%v), our average close fee(%v)", networkStats.NumChannels,
+		time.Duration(networkStats.AverageChannelAge)*time.Second,
+		networkStats.AverageOpenFee, networkStats.AverageCloseFee,
+		ourStats.AverageOpenFee, ourStats.AverageCloseFee)
+
+	return nil
+}
+
+// calculateNetworkStats calculates economics statistics of all channels in
+// the network, using the current graph and the channels closed since the
+// previous calculation.
+func (t *Topology) calculateNetworkStats(now int64,
+	height uint32) (*EconomicStats, *Graph) {
+
+	graph, closed := t.statsGraph(now)
+	for _, channel := range closed {
+		t.closedStats.add(channel, height, now)
+	}
+
+	acc := &economicsAccumulator{}
+	var fetches []func()
+
+	for _, channel := range graph.Channels {
+		acc.numChannels++
+		acc.capacity.add(int64(channel.Capacity))
+		acc.addPolicy(channel.Node1Policy)
+		acc.addPolicy(channel.Node2Policy)
+
+		if openTime, ok := fundingTime(channel.ShortChannelID, height,
+			now); ok {
+			acc.age.add(now - openTime)
+		}
+
+		if fee, ok := t.fees.cachedOpenFee(channel.ChannelID); ok {
+			acc.openFee.add(int64(fee))
+			continue
+		}
+
+		if channel.ChannelID == "" ||
+			!t.fees.available(t.fees.openFailures, channel.ChannelID, now) {
+			continue
+		}
+
+		channelID := channel.ChannelID
+		fetches = append(fetches, func() {
+			if fee, ok := t.fees.openFee(channelID, now); ok {
+				acc.openFee.add(int64(fee))
+			}
+		})
+	}
+
+	for _, pending := range t.closedStats.pending {
+		if !pending.fetchable(t.fees, now) {
+			continue
+		}
+
+		pending := pending
+		fetches = append(fetches, func() {
+			t.closedStats.fetchFees(pending, t.fees, now)
+		})
+	}
+
+	// Fetch fees of the random sample of channels, so that known fees
+	// wouldn't be biased towards the particular channels.
+	rand.Shuffle(len(fetches), func(i, j int) {
+		fetches[i], fetches[j] = fetches[j], fetches[i]
+	})
+
+	if len(fetches) > maxFeeFetches {
+		fetches = fetches[:maxFeeFetches]
+	}
+
+	for _, fetch := range fetches {
+		fetch()
+	}
+
+	// Keep cached fees only of the channels which are still needed.
+	keep := make(map[lightning.ChannelID]struct{})
+	for _, channel := range graph.Channels {
+		keep[channel.ChannelID] = struct{}{}
+	}
+	for _, pending := range t.closedStats.pending {
+		keep[pending.channel.ChannelID] = struct{}{}
+	}
+	t.fees.prune(keep)
+
+	acc.numClosedChannels = t.closedStats.numChannels
+	acc.closedLifetime = t.closedStats.lifetime
+	acc.openFee.merge(t.closedStats.openFee)
+	acc.closeFee = t.closedStats.closeFee
+
+	return acc.stats(), graph
+}
+
+// calculateOurStats calculates economics statistics of our channels.
+func (t *Topology) calculateOurStats(now int64) (*EconomicStats, error) {
+	channels, err := t.cfg.Client.Channels()
+	if err != nil {
+		return nil, errors.Errorf("unable fetch channels: %v", err)
+	}
+
+	policies, err := t.cfg.Client.FeePolicies()
+	if err != nil {
+		return nil, errors.Errorf("unable fetch fee policies: %v", err)
+	}
+
+	acc := &economicsAccumulator{}
+
+	for _, channel := range channels {
+		var opening *lightning.ChannelStateOpening
+		if state, ok := channel.States[lightning.ChannelOpening]; ok {
+			opening = state.(*lightning.ChannelStateOpening)
+
+			// Open fee is paid by the initiator of the channel.
+			if opening.Initiator == lightning.LocalInitiator {
+				acc.openFee.add(int64(opening.OpenFee))
+			}
+		}
+
+		switch channel.CurrentState() {
+		case lightning.ChannelOpened:
+			state := channel.States[lightning.ChannelOpened].(*lightning.ChannelStateOpened)
+
+			acc.numChannels++
+			acc.capacity.add(int64(state.LocalBalance + state.RemoteBalance))
+			acc.addPolicy(policies[channel.ChannelID])
+
+			if opening != nil {
+				acc.age.add(now - opening.CreationTime)
+			}
+
+		case lightning.ChannelClosed:
+			state := channel.States[lightning.ChannelClosed].(*lightning.ChannelStateClosed)
+
+			acc.numClosedChannels++
+			acc.closeFee.add(int64(state.CloseFee))
+
+			closeTime, err := channel.ClosingTime()
+			if err == nil && opening != nil {
+				acc.closedLifetime.add(closeTime - opening.CreationTime)
+			}
+		}
+	}
+
+	return acc.stats(), nil
+}
+
+// fundingTime estimates the time of channel funding transaction
+// confirmation, using the block height encoded in short channel id.
+func fundingTime(shortChannelID uint64, height uint32, now int64) (int64,
+	bool) {
+
+	fundingHeight := uint32(shortChannelID >> 40)
+	if fundingHeight == 0 || fundingHeight > height {
+		return 0, false
+	}
+
+	return now - int64(height-fundingHeight)*blockInterval, true
+}
+
+// splitChannelID splits channel id on funding transaction id and output
+// index.
+func splitChannelID(channelID lightning.ChannelID) (string, uint32, error) {
+	parts := strings.Split(string(channelID), ":")
+	if len(parts) != 2 {
+		return "", 0, errors.Errorf("wrong channel id: %v", channelID)
+	}
+
+	index, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return "", 0, errors.Errorf("wrong channel id: %v", channelID)
+	}
+
+	return parts[0], uint32(index), nil
+}
@@ -0,0 +1,294 @@
+package topology
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+	"github.com/bitlum/hub/lightning/lnd/explorer"
+	"github.com/btcsuite/btcutil"
+	"github.com/go-errors/errors"
+	"testing"
+)
+
+// mockExplorer is the explorer which returns fees of the known
+// transactions, and counts requests.
+type mockExplorer struct {
+	explorer.Explorer
+
+	fees     map[string]btcutil.Amount
+	spends   map[string]string
+	requests int
+}
+
+func (e *mockExplorer) FetchTxFee(txID string) (btcutil.Amount, error) {
+	e.requests++
+
+	fee, ok := e.fees[txID]
+	if !ok {
+		return 0, errors.Errorf("tx(%v) not found", txID)
+	}
+
+	return fee, nil
+}
+
+func (e *mockExplorer) FetchSpendTxID(txID string, index uint32,
+	height uint32) (string, error) {
+
+	e.requests++
+
+	spendTxID, ok := e.spends[txID]
+	if !ok {
+		return "", errors.Errorf("spend of tx(%v) not found", txID)
+	}
+
+	return spendTxID, nil
+}
+
+func TestFundingTime(t *testing.T) {
+	tests := []struct {
+		name           string
+		shortChannelID uint64
+		height         uint32
+		expected       int64
+		ok             bool
+	}{
+		{
+			name:           "funded in the current block",
+			shortChannelID: 100 << 40,
+			height:         100,
+			expected:       1000000,
+			ok:             true,
+		},
+		{
+			name:           "funded ten blocks ago",
+			shortChannelID: 90<<40 | 5<<16 | 1,
+			height:         100,
+			expected:       1000000 - 10*blockInterval,
+			ok:             true,
+		},
+		{
+			name:           "zero height",
+			shortChannelID: 5<<16 | 1,
+			height:         100,
+		},
+		{
+			name:           "height from the future",
+			shortChannelID: 101 << 40,
+			height:         100,
+		},
+	}
+
+	for _, test := range tests {
+		openTime, ok := fundingTime(test.shortChannelID, test.height,
+			1000000)
+		if ok != test.ok || openTime != test.expected {
+			t.Fatalf("(%v) wrong funding time: %v, %v", test.name,
+				openTime, ok)
+		}
+	}
+}
+
+func TestSplitChannelID(t *testing.T) {
+	tests := []struct {
+		channelID lightning.ChannelID
+		txID      string
+		index     uint32
+		valid     bool
+	}{
+		{channelID: "txid:1", txID: "txid", index: 1, valid: true},
+		{channelID: "txid:0", txID: "txid", index: 0, valid: true},
+		{channelID: "txid"},
+		{channelID: "txid:a"},
+		{channelID: "txid:1:2"},
+		{channelID: "txid:-1"},
+		{channelID: ""},
+	}
+
+	for _, test := range tests {
+		txID, index, err := splitChannelID(test.channelID)
+		if (err == nil) != test.valid {
+			t.Fatalf("(%v) wrong validity: %v", test.channelID, err)
+		}
+
+		if txID != test.txID || index != test.index {
+			t.Fatalf("(%v) wrong split: %v, %v", test.channelID, txID,
+				index)
+		}
+	}
+}
+
+func TestEconomicsAccumulator(t *testing.T) {
+	acc := &economicsAccumulator{}
+
+	if stats := acc.stats(); *stats != (EconomicStats{}) {
+		t.Fatalf("empty accumulator should give zero stats: %v", *stats)
+	}
+
+	acc.numChannels = 2
+	acc.capacity.add(100)
+	acc.capacity.add(301)
+	acc.addPolicy(&lightning.FeePolicy{BaseFeeMsat: 1000, FeeRatePPM: 1})
+	acc.addPolicy(&lightning.FeePolicy{BaseFeeMsat: 2000, FeeRatePPM: 3})
+	acc.addPolicy(nil)
+
+	var closedFee average
+	closedFee.add(30)
+	acc.openFee.add(10)
+	acc.openFee.merge(closedFee)
+
+	expected := EconomicStats{
+		NumChannels:        2,
+		AverageCapacity:    200,
+		AverageOpenFee:     20,
+		AverageBaseFeeMsat: 1500,
+		AverageFeeRatePPM:  2,
+	}
+
+	if stats := acc.stats(); *stats != expected {
+		t.Fatalf("wrong stats: %+v", *stats)
+	}
+}
+
+func TestFeeFetcherBackoff(t *testing.T) {
+	e := &mockExplorer{
+		fees: map[string]btcutil.Amount{"a": 100},
+	}
+	fees := newFeeFetcher(e)
+
+	if fee, ok := fees.openFee("a:0", 0); !ok || fee != 100 {
+		t.Fatalf("wrong open fee: %v, %v", fee, ok)
+	}
+
+	// Known fee should be taken from cache.
+	if fee, ok := fees.openFee("a:0", 0); !ok || fee != 100 ||
+		e.requests != 1 {
+		t.Fatalf("fee wasn't cached: %v, %v", fee, ok)
+	}
+
+	// Failed fetch shouldn't be retried until the delay passes, and every
+	// failure should double the delay.
+	now := int64(0)
+	delay := int64(feeRetryDelay.Seconds())
+	for i := 0; i < maxFeeAttempts; i++ {
+		if _, ok := fees.openFee("b:0", now); ok {
+			t.Fatalf("unknown fee shouldn't be fetched")
+		}
+
+		if fees.available(fees.openFailures, "b:0", now+delay-1) {
+			t.Fatalf("(%v) fetch shouldn't be available before delay", i)
+		}
+
+		now += delay
+		delay *= 2
+	}
+
+	if !fees.exhausted(fees.openFailures, "b:0") {
+		t.Fatalf("fee fetching should be exhausted")
+	}
+
+	requests := e.requests
+	if _, ok := fees.openFee("b:0", now*2); ok || e.requests != requests {
+		t.Fatalf("exhausted fee shouldn't be fetched")
+	}
+
+	// Transient failure shouldn't prevent fetch after the delay.
+	if _, ok := fees.openFee("c:0", 0); ok {
+		t.Fatalf("unknown fee shouldn't be fetched")
+	}
+
+	e.fees["c"] = 200
+	retryTime := int64(feeRetryDelay.Seconds())
+	if fee, ok := fees.openFee("c:0", retryTime); !ok || fee != 200 {
+		t.Fatalf("fee should be fetched after delay: %v, %v", fee, ok)
+	}
+
+	if _, ok := fees.openFailures["c:0"]; ok {
+		t.Fatalf("failure should be removed after successful fetch")
+	}
+
+	fees.prune(map[lightning.ChannelID]struct{}{"c:0": {}})
+	if len(fees.openFees) != 1 || len(fees.openFailures) != 0 {
+		t.Fatalf("fees weren't pruned: %v, %v", len(fees.openFees),
+			len(fees.openFailures))
+	}
+}
+
+func TestClosedAccumulator(t *testing.T) {
+	e := &mockExplorer{
+		fees: map[string]btcutil.Amount{
+			"a":       100,
+			"a-close": 10,
+			"b":       200,
+		},
+		spends: map[string]string{
+			"a": "a-close",
+		},
+	}
+	fees := newFeeFetcher(e)
+	acc := newClosedAccumulator()
+
+	now := int64(1000000)
+	height := uint32(100)
+
+	channels := []*db.ChannelState{
+		{
+			ShortChannelID: 90 << 40,
+			ChannelID:      "a:0",
+			Closed:         true,
+			ClosedHeight:   100,
+			Time:           now,
+		},
+		{
+			// Close fee of this channel can't be fetched, because
+			// spend transaction is unknown.
+			ShortChannelID: 80 << 40,
+			ChannelID:      "b:0",
+			Closed:         true,
+			ClosedHeight:   100,
+			Time:           now,
+		},
+		{
+			// Fees of this channel can't be fetched, because
+			// funding output is unknown.
+			ShortChannelID: 70 << 40,
+			Closed:         true,
+			Time:           now,
+		},
+	}
+
+	for _, channel := range channels {
+		acc.add(channel, height, now)
+	}
+
+	if acc.numChannels != 3 || acc.lifetime.value() != 20*blockInterval {
+		t.Fatalf("wrong closed channels stats: %v, %v", acc.numChannels,
+			acc.lifetime.value())
+	}
+
+	// Channel without funding output shouldn't wait for fees.
+	if len(acc.pending) != 2 {
+		t.Fatalf("wrong number of pending channels: %v", len(acc.pending))
+	}
+
+	for i := 0; i < maxFeeAttempts; i++ {
+		for _, pending := range acc.pending {
+			if pending.fetchable(fees, now) {
+				acc.fetchFees(pending, fees, now)
+			}
+		}
+
+		now += int64(feeRetryDelay.Seconds()) << uint(i)
+	}
+
+	if len(acc.pending) != 0 {
+		t.Fatalf("all channels should be processed, pending: %v",
+			len(acc.pending))
+	}
+
+	if acc.openFee.value() != 150 || acc.openFee.count != 2 {
+		t.Fatalf("wrong open fee: %v", acc.openFee.value())
+	}
+
+	if acc.closeFee.value() != 10 || acc.closeFee.count != 1 {
+		t.Fatalf("wrong close fee: %v", acc.closeFee.value())
+	}
+}
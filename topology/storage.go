@@ -0,0 +1,63 @@
+package topology
+
+import (
+	"github.com/bitlum/hub/db"
+	"github.com/bitlum/hub/lightning"
+)
+
+// Storage is used to persist the history of lightning network graph
+// changes, the events which are used to calculate nodes health, and the
+// detected anomalies.
+type Storage interface {
+	// AddNodeState saves the new state of the node.
+	AddNodeState(state *db.NodeState) error
+
+	// AddChannelState saves the new state of the channel.
+	AddChannelState(state *db.ChannelState) error
+
+	// LatestNodeStates returns the last state of every node, which was
+	// saved before or at the given time.
+	LatestNodeStates(end int64) ([]*db.NodeState, error)
+
+	// LatestChannelStates returns the last state of every channel, which
+	// was saved before or at the given time, including the closed
+	// channels.
+	LatestChannelStates(end int64) ([]*db.ChannelState, error)
+
+	// NodeChannelStates returns states of the given node channels, which
+	// were saved within the given period, preceded by the last states of
+	// the node channels saved before the period, sorted by time.
+	NodeChannelStates(nodeID lightning.NodeID, start,
+		end int64) ([]*db.ChannelState, error)
+
+	// AddPeerEvent saves the node connection or disconnection event.
+	AddPeerEvent(event *db.PeerEvent) error
+
+	// PeerEvents returns all saved peer events, sorted by time.
+	PeerEvents() ([]*db.PeerEvent, error)
+
+	// RemovePeerEvents removes peer events which were saved before the
+	// given time, except the last connection or disconnection event of
+	// every node, which is needed to restore the current connection state
+	// of the node.
+	RemovePeerEvents(end int64) error
+
+	// SaveNodeAnnouncements saves the given numbers of node announcements,
+	// replacing the previously saved numbers of the same node and day.
+	SaveNodeAnnouncements(announcements []*db.NodeAnnouncements) error
+
+	// NodeAnnouncements returns all saved numbers of node announcements,
+	// sorted by day.
+	NodeAnnouncements() ([]*db.NodeAnnouncements, error)
+
+	// RemoveNodeAnnouncements removes numbers of node announcements of the
+	// days which started before the given time.
+	RemoveNodeAnnouncements(end int64) error
+
+	// AddAnomalyEvent saves the detected anomaly of the network.
+	AddAnomalyEvent(event *db.AnomalyEvent) error
+
+	// LastAnomalyEvents returns the given number of the latest saved
+	// anomaly events, sorted from the oldest to the newest one.
+	LastAnomalyEvents(limit int) ([]*db.AnomalyEvent, error)
+}